@@ -0,0 +1,20 @@
+package client
+
+import (
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+// Backup returns a backup of the workspace, encrypted with the request's passphrase
+func (client *Client) Backup(request requests.BackupRequest) (responses.BackupResponse, error) {
+	result := responses.BackupResponse{}
+	err := client.do("POST", "/api/backup", nil, request, &result)
+	return result, err
+}
+
+// Restore restores the request's backup and returns a report of the records that were restored
+func (client *Client) Restore(request requests.RestoreRequest) (responses.RestoreResponse, error) {
+	result := responses.RestoreResponse{}
+	err := client.do("POST", "/api/restore", nil, request, &result)
+	return result, err
+}
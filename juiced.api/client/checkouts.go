@@ -0,0 +1,58 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// GetAllCheckouts returns a page of the Checkouts that match the filter, most recent first unless the page is sorted.
+// Only successful Checkouts are returned unless the filter has a Status.
+func (client *Client) GetAllCheckouts(filter entities.CheckoutFilter, page pagination.Page) (responses.CheckoutResponse, error) {
+	result := responses.CheckoutResponse{}
+	query := checkoutFilterQuery(filter)
+	for key, values := range page.Query() {
+		query[key] = values
+	}
+	err := client.do("GET", "/api/checkout", query, nil, &result)
+	return result, err
+}
+
+// GetCheckoutStats returns the stats of the Checkouts that match the filter, grouped by groupBy
+func (client *Client) GetCheckoutStats(groupBy enums.CheckoutStatsGroupBy, filter entities.CheckoutFilter) (responses.CheckoutStatsResponse, error) {
+	query := checkoutFilterQuery(filter)
+	if groupBy != "" {
+		query.Set("groupBy", groupBy)
+	}
+	result := responses.CheckoutStatsResponse{}
+	err := client.do("GET", "/api/checkout/stats", query, nil, &result)
+	return result, err
+}
+
+// checkoutFilterQuery returns the query parameters that the filter's fields are read from
+func checkoutFilterQuery(filter entities.CheckoutFilter) url.Values {
+	query := url.Values{}
+	if filter.Retailer != "" {
+		query.Set("retailer", filter.Retailer)
+	}
+	if filter.ProfileName != "" {
+		query.Set("profile", filter.ProfileName)
+	}
+	if filter.SKU != "" {
+		query.Set("sku", filter.SKU)
+	}
+	if filter.Status != "" {
+		query.Set("status", filter.Status)
+	}
+	if filter.From != 0 {
+		query.Set("from", strconv.FormatInt(filter.From, 10))
+	}
+	if filter.To != 0 {
+		query.Set("to", strconv.FormatInt(filter.To, 10))
+	}
+	return query
+}
@@ -0,0 +1,132 @@
+// Package client calls the local API with typed requests and responses. It has a method for every route in
+// openapi.Routes, named after the route's ID.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+// DefaultBaseURL is the address of the local server
+const DefaultBaseURL = "http://127.0.0.1:10000"
+
+// Client calls the API at BaseURL with Token, the session token that the backend prints when it starts or the remote
+// access token
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// New returns a Client for the API at baseURL, or at DefaultBaseURL if it's empty
+func New(baseURL string, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Error is returned when the API responds with an error status, with the code and errors from the response
+type Error struct {
+	StatusCode int
+	Code       responses.ErrorCode
+	Errors     []string
+}
+
+func (err *Error) Error() string {
+	if len(err.Errors) == 0 {
+		return fmt.Sprintf("the API responded with %d %s", err.StatusCode, http.StatusText(err.StatusCode))
+	}
+	return fmt.Sprintf("the API responded with %d %s: %s", err.StatusCode, http.StatusText(err.StatusCode), strings.Join(err.Errors, "; "))
+}
+
+// path returns the path with the parameters escaped and put in place of its %s verbs
+func path(format string, parameters ...string) string {
+	escapedParameters := make([]interface{}, len(parameters))
+	for i, parameter := range parameters {
+		escapedParameters[i] = url.PathEscape(parameter)
+	}
+	return fmt.Sprintf(format, escapedParameters...)
+}
+
+// do sends the request body as JSON, unless it's nil, and decodes the JSON response into result
+func (client *Client) do(method string, path string, query url.Values, body interface{}, result interface{}) error {
+	var reader io.Reader
+	contentType := ""
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+		contentType = "application/json"
+	}
+	data, err := client.send(method, path, query, contentType, reader)
+	return decode(data, err, result)
+}
+
+// decode decodes the JSON response into result, which is also done for error responses since they can have data
+func decode(data []byte, err error, result interface{}) error {
+	if _, ok := err.(*Error); err != nil && !ok {
+		return err
+	}
+	decodeErr := json.Unmarshal(data, result)
+	if err != nil {
+		return err
+	}
+	return decodeErr
+}
+
+// send sends the request and returns the response body, and an Error if the response has an error status
+func (client *Client) send(method string, path string, query url.Values, contentType string, body io.Reader) ([]byte, error) {
+	requestURL := client.BaseURL + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+	request, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	if client.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+client.Token)
+	}
+
+	httpClient := client.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 400 {
+		errorResponse := struct {
+			Errors []string            `json:"errors"`
+			Code   responses.ErrorCode `json:"code"`
+		}{}
+		json.Unmarshal(data, &errorResponse)
+		return data, &Error{StatusCode: response.StatusCode, Code: errorResponse.Code, Errors: errorResponse.Errors}
+	}
+	return data, nil
+}
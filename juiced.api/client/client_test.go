@@ -0,0 +1,81 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.api/openapi"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+// TestClientCallsEveryRoute calls the method of every route in openapi.Routes and checks that it requests the route
+func TestClientCallsEveryRoute(t *testing.T) {
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.EscapedPath()
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("%s %s: expected the session token, got %q", method, path, r.Header.Get("Authorization"))
+		}
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"success":true,"errors":[]}`))
+	}))
+	defer server.Close()
+
+	client := reflect.ValueOf(New(server.URL, "token"))
+	ids := make(map[string]bool)
+	for _, route := range openapi.Routes {
+		ids[route.ID] = true
+		clientMethod := client.MethodByName(route.ID)
+		if !clientMethod.IsValid() {
+			t.Errorf("the client doesn't have a %s method", route.ID)
+			continue
+		}
+		arguments := make([]reflect.Value, clientMethod.Type().NumIn())
+		for i := range arguments {
+			argument := reflect.New(clientMethod.Type().In(i)).Elem()
+			if argument.Kind() == reflect.String {
+				argument.SetString("id")
+			}
+			arguments[i] = argument
+		}
+		method, path = "", ""
+		results := clientMethod.Call(arguments)
+		if err, _ := results[len(results)-1].Interface().(error); err != nil {
+			t.Errorf("%s: %v", route.ID, err)
+			continue
+		}
+		if requested, ok := openapi.Lookup(method, path); !ok || requested.ID != route.ID {
+			t.Errorf("%s requested %s %s, which is %s", route.ID, method, path, requested.ID)
+		}
+	}
+
+	clientType := client.Type()
+	for i := 0; i < clientType.NumMethod(); i++ {
+		if name := clientType.Method(i).Name; !ids[name] {
+			t.Errorf("the client's %s method isn't a route in openapi.Routes", name)
+		}
+	}
+}
+
+func TestClientReturnsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success":false,"data":[],"errors":["invalid version"],"code":"invalid_request"}`))
+	}))
+	defer server.Close()
+
+	result, err := New(server.URL, "").SetVersion(requests.SetVersionRequest{})
+	clientErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected an *Error, got %v", err)
+	}
+	if clientErr.StatusCode != http.StatusBadRequest || clientErr.Code != responses.InvalidRequestCode || len(clientErr.Errors) != 1 || clientErr.Errors[0] != "invalid version" {
+		t.Errorf("expected a 400 with the response's code and errors, got %+v", clientErr)
+	}
+	if result.Success || len(result.Errors) != 1 {
+		t.Errorf("expected the response to be decoded, got %+v", result)
+	}
+}
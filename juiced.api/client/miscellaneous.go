@@ -0,0 +1,28 @@
+package client
+
+import (
+	"backend.juicedbot.io/juiced.api/openapi"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+// TestWebhooks sends a test message to the success and failure Discord webhooks and to the notification sinks
+func (client *Client) TestWebhooks(request requests.TestWebhooksRequest) (responses.MiscellaneousResponse, error) {
+	result := responses.MiscellaneousResponse{}
+	err := client.do("POST", "/api/settings/testWebhooks", nil, request, &result)
+	return result, err
+}
+
+// SetVersion sets the app version that's shown in the Discord activity
+func (client *Client) SetVersion(request requests.SetVersionRequest) (responses.MiscellaneousResponse, error) {
+	result := responses.MiscellaneousResponse{}
+	err := client.do("POST", "/api/setVersion", nil, request, &result)
+	return result, err
+}
+
+// GetOpenAPI returns the API's OpenAPI document
+func (client *Client) GetOpenAPI() (openapi.Document, error) {
+	result := openapi.Document{}
+	err := client.do("GET", "/api/openapi.json", nil, nil, &result)
+	return result, err
+}
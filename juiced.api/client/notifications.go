@@ -0,0 +1,39 @@
+package client
+
+import (
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// GetOutbox returns a page of the OutboxEntries with the status, or of every one if it's empty
+func (client *Client) GetOutbox(status enums.OutboxStatus, page pagination.Page) (responses.OutboxResponse, error) {
+	result := responses.OutboxResponse{}
+	query := page.Query()
+	if status != "" {
+		query.Set("status", status)
+	}
+	err := client.do("GET", "/api/notification/outbox", query, nil, &result)
+	return result, err
+}
+
+// ReplayOutbox queues every dead OutboxEntry to be delivered again and returns them
+func (client *Client) ReplayOutbox() (responses.OutboxResponse, error) {
+	result := responses.OutboxResponse{}
+	err := client.do("POST", "/api/notification/outbox/replay", nil, nil, &result)
+	return result, err
+}
+
+// ReplayOutboxEntry queues the dead OutboxEntry with the ID to be delivered again and returns it
+func (client *Client) ReplayOutboxEntry(ID string) (responses.OutboxResponse, error) {
+	result := responses.OutboxResponse{}
+	err := client.do("POST", path("/api/notification/outbox/%s/replay", ID), nil, nil, &result)
+	return result, err
+}
+
+// RemoveOutboxEntry removes the OutboxEntry with the ID, it won't be delivered
+func (client *Client) RemoveOutboxEntry(ID string) (responses.OutboxResponse, error) {
+	result := responses.OutboxResponse{}
+	err := client.do("DELETE", path("/api/notification/outbox/%s", ID), nil, nil, &result)
+	return result, err
+}
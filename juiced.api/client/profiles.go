@@ -0,0 +1,169 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetAllProfileGroups returns a page of ProfileGroups with their Profiles, or every one if the page doesn't have a Limit
+func (client *Client) GetAllProfileGroups(page pagination.Page) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("GET", "/api/profile/group", page.Query(), nil, &result)
+	return result, err
+}
+
+// GetProfileGroup returns the ProfileGroup with the groupID
+func (client *Client) GetProfileGroup(groupID string) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("GET", path("/api/profile/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// CreateProfileGroup creates the ProfileGroup and returns it
+func (client *Client) CreateProfileGroup(profileGroup entities.ProfileGroup) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("POST", "/api/profile/group", nil, profileGroup, &result)
+	return result, err
+}
+
+// RemoveProfileGroup deletes the ProfileGroup with the groupID and returns it
+func (client *Client) RemoveProfileGroup(groupID string) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("DELETE", path("/api/profile/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// UpdateProfileGroup renames the ProfileGroup with the groupID and returns it
+func (client *Client) UpdateProfileGroup(groupID string, request requests.UpdateProfileGroupRequest) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("PUT", path("/api/profile/group/%s", groupID), nil, request, &result)
+	return result, err
+}
+
+// CloneProfileGroup clones the ProfileGroup with the groupID and returns the clone
+func (client *Client) CloneProfileGroup(groupID string) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("POST", path("/api/profile/group/%s/clone", groupID), nil, nil, &result)
+	return result, err
+}
+
+// AddProfilesToGroup adds the Profiles to the ProfileGroup with the groupID and returns it
+func (client *Client) AddProfilesToGroup(groupID string, request requests.ProfileIDsRequest) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("POST", path("/api/profile/group/%s/add", groupID), nil, request, &result)
+	return result, err
+}
+
+// RemoveProfilesFromGroup removes the Profiles from the ProfileGroup with the groupID and returns it
+func (client *Client) RemoveProfilesFromGroup(groupID string, request requests.ProfileIDsRequest) (responses.ProfileGroupResponse, error) {
+	result := responses.ProfileGroupResponse{}
+	err := client.do("POST", path("/api/profile/group/%s/remove", groupID), nil, request, &result)
+	return result, err
+}
+
+// GetAllProfiles returns a page of Profiles, or every one if the page doesn't have a Limit
+func (client *Client) GetAllProfiles(page pagination.Page) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("GET", "/api/profile", page.Query(), nil, &result)
+	return result, err
+}
+
+// ExportProfiles returns a csv or json file of every Profile, or of the Profiles in the ProfileGroup with the groupID
+// if it isn't empty
+func (client *Client) ExportProfiles(format string, groupID string) ([]byte, error) {
+	query := url.Values{}
+	if format != "" {
+		query.Set("format", format)
+	}
+	if groupID != "" {
+		query.Set("groupID", groupID)
+	}
+	file, err := client.send("GET", "/api/profile/export", query, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// GetProfile returns the Profile with the ID
+func (client *Client) GetProfile(ID string) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("GET", path("/api/profile/%s", ID), nil, nil, &result)
+	return result, err
+}
+
+// CreateProfile creates the Profile and returns it
+func (client *Client) CreateProfile(profile entities.Profile) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("POST", "/api/profile", nil, profile, &result)
+	return result, err
+}
+
+// RemoveProfile deletes the Profile with the ID and returns it
+func (client *Client) RemoveProfile(ID string) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("DELETE", path("/api/profile/%s", ID), nil, nil, &result)
+	return result, err
+}
+
+// UpdateProfile replaces the Profile with the ID and returns it
+func (client *Client) UpdateProfile(ID string, profile entities.Profile) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("PUT", path("/api/profile/%s", ID), nil, profile, &result)
+	return result, err
+}
+
+// CloneProfile clones the Profile with the ID and returns the clone
+func (client *Client) CloneProfile(ID string) (responses.ProfileResponse, error) {
+	result := responses.ProfileResponse{}
+	err := client.do("POST", path("/api/profile/%s/clone", ID), nil, nil, &result)
+	return result, err
+}
+
+// ImportProfiles uploads the file and imports its Profiles with the request's options. If the file is nil, the backend
+// imports the file at the request's FilePath instead.
+func (client *Client) ImportProfiles(fileName string, file []byte, request requests.ImportProfilesRequest) (responses.ImportProfileResponse, error) {
+	result := responses.ImportProfileResponse{}
+	if file == nil {
+		err := client.do("POST", "/api/profile/import", nil, request, &result)
+		return result, err
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err == nil {
+		_, err = part.Write(file)
+	}
+	if err != nil {
+		return result, err
+	}
+	if request.Format != "" {
+		writer.WriteField("format", request.Format)
+	}
+	if len(request.GroupIDs) > 0 {
+		writer.WriteField("groupIDs", strings.Join(request.GroupIDs, ","))
+	}
+	if request.Mapping != nil {
+		mapping, err := json.Marshal(request.Mapping)
+		if err != nil {
+			return result, err
+		}
+		writer.WriteField("mapping", string(mapping))
+	}
+	err = writer.Close()
+	if err != nil {
+		return result, err
+	}
+
+	data, err := client.send("POST", "/api/profile/import", nil, writer.FormDataContentType(), &body)
+	return result, decode(data, err, &result)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetAllProxyGroups returns a page of ProxyGroups, or every one if the page doesn't have a Limit
+func (client *Client) GetAllProxyGroups(page pagination.Page) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("GET", "/api/proxy/group", page.Query(), nil, &result)
+	return result, err
+}
+
+// GetProxyGroup returns the ProxyGroup with the groupID
+func (client *Client) GetProxyGroup(groupID string) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("GET", path("/api/proxy/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// CreateProxyGroup creates the ProxyGroup and returns it
+func (client *Client) CreateProxyGroup(proxyGroup entities.ProxyGroup) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("POST", "/api/proxy/group", nil, proxyGroup, &result)
+	return result, err
+}
+
+// RemoveProxyGroup deletes the ProxyGroup with the groupID and returns it
+func (client *Client) RemoveProxyGroup(groupID string) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("DELETE", path("/api/proxy/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// UpdateProxyGroup replaces the name and proxies of the ProxyGroup with the groupID and returns it
+func (client *Client) UpdateProxyGroup(groupID string, proxyGroup entities.ProxyGroup) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("PUT", path("/api/proxy/group/%s", groupID), nil, proxyGroup, &result)
+	return result, err
+}
+
+// CloneProxyGroup clones the ProxyGroup with the groupID and returns the clone
+func (client *Client) CloneProxyGroup(groupID string) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("POST", path("/api/proxy/group/%s/clone", groupID), nil, nil, &result)
+	return result, err
+}
+
+// TestProxyGroup tests every Proxy of the ProxyGroup with the groupID and returns the ProxyGroup with their health
+func (client *Client) TestProxyGroup(groupID string, request requests.TestProxyGroupRequest) (responses.ProxyGroupResponse, error) {
+	result := responses.ProxyGroupResponse{}
+	err := client.do("POST", path("/api/proxy/group/%s/test", groupID), nil, request, &result)
+	return result, err
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetSettings returns the user's settings, with API keys and account passwords masked
+func (client *Client) GetSettings() (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("GET", "/api/settings", nil, nil, &result)
+	return result, err
+}
+
+// RevealSettings returns the user's settings, without masking API keys and account passwords
+func (client *Client) RevealSettings() (responses.RevealedSettingsResponse, error) {
+	result := responses.RevealedSettingsResponse{}
+	err := client.do("GET", "/api/settings/reveal", nil, nil, &result)
+	return result, err
+}
+
+// UpdateSettings updates the user's settings and returns them
+func (client *Client) UpdateSettings(settings entities.Settings) (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("PUT", "/api/settings", nil, settings, &result)
+	return result, err
+}
+
+// RekeyDatabase re-encrypts the stored profiles, cards, credentials and API keys with a new key and returns the settings
+func (client *Client) RekeyDatabase() (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("POST", "/api/settings/rekey", nil, nil, &result)
+	return result, err
+}
+
+// GetAccounts returns the retailer accounts with their login statuses and the tasks using them, with passwords masked
+func (client *Client) GetAccounts() (responses.AccountsResponse, error) {
+	result := responses.AccountsResponse{}
+	err := client.do("GET", "/api/settings/accounts", nil, nil, &result)
+	return result, err
+}
+
+// AddAccount adds the retailer account and returns the settings
+func (client *Client) AddAccount(account entities.Account) (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("POST", "/api/settings/accounts", nil, account, &result)
+	return result, err
+}
+
+// UpdateAccount replaces the retailer account with the ID and returns the settings
+func (client *Client) UpdateAccount(ID string, account entities.Account) (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("PUT", path("/api/settings/accounts/%s", ID), nil, account, &result)
+	return result, err
+}
+
+// RemoveAccounts removes the retailer accounts and returns the settings
+func (client *Client) RemoveAccounts(request requests.DeleteAccountsRequest) (responses.SettingsResponse, error) {
+	result := responses.SettingsResponse{}
+	err := client.do("POST", "/api/settings/accounts/remove", nil, request, &result)
+	return result, err
+}
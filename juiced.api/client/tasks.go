@@ -0,0 +1,184 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetAllTaskGroups returns a page of TaskGroups with their Tasks, or every one if the page doesn't have a Limit
+func (client *Client) GetAllTaskGroups(page pagination.Page) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("GET", "/api/task/group", page.Query(), nil, &result)
+	return result, err
+}
+
+// GetTaskGroup returns the TaskGroup with the groupID
+func (client *Client) GetTaskGroup(groupID string) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("GET", path("/api/task/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// CreateTaskGroup creates the TaskGroup and returns it
+func (client *Client) CreateTaskGroup(taskGroup entities.TaskGroup) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", "/api/task/group", nil, taskGroup, &result)
+	return result, err
+}
+
+// RemoveTaskGroup deletes the TaskGroup with the groupID and its Tasks, and returns it
+func (client *Client) RemoveTaskGroup(groupID string) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("DELETE", path("/api/task/group/%s", groupID), nil, nil, &result)
+	return result, err
+}
+
+// UpdateTaskGroup updates the TaskGroup with the groupID and returns it
+func (client *Client) UpdateTaskGroup(groupID string, request requests.UpdateTaskGroupRequest) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("PUT", path("/api/task/group/%s", groupID), nil, request, &result)
+	return result, err
+}
+
+// CloneTaskGroup clones the TaskGroup with the groupID and its Tasks, and returns the clone
+func (client *Client) CloneTaskGroup(groupID string) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", path("/api/task/group/%s/clone", groupID), nil, nil, &result)
+	return result, err
+}
+
+// StartTaskGroup starts the Monitor and Tasks of the TaskGroup with the groupID
+func (client *Client) StartTaskGroup(groupID string) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", path("/api/task/group/%s/start", groupID), nil, nil, &result)
+	return result, err
+}
+
+// StopTaskGroup stops the Monitor and Tasks of the TaskGroup with the groupID
+func (client *Client) StopTaskGroup(groupID string) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", path("/api/task/group/%s/stop", groupID), nil, nil, &result)
+	return result, err
+}
+
+// RemoveTasks deletes the Tasks from the TaskGroup with the groupID and returns it
+func (client *Client) RemoveTasks(groupID string, request requests.DeleteTasksRequest) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", path("/api/task/group/%s/removeTasks", groupID), nil, request, &result)
+	return result, err
+}
+
+// GetAllTasks returns a page of Tasks, or every one if the page doesn't have a Limit
+func (client *Client) GetAllTasks(page pagination.Page) (responses.TaskResponse, error) {
+	result := responses.TaskResponse{}
+	err := client.do("GET", "/api/task", page.Query(), nil, &result)
+	return result, err
+}
+
+// GetTask returns the Task with the ID, with its retailer account password masked
+func (client *Client) GetTask(ID string) (responses.TaskResponse, error) {
+	result := responses.TaskResponse{}
+	err := client.do("GET", path("/api/task/%s", ID), nil, nil, &result)
+	return result, err
+}
+
+// RevealTask returns the Task with the ID, without masking its retailer account password
+func (client *Client) RevealTask(ID string) (responses.RevealedTaskResponse, error) {
+	result := responses.RevealedTaskResponse{}
+	err := client.do("GET", path("/api/task/%s/reveal", ID), nil, nil, &result)
+	return result, err
+}
+
+// CreateTask creates Tasks in the TaskGroup with the groupID and returns the TaskGroup
+func (client *Client) CreateTask(groupID string, request requests.CreateTaskRequest) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("POST", path("/api/task/%s", groupID), nil, request, &result)
+	return result, err
+}
+
+// UpdateTasks updates Tasks in the TaskGroup with the groupID and returns the TaskGroup
+func (client *Client) UpdateTasks(groupID string, request requests.UpdateTasksRequest) (responses.TaskGroupResponse, error) {
+	result := responses.TaskGroupResponse{}
+	err := client.do("PUT", path("/api/task/group/%s/updateTasks", groupID), nil, request, &result)
+	return result, err
+}
+
+// GenerateTasks creates Tasks in the TaskGroup with the groupID from the generator's template, and returns their IDs
+func (client *Client) GenerateTasks(groupID string, generator entities.TaskGenerator) (responses.TaskIDsResponse, error) {
+	result := responses.TaskIDsResponse{}
+	err := client.do("POST", path("/api/task/group/%s/generate", groupID), nil, generator, &result)
+	return result, err
+}
+
+// CloneTask clones the Task with the ID and returns the clone
+func (client *Client) CloneTask(ID string) (responses.TaskResponse, error) {
+	result := responses.TaskResponse{}
+	err := client.do("POST", path("/api/task/%s/clone", ID), nil, nil, &result)
+	return result, err
+}
+
+// StartTask starts the Task with the ID
+func (client *Client) StartTask(ID string) (responses.TaskResponse, error) {
+	result := responses.TaskResponse{}
+	err := client.do("POST", path("/api/task/%s/start", ID), nil, nil, &result)
+	return result, err
+}
+
+// StopTask stops the Task with the ID
+func (client *Client) StopTask(ID string) (responses.TaskResponse, error) {
+	result := responses.TaskResponse{}
+	err := client.do("POST", path("/api/task/%s/stop", ID), nil, nil, &result)
+	return result, err
+}
+
+// GetTaskRuns returns the runs of the Task with the ID, most recent first
+func (client *Client) GetTaskRuns(ID string) (responses.TaskRunsResponse, error) {
+	result := responses.TaskRunsResponse{}
+	err := client.do("GET", path("/api/task/%s/runs", ID), nil, nil, &result)
+	return result, err
+}
+
+// GetTaskGroupTimeline returns the status transitions of the TaskGroup with the groupID at or after since, a Unix time
+// in milliseconds (0 returns all of them)
+func (client *Client) GetTaskGroupTimeline(groupID string, since int64) (responses.TaskGroupTimelineResponse, error) {
+	query := url.Values{}
+	if since > 0 {
+		query.Set("since", strconv.FormatInt(since, 10))
+	}
+	result := responses.TaskGroupTimelineResponse{}
+	err := client.do("GET", path("/api/task/group/%s/timeline", groupID), query, nil, &result)
+	return result, err
+}
+
+// GetTaskGroupSchedules returns the schedules of the TaskGroup with the groupID
+func (client *Client) GetTaskGroupSchedules(groupID string) (responses.TaskGroupSchedulesResponse, error) {
+	result := responses.TaskGroupSchedulesResponse{}
+	err := client.do("GET", path("/api/task/group/%s/schedule", groupID), nil, nil, &result)
+	return result, err
+}
+
+// CreateTaskGroupSchedule schedules the TaskGroup with the groupID and returns the schedule
+func (client *Client) CreateTaskGroupSchedule(groupID string, schedule entities.TaskGroupSchedule) (responses.TaskGroupSchedulesResponse, error) {
+	result := responses.TaskGroupSchedulesResponse{}
+	err := client.do("POST", path("/api/task/group/%s/schedule", groupID), nil, schedule, &result)
+	return result, err
+}
+
+// UpdateTaskGroupSchedule updates the schedule with the scheduleID of the TaskGroup with the groupID and returns it
+func (client *Client) UpdateTaskGroupSchedule(groupID string, scheduleID string, schedule entities.TaskGroupSchedule) (responses.TaskGroupSchedulesResponse, error) {
+	result := responses.TaskGroupSchedulesResponse{}
+	err := client.do("PUT", path("/api/task/group/%s/schedule/%s", groupID, scheduleID), nil, schedule, &result)
+	return result, err
+}
+
+// RemoveTaskGroupSchedule deletes the schedule with the scheduleID of the TaskGroup with the groupID and returns it
+func (client *Client) RemoveTaskGroupSchedule(groupID string, scheduleID string) (responses.TaskGroupSchedulesResponse, error) {
+	result := responses.TaskGroupSchedulesResponse{}
+	err := client.do("DELETE", path("/api/task/group/%s/schedule/%s", groupID, scheduleID), nil, nil, &result)
+	return result, err
+}
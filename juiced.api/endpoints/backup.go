@@ -0,0 +1,206 @@
+package endpoints
+
+import (
+	"strconv"
+
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// BackupEndpoint handles the POST request at /api/backup
+func BackupEndpoint(response http.ResponseWriter, request *http.Request) {
+	var archive []byte
+	errorsList := make([]string, 0)
+
+	body, err := ioutil.ReadAll(request.Body)
+	if err == nil {
+		backupRequestInfo := requests.BackupRequest{}
+		err = json.Unmarshal(body, &backupRequestInfo)
+		if err == nil {
+			if len(backupRequestInfo.Passphrase) >= common.MinBackupPassphraseLength {
+				var backup entities.Backup
+				backup, err = queries.GetBackup()
+				if err == nil {
+					var plaintext []byte
+					plaintext, err = json.Marshal(backup)
+					if err == nil {
+						archive, err = common.EncryptBackup(plaintext, backupRequestInfo.Passphrase)
+					}
+					if err != nil {
+						errorsList = append(errorsList, errors.EncryptBackupError+err.Error())
+					}
+				} else {
+					errorsList = append(errorsList, errors.CreateBackupError+err.Error())
+				}
+			} else {
+				errorsList = append(errorsList, errors.BackupPassphraseTooShortError+strconv.Itoa(common.MinBackupPassphraseLength))
+			}
+		} else {
+			errorsList = append(errorsList, errors.ParseBackupRequestError+err.Error())
+		}
+	} else {
+		errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+	}
+	result := &responses.BackupResponse{Success: true, Data: archive, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.BackupResponse{Success: false, Data: make([]byte, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RestoreEndpoint handles the POST request at /api/restore
+func RestoreEndpoint(response http.ResponseWriter, request *http.Request) {
+	var report entities.RestoreReport
+	errorsList := make([]string, 0)
+
+	body, err := ioutil.ReadAll(request.Body)
+	if err == nil {
+		restoreRequestInfo := requests.RestoreRequest{Mode: enums.RestoreMerge}
+		err = json.Unmarshal(body, &restoreRequestInfo)
+		if err == nil {
+			if restoreRequestInfo.Mode != enums.RestoreMerge && restoreRequestInfo.Mode != enums.RestoreReplace {
+				errorsList = append(errorsList, errors.InvalidRestoreModeError+restoreRequestInfo.Mode)
+			} else if len(restoreRequestInfo.Backup) == 0 {
+				errorsList = append(errorsList, errors.MissingBackupError)
+			} else if workspaceRunning() {
+				errorsList = append(errorsList, errors.RestoreWhileTasksRunningError)
+			} else {
+				var plaintext []byte
+				plaintext, err = common.DecryptBackup(restoreRequestInfo.Backup, restoreRequestInfo.Passphrase)
+				if err == nil {
+					backup := entities.Backup{}
+					err = entities.ParseBackup(&backup, plaintext)
+					if err == nil {
+						report, err = commands.RestoreBackup(backup, restoreRequestInfo.Mode)
+						if err == nil {
+							err = refreshStores()
+							if err != nil {
+								errorsList = append(errorsList, errors.RefreshStoresError+err.Error())
+							}
+						} else {
+							errorsList = append(errorsList, errors.RestoreBackupError+err.Error())
+						}
+					} else {
+						errorsList = append(errorsList, errors.ParseBackupError+err.Error())
+					}
+				} else {
+					errorsList = append(errorsList, errors.DecryptBackupError+err.Error())
+				}
+			}
+		} else {
+			errorsList = append(errorsList, errors.ParseRestoreRequestError+err.Error())
+		}
+	} else {
+		errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+	}
+	result := &responses.RestoreResponse{Success: true, Data: []entities.RestoreReport{report}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.RestoreResponse{Success: false, Data: make([]entities.RestoreReport, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// workspaceRunning returns true if any Task or Monitor in the stores is running
+func workspaceRunning() bool {
+	for _, runnableTask := range stores.GetTaskStore().GetRunnableTasks() {
+		if !runnableTask.GetTask().StopFlag() {
+			return true
+		}
+	}
+	for _, runnableMonitor := range stores.GetMonitorStore().GetRunnableMonitors() {
+		if !runnableMonitor.GetMonitor().StopFlag() {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshStores reloads the restored records into the stores, which would otherwise keep running with the records they
+// loaded before the restore, and removes the ones that the restore deleted
+func refreshStores() error {
+	proxyGroups, err := queries.GetAllProxyGroups()
+	if err != nil {
+		return err
+	}
+	proxyStore := stores.GetProxyStore()
+	for groupID := range proxyStore.GetProxyGroups() {
+		proxyStore.RemoveProxyGroup(groupID)
+	}
+	for i := range proxyGroups {
+		proxyStore.AddProxyGroup(&proxyGroups[i])
+	}
+
+	taskGroups, err := queries.GetAllTaskGroups()
+	if err != nil {
+		return err
+	}
+	monitorStore := stores.GetMonitorStore()
+	restoredGroups := make(map[string]bool)
+	for i := range taskGroups {
+		restoredGroups[taskGroups[i].GroupID] = true
+		if monitorStore.GetMonitor(taskGroups[i].GroupID) != nil {
+			taskGroups[i].UpdateMonitor = true
+			err = monitorStore.UpdateMonitor(&taskGroups[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for groupID := range monitorStore.GetRunnableMonitors() {
+		if !restoredGroups[groupID] {
+			monitorStore.RemoveMonitor(groupID)
+		}
+	}
+
+	tasks, err := queries.GetAllTasks()
+	if err != nil {
+		return err
+	}
+	taskStore := stores.GetTaskStore()
+	restoredTasks := make(map[string]bool)
+	for i := range tasks {
+		restoredTasks[tasks[i].ID] = true
+		if taskStore.GetTask(tasks[i].ID) != nil {
+			tasks[i].UpdateTask = true
+			err = taskStore.UpdateTask(&tasks[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for taskID := range taskStore.GetRunnableTasks() {
+		if !restoredTasks[taskID] {
+			taskStore.RemoveTask(taskID)
+		}
+	}
+
+	err = stores.GetScheduleStore().Reload()
+	if err != nil {
+		return err
+	}
+
+	// Replacing the settings can change or disable remote access
+	settings, err := queries.GetSettings()
+	if err != nil {
+		return err
+	}
+	if RemoteAccessChanged != nil {
+		RemoteAccessChanged(settings)
+	}
+	return nil
+}
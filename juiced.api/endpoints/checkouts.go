@@ -1,10 +1,16 @@
 package endpoints
 
 import (
+	e "errors"
+	"fmt"
+	"net/url"
 	"strconv"
+	"time"
 
+	"backend.juicedbot.io/juiced.api/pagination"
 	"backend.juicedbot.io/juiced.api/responses"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/errors"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
 
@@ -12,37 +18,129 @@ import (
 	"net/http"
 )
 
-// GetAllCheckoutsEndpoint handles the GET request at /api/checkout
-func GetAllCheckoutsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
-
-	request.ParseForm()
-	params := request.Form
+// parseCheckoutFilter builds a CheckoutFilter from the retailer, profile, sku, status, days, from and to URL parameters
+func parseCheckoutFilter(params url.Values) (entities.CheckoutFilter, error) {
+	filter := entities.CheckoutFilter{
+		Retailer:    params.Get("retailer"),
+		ProfileName: params.Get("profile"),
+		SKU:         params.Get("sku"),
+		Status:      params.Get("status"),
+	}
+	switch filter.Status {
+	case "", enums.OrderStatusSuccess, enums.OrderStatusDeclined, enums.OrderStatusFailed:
+	default:
+		return filter, e.New("unknown status " + filter.Status)
+	}
 
-	retailer := params.Get("retailer")
-	days := params.Get("days")
-	daysBack := -1
 	var err error
-	if days != "" {
+	if days := params.Get("days"); days != "" {
+		var daysBack int
 		daysBack, err = strconv.Atoi(days)
+		if err != nil {
+			return filter, err
+		}
+		if daysBack >= 0 {
+			filter.From = time.Now().Add(time.Duration(-daysBack) * (24 * time.Hour)).Unix()
+		}
+	}
+	if from := params.Get("from"); from != "" {
+		filter.From, err = strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return filter, err
+		}
+	}
+	if to := params.Get("to"); to != "" {
+		filter.To, err = strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// GetAllCheckoutsEndpoint handles the GET request at /api/checkout
+func GetAllCheckoutsEndpoint(response http.ResponseWriter, request *http.Request) {
+	request.ParseForm()
+	filter, err := parseCheckoutFilter(request.Form)
+	// Only successful checkouts were ever listed here, the others have to be asked for
+	if filter.Status == "" {
+		filter.Status = enums.OrderStatusSuccess
 	}
 
 	errorsList := make([]string, 0)
-	checkouts := make([]entities.Checkout, 0)
+	data := make([]entities.Checkout, 0)
+	nextCursor := ""
 	if err == nil {
-		checkouts, err = queries.GetCheckouts(retailer, daysBack)
-		if err != nil {
+		var checkouts []entities.Checkout
+		checkouts, err = queries.GetCheckouts(filter)
+		if err == nil {
+			// Checkouts don't have an ID, so they're told apart by everything that identifies one
+			id := func(i int) string {
+				return fmt.Sprintf("%d|%s|%s|%s|%d", checkouts[i].Time, checkouts[i].Retailer, checkouts[i].SKU, checkouts[i].ProfileName, checkouts[i].MsToCheckout)
+			}
+			indices, cursor, errorString := paginate(request, len(checkouts), pagination.Sorts{
+				"time": func(i int) pagination.Key {
+					return pagination.Key{Number: checkouts[i].Time, ID: id(i)}
+				},
+				"price": func(i int) pagination.Key {
+					return pagination.Key{Number: int64(checkouts[i].Price), ID: id(i)}
+				},
+				"msToCheckout": func(i int) pagination.Key {
+					return pagination.Key{Number: checkouts[i].MsToCheckout, ID: id(i)}
+				},
+			}, "time", pagination.Descending)
+			if errorString == "" {
+				for _, i := range indices {
+					data = append(data, checkouts[i])
+				}
+				nextCursor = cursor
+			} else {
+				errorsList = append(errorsList, errorString)
+			}
+		} else {
 			errorsList = append(errorsList, errors.GetAllCheckoutsError+err.Error())
 		}
 	} else {
-		errorsList = append(errorsList, errors.GetAllCheckoutsError+err.Error())
+		errorsList = append(errorsList, errors.ParseCheckoutFilterError+err.Error())
+	}
+
+	result := &responses.CheckoutResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.CheckoutResponse{Success: false, Data: make([]entities.Checkout, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// GetCheckoutStatsEndpoint handles the GET request at /api/checkout/stats
+func GetCheckoutStatsEndpoint(response http.ResponseWriter, request *http.Request) {
+	request.ParseForm()
+	filter, err := parseCheckoutFilter(request.Form)
+	groupBy := request.Form.Get("groupBy")
+	if groupBy == "" {
+		groupBy = enums.CheckoutStatsByRetailer
+	}
+
+	errorsList := make([]string, 0)
+	stats := make([]entities.CheckoutStats, 0)
+	if err != nil {
+		errorsList = append(errorsList, errors.ParseCheckoutFilterError+err.Error())
+	} else {
+		switch groupBy {
+		case enums.CheckoutStatsByRetailer, enums.CheckoutStatsByProfile, enums.CheckoutStatsBySKU, enums.CheckoutStatsByDay, enums.CheckoutStatsByWeek:
+			stats, err = queries.GetCheckoutStats(filter, groupBy)
+			if err != nil {
+				errorsList = append(errorsList, errors.GetCheckoutStatsError+err.Error())
+			}
+		default:
+			errorsList = append(errorsList, errors.InvalidCheckoutStatsGroupByError)
+		}
 	}
 
-	result := &responses.CheckoutResponse{Success: true, Data: checkouts, Errors: make([]string, 0)}
+	result := &responses.CheckoutStatsResponse{Success: true, Data: stats, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.CheckoutResponse{Success: false, Data: checkouts, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.CheckoutStatsResponse{Success: false, Data: make([]entities.CheckoutStats, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
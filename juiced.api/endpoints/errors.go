@@ -0,0 +1,129 @@
+package endpoints
+
+import (
+	"net/http"
+	"strings"
+
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// errorCodes maps the errors that requests can run into to the codes that they're reported with. Every error message
+// starts with one of the constants in errors, so the message's code is the code of the constant it starts with.
+// Errors that aren't listed are failures of the backend itself, which are internal errors.
+var errorCodes = map[responses.ErrorCode][]string{
+	responses.InvalidRequestCode: {
+		errors.MissingParameterError,
+		errors.IOUtilReadAllError,
+		errors.ParsePageError,
+		errors.ParseBackupRequestError,
+		errors.ParseRestoreRequestError,
+		errors.ParseBackupError,
+		errors.MissingBackupError,
+		errors.InvalidRestoreModeError,
+		errors.ParseCheckoutFilterError,
+		errors.InvalidCheckoutStatsGroupByError,
+		errors.ParseProfileGroupError,
+		errors.ParseProfileError,
+		errors.ParseImportProfilesRequestError,
+		errors.ParseImportProfilesFormError,
+		errors.ParseImportProfilesFileError,
+		errors.ParseImportProfilesCSVError,
+		errors.ParseProfileColumnMappingError,
+		errors.InvalidProfileFileFormatError,
+		errors.ParseProxyGroupError,
+		errors.ParseTestProxyGroupRequestError,
+		errors.ParseTaskGroupScheduleError,
+		errors.ParseSettingsError,
+		errors.ParseAccountError,
+		errors.ParseRemoveAccountsRequestError,
+		errors.ParseSinceError,
+		errors.ParseTaskGroupError,
+		errors.ParseTaskError,
+		errors.ParseDeleteTasksRequestError,
+		errors.ParseUpdateTasksRequestError,
+		errors.ParseUpdateTaskGroupRequestError,
+		errors.ParseTaskGeneratorError,
+		errors.InvalidOutboxStatusError,
+	},
+	responses.NotFoundCode: {
+		errors.TaskNotFoundError,
+		errors.TaskGroupNotFoundError,
+		errors.TaskGroupScheduleNotFoundError,
+		errors.ProfileNotFoundError,
+		errors.ProfileGroupNotFoundError,
+		errors.ProxyGroupNotFoundError,
+		errors.AccountNotFoundError,
+		errors.OutboxEntryNotFoundError,
+	},
+	responses.ConflictCode: {
+		errors.ProfileNameTakenError,
+		errors.RestoreWhileTasksRunningError,
+		errors.RekeyWhileTasksRunningError,
+		errors.OutboxEntryNotDeadError,
+		errors.AccountInUseError,
+		errors.NoFreeAccountError,
+	},
+	responses.ValidationFailedCode: {
+		errors.BackupPassphraseTooShortError,
+		errors.DecryptBackupError,
+		errors.OpenFileError,
+		errors.ReadFileError,
+		errors.MissingProfileFieldError,
+		errors.InvalidEmailError,
+		errors.InvalidPhoneNumberError,
+		errors.InvalidCountryCodeError,
+		errors.InvalidStateCodeError,
+		errors.InvalidCardNumberError,
+		errors.UnsupportedCardTypeError,
+		errors.InvalidCardExpiryError,
+		errors.CardExpiredError,
+		errors.InvalidCVVError,
+		errors.InvalidCronError,
+		errors.NoScheduledActionError,
+		errors.ConflictingStartTimesError,
+		errors.ConflictingStopTimesError,
+		errors.StopAfterWithoutStartError,
+		errors.InvalidStopAfterMinutesError,
+		errors.StopTimeBeforeStartTimeError,
+		errors.ScheduleTimeInPastError,
+		errors.StartTaskInvalidCardError,
+		errors.MissingTaskFieldsError,
+		errors.InvalidTaskRetailerError,
+		errors.StartMonitorInvalidCardError,
+		errors.MissingMonitorFieldsError,
+		errors.NoMonitorsError,
+		errors.MissingKeywordsError,
+		errors.InvalidMonitorTypeError,
+		errors.InvalidMonitorRetailerError,
+		errors.InvalidTaskGeneratorModeError,
+		errors.TooManyGeneratedTasksError,
+		errors.TaskRetailerMismatchError,
+		errors.EmptyProfileGroupError,
+		errors.InvalidSizeFallbackError,
+		errors.InvalidNotificationSinkError,
+		errors.InvalidProxyTestURLError,
+		errors.InvalidProxyTestOptionsError,
+		errors.AccountRetailerMismatchError,
+		errors.RemoteAccessTokenTooShortError,
+	},
+}
+
+// errorCode returns the code of the error message
+func errorCode(message string) responses.ErrorCode {
+	for code, prefixes := range errorCodes {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(message, prefix) {
+				return code
+			}
+		}
+	}
+	return responses.InternalErrorCode
+}
+
+// writeErrorStatus writes the status of the request's first error, which is the one that stopped it, and returns its code
+func writeErrorStatus(response http.ResponseWriter, errorsList []string) responses.ErrorCode {
+	code := errorCode(errorsList[0])
+	response.WriteHeader(responses.ErrorStatus(code))
+	return code
+}
@@ -0,0 +1,42 @@
+package endpoints
+
+import (
+	"strings"
+	"testing"
+
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// TestErrorCodes fails when an error is listed twice, or starts with an error that has a different code, since then
+// its code would depend on the order that the map is iterated in
+func TestErrorCodes(t *testing.T) {
+	codes := make(map[string]responses.ErrorCode)
+	for code, prefixes := range errorCodes {
+		for _, prefix := range prefixes {
+			if other, ok := codes[prefix]; ok {
+				t.Errorf("%q is listed under %s and %s", prefix, other, code)
+			}
+			codes[prefix] = code
+		}
+	}
+	for prefix, code := range codes {
+		for other, otherCode := range codes {
+			if prefix != other && code != otherCode && strings.HasPrefix(prefix, other) {
+				t.Errorf("%q is %s, but it starts with %q, which is %s", prefix, code, other, otherCode)
+			}
+		}
+	}
+
+	cases := map[string]responses.ErrorCode{
+		errors.TaskNotFoundError:                       responses.NotFoundCode,
+		errors.ParseTaskError + "unexpected EOF":       responses.InvalidRequestCode,
+		errors.ProfileNameTakenError:                   responses.ConflictCode,
+		errors.GetAllTasksError + "database is locked": responses.InternalErrorCode,
+	}
+	for message, expected := range cases {
+		if code := errorCode(message); code != expected {
+			t.Errorf("%q: expected %s, got %s", message, expected, code)
+		}
+	}
+}
@@ -7,20 +7,27 @@ import (
 	"sync"
 	"time"
 
+	"backend.juicedbot.io/juiced.api/openapi"
+	"backend.juicedbot.io/juiced.api/requests"
 	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
 	rpc "backend.juicedbot.io/juiced.rpc"
 	"backend.juicedbot.io/juiced.sitescripts/util"
 )
 
+// TestWebhooksEndpoint handles the POST request at /api/settings/testWebhooks, it sends a test message to the Discord
+// webhooks and to every NotificationSink in the request
 func TestWebhooksEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
-
-	type TestWebhooksRequest struct {
-		SuccessWebhook string `json:"successDiscordWebhook"`
-		FailureWebhook string `json:"failureDiscordWebhook"`
+	errorsMutex := sync.Mutex{}
+	addError := func(errorString string) {
+		errorsMutex.Lock()
+		errorsList = append(errorsList, errorString)
+		errorsMutex.Unlock()
 	}
 
 	embed := util.Embed{
@@ -33,31 +40,53 @@ func TestWebhooksEndpoint(response http.ResponseWriter, request *http.Request) {
 
 	body, err := ioutil.ReadAll(request.Body)
 	if err == nil {
-		testWebhooksRequest := TestWebhooksRequest{}
+		testWebhooksRequest := requests.TestWebhooksRequest{}
 		err = json.Unmarshal(body, &testWebhooksRequest)
 		if err == nil {
+			var currentSinks []entities.NotificationSink
+			if len(testWebhooksRequest.NotificationSinks) > 0 {
+				// The sinks can be ones that are already saved with their secrets masked
+				currentSinks, err = queries.GetNotificationSinks()
+				if err != nil {
+					addError(errors.GetSettingsError + err.Error())
+				}
+			}
+
 			wg := sync.WaitGroup{}
 			wg.Add(2)
 			go func() {
 				if testWebhooksRequest.SuccessWebhook != "" {
-					embed.Title = "Success Webhook"
-					embed.Color = 16742912
-					if !util.SendDiscordWebhook(testWebhooksRequest.SuccessWebhook, []util.Embed{embed}) {
-						errorsList = append(errorsList, errors.TestSuccessWebhookError)
+					successEmbed := embed
+					successEmbed.Title = "Success Webhook"
+					successEmbed.Color = 16742912
+					if !util.SendDiscordWebhook(testWebhooksRequest.SuccessWebhook, []util.Embed{successEmbed}) {
+						addError(errors.TestSuccessWebhookError)
 					}
 				}
 				wg.Done()
 			}()
 			go func() {
 				if testWebhooksRequest.FailureWebhook != "" {
-					embed.Title = "Failure Webhook"
-					embed.Color = 14495044
-					if !util.SendDiscordWebhook(testWebhooksRequest.FailureWebhook, []util.Embed{embed}) {
-						errorsList = append(errorsList, errors.TestFailureWebhookError)
+					failureEmbed := embed
+					failureEmbed.Title = "Failure Webhook"
+					failureEmbed.Color = 14495044
+					if !util.SendDiscordWebhook(testWebhooksRequest.FailureWebhook, []util.Embed{failureEmbed}) {
+						addError(errors.TestFailureWebhookError)
 					}
 				}
 				wg.Done()
 			}()
+			for _, sink := range testWebhooksRequest.NotificationSinks {
+				wg.Add(1)
+				go func(sink entities.NotificationSink) {
+					unmaskNotificationSink(&sink, currentSinks)
+					err := notifications.Send(sink, notifications.Message{Event: enums.NotificationTest})
+					if err != nil {
+						addError(errors.TestNotificationSinkError + sink.Name + ": " + err.Error())
+					}
+					wg.Done()
+				}(sink)
+			}
 			wg.Wait()
 		}
 	} else {
@@ -65,24 +94,18 @@ func TestWebhooksEndpoint(response http.ResponseWriter, request *http.Request) {
 	}
 	result := &responses.MiscellaneousResponse{Success: true, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.MiscellaneousResponse{Success: false, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.MiscellaneousResponse{Success: false, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 func SetVersion(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 
-	type SetVersionRequest struct {
-		Version string `json:"version"`
-	}
-
 	body, err := ioutil.ReadAll(request.Body)
 	if err == nil {
-		setVersionRequest := SetVersionRequest{}
+		setVersionRequest := requests.SetVersionRequest{}
 		err = json.Unmarshal(body, &setVersionRequest)
 		if err == nil {
 			rpc.SetActivity(setVersionRequest.Version)
@@ -93,8 +116,13 @@ func SetVersion(response http.ResponseWriter, request *http.Request) {
 
 	result := &responses.MiscellaneousResponse{Success: true, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.MiscellaneousResponse{Success: false, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.MiscellaneousResponse{Success: false, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
+
+// GetOpenAPIEndpoint handles the GET request at /api/openapi.json
+func GetOpenAPIEndpoint(response http.ResponseWriter, request *http.Request) {
+	json.NewEncoder(response).Encode(openapi.Generate())
+}
@@ -0,0 +1,134 @@
+package endpoints
+
+import (
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetOutboxEndpoint handles the GET request at /api/notification/outbox, the status parameter only returns the
+// pending or the dead OutboxEntries
+func GetOutboxEndpoint(response http.ResponseWriter, request *http.Request) {
+	errorsList := make([]string, 0)
+	data := make([]entities.OutboxEntry, 0)
+	nextCursor := ""
+
+	status := request.URL.Query().Get("status")
+	if status != "" && status != enums.OutboxPending && status != enums.OutboxDead {
+		errorsList = append(errorsList, errors.InvalidOutboxStatusError)
+	} else {
+		entries, err := stores.GetOutboxStore().GetEntries(status)
+		if err == nil {
+			indices, cursor, errorString := paginate(request, len(entries), pagination.Sorts{
+				"creationDate": func(i int) pagination.Key {
+					return pagination.Key{Number: entries[i].CreationDate, ID: entries[i].ID}
+				},
+				"nextAttempt": func(i int) pagination.Key {
+					return pagination.Key{Number: entries[i].NextAttempt, ID: entries[i].ID}
+				},
+				"attempts": func(i int) pagination.Key {
+					return pagination.Key{Number: int64(entries[i].Attempts), ID: entries[i].ID}
+				},
+			}, "creationDate", pagination.Ascending)
+			if errorString == "" {
+				for _, i := range indices {
+					data = append(data, entries[i])
+				}
+				nextCursor = cursor
+			} else {
+				errorsList = append(errorsList, errorString)
+			}
+		} else {
+			errorsList = append(errorsList, errors.GetOutboxError+err.Error())
+		}
+	}
+
+	result := &responses.OutboxResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.OutboxResponse{Success: false, Data: make([]entities.OutboxEntry, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// ReplayOutboxEndpoint handles the POST request at /api/notification/outbox/replay, it replays every dead OutboxEntry
+func ReplayOutboxEndpoint(response http.ResponseWriter, request *http.Request) {
+	errorsList := make([]string, 0)
+
+	entries, err := stores.GetOutboxStore().ReplayAll()
+	if err != nil {
+		errorsList = append(errorsList, errors.ReplayOutboxEntryError+err.Error())
+	}
+
+	result := &responses.OutboxResponse{Success: true, Data: entries, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.OutboxResponse{Success: false, Data: make([]entities.OutboxEntry, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// ReplayOutboxEntryEndpoint handles the POST request at /api/notification/outbox/{ID}/replay
+func ReplayOutboxEntryEndpoint(response http.ResponseWriter, request *http.Request) {
+	var entry entities.OutboxEntry
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	ID, ok := params["ID"]
+	if ok {
+		var err error
+		entry, err = stores.GetOutboxStore().Replay(ID)
+		if err != nil {
+			errorsList = append(errorsList, outboxErrorString(errors.ReplayOutboxEntryError, err))
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+
+	result := &responses.OutboxResponse{Success: true, Data: []entities.OutboxEntry{entry}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.OutboxResponse{Success: false, Data: make([]entities.OutboxEntry, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RemoveOutboxEntryEndpoint handles the DELETE request at /api/notification/outbox/{ID}
+func RemoveOutboxEntryEndpoint(response http.ResponseWriter, request *http.Request) {
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	ID, ok := params["ID"]
+	if ok {
+		err := stores.GetOutboxStore().Remove(ID)
+		if err != nil {
+			errorsList = append(errorsList, outboxErrorString(errors.RemoveOutboxEntryError, err))
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+
+	result := &responses.OutboxResponse{Success: true, Data: make([]entities.OutboxEntry, 0), Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.OutboxResponse{Success: false, Data: make([]entities.OutboxEntry, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// outboxErrorString returns the error that the OutboxStore returned as is if it's one of its own errors, so that it's
+// reported with its code, and with the prefix otherwise
+func outboxErrorString(prefix string, err error) string {
+	if err.Error() == errors.OutboxEntryNotFoundError || err.Error() == errors.OutboxEntryNotDeadError {
+		return err.Error()
+	}
+	return prefix + err.Error()
+}
@@ -0,0 +1,22 @@
+package endpoints
+
+import (
+	"net/http"
+
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// paginate returns the indices of the list's count items that are on the request's page, in order, and the cursor of
+// the next page, or the error to report if the request's pagination parameters are invalid
+func paginate(request *http.Request, count int, sorts pagination.Sorts, defaultSort string, defaultOrder pagination.Order) ([]int, string, string) {
+	page, err := pagination.Parse(request.URL.Query(), defaultSort, defaultOrder)
+	if err != nil {
+		return nil, "", errors.ParsePageError + err.Error()
+	}
+	indices, nextCursor, err := page.Apply(count, sorts)
+	if err != nil {
+		return nil, "", errors.ParsePageError + err.Error()
+	}
+	return indices, nextCursor, ""
+}
@@ -1,10 +1,15 @@
 package endpoints
 
 import (
+	"bytes"
+	"mime"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
 	"backend.juicedbot.io/juiced.api/responses"
 	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common"
@@ -23,8 +28,6 @@ import (
 
 // GetProfileGroupEndpoint handles the GET request at /api/profile/group/{groupID}
 func GetProfileGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -32,9 +35,10 @@ func GetProfileGroupEndpoint(response http.ResponseWriter, request *http.Request
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		profileGroup, err = queries.GetProfileGroup(groupID)
-		if err != nil {
-			errorsList = append(errorsList, errors.GetProfileGroupError+err.Error())
+		var errorString string
+		profileGroup, errorString = getProfileGroup(groupID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -46,41 +50,48 @@ func GetProfileGroupEndpoint(response http.ResponseWriter, request *http.Request
 	data := []entities.ProfileGroupWithProfiles{newProfileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetAllProfileGroupsEndpoint handles the GET request at /api/profile/group
 func GetAllProfileGroupsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 	profileGroups, err := queries.GetAllProfileGroups()
 	if err != nil {
 		errorsList = append(errorsList, errors.GetAllProfileGroupsError+err.Error())
 	}
+	indices, nextCursor, errorString := paginate(request, len(profileGroups), pagination.Sorts{
+		"creationDate": func(i int) pagination.Key {
+			return pagination.Key{Number: profileGroups[i].CreationDate, ID: profileGroups[i].GroupID}
+		},
+		"name": func(i int) pagination.Key {
+			return pagination.Key{Text: profileGroups[i].Name, ID: profileGroups[i].GroupID}
+		},
+	}, "creationDate", pagination.Ascending)
+	if errorString != "" {
+		errorsList = append(errorsList, errorString)
+	}
 	data := []entities.ProfileGroupWithProfiles{}
-	for i := 0; i < len(profileGroups); i++ {
+	for _, i := range indices {
 		newProfileGroupWithProfiles, err := queries.ConvertProfileIDsToProfiles(&profileGroups[i])
 		if err != nil {
 			errorsList = append(errorsList, errors.GetProfileError+err.Error())
 		}
 		data = append(data, newProfileGroupWithProfiles)
 	}
-	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
+	result := &responses.ProfileGroupResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CreateProfileGroupEndpoint handles the POST request at /api/profile/group
 func CreateProfileGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	profileGroup := &entities.ProfileGroup{GroupID: uuid.New().String(), ProfileIDs: []string{}}
 	errorsList := make([]string, 0)
 
@@ -106,29 +117,29 @@ func CreateProfileGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	data := []entities.ProfileGroupWithProfiles{newProfileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveProfileGroupEndpoint handles the DELETE request at /api/profile/group/{GroupID}
 func RemoveProfileGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProfileGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		profileGroup, err = commands.RemoveProfileGroup(groupID)
 		if err != nil {
 			errorsList = append(errorsList, errors.RemoveProfileGroupError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	newProfileGroupWithProfiles, err := queries.ConvertProfileIDsToProfiles(&profileGroup)
 	if err != nil {
@@ -137,16 +148,14 @@ func RemoveProfileGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	data := []entities.ProfileGroupWithProfiles{newProfileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateProfileGroupEndpoint handles the PUT request at /api/profile/group/{GroupID}
 func UpdateProfileGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -154,14 +163,12 @@ func UpdateProfileGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		profileGroup, err = queries.GetProfileGroup(groupID)
-		if err == nil {
+		var errorString string
+		profileGroup, errorString = getProfileGroup(groupID)
+		if errorString == "" {
 			body, err := ioutil.ReadAll(request.Body)
 			if err == nil {
-				type Name struct {
-					Name string `json:"name"`
-				}
-				name := Name{}
+				name := requests.UpdateProfileGroupRequest{}
 				err := json.Unmarshal(body, &name)
 				if err == nil {
 					profileGroup.SetName(name.Name)
@@ -176,7 +183,7 @@ func UpdateProfileGroupEndpoint(response http.ResponseWriter, request *http.Requ
 				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProfileGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -188,16 +195,14 @@ func UpdateProfileGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	data := []entities.ProfileGroupWithProfiles{profileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CloneProfileGroupEndpoint handles the POST request at /api/profile/group/{GroupID}/clone
 func CloneProfileGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newProfileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -205,8 +210,9 @@ func CloneProfileGroupEndpoint(response http.ResponseWriter, request *http.Reque
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		newProfileGroup, err = queries.GetProfileGroup(groupID)
-		if err == nil {
+		var errorString string
+		newProfileGroup, errorString = getProfileGroup(groupID)
+		if errorString == "" {
 			newProfileGroup.SetGroupID(uuid.New().String())
 			newProfileGroup.SetName(newProfileGroup.Name + " (Copy " + common.RandID(4) + ")")
 			newProfileGroup.CreationDate = time.Now().Unix()
@@ -215,7 +221,7 @@ func CloneProfileGroupEndpoint(response http.ResponseWriter, request *http.Reque
 				errorsList = append(errorsList, errors.CreateProfileGroupError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProfileGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -227,16 +233,14 @@ func CloneProfileGroupEndpoint(response http.ResponseWriter, request *http.Reque
 	data := []entities.ProfileGroupWithProfiles{newProfileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // AddProfilesToGroupEndpoint handles the POST request at /api/profile/group/{GroupID}/add
 func AddProfilesToGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -244,14 +248,12 @@ func AddProfilesToGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		profileGroup, err = queries.GetProfileGroup(groupID)
-		if err == nil {
+		var errorString string
+		profileGroup, errorString = getProfileGroup(groupID)
+		if errorString == "" {
 			body, err := ioutil.ReadAll(request.Body)
 			if err == nil {
-				type ProfileIDs struct {
-					ProfileIDs []string `json:"profileIDs"`
-				}
-				profileIDs := ProfileIDs{}
+				profileIDs := requests.ProfileIDsRequest{}
 				err := json.Unmarshal(body, &profileIDs)
 				if err == nil {
 					profileGroup.AddProfileIDsToGroup(profileIDs.ProfileIDs)
@@ -266,7 +268,7 @@ func AddProfilesToGroupEndpoint(response http.ResponseWriter, request *http.Requ
 				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProfileGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -278,16 +280,14 @@ func AddProfilesToGroupEndpoint(response http.ResponseWriter, request *http.Requ
 	data := []entities.ProfileGroupWithProfiles{profileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveProfilesFromGroupEndpoint handles the POST request at /api/profile/group/{GroupID}/remove
 func RemoveProfilesFromGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profileGroup entities.ProfileGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -295,14 +295,12 @@ func RemoveProfilesFromGroupEndpoint(response http.ResponseWriter, request *http
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		profileGroup, err = queries.GetProfileGroup(groupID)
-		if err == nil {
+		var errorString string
+		profileGroup, errorString = getProfileGroup(groupID)
+		if errorString == "" {
 			body, err := ioutil.ReadAll(request.Body)
 			if err == nil {
-				type ProfileIDs struct {
-					ProfileIDs []string `json:"profileIDs"`
-				}
-				profileIDs := ProfileIDs{}
+				profileIDs := requests.ProfileIDsRequest{}
 				err := json.Unmarshal(body, &profileIDs)
 				if err == nil {
 					profileGroup.RemoveProfileIDsFromGroup(profileIDs.ProfileIDs)
@@ -317,7 +315,7 @@ func RemoveProfilesFromGroupEndpoint(response http.ResponseWriter, request *http
 				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProfileGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -329,66 +327,77 @@ func RemoveProfilesFromGroupEndpoint(response http.ResponseWriter, request *http
 	data := []entities.ProfileGroupWithProfiles{profileGroupWithProfiles}
 	result := &responses.ProfileGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetAllProfilesEndpoint handles the GET request at /api/profile/all
 func GetAllProfilesEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 	profiles, err := queries.GetAllProfiles()
 	if err != nil {
 		errorsList = append(errorsList, errors.GetAllProfilesError+err.Error())
 	}
-	result := &responses.ProfileResponse{Success: true, Data: profiles, Errors: make([]string, 0)}
+	indices, nextCursor, errorString := paginate(request, len(profiles), pagination.Sorts{
+		"creationDate": func(i int) pagination.Key {
+			return pagination.Key{Number: profiles[i].CreationDate, ID: profiles[i].ID}
+		},
+		"name": func(i int) pagination.Key {
+			return pagination.Key{Text: profiles[i].Name, ID: profiles[i].ID}
+		},
+	}, "creationDate", pagination.Ascending)
+	if errorString != "" {
+		errorsList = append(errorsList, errorString)
+	}
+	data := make([]entities.Profile, 0)
+	for _, i := range indices {
+		data = append(data, profiles[i])
+	}
+	result := &responses.ProfileResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetProfileEndpoint handles the GET request at /api/profile/{ID}
 func GetProfileEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profile entities.Profile
-	var err error
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		profile, err = queries.GetProfile(ID)
-		if err != nil {
-			errorsList = append(errorsList, errors.GetProfileError+err.Error())
+		var errorString string
+		profile, errorString = getProfile(ID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProfileResponse{Success: true, Data: []entities.Profile{profile}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CreateProfileEndpoint handles the POST request at /api/profile
 func CreateProfileEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	profile := &entities.Profile{ID: uuid.New().String()}
 	errorsList := make([]string, 0)
 
 	body, err := ioutil.ReadAll(request.Body)
 	if err == nil {
 		err = entities.ParseProfile(profile, body)
-		if err == nil {
+		if err == nil && profileNameTaken(profile.Name, profile.ID) {
+			errorsList = append(errorsList, errors.ProfileNameTakenError)
+		} else if err == nil {
 			profile.CreationDate = time.Now().Unix()
 			err = commands.CreateProfile(*profile)
 			if err != nil {
@@ -402,22 +411,24 @@ func CreateProfileEndpoint(response http.ResponseWriter, request *http.Request)
 	}
 	result := &responses.ProfileResponse{Success: true, Data: []entities.Profile{*profile}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveProfileEndpoint handles the DELETE request at /api/profile/{ID}
 func RemoveProfileEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profile entities.Profile
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProfile(ID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		tasks, err := queries.GetTasksByProfileID(ID)
 		if err == nil {
 			next := true
@@ -445,33 +456,35 @@ func RemoveProfileEndpoint(response http.ResponseWriter, request *http.Request)
 		} else {
 			errorsList = append(errorsList, errors.GetTaskError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProfileResponse{Success: true, Data: []entities.Profile{profile}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateProfileEndpoint handles the PUT request at /api/profile/{ID}
 func UpdateProfileEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profile entities.Profile
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProfile(ID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		newProfile := &entities.Profile{ID: ID}
 		body, err := ioutil.ReadAll(request.Body)
 		if err == nil {
 			err = entities.ParseProfile(newProfile, body)
-			if err == nil {
+			if err == nil && profileNameTaken(newProfile.Name, ID) {
+				errorsList = append(errorsList, errors.ProfileNameTakenError)
+			} else if err == nil {
 				profile, err = commands.UpdateProfile(ID, *newProfile)
 				if err != nil {
 					errorsList = append(errorsList, errors.UpdateProfileError+err.Error())
@@ -482,21 +495,17 @@ func UpdateProfileEndpoint(response http.ResponseWriter, request *http.Request)
 		} else {
 			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProfileResponse{Success: true, Data: []entities.Profile{profile}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CloneProfileEndpoint handles the POST request at /api/profile/{ID}/clone
 func CloneProfileEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var profile entities.Profile
 	var err error
 	errorsList := make([]string, 0)
@@ -504,8 +513,9 @@ func CloneProfileEndpoint(response http.ResponseWriter, request *http.Request) {
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		profile, err = queries.GetProfile(ID)
-		if err == nil {
+		var errorString string
+		profile, errorString = getProfile(ID)
+		if errorString == "" {
 			newProfileID := uuid.New().String()
 			profile.SetID(newProfileID)
 			profile.SetName(profile.Name + " (Copy " + common.RandID(4) + ")")
@@ -524,125 +534,113 @@ func CloneProfileEndpoint(response http.ResponseWriter, request *http.Request) {
 				errorsList = append(errorsList, errors.CreateProfileError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProfileError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProfileResponse{Success: true, Data: []entities.Profile{profile}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
-// ImportProfilesEndpoint handles the POST request at /api/profile/import
+// ImportProfilesEndpoint handles the POST request at /api/profile/import. The CSV or JSON file is uploaded as the "file"
+// field of a multipart form or as the request body, or read from the filePath of a JSON request.
 func ImportProfilesEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	newProfiles := []entities.Profile{}
+	results := []entities.ProfileImportResult{}
 	skippedProfiles := 0
 	skippedGroups := 0
 	var err error
 	errorsList := make([]string, 0)
 
-	type ImportProfilesRequest struct {
-		FilePath string   `json:"filePath"`
-		GroupIDs []string `json:"groupIDs"`
-	}
+	importProfilesRequestInfo, requestError := readImportProfilesRequest(request)
+	if requestError == "" {
+		validGroupIDs := []string{}
+		validGroups := []entities.ProfileGroup{}
+		for _, groupID := range importProfilesRequestInfo.GroupIDs {
+			group, err := queries.GetProfileGroup(groupID)
+			if err == nil && group.GroupID != "" {
+				validGroups = append(validGroups, group)
+				validGroupIDs = append(validGroupIDs, groupID)
+			} else {
+				skippedGroups++
+			}
+		}
 
-	type ImportProfilesFileFormat struct {
-		Profiles []entities.Profile `json:"profiles"`
-	}
+		var profiles []entities.Profile
+		if importProfilesRequestInfo.Format == "csv" {
+			profiles, err = common.ParseProfilesCSV(importProfilesRequestInfo.File, importProfilesRequestInfo.Mapping)
+			if err != nil {
+				errorsList = append(errorsList, errors.ParseImportProfilesCSVError+err.Error())
+			}
+		} else {
+			profiles, err = parseImportProfilesJSON(importProfilesRequestInfo.File)
+			if err != nil {
+				errorsList = append(errorsList, errors.ParseImportProfilesFileError+err.Error())
+			}
+		}
+		if err == nil && len(profiles) == 0 {
+			errorsList = append(errorsList, errors.ParseImportProfilesFileError+"No profiles detected.")
+		}
 
-	body, err := ioutil.ReadAll(request.Body)
-	if err == nil {
-		importProfilesRequestInfo := ImportProfilesRequest{}
-		err = json.Unmarshal(body, &importProfilesRequestInfo)
-		if err == nil {
-			validGroupIDs := []string{}
-			validGroups := []entities.ProfileGroup{}
-			for _, groupID := range importProfilesRequestInfo.GroupIDs {
-				group, err := queries.GetProfileGroup(groupID)
-				if err == nil && group.GroupID != "" {
-					validGroups = append(validGroups, group)
-					validGroupIDs = append(validGroupIDs, groupID)
-				} else {
-					skippedGroups++
+		if len(errorsList) == 0 {
+			newProfileIDs := []string{}
+			for i, profile := range profiles {
+				common.NormalizeProfile(&profile)
+				result := entities.ProfileImportResult{Row: i + 1, Name: profile.Name, Errors: common.ValidateProfile(profile, time.Now())}
+				if profile.Name != "" && profileNameTaken(profile.Name, "") {
+					result.Errors = append(result.Errors, errors.ProfileNameTakenError)
 				}
-			}
 
-			file, err := os.Open(importProfilesRequestInfo.FilePath)
-			if err == nil {
-				defer file.Close()
-				byteValue, err := ioutil.ReadAll(file)
-				if err == nil {
-					profiles := ImportProfilesFileFormat{}
-					err = json.Unmarshal(byteValue, &profiles)
-					if err == nil && len(profiles.Profiles) > 0 {
-						newProfileIDs := []string{}
-						for _, profile := range profiles.Profiles {
-							// TODO @silent: Validate all fields
-							existingProfile, err := queries.GetProfileByName(profile.Name)
-							if err != nil || existingProfile.ID == "" || profile.Name == "" {
-								profile.ID = uuid.New().String()
-								profile.ProfileGroupIDs = validGroupIDs
-								if len(validGroupIDs) > 0 {
-									profile.ProfileGroupIDsJoined = strings.Join(validGroupIDs, ",")
-								}
-								profile.CreationDate = time.Now().Unix()
-
-								profile.ShippingAddress.ID = uuid.New().String()
-								profile.ShippingAddress.ProfileID = profile.ID
-								profile.BillingAddress.ID = uuid.New().String()
-								profile.BillingAddress.ProfileID = profile.ID
-								profile.CreditCard.ID = uuid.New().String()
-								profile.CreditCard.ProfileID = profile.ID
-								cardType := common.DetectCardType([]byte(profile.CreditCard.CardNumber))
-								if cardType != "" {
-									profile.CreditCard.CardType = cardType
-
-									err = commands.CreateProfile(profile)
-									if err == nil {
-										newProfiles = append(newProfiles, profile)
-										newProfileIDs = append(newProfileIDs, profile.ID)
-									} else {
-										skippedProfiles++
-									}
-								} else {
-									skippedProfiles++
-								}
-							} else {
-								skippedProfiles++
-							}
-						}
-
-						for _, group := range validGroups {
-							group.AddProfileIDsToGroup(newProfileIDs)
-							_, err = commands.UpdateProfileGroup(group.GroupID, group)
-							if err != nil {
-								skippedGroups++
-							}
-						}
+				if len(result.Errors) == 0 {
+					profile.ID = uuid.New().String()
+					profile.ProfileGroupIDs = validGroupIDs
+					if len(validGroupIDs) > 0 {
+						profile.ProfileGroupIDsJoined = strings.Join(validGroupIDs, ",")
+					}
+					profile.CreationDate = time.Now().Unix()
+
+					profile.ShippingAddress.ID = uuid.New().String()
+					profile.ShippingAddress.ProfileID = profile.ID
+					profile.BillingAddress.ID = uuid.New().String()
+					profile.BillingAddress.ProfileID = profile.ID
+					profile.CreditCard.ID = uuid.New().String()
+					profile.CreditCard.ProfileID = profile.ID
+					profile.CreditCard.CardType = common.DetectCardType([]byte(profile.CreditCard.CardNumber))
+
+					err = commands.CreateProfile(profile)
+					if err == nil {
+						newProfiles = append(newProfiles, profile)
+						newProfileIDs = append(newProfileIDs, profile.ID)
+						result.Imported = true
+						result.ProfileID = profile.ID
 					} else {
-						if err != nil {
-							errorsList = append(errorsList, errors.ParseImportProfilesFileError+err.Error())
-						} else {
-							errorsList = append(errorsList, errors.ParseImportProfilesFileError+"No profiles detected.")
-						}
+						result.Errors = append(result.Errors, errors.CreateProfileError+err.Error())
 					}
-				} else {
-					errorsList = append(errorsList, errors.ReadFileError+err.Error())
 				}
-			} else {
-				errorsList = append(errorsList, errors.OpenFileError+err.Error())
+				if !result.Imported {
+					skippedProfiles++
+				}
+				if result.Errors == nil {
+					result.Errors = []string{}
+				}
+				results = append(results, result)
+			}
+
+			for _, group := range validGroups {
+				group.AddProfileIDsToGroup(newProfileIDs)
+				_, err = commands.UpdateProfileGroup(group.GroupID, group)
+				if err != nil {
+					skippedGroups++
+				}
 			}
-		} else {
-			errorsList = append(errorsList, errors.ParseImportProfilesRequestError+err.Error())
 		}
 	} else {
-		errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+		errorsList = append(errorsList, requestError)
 	}
 
 	profileGroups, err := queries.GetAllProfileGroups()
@@ -657,10 +655,228 @@ func ImportProfilesEndpoint(response http.ResponseWriter, request *http.Request)
 		}
 		data = append(data, newProfileGroupWithProfiles)
 	}
-	result := &responses.ImportProfileResponse{Success: true, NewProfiles: newProfiles, SkippedProfiles: skippedProfiles, SkippedGroups: skippedGroups, Data: data, Errors: make([]string, 0)}
+	result := &responses.ImportProfileResponse{Success: true, NewProfiles: newProfiles, SkippedProfiles: skippedProfiles, SkippedGroups: skippedGroups, Results: results, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ImportProfileResponse{Success: false, NewProfiles: []entities.Profile{}, SkippedProfiles: 0, SkippedGroups: 0, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ImportProfileResponse{Success: false, NewProfiles: []entities.Profile{}, SkippedProfiles: 0, SkippedGroups: 0, Results: results, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
+
+// importProfilesRequest is a profile import file and the options to import it with
+type importProfilesRequest struct {
+	File     []byte
+	FileName string
+	// Format is csv or json
+	Format   string
+	GroupIDs []string
+	// Mapping maps Profile fields to the columns of a CSV file
+	Mapping map[string]string
+}
+
+// readImportProfilesRequest reads the import file and its options from the request. The options are read from the
+// multipart form's fields, the JSON request or the query, and a file without a format is detected from its name,
+// content type or contents. It returns the error to report if the request can't be read.
+func readImportProfilesRequest(request *http.Request) (importProfilesRequest, string) {
+	query := request.URL.Query()
+	importProfilesRequestInfo := importProfilesRequest{Format: strings.ToLower(query.Get("format"))}
+	if groupIDs := query.Get("groupIDs"); groupIDs != "" {
+		importProfilesRequestInfo.GroupIDs = strings.Split(groupIDs, ",")
+	}
+	mapping := query.Get("mapping")
+	contentType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+
+	if contentType == "multipart/form-data" {
+		err := request.ParseMultipartForm(32 << 20)
+		if err != nil {
+			return importProfilesRequestInfo, errors.ParseImportProfilesFormError + err.Error()
+		}
+		file, header, err := request.FormFile("file")
+		if err != nil {
+			return importProfilesRequestInfo, errors.ParseImportProfilesFormError + err.Error()
+		}
+		defer file.Close()
+		importProfilesRequestInfo.File, err = ioutil.ReadAll(file)
+		if err != nil {
+			return importProfilesRequestInfo, errors.ReadFileError + err.Error()
+		}
+		importProfilesRequestInfo.FileName = header.Filename
+		contentType = header.Header.Get("Content-Type")
+		if format := request.FormValue("format"); format != "" {
+			importProfilesRequestInfo.Format = strings.ToLower(format)
+		}
+		if groupIDs := request.MultipartForm.Value["groupIDs"]; len(groupIDs) > 0 {
+			importProfilesRequestInfo.GroupIDs = strings.Split(strings.Join(groupIDs, ","), ",")
+		}
+		if request.FormValue("mapping") != "" {
+			mapping = request.FormValue("mapping")
+		}
+	} else {
+		body, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			return importProfilesRequestInfo, errors.IOUtilReadAllError + err.Error()
+		}
+		importProfilesRequestInfo.File = body
+
+		// A JSON request can point to a file on disk, or be the file itself with the options alongside its profiles
+		trimmedBody := bytes.TrimSpace(body)
+		if contentType == "application/json" || (contentType == "" && bytes.HasPrefix(trimmedBody, []byte("{"))) {
+			jsonRequest := requests.ImportProfilesRequest{}
+			if bytes.HasPrefix(trimmedBody, []byte("{")) {
+				err = json.Unmarshal(body, &jsonRequest)
+				if err != nil {
+					return importProfilesRequestInfo, errors.ParseImportProfilesRequestError + err.Error()
+				}
+			}
+			if len(jsonRequest.GroupIDs) > 0 {
+				importProfilesRequestInfo.GroupIDs = jsonRequest.GroupIDs
+			}
+			if jsonRequest.Format != "" {
+				importProfilesRequestInfo.Format = strings.ToLower(jsonRequest.Format)
+			}
+			if jsonRequest.Mapping != nil {
+				importProfilesRequestInfo.Mapping = jsonRequest.Mapping
+			}
+			if jsonRequest.FilePath != "" {
+				file, err := os.Open(jsonRequest.FilePath)
+				if err != nil {
+					return importProfilesRequestInfo, errors.OpenFileError + err.Error()
+				}
+				defer file.Close()
+				importProfilesRequestInfo.File, err = ioutil.ReadAll(file)
+				if err != nil {
+					return importProfilesRequestInfo, errors.ReadFileError + err.Error()
+				}
+				importProfilesRequestInfo.FileName = jsonRequest.FilePath
+				contentType = ""
+			}
+		}
+	}
+
+	if mapping != "" && importProfilesRequestInfo.Mapping == nil {
+		err := json.Unmarshal([]byte(mapping), &importProfilesRequestInfo.Mapping)
+		if err != nil {
+			return importProfilesRequestInfo, errors.ParseProfileColumnMappingError + err.Error()
+		}
+	}
+
+	if importProfilesRequestInfo.Format == "" {
+		switch {
+		case strings.EqualFold(filepath.Ext(importProfilesRequestInfo.FileName), ".csv"), contentType == "text/csv":
+			importProfilesRequestInfo.Format = "csv"
+		case strings.EqualFold(filepath.Ext(importProfilesRequestInfo.FileName), ".json"), contentType == "application/json":
+			importProfilesRequestInfo.Format = "json"
+		default:
+			trimmedFile := bytes.TrimSpace(importProfilesRequestInfo.File)
+			if bytes.HasPrefix(trimmedFile, []byte("{")) || bytes.HasPrefix(trimmedFile, []byte("[")) {
+				importProfilesRequestInfo.Format = "json"
+			} else {
+				importProfilesRequestInfo.Format = "csv"
+			}
+		}
+	}
+	if importProfilesRequestInfo.Format != "csv" && importProfilesRequestInfo.Format != "json" {
+		return importProfilesRequestInfo, errors.InvalidProfileFileFormatError + importProfilesRequestInfo.Format
+	}
+	return importProfilesRequestInfo, ""
+}
+
+// parseImportProfilesJSON returns the Profiles in a JSON import file, either a ProfilesFile or a list of Profiles
+func parseImportProfilesJSON(file []byte) ([]entities.Profile, error) {
+	trimmedFile := bytes.TrimSpace(file)
+	if bytes.HasPrefix(trimmedFile, []byte("[")) {
+		profiles := []entities.Profile{}
+		err := json.Unmarshal(trimmedFile, &profiles)
+		return profiles, err
+	}
+	profiles := responses.ProfilesFile{}
+	err := json.Unmarshal(trimmedFile, &profiles)
+	return profiles.Profiles, err
+}
+
+// ExportProfilesEndpoint handles the GET request at /api/profile/export
+func ExportProfilesEndpoint(response http.ResponseWriter, request *http.Request) {
+	profiles := []entities.Profile{}
+	var file []byte
+	var err error
+	errorsList := make([]string, 0)
+
+	format := strings.ToLower(request.URL.Query().Get("format"))
+	if format == "" {
+		format = "json"
+	}
+	groupID := request.URL.Query().Get("groupID")
+	if format != "csv" && format != "json" {
+		errorsList = append(errorsList, errors.InvalidProfileFileFormatError+format)
+	} else if groupID != "" {
+		profileGroup, errorString := getProfileGroup(groupID)
+		if errorString == "" {
+			var profileGroupWithProfiles entities.ProfileGroupWithProfiles
+			profileGroupWithProfiles, err = queries.ConvertProfileIDsToProfiles(&profileGroup)
+			if err == nil {
+				profiles = profileGroupWithProfiles.Profiles
+			} else {
+				errorsList = append(errorsList, errors.GetProfileError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errorString)
+		}
+	} else {
+		profiles, err = queries.GetAllProfiles()
+		if err != nil {
+			errorsList = append(errorsList, errors.GetAllProfilesError+err.Error())
+		}
+	}
+
+	if len(errorsList) == 0 {
+		if format == "csv" {
+			file, err = common.ProfilesToCSV(profiles)
+		} else {
+			file, err = json.MarshalIndent(responses.ProfilesFile{Profiles: profiles}, "", "  ")
+		}
+		if err != nil {
+			errorsList = append(errorsList, errors.ExportProfilesError+err.Error())
+		}
+	}
+
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		json.NewEncoder(response).Encode(&responses.ProfileResponse{Success: false, Data: make([]entities.Profile, 0), Errors: errorsList, Code: code})
+		return
+	}
+	if format == "csv" {
+		response.Header().Set("content-type", "text/csv")
+	}
+	response.Header().Set("Content-Disposition", `attachment; filename="profiles.`+format+`"`)
+	response.Write(file)
+}
+
+// getProfile returns the Profile with the ID, or the error to report if there isn't one
+func getProfile(ID string) (entities.Profile, string) {
+	profile, err := queries.GetProfile(ID)
+	if err != nil {
+		return profile, errors.GetProfileError + err.Error()
+	}
+	if profile.ID == "" {
+		return profile, errors.ProfileNotFoundError
+	}
+	return profile, ""
+}
+
+// getProfileGroup returns the ProfileGroup with the groupID, or the error to report if there isn't one
+func getProfileGroup(groupID string) (entities.ProfileGroup, string) {
+	profileGroup, err := queries.GetProfileGroup(groupID)
+	if err != nil {
+		return profileGroup, errors.GetProfileGroupError + err.Error()
+	}
+	if profileGroup.GroupID == "" {
+		return profileGroup, errors.ProfileGroupNotFoundError
+	}
+	return profileGroup, ""
+}
+
+// profileNameTaken returns true if a Profile other than the one with the ID has the name
+func profileNameTaken(name string, ID string) bool {
+	existingProfile, err := queries.GetProfileByName(name)
+	return err == nil && existingProfile.ID != "" && existingProfile.ID != ID
+}
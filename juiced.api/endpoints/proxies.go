@@ -3,6 +3,8 @@ package endpoints
 import (
 	"time"
 
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
 	"backend.juicedbot.io/juiced.api/responses"
 	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common"
@@ -14,6 +16,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -21,56 +24,68 @@ import (
 
 // GetProxyGroupEndpoint handles the GET request at /api/proxy/group/{groupID}
 func GetProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var proxyGroup entities.ProxyGroup
-	var err error
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		proxyGroup, err = queries.GetProxyGroup(groupID)
-		if err != nil {
-			errorsList = append(errorsList, errors.GetProxyGroupError+err.Error())
+		var errorString string
+		proxyGroup, errorString = getProxyGroup(groupID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{proxyGroup}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetAllProxyGroupsEndpoint handles the GET request at /api/proxy/group
 func GetAllProxyGroupsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 	proxyGroups, err := queries.GetAllProxyGroups()
 
+	data := make([]entities.ProxyGroup, 0)
+	nextCursor := ""
 	if err == nil {
 		for i := range proxyGroups {
 			stores.GetProxyStore().AddProxyGroup(&proxyGroups[i])
 		}
+		indices, cursor, errorString := paginate(request, len(proxyGroups), pagination.Sorts{
+			"creationDate": func(i int) pagination.Key {
+				return pagination.Key{Number: proxyGroups[i].CreationDate, ID: proxyGroups[i].GroupID}
+			},
+			"name": func(i int) pagination.Key {
+				return pagination.Key{Text: proxyGroups[i].Name, ID: proxyGroups[i].GroupID}
+			},
+		}, "creationDate", pagination.Ascending)
+		if errorString == "" {
+			for _, i := range indices {
+				data = append(data, proxyGroups[i])
+			}
+			nextCursor = cursor
+		} else {
+			errorsList = append(errorsList, errorString)
+		}
 	} else {
 		errorsList = append(errorsList, errors.GetAllProxyGroupsError+err.Error())
 	}
-	result := &responses.ProxyGroupResponse{Success: true, Data: proxyGroups, Errors: make([]string, 0)}
+	result := &responses.ProxyGroupResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CreateProxyGroupEndpoint handles the POST request at /api/proxy/group
 func CreateProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	proxyGroup := &entities.ProxyGroup{GroupID: uuid.New().String()}
 	errorsList := make([]string, 0)
 
@@ -100,22 +115,24 @@ func CreateProxyGroupEndpoint(response http.ResponseWriter, request *http.Reques
 	}
 	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{*proxyGroup}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveProxyGroupEndpoint handles the DELETE request at /api/proxy/group/{GroupID}
 func RemoveProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var proxyGroup entities.ProxyGroup
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProxyGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		taskGroups, err := queries.GetTaskGroupsByProxyGroupID(groupID)
 		if err == nil {
 			next := true
@@ -157,28 +174,28 @@ func RemoveProxyGroupEndpoint(response http.ResponseWriter, request *http.Reques
 		} else {
 			errorsList = append(errorsList, errors.RemoveProxyGroupError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{proxyGroup}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateProxyGroupEndpoint handles the PUT request at /api/proxy/group/{GroupID}
 func UpdateProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newProxyGroup entities.ProxyGroup
 
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProxyGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		newProxyGroup = entities.ProxyGroup{GroupID: groupID}
 		body, err := ioutil.ReadAll(request.Body)
 		if err == nil {
@@ -196,30 +213,26 @@ func UpdateProxyGroupEndpoint(response http.ResponseWriter, request *http.Reques
 		} else {
 			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{newProxyGroup}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CloneProxyGroupEndpoint handles the POST request at /api/proxy/group/{GroupID}/clone
 func CloneProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var proxyGroup entities.ProxyGroup
-	var err error
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		proxyGroup, err = queries.GetProxyGroup(groupID)
-		if err == nil {
+		var errorString string
+		proxyGroup, errorString = getProxyGroup(groupID)
+		if errorString == "" {
 			newGroupID := uuid.New().String()
 			proxyGroup.SetGroupID(newGroupID)
 			proxyGroup.SetName(proxyGroup.Name + " (Copy " + common.RandID(4) + ")")
@@ -229,22 +242,99 @@ func CloneProxyGroupEndpoint(response http.ResponseWriter, request *http.Request
 				proxy.SetID(uuid.New().String())
 				proxy.ProxyGroupID = newGroupID
 			}
-			err = commands.CreateProxyGroup(proxyGroup)
+			err := commands.CreateProxyGroup(proxyGroup)
 			if err == nil {
 				stores.GetProxyStore().AddProxyGroup(&proxyGroup)
 			} else {
 				errorsList = append(errorsList, errors.CreateProxyGroupError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetProxyGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{proxyGroup}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
+
+// getProxyGroup returns the ProxyGroup with the groupID, or the error to report if there isn't one
+// The concurrency and timeout in seconds of proxy tests that don't set them
+const (
+	defaultProxyTestConcurrency = 10
+	defaultProxyTestTimeout     = 10
+)
+
+// TestProxyGroupEndpoint handles the POST request at /api/proxy/group/{GroupID}/test
+func TestProxyGroupEndpoint(response http.ResponseWriter, request *http.Request) {
+	var proxyGroup entities.ProxyGroup
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getProxyGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
+		body, err := ioutil.ReadAll(request.Body)
+		if err == nil {
+			testRequest, errorString := parseTestProxyGroupRequest(body)
+			if errorString == "" {
+				proxyGroup, err = stores.GetProxyStore().TestProxyGroup(groupID, testRequest.URL, testRequest.Concurrency, time.Duration(testRequest.Timeout)*time.Second)
+				if err != nil {
+					errorsList = append(errorsList, errors.TestProxyGroupError+err.Error())
+				}
+			} else {
+				errorsList = append(errorsList, errorString)
+			}
+		} else {
+			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+		}
+	}
+	result := &responses.ProxyGroupResponse{Success: true, Data: []entities.ProxyGroup{proxyGroup}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.ProxyGroupResponse{Success: false, Data: make([]entities.ProxyGroup, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// parseTestProxyGroupRequest returns the TestProxyGroupRequest in the body with the defaults filled in, or the error
+// to report if it's invalid
+func parseTestProxyGroupRequest(body []byte) (requests.TestProxyGroupRequest, string) {
+	testRequest := requests.TestProxyGroupRequest{}
+	err := json.Unmarshal(body, &testRequest)
+	if err != nil {
+		return testRequest, errors.ParseTestProxyGroupRequestError + err.Error()
+	}
+	testURL, err := url.Parse(testRequest.URL)
+	if err != nil || (testURL.Scheme != "http" && testURL.Scheme != "https") || testURL.Host == "" {
+		return testRequest, errors.InvalidProxyTestURLError
+	}
+	if testRequest.Concurrency == 0 {
+		testRequest.Concurrency = defaultProxyTestConcurrency
+	}
+	if testRequest.Timeout == 0 {
+		testRequest.Timeout = defaultProxyTestTimeout
+	}
+	if testRequest.Concurrency < 1 || testRequest.Concurrency > 100 || testRequest.Timeout < 1 || testRequest.Timeout > 60 {
+		return testRequest, errors.InvalidProxyTestOptionsError
+	}
+	return testRequest, ""
+}
+
+func getProxyGroup(groupID string) (entities.ProxyGroup, string) {
+	proxyGroup, err := queries.GetProxyGroup(groupID)
+	if err != nil {
+		return proxyGroup, errors.GetProxyGroupError + err.Error()
+	}
+	if proxyGroup.GroupID == "" {
+		return proxyGroup, errors.ProxyGroupNotFoundError
+	}
+	return proxyGroup, ""
+}
@@ -0,0 +1,159 @@
+package endpoints
+
+import (
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetTaskGroupSchedulesEndpoint handles the GET request at /api/task/group/{GroupID}/schedule
+func GetTaskGroupSchedulesEndpoint(response http.ResponseWriter, request *http.Request) {
+	schedules := make([]entities.TaskGroupSchedule, 0)
+	var err error
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getTaskGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
+		schedules, err = queries.GetTaskGroupSchedules(groupID)
+		if err != nil {
+			errorsList = append(errorsList, errors.GetTaskGroupSchedulesError+err.Error())
+		}
+	}
+	result := &responses.TaskGroupSchedulesResponse{Success: true, Data: schedules, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupSchedulesResponse{Success: false, Data: make([]entities.TaskGroupSchedule, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// CreateTaskGroupScheduleEndpoint handles the POST request at /api/task/group/{GroupID}/schedule
+func CreateTaskGroupScheduleEndpoint(response http.ResponseWriter, request *http.Request) {
+	schedule := entities.TaskGroupSchedule{Enabled: true}
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	if ok {
+		_, errorString := getTaskGroup(groupID)
+		if errorString == "" {
+			body, err := ioutil.ReadAll(request.Body)
+			if err == nil {
+				err = entities.ParseTaskGroupSchedule(&schedule, body)
+				if err == nil {
+					schedule.TaskGroupID = groupID
+					err = stores.GetScheduleStore().AddSchedule(&schedule)
+					if err != nil {
+						errorsList = append(errorsList, errors.CreateTaskGroupScheduleError+err.Error())
+					}
+				} else {
+					errorsList = append(errorsList, errors.ParseTaskGroupScheduleError+err.Error())
+				}
+			} else {
+				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errorString)
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+	result := &responses.TaskGroupSchedulesResponse{Success: true, Data: []entities.TaskGroupSchedule{schedule}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupSchedulesResponse{Success: false, Data: make([]entities.TaskGroupSchedule, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// UpdateTaskGroupScheduleEndpoint handles the PUT request at /api/task/group/{GroupID}/schedule/{ScheduleID},
+// fields that aren't in the request keep their current values
+func UpdateTaskGroupScheduleEndpoint(response http.ResponseWriter, request *http.Request) {
+	var schedule entities.TaskGroupSchedule
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	scheduleID, ok2 := params["ScheduleID"]
+	if ok && ok2 {
+		var found bool
+		var err error
+		schedule, found, err = queries.GetTaskGroupSchedule(scheduleID)
+		if err == nil && (!found || schedule.TaskGroupID != groupID) {
+			errorsList = append(errorsList, errors.TaskGroupScheduleNotFoundError)
+		} else if err == nil {
+			var body []byte
+			body, err = ioutil.ReadAll(request.Body)
+			if err == nil {
+				err = entities.ParseTaskGroupSchedule(&schedule, body)
+				if err == nil {
+					schedule.ID = scheduleID
+					err = stores.GetScheduleStore().UpdateSchedule(groupID, &schedule)
+					if err != nil {
+						errorsList = append(errorsList, errors.UpdateTaskGroupScheduleError+err.Error())
+					}
+				} else {
+					errorsList = append(errorsList, errors.ParseTaskGroupScheduleError+err.Error())
+				}
+			} else {
+				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errors.GetTaskGroupSchedulesError+err.Error())
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+	result := &responses.TaskGroupSchedulesResponse{Success: true, Data: []entities.TaskGroupSchedule{schedule}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupSchedulesResponse{Success: false, Data: make([]entities.TaskGroupSchedule, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RemoveTaskGroupScheduleEndpoint handles the DELETE request at /api/task/group/{GroupID}/schedule/{ScheduleID}
+func RemoveTaskGroupScheduleEndpoint(response http.ResponseWriter, request *http.Request) {
+	var schedule entities.TaskGroupSchedule
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	scheduleID, ok2 := params["ScheduleID"]
+	if ok && ok2 {
+		var found bool
+		var err error
+		schedule, found, err = queries.GetTaskGroupSchedule(scheduleID)
+		if err == nil && (!found || schedule.TaskGroupID != groupID) {
+			errorsList = append(errorsList, errors.TaskGroupScheduleNotFoundError)
+		} else if err == nil {
+			err = stores.GetScheduleStore().RemoveSchedule(groupID, scheduleID)
+			if err != nil {
+				errorsList = append(errorsList, errors.RemoveTaskGroupScheduleError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errors.GetTaskGroupSchedulesError+err.Error())
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+	result := &responses.TaskGroupSchedulesResponse{Success: true, Data: []entities.TaskGroupSchedule{schedule}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupSchedulesResponse{Success: false, Data: make([]entities.TaskGroupSchedule, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
@@ -4,12 +4,17 @@ import (
 	"log"
 	"time"
 
+	"backend.juicedbot.io/juiced.api/middleware"
+	"backend.juicedbot.io/juiced.api/requests"
 	"backend.juicedbot.io/juiced.api/responses"
 	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/captcha"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,10 +24,12 @@ import (
 	"net/http"
 )
 
+// RemoteAccessChanged is called with the new Settings whenever the remote access settings might have changed, so that the
+// remote access server can be started, stopped or restarted to match them
+var RemoteAccessChanged func(settings entities.Settings)
+
 // GetSettingsEndpoint handles the GET request at /api/settings
 func GetSettingsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var settings entities.Settings
 	errorsList := make([]string, 0)
 
@@ -32,16 +39,30 @@ func GetSettingsEndpoint(response http.ResponseWriter, request *http.Request) {
 	}
 	result := &responses.SettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RevealSettingsEndpoint handles the GET request at /api/settings/reveal
+func RevealSettingsEndpoint(response http.ResponseWriter, request *http.Request) {
+	errorsList := make([]string, 0)
+
+	settings, err := queries.GetSettings()
+	if err != nil {
+		errorsList = append(errorsList, errors.GetSettingsError+err.Error())
+	}
+	result := &responses.RevealedSettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.RevealedSettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateSettingsEndpoint handles the PUT request at /api/settings
 func UpdateSettingsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newSettings entities.Settings
 	errorsList := make([]string, 0)
 
@@ -66,27 +87,60 @@ func UpdateSettingsEndpoint(response http.ResponseWriter, request *http.Request)
 				if newSettings.CapMonsterAPIKey == "-1" {
 					newSettings.CapMonsterAPIKey = currentSettings.CapMonsterAPIKey
 				}
-				aycdChanged := false
 				if newSettings.AYCDAccessToken == "-1" {
 					newSettings.AYCDAccessToken = currentSettings.AYCDAccessToken
-				} else {
-					aycdChanged = true
 				}
 				if newSettings.AYCDAPIKey == "-1" {
 					newSettings.AYCDAPIKey = currentSettings.AYCDAPIKey
-				} else {
-					aycdChanged = true
 				}
+				// The secrets are masked in responses, so a masked secret sent back means it hasn't changed
+				newSettings.TwoCaptchaAPIKey = common.UnmaskSecret(newSettings.TwoCaptchaAPIKey, currentSettings.TwoCaptchaAPIKey)
+				newSettings.AntiCaptchaAPIKey = common.UnmaskSecret(newSettings.AntiCaptchaAPIKey, currentSettings.AntiCaptchaAPIKey)
+				newSettings.CapMonsterAPIKey = common.UnmaskSecret(newSettings.CapMonsterAPIKey, currentSettings.CapMonsterAPIKey)
+				newSettings.AYCDAccessToken = common.UnmaskSecret(newSettings.AYCDAccessToken, currentSettings.AYCDAccessToken)
+				newSettings.AYCDAPIKey = common.UnmaskSecret(newSettings.AYCDAPIKey, currentSettings.AYCDAPIKey)
+				aycdChanged := newSettings.AYCDAccessToken != currentSettings.AYCDAccessToken || newSettings.AYCDAPIKey != currentSettings.AYCDAPIKey
 				if !newSettings.DarkModeUpdate {
 					newSettings.DarkMode = currentSettings.DarkMode
 				}
 				if !newSettings.UseAnimationsUpdate {
 					newSettings.UseAnimations = currentSettings.UseAnimations
 				}
-				newSettings, err = commands.UpdateSettings(newSettings)
+				if !newSettings.TaskHistoryUpdate {
+					newSettings.TaskHistoryRetentionDays = currentSettings.TaskHistoryRetentionDays
+					newSettings.TaskHistoryMaxRuns = currentSettings.TaskHistoryMaxRuns
+				}
+				if newSettings.RemoteAccessUpdate {
+					newSettings.RemoteAccessToken = common.UnmaskSecret(newSettings.RemoteAccessToken, currentSettings.RemoteAccessToken)
+					if newSettings.RemoteAccessEnabled && newSettings.RemoteAccessToken == "" {
+						newSettings.RemoteAccessToken, err = middleware.NewToken()
+					}
+					if newSettings.RemoteAccessToken != "" && !middleware.StrongToken(newSettings.RemoteAccessToken) {
+						errorsList = append(errorsList, errors.RemoteAccessTokenTooShortError)
+					}
+				} else {
+					newSettings.RemoteAccessEnabled = currentSettings.RemoteAccessEnabled
+					newSettings.RemoteAccessPort = currentSettings.RemoteAccessPort
+					newSettings.RemoteAccessToken = currentSettings.RemoteAccessToken
+				}
+				if newSettings.NotificationSinksUpdate {
+					var errorString string
+					newSettings.NotificationSinks, errorString = updateNotificationSinks(newSettings.NotificationSinks, currentSettings.NotificationSinks)
+					if errorString != "" {
+						errorsList = append(errorsList, errorString)
+					}
+				} else {
+					newSettings.NotificationSinks = currentSettings.NotificationSinks
+				}
+				if err == nil && len(errorsList) == 0 {
+					newSettings, err = commands.UpdateSettings(newSettings)
+				}
 				if err != nil {
 					errorsList = append(errorsList, errors.UpdateSettingsError+err.Error())
-				} else {
+				} else if len(errorsList) == 0 {
+					if newSettings.RemoteAccessUpdate && RemoteAccessChanged != nil {
+						RemoteAccessChanged(newSettings)
+					}
 					if aycdChanged && newSettings.AYCDAccessToken != "" && newSettings.AYCDAPIKey != "" {
 						err = captcha.ConnectToAycd(newSettings.AYCDAccessToken, newSettings.AYCDAPIKey)
 						if err != nil {
@@ -108,16 +162,60 @@ func UpdateSettingsEndpoint(response http.ResponseWriter, request *http.Request)
 	}
 	result := &responses.SettingsResponse{Success: true, Data: newSettings, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RekeyDatabaseEndpoint handles the POST request at /api/settings/rekey
+func RekeyDatabaseEndpoint(response http.ResponseWriter, request *http.Request) {
+	var settings entities.Settings
+	errorsList := make([]string, 0)
+
+	// Running tasks could write values encrypted with the old key while the database is being rekeyed
+	if workspaceRunning() {
+		errorsList = append(errorsList, errors.RekeyWhileTasksRunningError)
+	} else {
+		newKey, err := common.NewKey()
+		if err == nil {
+			err = commands.RekeyDatabase(newKey)
+		}
+		if err == nil {
+			settings, err = queries.GetSettings()
+			if err != nil {
+				errorsList = append(errorsList, errors.GetSettingsError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errors.RekeyDatabaseError+err.Error())
+		}
+	}
+	result := &responses.SettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// GetAccountsEndpoint handles the GET request at /api/settings/accounts
+func GetAccountsEndpoint(response http.ResponseWriter, request *http.Request) {
+	errorsList := make([]string, 0)
+
+	accounts, err := stores.GetAccountStore().GetAccounts()
+	if err != nil {
+		errorsList = append(errorsList, errors.GetAccountsError+err.Error())
+	}
+	result := &responses.AccountsResponse{Success: true, Data: accounts, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.AccountsResponse{Success: false, Data: []entities.Account{}, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // AddAccountEndpoint handles the POST request at /api/settings/accounts
 func AddAccountEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var settings entities.Settings
 	var newAccount entities.Account
 	errorsList := make([]string, 0)
@@ -129,6 +227,7 @@ func AddAccountEndpoint(response http.ResponseWriter, request *http.Request) {
 			err = json.Unmarshal(body, &newAccount)
 			if err == nil {
 				newAccount.ID = uuid.New().String()
+				newAccount.Status, newAccount.StatusDate, newAccount.TaskID = enums.AccountStatusUnknown, 0, ""
 				newAccount.CreationDate = time.Now().Unix()
 				err = commands.AddAccount(newAccount)
 				if err == nil {
@@ -147,16 +246,14 @@ func AddAccountEndpoint(response http.ResponseWriter, request *http.Request) {
 	}
 	result := &responses.SettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateAccountEndpoint handles the PUT request at /api/settings/accounts/{ID}
 func UpdateAccountEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var settings entities.Settings
 	var newAccount entities.Account
 	errorsList := make([]string, 0)
@@ -171,8 +268,16 @@ func UpdateAccountEndpoint(response http.ResponseWriter, request *http.Request)
 				err = json.Unmarshal(body, &newAccount)
 				if err == nil {
 					newAccounts := []entities.Account{}
+					found := false
 					for _, account := range settings.Accounts {
 						if account.ID == ID {
+							found = true
+							newAccount.Password = common.UnmaskSecret(newAccount.Password, account.Password)
+							// The status only carries over if the Account still logs in the same way
+							newAccount.Status, newAccount.StatusDate, newAccount.TaskID = enums.AccountStatusUnknown, 0, ""
+							if newAccount.Email == account.Email && newAccount.Password == account.Password {
+								newAccount.Status, newAccount.StatusDate = account.Status, account.StatusDate
+							}
 							_, err = commands.UpdateAccount(ID, newAccount)
 							if err != nil {
 								errorsList = append(errorsList, errors.UpdateAccountError+err.Error())
@@ -182,6 +287,9 @@ func UpdateAccountEndpoint(response http.ResponseWriter, request *http.Request)
 							newAccounts = append(newAccounts, account)
 						}
 					}
+					if !found {
+						errorsList = append(errorsList, errors.AccountNotFoundError)
+					}
 					settings.Accounts = newAccounts
 				} else {
 					errorsList = append(errorsList, errors.ParseAccountError+err.Error())
@@ -197,33 +305,32 @@ func UpdateAccountEndpoint(response http.ResponseWriter, request *http.Request)
 	}
 	result := &responses.SettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveAccountsEndpoint handles the POST request at /api/settings/accounts/remove
 func RemoveAccountsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var settings entities.Settings
 	errorsList := make([]string, 0)
 
-	type DeleteAccountsRequest struct {
-		AccountIDs []string `json:"accountIDs"`
-	}
-
 	body, err := ioutil.ReadAll(request.Body)
 	if err == nil {
 		settings, err = queries.GetSettings()
 		if err == nil {
-			deleteAccountsRequestInfo := DeleteAccountsRequest{}
+			deleteAccountsRequestInfo := requests.DeleteAccountsRequest{}
 			err = json.Unmarshal(body, &deleteAccountsRequestInfo)
 			if err == nil {
 				newAccounts := []entities.Account{}
 				for _, account := range settings.Accounts {
 					if common.InSlice(deleteAccountsRequestInfo.AccountIDs, account.ID) {
+						if stores.GetAccountStore().InUse(account.ID) {
+							errorsList = append(errorsList, errors.AccountInUseError)
+							newAccounts = append(newAccounts, account)
+							continue
+						}
 						_, err = commands.RemoveAccount(account.ID)
 						if err != nil {
 							errorsList = append(errorsList, errors.RemoveAccountError+err.Error())
@@ -244,8 +351,37 @@ func RemoveAccountsEndpoint(response http.ResponseWriter, request *http.Request)
 	}
 	result := &responses.SettingsResponse{Success: true, Data: settings, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.SettingsResponse{Success: false, Data: entities.Settings{}, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
+
+// updateNotificationSinks returns the new NotificationSinks with IDs given to the ones that don't have one and the
+// masked secrets of the current ones unmasked, or the error to report if one of them is invalid
+func updateNotificationSinks(sinks []entities.NotificationSink, currentSinks []entities.NotificationSink) ([]entities.NotificationSink, string) {
+	if sinks == nil {
+		sinks = []entities.NotificationSink{}
+	}
+	for i := range sinks {
+		unmaskNotificationSink(&sinks[i], currentSinks)
+		if sinks[i].ID == "" {
+			sinks[i].ID = uuid.New().String()
+		}
+		err := notifications.Validate(sinks[i])
+		if err != nil {
+			return sinks, errors.InvalidNotificationSinkError + sinks[i].Name + ": " + err.Error()
+		}
+	}
+	return sinks, ""
+}
+
+// unmaskNotificationSink unmasks the secrets of the sink that are the masked secrets of the current sink with its ID
+func unmaskNotificationSink(sink *entities.NotificationSink, currentSinks []entities.NotificationSink) {
+	for _, currentSink := range currentSinks {
+		if sink.ID != "" && currentSink.ID == sink.ID {
+			sink.BotToken = common.UnmaskSecret(sink.BotToken, currentSink.BotToken)
+			sink.SMTPPassword = common.UnmaskSecret(sink.SMTPPassword, currentSink.SMTPPassword)
+		}
+	}
+}
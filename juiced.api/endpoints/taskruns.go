@@ -0,0 +1,77 @@
+package endpoints
+
+import (
+	"strconv"
+
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// GetTaskRunsEndpoint handles the GET request at /api/task/{ID}/runs
+func GetTaskRunsEndpoint(response http.ResponseWriter, request *http.Request) {
+	taskRuns := make([]entities.TaskRun, 0)
+	var err error
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	ID, ok := params["ID"]
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getTask(ID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
+		taskRuns, err = queries.GetTaskRuns(ID)
+		if err != nil {
+			errorsList = append(errorsList, errors.GetTaskRunsError+err.Error())
+		}
+	}
+	result := &responses.TaskRunsResponse{Success: true, Data: taskRuns, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskRunsResponse{Success: false, Data: make([]entities.TaskRun, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// GetTaskGroupTimelineEndpoint handles the GET request at /api/task/group/{GroupID}/timeline
+func GetTaskGroupTimelineEndpoint(response http.ResponseWriter, request *http.Request) {
+	timeline := make([]entities.TaskRunEvent, 0)
+	var err error
+	errorsList := make([]string, 0)
+
+	var since int64
+	if sinceParam := request.URL.Query().Get("since"); sinceParam != "" {
+		since, err = strconv.ParseInt(sinceParam, 10, 64)
+		if err != nil {
+			errorsList = append(errorsList, errors.ParseSinceError+err.Error())
+		}
+	}
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if len(errorsList) == 0 {
+		if _, errorString := getTaskGroup(groupID); errorString != "" {
+			errorsList = append(errorsList, errorString)
+		} else {
+			timeline, err = queries.GetTaskGroupTimeline(groupID, since)
+			if err != nil {
+				errorsList = append(errorsList, errors.GetTaskGroupTimelineError+err.Error())
+			}
+		}
+	}
+	result := &responses.TaskGroupTimelineResponse{Success: true, Data: timeline, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupTimelineResponse{Success: false, Data: make([]entities.TaskRunEvent, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
@@ -2,10 +2,12 @@ package endpoints
 
 import (
 	"log"
-	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
 	"backend.juicedbot.io/juiced.api/responses"
 	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common"
@@ -14,6 +16,7 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common/errors"
 	"backend.juicedbot.io/juiced.infrastructure/common/stores"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"backend.juicedbot.io/juiced.sitescripts/util"
 
 	"encoding/json"
 	"io/ioutil"
@@ -46,8 +49,6 @@ func UpdateStatuses(taskGroupWithTasks entities.TaskGroupWithTasks) entities.Tas
 
 // GetTaskGroupEndpoint handles the GET request at /api/task/group/{groupID}
 func GetTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskGroup entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -55,9 +56,10 @@ func GetTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroup, err = queries.GetTaskGroup(groupID)
-		if err != nil {
-			errorsList = append(errorsList, errors.GetTaskGroupError+err.Error())
+		var errorString string
+		taskGroup, errorString = getTaskGroup(groupID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -69,33 +71,45 @@ func GetTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
 	data := []entities.TaskGroupWithTasks{UpdateStatuses(newTaskGroupWithTasks)}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetAllTaskGroupsEndpoint handles the GET request at /api/task/group
 func GetAllTaskGroupsEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 	taskGroups, err := queries.GetAllTaskGroups()
 	if err != nil {
 		errorsList = append(errorsList, errors.GetAllTaskGroupsError+err.Error())
 	}
+	indices, nextCursor, errorString := paginate(request, len(taskGroups), pagination.Sorts{
+		"creationDate": func(i int) pagination.Key {
+			return pagination.Key{Number: taskGroups[i].CreationDate, ID: taskGroups[i].GroupID}
+		},
+		"name": func(i int) pagination.Key {
+			return pagination.Key{Text: taskGroups[i].Name, ID: taskGroups[i].GroupID}
+		},
+		"retailer": func(i int) pagination.Key {
+			return pagination.Key{Text: taskGroups[i].MonitorRetailer, ID: taskGroups[i].GroupID}
+		},
+	}, "creationDate", pagination.Ascending)
+	if errorString != "" {
+		errorsList = append(errorsList, errorString)
+	}
 	data := []entities.TaskGroupWithTasks{}
-	for i := 0; i < len(taskGroups); i++ {
+	for _, i := range indices {
 		newTaskGroupWithTasks, err := queries.ConvertTaskIDsToTasks(&taskGroups[i])
 		if err != nil {
 			errorsList = append(errorsList, errors.GetTaskError+err.Error())
 		}
 		data = append(data, UpdateStatuses(newTaskGroupWithTasks))
 	}
-	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
+	result := &responses.TaskGroupResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	err = json.NewEncoder(response).Encode(result)
 	if err != nil {
@@ -105,14 +119,6 @@ func GetAllTaskGroupsEndpoint(response http.ResponseWriter, request *http.Reques
 
 // CreateTaskGroupEndpoint handles the POST request at /api/task/group
 func CreateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Println(r)
-			log.Println(string(debug.Stack()))
-		}
-	}()
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	groupID := uuid.New().String()
 	taskGroup := &entities.TaskGroup{GroupID: groupID, TaskIDs: []string{}, MonitorDelay: 2000}
 	errorsList := make([]string, 0)
@@ -121,10 +127,14 @@ func CreateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 	if err == nil {
 		err = entities.ParseTaskGroup(taskGroup, body)
 		if err == nil {
-			taskGroup.CreationDate = time.Now().Unix()
-			err = commands.CreateTaskGroup(*taskGroup)
-			if err != nil {
-				errorsList = append(errorsList, errors.CreateTaskGroupError+err.Error())
+			if errorString := validateTargetMonitorInfo(taskGroup.TargetMonitorInfo); errorString != "" {
+				errorsList = append(errorsList, errorString)
+			} else {
+				taskGroup.CreationDate = time.Now().Unix()
+				err = commands.CreateTaskGroup(*taskGroup)
+				if err != nil {
+					errorsList = append(errorsList, errors.CreateTaskGroupError+err.Error())
+				}
 			}
 		} else {
 			errorsList = append(errorsList, errors.ParseTaskGroupError+err.Error())
@@ -139,8 +149,8 @@ func CreateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 	data := []entities.TaskGroupWithTasks{newTaskGroupWithTasks}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	err = json.NewEncoder(response).Encode(result)
 	if err != nil {
@@ -150,8 +160,6 @@ func CreateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 
 // RemoveTaskGroupEndpoint handles the DELETE request at /api/task/group/{GroupID}
 func RemoveTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskGroup entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -159,8 +167,9 @@ func RemoveTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroup, err = queries.GetTaskGroup(groupID)
-		if err == nil {
+		var errorString string
+		taskGroup, errorString = getTaskGroup(groupID)
+		if errorString == "" {
 			monitorStore := stores.GetMonitorStore()
 			_, err = monitorStore.StopMonitor(&taskGroup)
 			if err == nil {
@@ -182,7 +191,12 @@ func RemoveTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 				}
 				if next {
 					taskGroup, err = commands.RemoveTaskGroup(groupID, true)
-					if err != nil {
+					if err == nil {
+						err = stores.GetScheduleStore().RemoveTaskGroupSchedules(groupID)
+						if err != nil {
+							errorsList = append(errorsList, errors.RemoveTaskGroupScheduleError+err.Error())
+						}
+					} else {
 						errorsList = append(errorsList, errors.RemoveTaskGroupError+err.Error())
 					}
 				}
@@ -190,7 +204,7 @@ func RemoveTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 				errorsList = append(errorsList, errors.StopMonitorError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.RemoveTaskGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -202,80 +216,28 @@ func RemoveTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 	data := []entities.TaskGroupWithTasks{newTaskGroupWithTasks}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // UpdateTaskGroupEndpoint handles the PUT request at /api/task/group/{GroupID}
 func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newTaskGroup entities.TaskGroup
 	errorsList := make([]string, 0)
 
-	type AmazonUpdateInfo struct {
-		MonitorType enums.MonitorType `json:"monitorType"`
-	}
-	type BestBuyUpdateInfo struct{}
-	type BoxlunchUpdateInfo struct {
-		Sizes  string `json:"sizes"`
-		Colors string `json:"colors"`
-	}
-	type DisneyUpdateInfo struct {
-		Sizes  string `json:"sizes"`
-		Colors string `json:"colors"`
-	}
-	type GamestopUpdateInfo struct{}
-	type NeweggUpdateInfo struct{}
-	type PokemonCenterUpdateInfo struct{}
-	type HottopicUpdateInfo struct {
-		Sizes  string `json:"sizes"`
-		Colors string `json:"colors"`
-	}
-	type ShopifyUpdateInfo struct{}
-
-	type ToppsUpdateInfo struct{}
-	type TargetUpdateInfo struct {
-		CheckoutType enums.CheckoutType `json:"checkoutType"`
-		StoreID      string             `json:"storeID"`
-	}
-	type WalmartUpdateInfo struct {
-		SoldByWalmart bool `json:"soldByWalmart"`
-	}
-
-	type UpdateTaskGroupRequest struct {
-		Name                    string                  `json:"name"`
-		MonitorInput            string                  `json:"input"`
-		MonitorDelay            int                     `json:"delay"`
-		MonitorProxyGroupID     string                  `json:"proxyGroupId"`
-		MaxPrice                int                     `json:"maxPrice"`
-		AmazonUpdateInfo        AmazonUpdateInfo        `json:"amazonUpdateInfo"`
-		BestbuyUpdateInfo       BestBuyUpdateInfo       `json:"bestbuyUpdateInfo"`
-		BoxlunchUpdateInfo      BoxlunchUpdateInfo      `json:"boxlunchUpdateInfo"`
-		DisneyUpdateInfo        DisneyUpdateInfo        `json:"disneyUpdateInfo"`
-		GamestopUpdateInfo      GamestopUpdateInfo      `json:"gamestopUpdateInfo"`
-		HottopicUpdateInfo      HottopicUpdateInfo      `json:"hottopicUpdateInfo"`
-		NeweggUpdateInfo        NeweggUpdateInfo        `json:"neweggUpdateInfo"`
-		PokemonCenterUpdateInfo PokemonCenterUpdateInfo `json:"pokemoncenterUpdateInfo"`
-		ShopifyUpdateInfo       ShopifyUpdateInfo       `json:"shopifyUpdateInfo"`
-		TargetUpdateInfo        TargetUpdateInfo        `json:"targetUpdateInfo"`
-		ToppsUpdateInfo         ToppsUpdateInfo         `json:"toppsUpdateInfo"`
-		WalmartUpdateInfo       WalmartUpdateInfo       `json:"walmartUpdateInfo"`
-	}
-
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroup, err := queries.GetTaskGroup(groupID)
-		if err == nil {
+		taskGroup, errorString := getTaskGroup(groupID)
+		if errorString == "" {
 			monitorStore := stores.GetMonitorStore()
 			wasRunning, err := monitorStore.StopMonitor(&taskGroup)
 			if err == nil {
 				body, err := ioutil.ReadAll(request.Body)
 				if err == nil {
-					updateTaskGroupRequestInfo := UpdateTaskGroupRequest{}
+					updateTaskGroupRequestInfo := requests.UpdateTaskGroupRequest{}
 					err = json.Unmarshal(body, &updateTaskGroupRequestInfo)
 					if err == nil {
 						taskGroup.Name = updateTaskGroupRequestInfo.Name
@@ -440,15 +402,32 @@ func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 						case enums.Shopify:
 							newMonitors := make([]entities.ShopifySingleMonitorInfo, 0)
 							if updateTaskGroupRequestInfo.MonitorInput != "" {
-								vids := strings.Split(updateTaskGroupRequestInfo.MonitorInput, ",")
-								for _, vid := range vids {
-									monitor := entities.ShopifySingleMonitorInfo{
+								monitorType := updateTaskGroupRequestInfo.ShopifyUpdateInfo.MonitorType
+								if monitorType == "" && len(taskGroup.ShopifyMonitorInfo.Monitors) > 0 {
+									monitorType = taskGroup.ShopifyMonitorInfo.Monitors[0].MonitorType
+								}
+								if monitorType == enums.KeywordMonitor {
+									// The keywords are one comma-separated list, like "+dunk,-kids"
+									newMonitors = append(newMonitors, entities.ShopifySingleMonitorInfo{
 										MonitorID:   uuid.New().String(),
 										TaskGroupID: taskGroup.GroupID,
-										VariantID:   vid,
+										Keywords:    updateTaskGroupRequestInfo.MonitorInput,
+										Size:        updateTaskGroupRequestInfo.ShopifyUpdateInfo.Sizes,
 										MaxPrice:    maxPrice,
+										MonitorType: monitorType,
+									})
+								} else {
+									vids := strings.Split(updateTaskGroupRequestInfo.MonitorInput, ",")
+									for _, vid := range vids {
+										monitor := entities.ShopifySingleMonitorInfo{
+											MonitorID:   uuid.New().String(),
+											TaskGroupID: taskGroup.GroupID,
+											VariantID:   vid,
+											MaxPrice:    maxPrice,
+											MonitorType: enums.SKUMonitor,
+										}
+										newMonitors = append(newMonitors, monitor)
 									}
-									newMonitors = append(newMonitors, monitor)
 								}
 								taskGroup.ShopifyMonitorInfo.Monitors = newMonitors
 							}
@@ -456,17 +435,37 @@ func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 						case enums.Target:
 							newMonitors := make([]entities.TargetSingleMonitorInfo, 0)
 							if updateTaskGroupRequestInfo.MonitorInput != "" {
-								tcins := strings.Split(updateTaskGroupRequestInfo.MonitorInput, ",")
-								for _, tcin := range tcins {
-									monitor := entities.TargetSingleMonitorInfo{
+								monitorType := updateTaskGroupRequestInfo.TargetUpdateInfo.MonitorType
+								if monitorType == "" {
+									monitorType = taskGroup.TargetMonitorInfo.MonitorType
+								}
+								if monitorType == enums.KeywordMonitor {
+									// The keywords are one comma-separated list, like "+pokemon,+booster,-sleeve"
+									newMonitors = append(newMonitors, entities.TargetSingleMonitorInfo{
 										MonitorID:    uuid.New().String(),
 										TaskGroupID:  taskGroup.GroupID,
-										TCIN:         tcin,
+										Keywords:     updateTaskGroupRequestInfo.MonitorInput,
 										MaxPrice:     maxPrice,
 										CheckoutType: updateTaskGroupRequestInfo.TargetUpdateInfo.CheckoutType,
+									})
+								} else {
+									inputs := strings.Split(updateTaskGroupRequestInfo.MonitorInput, ",")
+									for _, input := range inputs {
+										monitor := entities.TargetSingleMonitorInfo{
+											MonitorID:    uuid.New().String(),
+											TaskGroupID:  taskGroup.GroupID,
+											MaxPrice:     maxPrice,
+											CheckoutType: updateTaskGroupRequestInfo.TargetUpdateInfo.CheckoutType,
+										}
+										if monitorType == enums.URLMonitor {
+											monitor.URL = input
+										} else {
+											monitor.TCIN = input
+										}
+										newMonitors = append(newMonitors, monitor)
 									}
-									newMonitors = append(newMonitors, monitor)
 								}
+								taskGroup.TargetMonitorInfo.MonitorType = monitorType
 								taskGroup.TargetMonitorInfo.StoreID = updateTaskGroupRequestInfo.TargetUpdateInfo.StoreID
 								taskGroup.TargetMonitorInfo.Monitors = newMonitors
 							}
@@ -511,24 +510,28 @@ func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 
 						}
 
-						newTaskGroup, err = commands.UpdateTaskGroup(groupID, taskGroup)
-						if err == nil {
-							newTaskGroup.UpdateMonitor = true
-							if wasRunning {
-								err = monitorStore.StartMonitor(&newTaskGroup)
-								if err != nil {
-									errorsList = append(errorsList, errors.StartTaskGroupError+err.Error())
-								}
-							} else {
-								if monitorStore.GetMonitor(newTaskGroup.MonitorRetailer, newTaskGroup.GroupID) != nil {
-									err = monitorStore.UpdateMonitor(&newTaskGroup)
+						if errorString := validateTargetMonitorInfo(taskGroup.TargetMonitorInfo); errorString != "" {
+							errorsList = append(errorsList, errorString)
+						} else {
+							newTaskGroup, err = commands.UpdateTaskGroup(groupID, taskGroup)
+							if err == nil {
+								newTaskGroup.UpdateMonitor = true
+								if wasRunning {
+									err = monitorStore.StartMonitor(&newTaskGroup)
 									if err != nil {
-										errorsList = append(errorsList, errors.UpdateTaskGroupError+err.Error())
+										errorsList = append(errorsList, errors.StartTaskGroupError+err.Error())
+									}
+								} else {
+									if monitorStore.GetMonitor(newTaskGroup.GroupID) != nil {
+										err = monitorStore.UpdateMonitor(&newTaskGroup)
+										if err != nil {
+											errorsList = append(errorsList, errors.UpdateTaskGroupError+err.Error())
+										}
 									}
 								}
+							} else {
+								errorsList = append(errorsList, errors.UpdateTaskGroupError+err.Error())
 							}
-						} else {
-							errorsList = append(errorsList, errors.UpdateTaskGroupError+err.Error())
 						}
 					} else {
 						errorsList = append(errorsList, errors.ParseUpdateTaskGroupRequestError+err.Error())
@@ -540,7 +543,7 @@ func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 				errorsList = append(errorsList, errors.StopMonitorError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -552,16 +555,14 @@ func UpdateTaskGroupEndpoint(response http.ResponseWriter, request *http.Request
 	data := []entities.TaskGroupWithTasks{UpdateStatuses(newTaskGroupWithTasks)}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CloneTaskGroupEndpoint handles the POST request at /api/task/group/{GroupID}/clone
 func CloneTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newTaskGroup entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -570,8 +571,9 @@ func CloneTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 	groupID, ok := params["GroupID"]
 
 	if ok {
-		newTaskGroup, err = queries.GetTaskGroup(groupID)
-		if err == nil {
+		var errorString string
+		newTaskGroup, errorString = getTaskGroup(groupID)
+		if errorString == "" {
 			newTaskGroup.SetGroupID(uuid.New().String())
 			newTaskGroup.SetName(newTaskGroup.Name + " (Copy " + common.RandID(4) + ")")
 			newTaskGroup.CreationDate = time.Now().Unix()
@@ -600,7 +602,7 @@ func CloneTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 				errorsList = append(errorsList, errors.CreateTaskGroupError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -612,16 +614,14 @@ func CloneTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 	data := []entities.TaskGroupWithTasks{newTaskGroupWithTasks}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // StartTaskGroupEndpoint handles the POST request at /api/task/group/{GroupID}/start
 func StartTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskGroupToStart entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -630,15 +630,16 @@ func StartTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroupToStart, err = queries.GetTaskGroup(groupID)
-		if err == nil {
+		var errorString string
+		taskGroupToStart, errorString = getTaskGroup(groupID)
+		if errorString == "" {
 			taskStore := stores.GetTaskStore()
 			warningsList, err = taskStore.StartTaskGroup(&taskGroupToStart)
 			if err != nil {
 				errorsList = append(errorsList, errors.StartTaskGroupError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -651,16 +652,14 @@ func StartTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 
 	result := &responses.TaskGroupResponse{Success: true, Data: []entities.TaskGroupWithTasks{UpdateStatuses(taskGroupToStartWithTasks)}, Errors: make([]string, 0), Warnings: warningsList}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: make([]entities.TaskGroupWithTasks, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: make([]entities.TaskGroupWithTasks, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // StopTaskGroupEndpoint handles the POST request at /api/task/group/{GroupID}/stop
 func StopTaskGroupEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskGroupToStop entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
@@ -668,15 +667,16 @@ func StopTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroupToStop, err = queries.GetTaskGroup(groupID)
-		if err == nil {
+		var errorString string
+		taskGroupToStop, errorString = getTaskGroup(groupID)
+		if errorString == "" {
 			taskStore := stores.GetTaskStore()
 			err = taskStore.StopTaskGroup(&taskGroupToStop)
 			if err != nil {
 				errorsList = append(errorsList, errors.StopTaskError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -689,31 +689,26 @@ func StopTaskGroupEndpoint(response http.ResponseWriter, request *http.Request)
 
 	result := &responses.TaskGroupResponse{Success: true, Data: []entities.TaskGroupWithTasks{UpdateStatuses(taskGroupToStopWithTasks)}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: make([]entities.TaskGroupWithTasks, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: make([]entities.TaskGroupWithTasks, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // RemoveTasksEndpoint handles the POST request at api/task/group/{GroupID}/removeTasks
 func RemoveTasksEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var newTaskGroup entities.TaskGroup
 	errorsList := make([]string, 0)
 
-	type DeleteTasksRequest struct {
-		TaskIDs []string `json:"taskIDs"`
-	}
-
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
 		body, err := ioutil.ReadAll(request.Body)
 		if err == nil {
-			newTaskGroup, err = queries.GetTaskGroup(groupID)
-			if err == nil {
-				deleteTasksRequestInfo := DeleteTasksRequest{}
+			var errorString string
+			newTaskGroup, errorString = getTaskGroup(groupID)
+			if errorString == "" {
+				deleteTasksRequestInfo := requests.DeleteTasksRequest{}
 				err = json.Unmarshal(body, &deleteTasksRequestInfo)
 				if err == nil {
 					newTaskIDs := make([]string, 0)
@@ -740,7 +735,7 @@ func RemoveTasksEndpoint(response http.ResponseWriter, request *http.Request) {
 								errorsList = append(errorsList, errors.RemoveTaskError+err.Error())
 							}
 						}
-						if !taskStore.TasksRunning(newTaskGroup.TaskIDs, newTaskGroup.MonitorRetailer) {
+						if !taskStore.TasksRunning(newTaskGroup.TaskIDs) {
 							monitorStore := stores.GetMonitorStore()
 							_, err = monitorStore.StopMonitor(&newTaskGroup)
 							if err != nil {
@@ -754,7 +749,7 @@ func RemoveTasksEndpoint(response http.ResponseWriter, request *http.Request) {
 					errorsList = append(errorsList, errors.ParseDeleteTasksRequestError+err.Error())
 				}
 			} else {
-				errorsList = append(errorsList, errors.GetTaskGroupError+err.Error())
+				errorsList = append(errorsList, errorString)
 			}
 		} else {
 			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
@@ -769,99 +764,122 @@ func RemoveTasksEndpoint(response http.ResponseWriter, request *http.Request) {
 	data := []entities.TaskGroupWithTasks{UpdateStatuses(newTaskGroupWithTasks)}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetAllTasksEndpoint handles the GET request at /api/task/all
 func GetAllTasksEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	errorsList := make([]string, 0)
 	tasks, err := queries.GetAllTasks()
-	if err != nil {
+	data := make([]entities.Task, 0)
+	nextCursor := ""
+	if err == nil {
+		indices, cursor, errorString := paginate(request, len(tasks), pagination.Sorts{
+			"creationDate": func(i int) pagination.Key {
+				return pagination.Key{Number: tasks[i].CreationDate, ID: tasks[i].ID}
+			},
+			"retailer": func(i int) pagination.Key {
+				return pagination.Key{Text: tasks[i].TaskRetailer, ID: tasks[i].ID}
+			},
+			"status": func(i int) pagination.Key {
+				return pagination.Key{Text: tasks[i].TaskStatus, ID: tasks[i].ID}
+			},
+		}, "creationDate", pagination.Ascending)
+		if errorString == "" {
+			for _, i := range indices {
+				data = append(data, tasks[i])
+			}
+			nextCursor = cursor
+		} else {
+			errorsList = append(errorsList, errorString)
+		}
+	} else {
 		errorsList = append(errorsList, errors.GetAllTasksError+err.Error())
 	}
-	result := &responses.TaskResponse{Success: true, Data: tasks, Errors: make([]string, 0)}
+	result := &responses.TaskResponse{Success: true, Data: data, NextCursor: nextCursor, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // GetTaskEndpoint handles the GET request at /api/task/{ID}
 func GetTaskEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var task entities.Task
-	var err error
 	errorsList := make([]string, 0)
 
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		task, err = queries.GetTask(ID)
-		if err != nil {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+		var errorString string
+		task, errorString = getTask(ID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.TaskResponse{Success: true, Data: []entities.Task{task}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// RevealTaskEndpoint handles the GET request at /api/task/{ID}/reveal
+func RevealTaskEndpoint(response http.ResponseWriter, request *http.Request) {
+	var task entities.Task
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	ID, ok := params["ID"]
+	if ok {
+		var errorString string
+		task, errorString = getTask(ID)
+		if errorString != "" {
+			errorsList = append(errorsList, errorString)
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+	result := &responses.RevealedTaskResponse{Success: true, Data: []entities.Task{task}, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.RevealedTaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CreateTaskEndpoint handles the POST request at /api/task/{groupID}
 func CreateTaskEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	task := &entities.Task{ID: uuid.New().String(), TaskSize: make([]string, 0), TaskQty: 1, TaskStatus: enums.TaskIdle}
 	var newTaskGroup entities.TaskGroup
 	errorsList := make([]string, 0)
 
-	type CreateTaskRequest struct {
-		NumTasksPerProfile    int                             `json:"numTasksPerProfile"`
-		ProfileIDs            []string                        `json:"profileIDs"`
-		ProfileGroupID        string                          `json:"profileGroupID"`
-		ProxyGroupID          string                          `json:"proxyGroupID"`
-		Retailer              string                          `json:"retailer"`
-		Sizes                 []string                        `json:"sizes"`
-		Quantity              int                             `json:"quantity"`
-		Delay                 int                             `json:"delay"`
-		AmazonTaskInfo        *entities.AmazonTaskInfo        `json:"amazonTaskInfo"`
-		BestbuyTaskInfo       *entities.BestbuyTaskInfo       `json:"bestbuyTaskInfo"`
-		BoxlunchTaskInfo      *entities.BoxlunchTaskInfo      `json:"boxlunchTaskInfo"`
-		DisneyTaskInfo        *entities.DisneyTaskInfo        `json:"disneyTaskInfo"`
-		GamestopTaskInfo      *entities.GamestopTaskInfo      `json:"gamestopTaskInfo"`
-		HottopicTaskInfo      *entities.HottopicTaskInfo      `json:"hottopicTaskInfo"`
-		NeweggTaskInfo        *entities.NeweggTaskInfo        `json:"neweggTaskInfo"`
-		PokemonCenterTaskInfo *entities.PokemonCenterTaskInfo `json:"pokemoncenterTaskInfo"`
-		ShopifyTaskInfo       *entities.ShopifyTaskInfo       `json:"shopifyTaskInfo"`
-		TargetTaskInfo        *entities.TargetTaskInfo        `json:"targetTaskInfo"`
-		ToppsTaskInfo         *entities.ToppsTaskInfo         `json:"toppsTaskInfo"`
-		WalmartTaskInfo       *entities.WalmartTaskInfo       `json:"walmartTaskInfo"`
-	}
-
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
-	if ok {
+	if !ok {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	} else if _, errorString := getTaskGroup(groupID); errorString != "" {
+		errorsList = append(errorsList, errorString)
+	} else {
 		task.TaskGroupID = groupID
 		body, err := ioutil.ReadAll(request.Body)
 		if err == nil {
-			createTaskRequestInfo := CreateTaskRequest{}
+			createTaskRequestInfo := requests.CreateTaskRequest{}
 			err = json.Unmarshal(body, &createTaskRequestInfo)
 			if err == nil {
 				task.TaskProxyGroupID = createTaskRequestInfo.ProxyGroupID
+				task.TaskAccountID = createTaskRequestInfo.AccountID
 				task.TaskRetailer = createTaskRequestInfo.Retailer
 				task.TaskSize = createTaskRequestInfo.Sizes
 				task.TaskSizeJoined = strings.Join(createTaskRequestInfo.Sizes, ",")
+				task.TaskSizeFallback = createTaskRequestInfo.SizeFallback
 				if createTaskRequestInfo.Quantity > 0 {
 					task.TaskQty = createTaskRequestInfo.Quantity
 				}
@@ -920,7 +938,11 @@ func CreateTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 					profileIDs = profileGroup.ProfileIDs
 				}
 
-				if err == nil {
+				if !validSizeFallback(createTaskRequestInfo.SizeFallback) {
+					errorsList = append(errorsList, errors.InvalidSizeFallbackError+createTaskRequestInfo.SizeFallback)
+				} else if errorString := validateTaskAccount(task.TaskAccountID, task.TaskRetailer); errorString != "" {
+					errorsList = append(errorsList, errorString)
+				} else if err == nil {
 					oldTaskGroup, err := queries.GetTaskGroup(groupID)
 					if err == nil {
 						if createTaskRequestInfo.Retailer == enums.Amazon {
@@ -973,8 +995,6 @@ func CreateTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 		} else {
 			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 		}
-	} else {
-		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 
 	newTaskGroupWithTasks, err := queries.ConvertTaskIDsToTasks(&newTaskGroup)
@@ -984,191 +1004,290 @@ func CreateTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 	data := []entities.TaskGroupWithTasks{UpdateStatuses(newTaskGroupWithTasks)}
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
+	}
+	json.NewEncoder(response).Encode(result)
+}
+
+// GenerateTasksEndpoint handles the POST request at /api/task/group/{GroupID}/generate
+func GenerateTasksEndpoint(response http.ResponseWriter, request *http.Request) {
+	taskIDs := []string{}
+	errorsList := make([]string, 0)
+
+	params := mux.Vars(request)
+	groupID, ok := params["GroupID"]
+	if ok {
+		body, err := ioutil.ReadAll(request.Body)
+		if err == nil {
+			generator := entities.TaskGenerator{}
+			err = entities.ParseTaskGenerator(&generator, body)
+			if err == nil {
+				var generateError string
+				taskIDs, generateError = generateTasks(groupID, generator)
+				if generateError != "" {
+					errorsList = append(errorsList, generateError)
+				}
+			} else {
+				errorsList = append(errorsList, errors.ParseTaskGeneratorError+err.Error())
+			}
+		} else {
+			errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
+		}
+	} else {
+		errorsList = append(errorsList, errors.MissingParameterError)
+	}
+	result := &responses.TaskIDsResponse{Success: true, Data: taskIDs, Errors: make([]string, 0)}
+	if len(errorsList) > 0 {
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskIDsResponse{Success: false, Data: make([]string, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
+// generateTasks creates the generator's Tasks in the TaskGroup with the given groupID and returns their IDs,
+// or the error to report if none of them were created
+func generateTasks(groupID string, generator entities.TaskGenerator) ([]string, string) {
+	if generator.OnePerProfile == (generator.Count > 0) {
+		return nil, errors.InvalidTaskGeneratorModeError
+	}
+
+	taskGroup, errorString := getTaskGroup(groupID)
+	if errorString != "" {
+		return nil, errorString
+	}
+	template := &generator.Template
+	if template.TaskRetailer == "" {
+		template.TaskRetailer = taskGroup.MonitorRetailer
+	} else if template.TaskRetailer != taskGroup.MonitorRetailer {
+		return nil, errors.TaskRetailerMismatchError + template.TaskRetailer
+	}
+	if template.TaskQty <= 0 {
+		template.TaskQty = 1
+	}
+	if !validSizeFallback(template.TaskSizeFallback) {
+		return nil, errors.InvalidSizeFallbackError + template.TaskSizeFallback
+	}
+	if template.TaskRetailer == enums.Shopify && template.ShopifyTaskInfo != nil && taskGroup.ShopifyMonitorInfo != nil {
+		template.ShopifyTaskInfo.SiteURL = taskGroup.ShopifyMonitorInfo.SiteURL
+		template.ShopifyTaskInfo.SitePassword = taskGroup.ShopifyMonitorInfo.SitePassword
+	}
+
+	profileGroup, err := queries.GetProfileGroup(generator.ProfileGroupID)
+	if err != nil {
+		return nil, errors.GetProfileGroupError + err.Error()
+	}
+	if profileGroup.GroupID == "" {
+		return nil, errors.GetProfileGroupError + "no ProfileGroup has the given ID"
+	}
+	profileIDs := []string{}
+	for _, profileID := range profileGroup.ProfileIDs {
+		profile, err := queries.GetProfile(profileID)
+		if err == nil && profile.ID != "" {
+			profileIDs = append(profileIDs, profileID)
+		}
+	}
+	if len(profileIDs) == 0 {
+		return nil, errors.EmptyProfileGroupError
+	}
+
+	if generator.ProxyGroupID != "" {
+		proxyGroup, err := queries.GetProxyGroup(generator.ProxyGroupID)
+		if err != nil {
+			return nil, errors.GetProxyGroupError + err.Error()
+		}
+		if proxyGroup.GroupID == "" {
+			return nil, errors.GetProxyGroupError + "no ProxyGroup has the given ID"
+		}
+	}
+
+	if generator.NumTasks(len(profileIDs)) > entities.MaxGeneratedTasks {
+		return nil, errors.TooManyGeneratedTasksError + strconv.Itoa(entities.MaxGeneratedTasks)
+	}
+	tasks := generator.Generate(groupID, profileIDs)
+	err = commands.CreateTasks(groupID, tasks)
+	if err != nil {
+		return nil, errors.GenerateTasksError + err.Error()
+	}
+
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	return taskIDs, ""
+}
+
 // UpdateTasksEndpoint handles the PUT request at /api/task/group/{groupID}/updateTasks
 func UpdateTasksEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskGroup entities.TaskGroup
 	var err error
 	errorsList := make([]string, 0)
 
-	type UpdateTasksRequest struct {
-		TaskIDs               []string                       `json:"taskIDs"`
-		ProfileID             string                         `json:"profileID"`
-		ProxyGroupID          string                         `json:"proxyGroupID"`
-		Quantity              int                            `json:"quantity"`
-		AmazonTaskInfo        entities.AmazonTaskInfo        `json:"amazonTaskInfo"`
-		BestbuyTaskInfo       entities.BestbuyTaskInfo       `json:"bestbuyTaskInfo"`
-		BoxlunchTaskInfo      entities.BoxlunchTaskInfo      `json:"boxlunchTaskInfo"`
-		DisneyTaskInfo        entities.DisneyTaskInfo        `json:"disneyTaskInfo"`
-		GamestopTaskInfo      entities.GamestopTaskInfo      `json:"gamestopTaskInfo"`
-		HottopicTaskInfo      entities.HottopicTaskInfo      `json:"hottopicTaskInfo"`
-		NeweggTaskInfo        entities.NeweggTaskInfo        `json:"neweggTaskInfo"`
-		PokemonCenterTaskInfo entities.PokemonCenterTaskInfo `json:"pokemoncenterTaskInfo"`
-		ShopifyTaskInfo       entities.ShopifyTaskInfo       `json:"shopifyTaskInfo"`
-		TargetTaskInfo        entities.TargetTaskInfo        `json:"targetTaskInfo"`
-		ToppsTaskInfo         entities.ToppsTaskInfo         `json:"toppsTaskInfo"`
-		WalmartTaskInfo       entities.WalmartTaskInfo       `json:"walmartTaskInfo"`
-	}
-
 	params := mux.Vars(request)
 	groupID, ok := params["GroupID"]
 	if ok {
-		taskGroup, err = queries.GetTaskGroup(groupID)
-		if err == nil {
+		var errorString string
+		taskGroup, errorString = getTaskGroup(groupID)
+		if errorString == "" {
 			body, err := ioutil.ReadAll(request.Body)
 			if err == nil {
-				updateTasksRequestInfo := UpdateTasksRequest{}
+				updateTasksRequestInfo := requests.UpdateTasksRequest{}
 				err = json.Unmarshal(body, &updateTasksRequestInfo)
-				if err == nil {
+				if err == nil && !validSizeFallback(updateTasksRequestInfo.SizeFallback) {
+					errorsList = append(errorsList, errors.InvalidSizeFallbackError+updateTasksRequestInfo.SizeFallback)
+				} else if errorString := validateTaskAccount(updateTasksRequestInfo.AccountID, taskGroup.MonitorRetailer); err == nil && errorString != "" {
+					errorsList = append(errorsList, errorString)
+				} else if err == nil {
 					singleTask := len(updateTasksRequestInfo.TaskIDs) == 1
 					for _, taskID := range updateTasksRequestInfo.TaskIDs {
 						task, err := queries.GetTask(taskID)
 						if err == nil {
 							taskStore := stores.GetTaskStore()
 							var wasRunning bool
+							// StopTask waits for the task to exit, so it has to know not to publish its stop event beforehand
+							taskStore.SetDontPublishEvents(task.ID, true)
 							wasRunning, err = taskStore.StopTask(&task)
 							if err == nil {
-								err = taskStore.SetDontPublishEvents(task.TaskRetailer, task.ID, true)
-								if err == nil {
-									if updateTasksRequestInfo.ProfileID != "DO_NOT_UPDATE" {
-										task.TaskProfileID = updateTasksRequestInfo.ProfileID
-									}
-									if updateTasksRequestInfo.ProxyGroupID != "DO_NOT_UPDATE" {
-										task.TaskProxyGroupID = updateTasksRequestInfo.ProxyGroupID
+								if updateTasksRequestInfo.ProfileID != "DO_NOT_UPDATE" {
+									task.TaskProfileID = updateTasksRequestInfo.ProfileID
+								}
+								if updateTasksRequestInfo.ProxyGroupID != "DO_NOT_UPDATE" {
+									task.TaskProxyGroupID = updateTasksRequestInfo.ProxyGroupID
+								}
+								if updateTasksRequestInfo.AccountID != "DO_NOT_UPDATE" {
+									task.TaskAccountID = updateTasksRequestInfo.AccountID
+								}
+								if updateTasksRequestInfo.Quantity != -1 && updateTasksRequestInfo.Quantity > 0 {
+									task.TaskQty = updateTasksRequestInfo.Quantity
+								}
+								if updateTasksRequestInfo.Sizes != nil {
+									task.TaskSize = updateTasksRequestInfo.Sizes
+									task.TaskSizeJoined = strings.Join(updateTasksRequestInfo.Sizes, ",")
+								}
+								if updateTasksRequestInfo.SizeFallback != "" {
+									task.TaskSizeFallback = updateTasksRequestInfo.SizeFallback
+								}
+								switch taskGroup.MonitorRetailer {
+								case enums.Amazon:
+									if singleTask || updateTasksRequestInfo.AmazonTaskInfo.Email != "" {
+										task.AmazonTaskInfo.Email = updateTasksRequestInfo.AmazonTaskInfo.Email
 									}
-									if updateTasksRequestInfo.Quantity != -1 && updateTasksRequestInfo.Quantity > 0 {
-										task.TaskQty = updateTasksRequestInfo.Quantity
+									if singleTask || updateTasksRequestInfo.AmazonTaskInfo.Password != "" {
+										task.AmazonTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.AmazonTaskInfo.Password, task.AmazonTaskInfo.Password)
 									}
-									switch taskGroup.MonitorRetailer {
-									case enums.Amazon:
-										if singleTask || updateTasksRequestInfo.AmazonTaskInfo.Email != "" {
-											task.AmazonTaskInfo.Email = updateTasksRequestInfo.AmazonTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.AmazonTaskInfo.Password != "" {
-											task.AmazonTaskInfo.Password = updateTasksRequestInfo.AmazonTaskInfo.Password
-										}
 
-									case enums.BestBuy:
-										if updateTasksRequestInfo.BestbuyTaskInfo.TaskType != "DO_NOT_UPDATE" {
-											task.BestbuyTaskInfo.TaskType = updateTasksRequestInfo.BestbuyTaskInfo.TaskType
-										}
-										if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Email != "" {
-											task.BestbuyTaskInfo.Email = updateTasksRequestInfo.BestbuyTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Password != "" {
-											task.BestbuyTaskInfo.Password = updateTasksRequestInfo.BestbuyTaskInfo.Password
-										}
-									case enums.BoxLunch:
+								case enums.BestBuy:
+									if updateTasksRequestInfo.BestbuyTaskInfo.TaskType != "DO_NOT_UPDATE" {
+										task.BestbuyTaskInfo.TaskType = updateTasksRequestInfo.BestbuyTaskInfo.TaskType
+									}
+									if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Email != "" {
+										task.BestbuyTaskInfo.Email = updateTasksRequestInfo.BestbuyTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Password != "" {
+										task.BestbuyTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.BestbuyTaskInfo.Password, task.BestbuyTaskInfo.Password)
+									}
+								case enums.BoxLunch:
 
-									case enums.Disney:
-										if updateTasksRequestInfo.DisneyTaskInfo.TaskType != "DO_NOT_UPDATE" {
-											task.DisneyTaskInfo.TaskType = updateTasksRequestInfo.DisneyTaskInfo.TaskType
-										}
-										if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Email != "" {
-											task.DisneyTaskInfo.Email = updateTasksRequestInfo.DisneyTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Password != "" {
-											task.DisneyTaskInfo.Password = updateTasksRequestInfo.DisneyTaskInfo.Password
-										}
+								case enums.Disney:
+									if updateTasksRequestInfo.DisneyTaskInfo.TaskType != "DO_NOT_UPDATE" {
+										task.DisneyTaskInfo.TaskType = updateTasksRequestInfo.DisneyTaskInfo.TaskType
+									}
+									if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Email != "" {
+										task.DisneyTaskInfo.Email = updateTasksRequestInfo.DisneyTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.BestbuyTaskInfo.Password != "" {
+										task.DisneyTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.DisneyTaskInfo.Password, task.DisneyTaskInfo.Password)
+									}
 
-									case enums.GameStop:
-										if updateTasksRequestInfo.GamestopTaskInfo.TaskType != "DO_NOT_UPDATE" {
-											task.GamestopTaskInfo.TaskType = updateTasksRequestInfo.GamestopTaskInfo.TaskType
-										}
-										if singleTask || updateTasksRequestInfo.GamestopTaskInfo.Email != "" {
-											task.GamestopTaskInfo.Email = updateTasksRequestInfo.GamestopTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.GamestopTaskInfo.Password != "" {
-											task.GamestopTaskInfo.Password = updateTasksRequestInfo.GamestopTaskInfo.Password
-										}
+								case enums.GameStop:
+									if updateTasksRequestInfo.GamestopTaskInfo.TaskType != "DO_NOT_UPDATE" {
+										task.GamestopTaskInfo.TaskType = updateTasksRequestInfo.GamestopTaskInfo.TaskType
+									}
+									if singleTask || updateTasksRequestInfo.GamestopTaskInfo.Email != "" {
+										task.GamestopTaskInfo.Email = updateTasksRequestInfo.GamestopTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.GamestopTaskInfo.Password != "" {
+										task.GamestopTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.GamestopTaskInfo.Password, task.GamestopTaskInfo.Password)
+									}
 
-									case enums.HotTopic:
+								case enums.HotTopic:
 
-									case enums.Newegg:
+								case enums.Newegg:
 
-									case enums.PokemonCenter:
-										if updateTasksRequestInfo.PokemonCenterTaskInfo.TaskType != "DO_NOT_UPDATE" {
-											task.PokemonCenterTaskInfo.TaskType = updateTasksRequestInfo.PokemonCenterTaskInfo.TaskType
-										}
-										if updateTasksRequestInfo.PokemonCenterTaskInfo.AddressType != "DO_NOT_UPDATE" {
-											task.PokemonCenterTaskInfo.AddressType = updateTasksRequestInfo.PokemonCenterTaskInfo.AddressType
-										}
-										if singleTask || updateTasksRequestInfo.PokemonCenterTaskInfo.Email != "" {
-											task.PokemonCenterTaskInfo.Email = updateTasksRequestInfo.PokemonCenterTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.PokemonCenterTaskInfo.Password != "" {
-											task.PokemonCenterTaskInfo.Password = updateTasksRequestInfo.PokemonCenterTaskInfo.Password
-										}
+								case enums.PokemonCenter:
+									if updateTasksRequestInfo.PokemonCenterTaskInfo.TaskType != "DO_NOT_UPDATE" {
+										task.PokemonCenterTaskInfo.TaskType = updateTasksRequestInfo.PokemonCenterTaskInfo.TaskType
+									}
+									if updateTasksRequestInfo.PokemonCenterTaskInfo.AddressType != "DO_NOT_UPDATE" {
+										task.PokemonCenterTaskInfo.AddressType = updateTasksRequestInfo.PokemonCenterTaskInfo.AddressType
+									}
+									if singleTask || updateTasksRequestInfo.PokemonCenterTaskInfo.Email != "" {
+										task.PokemonCenterTaskInfo.Email = updateTasksRequestInfo.PokemonCenterTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.PokemonCenterTaskInfo.Password != "" {
+										task.PokemonCenterTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.PokemonCenterTaskInfo.Password, task.PokemonCenterTaskInfo.Password)
+									}
 
-									case enums.Shopify:
-										if updateTasksRequestInfo.ShopifyTaskInfo.CouponCode != "DO_NOT_UPDATE" {
-											task.ShopifyTaskInfo.CouponCode = updateTasksRequestInfo.ShopifyTaskInfo.CouponCode
-										}
-										if singleTask || updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Email != "" {
-											task.ShopifyTaskInfo.HotWheelsTaskInfo.Email = updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Password != "" {
-											task.ShopifyTaskInfo.HotWheelsTaskInfo.Password = updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Password
-										}
+								case enums.Shopify:
+									if updateTasksRequestInfo.ShopifyTaskInfo.CouponCode != "DO_NOT_UPDATE" {
+										task.ShopifyTaskInfo.CouponCode = updateTasksRequestInfo.ShopifyTaskInfo.CouponCode
+									}
+									if singleTask || updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Email != "" {
+										task.ShopifyTaskInfo.HotWheelsTaskInfo.Email = updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Password != "" {
+										task.ShopifyTaskInfo.HotWheelsTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.ShopifyTaskInfo.HotWheelsTaskInfo.Password, task.ShopifyTaskInfo.HotWheelsTaskInfo.Password)
+									}
 
-									case enums.Target:
-										if updateTasksRequestInfo.TargetTaskInfo.CheckoutType != "DO_NOT_UPDATE" {
-											task.TargetTaskInfo.CheckoutType = updateTasksRequestInfo.TargetTaskInfo.CheckoutType
-										}
-										if updateTasksRequestInfo.TargetTaskInfo.PaymentType != "DO_NOT_UPDATE" {
-											task.TargetTaskInfo.PaymentType = updateTasksRequestInfo.TargetTaskInfo.PaymentType
-										}
-										if singleTask || updateTasksRequestInfo.TargetTaskInfo.Email != "" {
-											task.TargetTaskInfo.Email = updateTasksRequestInfo.TargetTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.TargetTaskInfo.Password != "" {
-											task.TargetTaskInfo.Password = updateTasksRequestInfo.TargetTaskInfo.Password
-										}
+								case enums.Target:
+									if updateTasksRequestInfo.TargetTaskInfo.CheckoutType != "DO_NOT_UPDATE" {
+										task.TargetTaskInfo.CheckoutType = updateTasksRequestInfo.TargetTaskInfo.CheckoutType
+									}
+									if updateTasksRequestInfo.TargetTaskInfo.PaymentType != "DO_NOT_UPDATE" {
+										task.TargetTaskInfo.PaymentType = updateTasksRequestInfo.TargetTaskInfo.PaymentType
+									}
+									if singleTask || updateTasksRequestInfo.TargetTaskInfo.Email != "" {
+										task.TargetTaskInfo.Email = updateTasksRequestInfo.TargetTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.TargetTaskInfo.Password != "" {
+										task.TargetTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.TargetTaskInfo.Password, task.TargetTaskInfo.Password)
+									}
 
-									case enums.Topps:
-										if updateTasksRequestInfo.ToppsTaskInfo.TaskType != "DO_NOT_UPDATE" {
-											task.ToppsTaskInfo.TaskType = updateTasksRequestInfo.ToppsTaskInfo.TaskType
-										}
-										if singleTask || updateTasksRequestInfo.ToppsTaskInfo.Email != "" {
-											task.ToppsTaskInfo.Email = updateTasksRequestInfo.ToppsTaskInfo.Email
-										}
-										if singleTask || updateTasksRequestInfo.ToppsTaskInfo.Password != "" {
-											task.ToppsTaskInfo.Password = updateTasksRequestInfo.ToppsTaskInfo.Password
-										}
+								case enums.Topps:
+									if updateTasksRequestInfo.ToppsTaskInfo.TaskType != "DO_NOT_UPDATE" {
+										task.ToppsTaskInfo.TaskType = updateTasksRequestInfo.ToppsTaskInfo.TaskType
+									}
+									if singleTask || updateTasksRequestInfo.ToppsTaskInfo.Email != "" {
+										task.ToppsTaskInfo.Email = updateTasksRequestInfo.ToppsTaskInfo.Email
+									}
+									if singleTask || updateTasksRequestInfo.ToppsTaskInfo.Password != "" {
+										task.ToppsTaskInfo.Password = common.UnmaskSecret(updateTasksRequestInfo.ToppsTaskInfo.Password, task.ToppsTaskInfo.Password)
+									}
 
-									case enums.Walmart:
+								case enums.Walmart:
 
-									}
-									_, err := commands.UpdateTask(taskID, task)
-									if err == nil {
-										task.UpdateTask = true
-										if wasRunning {
-											err = taskStore.StartTask(&task)
+								}
+								_, err := commands.UpdateTask(taskID, task)
+								if err == nil {
+									task.UpdateTask = true
+									if wasRunning {
+										err = taskStore.StartTask(&task)
+										if err != nil {
+											errorsList = append(errorsList, errors.StartTaskError+err.Error())
+										}
+									} else {
+										if taskStore.GetTask(task.ID) != nil {
+											err = taskStore.UpdateTask(&task)
 											if err != nil {
-												errorsList = append(errorsList, errors.StartTaskError+err.Error())
-											}
-										} else {
-											if taskStore.GetTask(task.TaskRetailer, task.ID) != nil {
-												err = taskStore.UpdateTask(&task)
-												if err != nil {
-													errorsList = append(errorsList, errors.UpdateTaskError+err.Error())
-												}
+												errorsList = append(errorsList, errors.UpdateTaskError+err.Error())
 											}
 										}
-									} else {
-										errorsList = append(errorsList, errors.UpdateTaskError+err.Error())
 									}
 								} else {
-									errorsList = append(errorsList, errors.StopTaskError+err.Error())
+									errorsList = append(errorsList, errors.UpdateTaskError+err.Error())
 								}
 							} else {
+								taskStore.SetDontPublishEvents(task.ID, false)
 								errorsList = append(errorsList, errors.StopTaskError+err.Error())
 							}
 						} else {
@@ -1182,7 +1301,7 @@ func UpdateTasksEndpoint(response http.ResponseWriter, request *http.Request) {
 				errorsList = append(errorsList, errors.IOUtilReadAllError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskGroupError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -1196,16 +1315,14 @@ func UpdateTasksEndpoint(response http.ResponseWriter, request *http.Request) {
 
 	result := &responses.TaskGroupResponse{Success: true, Data: data, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskGroupResponse{Success: false, Data: data, Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // CloneTaskEndpoint handles the POST request at /api/task/{ID}/clone
 func CloneTaskEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var task entities.Task
 	var err error
 	errorsList := make([]string, 0)
@@ -1213,8 +1330,9 @@ func CloneTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		task, err = queries.GetTask(ID)
-		if err == nil {
+		var errorString string
+		task, errorString = getTask(ID)
+		if errorString == "" {
 			task.SetID(uuid.New().String())
 			task.CreationDate = time.Now().Unix()
 			err = commands.CreateTask(task)
@@ -1234,23 +1352,21 @@ func CloneTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 				errorsList = append(errorsList, errors.CreateTaskError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.TaskResponse{Success: true, Data: []entities.Task{task}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // StartTaskEndpoint handles the POST request at /api/task/{ID}/start
 func StartTaskEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskToStart entities.Task
 	var err error
 	errorsList := make([]string, 0)
@@ -1258,31 +1374,30 @@ func StartTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		taskToStart, err = queries.GetTask(ID)
-		if err == nil {
+		var errorString string
+		taskToStart, errorString = getTask(ID)
+		if errorString == "" {
 			taskStore := stores.GetTaskStore()
 			err = taskStore.StartTask(&taskToStart)
 			if err != nil {
 				errorsList = append(errorsList, errors.StartTaskError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
 	}
 	result := &responses.TaskResponse{Success: true, Data: []entities.Task{taskToStart}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
 
 // StopTaskEndpoint handles the POST request at /api/task/{ID}/stop
 func StopTaskEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("content-type", "application/json")
-	response.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
 	var taskToStop entities.Task
 	var taskGroup entities.TaskGroup
 	var err error
@@ -1291,14 +1406,15 @@ func StopTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 	params := mux.Vars(request)
 	ID, ok := params["ID"]
 	if ok {
-		taskToStop, err = queries.GetTask(ID)
-		if err == nil {
+		var errorString string
+		taskToStop, errorString = getTask(ID)
+		if errorString == "" {
 			taskStore := stores.GetTaskStore()
 			_, err = taskStore.StopTask(&taskToStop)
 			if err == nil {
 				taskGroup, err = queries.GetTaskGroup(taskToStop.TaskGroupID)
 				if err == nil {
-					if !taskStore.TasksRunning(taskGroup.TaskIDs, taskGroup.MonitorRetailer) {
+					if !taskStore.TasksRunning(taskGroup.TaskIDs) {
 						monitorStore := stores.GetMonitorStore()
 						_, err = monitorStore.StopMonitor(&taskGroup)
 						if err != nil {
@@ -1312,7 +1428,7 @@ func StopTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 				errorsList = append(errorsList, errors.StopTaskError+err.Error())
 			}
 		} else {
-			errorsList = append(errorsList, errors.GetTaskError+err.Error())
+			errorsList = append(errorsList, errorString)
 		}
 	} else {
 		errorsList = append(errorsList, errors.MissingParameterError)
@@ -1320,8 +1436,86 @@ func StopTaskEndpoint(response http.ResponseWriter, request *http.Request) {
 
 	result := &responses.TaskResponse{Success: true, Data: []entities.Task{taskToStop}, Errors: make([]string, 0)}
 	if len(errorsList) > 0 {
-		response.WriteHeader(http.StatusBadRequest)
-		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList}
+		code := writeErrorStatus(response, errorsList)
+		result = &responses.TaskResponse{Success: false, Data: make([]entities.Task, 0), Errors: errorsList, Code: code}
 	}
 	json.NewEncoder(response).Encode(result)
 }
+
+// getTask returns the Task with the ID, or the error to report if there isn't one
+func getTask(ID string) (entities.Task, string) {
+	task, err := queries.GetTask(ID)
+	if err != nil {
+		return task, errors.GetTaskError + err.Error()
+	}
+	if task.ID == "" {
+		return task, errors.TaskNotFoundError
+	}
+	return task, ""
+}
+
+// getTaskGroup returns the TaskGroup with the groupID, or the error to report if there isn't one
+func getTaskGroup(groupID string) (entities.TaskGroup, string) {
+	taskGroup, err := queries.GetTaskGroup(groupID)
+	if err != nil {
+		return taskGroup, errors.GetTaskGroupError + err.Error()
+	}
+	if taskGroup.GroupID == "" {
+		return taskGroup, errors.TaskGroupNotFoundError
+	}
+	return taskGroup, ""
+}
+
+// validSizeFallback returns true if the fallback is one of the SizeFallback values, or empty for the default
+func validSizeFallback(fallback enums.SizeFallback) bool {
+	return fallback == "" || fallback == enums.SizeFallbackWait || fallback == enums.SizeFallbackAny
+}
+
+// validateTaskAccount returns an error if the accountID isn't empty, enums.AnyAccount or DO_NOT_UPDATE and isn't the ID
+// of one of the retailer's Accounts
+func validateTaskAccount(accountID string, retailer enums.Retailer) string {
+	if accountID == "" || accountID == enums.AnyAccount || accountID == "DO_NOT_UPDATE" {
+		return ""
+	}
+	account, err := queries.GetAccount(accountID)
+	if err != nil {
+		return errors.GetAccountsError + err.Error()
+	}
+	if account.ID == "" {
+		return errors.AccountNotFoundError
+	}
+	if account.Retailer != retailer {
+		return errors.AccountRetailerMismatchError + account.Retailer
+	}
+	return ""
+}
+
+// validateTargetMonitorInfo returns an error if the TargetMonitorInfo's MonitorType isn't a Target monitor type (or empty for
+// SKU monitors), or one of its monitors is missing the TCIN, URL or keywords that its MonitorType needs
+func validateTargetMonitorInfo(monitorInfo *entities.TargetMonitorInfo) string {
+	if monitorInfo == nil {
+		return ""
+	}
+	switch monitorInfo.MonitorType {
+	case "", enums.SKUMonitor, enums.URLMonitor, enums.KeywordMonitor:
+	default:
+		return errors.InvalidMonitorTypeError + monitorInfo.MonitorType
+	}
+	for _, monitor := range monitorInfo.Monitors {
+		switch monitorInfo.MonitorType {
+		case enums.URLMonitor:
+			if monitor.URL == "" {
+				return errors.MissingMonitorFieldsError
+			}
+		case enums.KeywordMonitor:
+			if positive, _ := util.ParseKeywords(monitor.Keywords); len(positive) == 0 {
+				return errors.MissingKeywordsError
+			}
+		default:
+			if monitor.TCIN == "" {
+				return errors.MissingMonitorFieldsError
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// SessionTokenEnv is the environment variable the app can set to choose the session token, instead of reading the generated one from stdout
+const SessionTokenEnv = "JUICED_SESSION_TOKEN"
+
+// minTokenLength is the shortest token that's accepted, in characters
+const minTokenLength = 32
+
+// AllowedOrigins are the only browser origins that can call the API or connect to the websocket, the dev server and the packaged app
+var AllowedOrigins = []string{"http://localhost:3000", "file://"}
+
+var sessionToken string
+
+var remoteToken string
+var remoteTokenMutex sync.RWMutex
+
+// InitSessionToken sets the session token that every request to the local servers must present, from SessionTokenEnv if it's
+// set and otherwise a new random one, and returns it. It changes every time the backend starts.
+func InitSessionToken() (string, error) {
+	token := os.Getenv(SessionTokenEnv)
+	if !StrongToken(token) {
+		var err error
+		token, err = NewToken()
+		if err != nil {
+			return "", err
+		}
+	}
+	sessionToken = token
+	return token, nil
+}
+
+// GetSessionToken returns the session token
+func GetSessionToken() string {
+	return sessionToken
+}
+
+// StrongToken returns true if the token is long enough to be accepted
+func StrongToken(token string) bool {
+	return len(token) >= minTokenLength
+}
+
+// NewToken returns a random 256-bit token, hex encoded
+func NewToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// AllowedOrigin returns true if the request is from one of the AllowedOrigins, or doesn't have an Origin because it isn't from a browser
+func AllowedOrigin(request *http.Request) bool {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowedOrigin := range AllowedOrigins {
+		if origin == allowedOrigin {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestToken returns the bearer token from the request's Authorization header. Browsers can't set headers on websocket
+// connections, so websocket upgrade requests can pass it in the token query parameter instead.
+func RequestToken(request *http.Request) string {
+	authorization := request.Header.Get("Authorization")
+	if strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	}
+	if strings.EqualFold(request.Header.Get("Upgrade"), "websocket") {
+		return request.URL.Query().Get("token")
+	}
+	return ""
+}
+
+// ValidToken returns true if the token matches one of the accepted tokens, in constant time. Empty tokens never match.
+func ValidToken(token string, acceptedTokens ...string) bool {
+	valid := false
+	for _, acceptedToken := range acceptedTokens {
+		if acceptedToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(acceptedToken)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// Authenticate returns middleware that rejects requests from origins that aren't allowed with 403 Forbidden,
+// and requests that don't present one of the tokens returned by acceptedTokens with 401 Unauthorized
+func Authenticate(acceptedTokens func() []string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			if !AllowedOrigin(request) {
+				reject(response, responses.ForbiddenCode, errors.OriginNotAllowedError)
+				return
+			}
+			if !ValidToken(RequestToken(request), acceptedTokens()...) {
+				reject(response, responses.UnauthorizedCode, errors.InvalidSessionTokenError)
+				return
+			}
+			next.ServeHTTP(response, request)
+		})
+	}
+}
+
+// SessionTokens returns the session token, for Authenticate
+func SessionTokens() []string {
+	return []string{sessionToken}
+}
+
+// SetRemoteToken sets the token that the remote access server accepts, an empty token isn't accepted
+func SetRemoteToken(token string) {
+	remoteTokenMutex.Lock()
+	defer remoteTokenMutex.Unlock()
+	remoteToken = token
+}
+
+// RemoteTokens returns the remote access token, for Authenticate
+func RemoteTokens() []string {
+	remoteTokenMutex.RLock()
+	defer remoteTokenMutex.RUnlock()
+	return []string{remoteToken}
+}
+
+// reject responds with the code's status and the message, for requests that don't reach a handler
+func reject(response http.ResponseWriter, code responses.ErrorCode, message string) {
+	response.Header().Set("content-type", "application/json")
+	response.WriteHeader(responses.ErrorStatus(code))
+	json.NewEncoder(response).Encode(&responses.MiscellaneousResponse{Success: false, Errors: []string{message}, Code: code})
+}
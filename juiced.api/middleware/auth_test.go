@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	token, err := NewToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := Authenticate(func() []string { return []string{token} })(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.WriteHeader(http.StatusOK)
+	}))
+
+	cases := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		status  int
+	}{
+		{"no token", "/api/profile", nil, http.StatusUnauthorized},
+		{"wrong token", "/api/profile", map[string]string{"Authorization": "Bearer " + token[1:]}, http.StatusUnauthorized},
+		{"bearer token", "/api/profile", map[string]string{"Authorization": "Bearer " + token}, http.StatusOK},
+		{"allowed origin", "/api/profile", map[string]string{"Authorization": "Bearer " + token, "Origin": "http://localhost:3000"}, http.StatusOK},
+		{"other origin", "/api/profile", map[string]string{"Authorization": "Bearer " + token, "Origin": "http://example.com"}, http.StatusForbidden},
+		// Only websocket connections can pass the token in the URL
+		{"query token", "/api/profile?token=" + token, nil, http.StatusUnauthorized},
+		{"websocket query token", "/?token=" + token, map[string]string{"Upgrade": "websocket"}, http.StatusOK},
+	}
+	for _, c := range cases {
+		request := httptest.NewRequest("GET", c.target, nil)
+		for header, value := range c.headers {
+			request.Header.Set(header, value)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != c.status {
+			t.Errorf("%s: expected status %d, got %d", c.name, c.status, recorder.Code)
+		}
+	}
+}
+
+func TestValidTokenRejectsEmptyTokens(t *testing.T) {
+	if ValidToken("", "") {
+		t.Error("expected an empty token to never be valid")
+	}
+}
+
+func TestRemoteTokensChange(t *testing.T) {
+	handler := Authenticate(RemoteTokens)(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.WriteHeader(http.StatusOK)
+	}))
+	status := func(token string) int {
+		request := httptest.NewRequest("GET", "/api/profile", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	oldToken, _ := NewToken()
+	newToken, _ := NewToken()
+	SetRemoteToken(oldToken)
+	if status(oldToken) != http.StatusOK {
+		t.Error("expected the remote token to be accepted")
+	}
+	SetRemoteToken(newToken)
+	if status(oldToken) != http.StatusUnauthorized || status(newToken) != http.StatusOK {
+		t.Error("expected only the rotated token to be accepted")
+	}
+	SetRemoteToken("")
+	if status(newToken) != http.StatusUnauthorized || status("") != http.StatusUnauthorized {
+		t.Error("expected no token to be accepted once remote access is disabled")
+	}
+}
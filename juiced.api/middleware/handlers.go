@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// JSON sets every response's content type to JSON. Handlers that respond with files set their own.
+func JSON(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		response.Header().Set("content-type", "application/json")
+		next.ServeHTTP(response, request)
+	})
+}
+
+// Recover responds with 500 Internal Server Error when a handler panics, instead of dropping the connection
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Println(r)
+				log.Println(string(debug.Stack()))
+				reject(response, responses.InternalErrorCode, errors.InternalServerError)
+			}
+		}()
+		next.ServeHTTP(response, request)
+	})
+}
+
+// NotFound responds to requests whose path doesn't match any route with 404 Not Found
+func NotFound() http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		reject(response, responses.NotFoundCode, errors.RouteNotFoundError)
+	})
+}
+
+// MethodNotAllowed responds to requests whose path matches a route, but not with their method, with 405 Method Not Allowed
+func MethodNotAllowed() http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		reject(response, responses.MethodNotAllowedCode, errors.MethodNotAllowedError)
+	})
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+func TestRecover(t *testing.T) {
+	handler := Recover(JSON(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		panic("handler failed")
+	})))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/api/task", nil))
+
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("content-type"); contentType != "application/json" {
+		t.Errorf("expected a JSON response, got %s", contentType)
+	}
+	result := responses.MiscellaneousResponse{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil || result.Code != responses.InternalErrorCode || len(result.Errors) != 1 {
+		t.Errorf("expected an %s error, got %s", responses.InternalErrorCode, recorder.Body.String())
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NotFound().ServeHTTP(recorder, httptest.NewRequest("GET", "/api/missing", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+}
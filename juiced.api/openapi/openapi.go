@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"regexp"
+	"strings"
+
+	"backend.juicedbot.io/juiced.api/responses"
+)
+
+// Version is the version of the API that the document describes
+const Version = "1.0.0"
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers"`
+	Security   []map[string][]string `json:"security"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps the lower-case methods of a path to their operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema is a JSON schema, either a reference to one of the Document's component schemas or an inline one
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// securityScheme is the name of the session token security scheme
+const securityScheme = "sessionToken"
+
+// pathParameterRegex matches the parameters in a route's path, like {GroupID}
+var pathParameterRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+// Generate returns the OpenAPI document of every route in Routes, with the JSON schemas of their request and response
+// types generated from the types themselves
+func Generate() Document {
+	generator := newSchemaGenerator()
+	document := Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Juiced API",
+			Description: "The local API that the Juiced app uses to manage the backend.",
+			Version:     Version,
+		},
+		Servers:  []Server{{URL: "http://127.0.0.1:10000", Description: "The local server"}},
+		Security: []map[string][]string{{securityScheme: {}}},
+		Paths:    make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				securityScheme: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "The session token that the backend prints when it starts, or the remote access token",
+				},
+			},
+		},
+	}
+
+	rejected := Response{
+		Description: "The request wasn't authorized",
+		Content:     jsonContent(generator.schema(responses.MiscellaneousResponse{})),
+	}
+	for _, route := range Routes {
+		operation := &Operation{
+			OperationID: route.ID,
+			Tags:        []string{route.Tag},
+			Summary:     route.Summary,
+			Responses: map[string]Response{
+				"401": rejected,
+				"403": rejected,
+			},
+		}
+		for _, match := range pathParameterRegex.FindAllStringSubmatch(route.Path, -1) {
+			operation.Parameters = append(operation.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		operation.Parameters = append(operation.Parameters, route.Query...)
+
+		requestContent := make(map[string]MediaType)
+		if route.Request != nil {
+			requestContent["application/json"] = MediaType{Schema: generator.schema(route.Request)}
+		}
+		if route.Form != nil {
+			requestContent["multipart/form-data"] = MediaType{Schema: route.Form}
+		}
+		for _, contentType := range route.RequestFiles {
+			requestContent[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+		if len(requestContent) > 0 {
+			operation.RequestBody = &RequestBody{Required: true, Content: requestContent}
+		}
+
+		if route.Response != nil {
+			content := jsonContent(generator.schema(route.Response))
+			operation.Responses["200"] = Response{Description: "The request succeeded", Content: content}
+			operation.Responses["default"] = Response{Description: "The request failed, its code and errors say why", Content: content}
+		}
+		if len(route.ResponseFiles) > 0 {
+			content := make(map[string]MediaType)
+			for _, contentType := range route.ResponseFiles {
+				content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+			}
+			operation.Responses["200"] = Response{Description: "The file", Content: content}
+		}
+
+		pathItem, ok := document.Paths[route.Path]
+		if !ok {
+			pathItem = make(PathItem)
+			document.Paths[route.Path] = pathItem
+		}
+		pathItem[strings.ToLower(route.Method)] = operation
+	}
+	document.Components.Schemas = generator.schemas
+	return document
+}
+
+// Lookup returns the route with the method whose path matches the path, which can be a path template like /api/task/{ID}
+// or a request's path. When several routes match, like /api/task/group and /api/task/{ID}, the one with the fewest path
+// parameters wins, like it does in the router.
+func Lookup(method string, path string) (Route, bool) {
+	found := false
+	var match Route
+	for _, route := range Routes {
+		if route.Method != method || !route.Matches(path) {
+			continue
+		}
+		if !found || route.parameterCount() < match.parameterCount() {
+			match = route
+			found = true
+		}
+	}
+	return match, found
+}
+
+// Matches returns true if the path is the route's path, or matches it with a non-empty value for each path parameter
+func (route Route) Matches(path string) bool {
+	routeSegments := strings.Split(route.Path, "/")
+	segments := strings.Split(path, "/")
+	if len(routeSegments) != len(segments) {
+		return false
+	}
+	for i, routeSegment := range routeSegments {
+		if pathParameterRegex.MatchString(routeSegment) {
+			if segments[i] == "" {
+				return false
+			}
+		} else if routeSegment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (route Route) parameterCount() int {
+	return len(pathParameterRegex.FindAllString(route.Path, -1))
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
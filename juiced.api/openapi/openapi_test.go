@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	document := Generate()
+	data, err := json.Marshal(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, match := range regexp.MustCompile(`"\$ref":"([^"]+)"`).FindAllStringSubmatch(string(data), -1) {
+		name := strings.TrimPrefix(match[1], "#/components/schemas/")
+		if _, ok := document.Components.Schemas[name]; !ok {
+			t.Errorf("%s isn't a component schema", match[1])
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, route := range Routes {
+		if ids[route.ID] {
+			t.Errorf("%s is the ID of more than one route", route.ID)
+		}
+		ids[route.ID] = true
+		operation, ok := document.Paths[route.Path][strings.ToLower(route.Method)]
+		if !ok {
+			t.Errorf("%s %s isn't in the document", route.Method, route.Path)
+			continue
+		}
+		if _, ok := operation.Responses["200"]; !ok {
+			t.Errorf("%s doesn't have a 200 response", route.ID)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		id     string
+	}{
+		{"GET", "/api/task/group", "GetAllTaskGroups"},
+		{"GET", "/api/task/abc", "GetTask"},
+		{"GET", "/api/task/{ID}", "GetTask"},
+		{"POST", "/api/task/group/abc/start", "StartTaskGroup"},
+	}
+	for _, c := range cases {
+		if route, ok := Lookup(c.method, c.path); !ok || route.ID != c.id {
+			t.Errorf("%s %s: expected %s, got %s", c.method, c.path, c.id, route.ID)
+		}
+	}
+	if _, ok := Lookup("PATCH", "/api/task/abc"); ok {
+		t.Error("expected PATCH /api/task/abc not to match a route")
+	}
+}
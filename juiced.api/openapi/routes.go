@@ -0,0 +1,190 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"backend.juicedbot.io/juiced.api/pagination"
+	"backend.juicedbot.io/juiced.api/requests"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// Route is an endpoint of the API. Its ID is the name of its function in the endpoints package without the Endpoint
+// suffix, which is also the name of its method on the client.
+type Route struct {
+	Method  string
+	Path    string
+	ID      string
+	Tag     string
+	Summary string
+	// Query are the query parameters that the endpoint reads
+	Query []Parameter
+	// Request is a value of the type of the JSON request body, or nil if the endpoint doesn't read one
+	Request interface{}
+	// Form is the schema of the multipart form that the endpoint can read instead of a JSON body
+	Form *Schema
+	// RequestFiles are the content types of the files that the endpoint can read as the request body
+	RequestFiles []string
+	// Response is a value of the type of the JSON response body
+	Response interface{}
+	// ResponseFiles are the content types of the files that the endpoint returns when it succeeds, instead of JSON
+	ResponseFiles []string
+}
+
+// Routes are every endpoint that the router serves, the contract tests make sure that the two stay in sync
+var Routes = []Route{
+	// Proxies
+	{Method: "GET", Path: "/api/proxy/group", ID: "GetAllProxyGroups", Tag: "ProxyGroup", Summary: "Returns a page of ProxyGroups", Query: page("creationDate", "name"), Response: responses.ProxyGroupResponse{}},
+	{Method: "GET", Path: "/api/proxy/group/{GroupID}", ID: "GetProxyGroup", Tag: "ProxyGroup", Summary: "Returns the ProxyGroup with GroupID {GroupID}", Response: responses.ProxyGroupResponse{}},
+	{Method: "POST", Path: "/api/proxy/group", ID: "CreateProxyGroup", Tag: "ProxyGroup", Summary: "Creates a ProxyGroup, the lines of a proxy list in proxyLines are added to its proxies", Request: entities.ProxyGroup{}, Response: responses.ProxyGroupResponse{}},
+	{Method: "DELETE", Path: "/api/proxy/group/{GroupID}", ID: "RemoveProxyGroup", Tag: "ProxyGroup", Summary: "Deletes and returns the ProxyGroup with GroupID {GroupID}", Response: responses.ProxyGroupResponse{}},
+	{Method: "PUT", Path: "/api/proxy/group/{GroupID}", ID: "UpdateProxyGroup", Tag: "ProxyGroup", Summary: "Updates and returns the ProxyGroup with GroupID {GroupID}", Request: entities.ProxyGroup{}, Response: responses.ProxyGroupResponse{}},
+	{Method: "POST", Path: "/api/proxy/group/{GroupID}/clone", ID: "CloneProxyGroup", Tag: "ProxyGroup", Summary: "Clones the ProxyGroup with GroupID {GroupID} and returns the clone", Response: responses.ProxyGroupResponse{}},
+	{
+		Method: "POST", Path: "/api/proxy/group/{GroupID}/test", ID: "TestProxyGroup", Tag: "ProxyGroup",
+		Summary:  "Tests every Proxy of the ProxyGroup with GroupID {GroupID} against a URL and returns the ProxyGroup with their health, progress is published on the proxy topic",
+		Request:  requests.TestProxyGroupRequest{},
+		Response: responses.ProxyGroupResponse{},
+	},
+
+	// Profiles
+	{Method: "GET", Path: "/api/profile/group", ID: "GetAllProfileGroups", Tag: "ProfileGroup", Summary: "Returns a page of ProfileGroups", Query: page("creationDate", "name"), Response: responses.ProfileGroupResponse{}},
+	{Method: "GET", Path: "/api/profile/group/{GroupID}", ID: "GetProfileGroup", Tag: "ProfileGroup", Summary: "Returns the ProfileGroup with GroupID {GroupID}", Response: responses.ProfileGroupResponse{}},
+	{Method: "POST", Path: "/api/profile/group", ID: "CreateProfileGroup", Tag: "ProfileGroup", Summary: "Creates a ProfileGroup", Request: entities.ProfileGroup{}, Response: responses.ProfileGroupResponse{}},
+	{Method: "DELETE", Path: "/api/profile/group/{GroupID}", ID: "RemoveProfileGroup", Tag: "ProfileGroup", Summary: "Deletes and returns the ProfileGroup with GroupID {GroupID}", Response: responses.ProfileGroupResponse{}},
+	{Method: "PUT", Path: "/api/profile/group/{GroupID}", ID: "UpdateProfileGroup", Tag: "ProfileGroup", Summary: "Renames and returns the ProfileGroup with GroupID {GroupID}", Request: requests.UpdateProfileGroupRequest{}, Response: responses.ProfileGroupResponse{}},
+	{Method: "POST", Path: "/api/profile/group/{GroupID}/clone", ID: "CloneProfileGroup", Tag: "ProfileGroup", Summary: "Clones the ProfileGroup with GroupID {GroupID} and returns the clone", Response: responses.ProfileGroupResponse{}},
+	{Method: "POST", Path: "/api/profile/group/{GroupID}/add", ID: "AddProfilesToGroup", Tag: "ProfileGroup", Summary: "Adds Profiles to the ProfileGroup with GroupID {GroupID} and returns the updated ProfileGroup", Request: requests.ProfileIDsRequest{}, Response: responses.ProfileGroupResponse{}},
+	{Method: "POST", Path: "/api/profile/group/{GroupID}/remove", ID: "RemoveProfilesFromGroup", Tag: "ProfileGroup", Summary: "Removes Profiles from the ProfileGroup with GroupID {GroupID} and returns the updated ProfileGroup", Request: requests.ProfileIDsRequest{}, Response: responses.ProfileGroupResponse{}},
+	{Method: "GET", Path: "/api/profile", ID: "GetAllProfiles", Tag: "Profile", Summary: "Returns a page of Profiles", Query: page("creationDate", "name"), Response: responses.ProfileResponse{}},
+	{
+		Method: "GET", Path: "/api/profile/export", ID: "ExportProfiles", Tag: "Profile",
+		Summary: "Returns a CSV or JSON file of all Profiles, or of the Profiles in a ProfileGroup, which can be imported again",
+		Query: []Parameter{
+			query("format", "csv or json (the default)"),
+			query("groupID", "Only export the Profiles in this ProfileGroup"),
+		},
+		Response:      responses.ProfileResponse{},
+		ResponseFiles: []string{"application/json", "text/csv"},
+	},
+	{Method: "GET", Path: "/api/profile/{ID}", ID: "GetProfile", Tag: "Profile", Summary: "Returns the Profile with ID {ID}", Response: responses.ProfileResponse{}},
+	{Method: "POST", Path: "/api/profile", ID: "CreateProfile", Tag: "Profile", Summary: "Creates a Profile", Request: entities.Profile{}, Response: responses.ProfileResponse{}},
+	{Method: "DELETE", Path: "/api/profile/{ID}", ID: "RemoveProfile", Tag: "Profile", Summary: "Deletes and returns the Profile with ID {ID}", Response: responses.ProfileResponse{}},
+	{Method: "PUT", Path: "/api/profile/{ID}", ID: "UpdateProfile", Tag: "Profile", Summary: "Updates and returns the Profile with ID {ID}", Request: entities.Profile{}, Response: responses.ProfileResponse{}},
+	{Method: "POST", Path: "/api/profile/{ID}/clone", ID: "CloneProfile", Tag: "Profile", Summary: "Clones the Profile with ID {ID} and returns the clone", Response: responses.ProfileResponse{}},
+	{
+		Method: "POST", Path: "/api/profile/import", ID: "ImportProfiles", Tag: "Profile",
+		Summary: "Imports the Profiles in a CSV or JSON file and returns whether each one was imported or why it was rejected",
+		Query: []Parameter{
+			query("format", "csv or json, detected from the file if it isn't given"),
+			query("groupIDs", "Comma separated IDs of the ProfileGroups to add the imported Profiles to"),
+			query("mapping", "JSON object mapping Profile fields to the CSV file's column names"),
+		},
+		Request: requests.ImportProfilesRequest{},
+		Form: &Schema{Type: "object", Properties: map[string]*Schema{
+			"file":     {Type: "string", Format: "binary"},
+			"format":   {Type: "string"},
+			"groupIDs": {Type: "string", Description: "Comma separated IDs of the ProfileGroups to add the imported Profiles to"},
+			"mapping":  {Type: "string", Description: "JSON object mapping Profile fields to the CSV file's column names"},
+		}},
+		RequestFiles: []string{"text/csv"},
+		Response:     responses.ImportProfileResponse{},
+	},
+
+	// Tasks
+	{Method: "GET", Path: "/api/task/group", ID: "GetAllTaskGroups", Tag: "TaskGroup", Summary: "Returns a page of TaskGroups", Query: page("creationDate", "name", "retailer"), Response: responses.TaskGroupResponse{}},
+	{Method: "GET", Path: "/api/task/group/{GroupID}", ID: "GetTaskGroup", Tag: "TaskGroup", Summary: "Returns the TaskGroup with GroupID {GroupID}", Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group", ID: "CreateTaskGroup", Tag: "TaskGroup", Summary: "Creates a TaskGroup", Request: entities.TaskGroup{}, Response: responses.TaskGroupResponse{}},
+	{Method: "DELETE", Path: "/api/task/group/{GroupID}", ID: "RemoveTaskGroup", Tag: "TaskGroup", Summary: "Deletes and returns the TaskGroup with GroupID {GroupID}", Response: responses.TaskGroupResponse{}},
+	{Method: "PUT", Path: "/api/task/group/{GroupID}", ID: "UpdateTaskGroup", Tag: "TaskGroup", Summary: "Updates and returns the TaskGroup with GroupID {GroupID}", Request: requests.UpdateTaskGroupRequest{}, Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/clone", ID: "CloneTaskGroup", Tag: "TaskGroup", Summary: "Clones the TaskGroup with GroupID {GroupID} and returns the clone", Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/start", ID: "StartTaskGroup", Tag: "TaskGroup", Summary: "Starts a TaskGroup's Monitor and all of its Tasks", Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/stop", ID: "StopTaskGroup", Tag: "TaskGroup", Summary: "Stops a TaskGroup's Monitor and all of its Tasks", Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/removeTasks", ID: "RemoveTasks", Tag: "TaskGroup", Summary: "Deletes Tasks from the TaskGroup with GroupID {GroupID}", Request: requests.DeleteTasksRequest{}, Response: responses.TaskGroupResponse{}},
+	{Method: "GET", Path: "/api/task", ID: "GetAllTasks", Tag: "Task", Summary: "Returns a page of Tasks", Query: page("creationDate", "retailer", "status"), Response: responses.TaskResponse{}},
+	{Method: "GET", Path: "/api/task/{ID}", ID: "GetTask", Tag: "Task", Summary: "Returns the Task with ID {ID}", Response: responses.TaskResponse{}},
+	{Method: "GET", Path: "/api/task/{ID}/reveal", ID: "RevealTask", Tag: "Task", Summary: "Returns the Task with ID {ID}, without masking its retailer account password", Response: responses.RevealedTaskResponse{}},
+	{Method: "POST", Path: "/api/task/{GroupID}", ID: "CreateTask", Tag: "Task", Summary: "Creates Tasks in the TaskGroup with GroupID {GroupID}", Request: requests.CreateTaskRequest{}, Response: responses.TaskGroupResponse{}},
+	{Method: "PUT", Path: "/api/task/group/{GroupID}/updateTasks", ID: "UpdateTasks", Tag: "Task", Summary: "Updates Tasks in the TaskGroup with GroupID {GroupID}", Request: requests.UpdateTasksRequest{}, Response: responses.TaskGroupResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/generate", ID: "GenerateTasks", Tag: "Task", Summary: "Creates Tasks in the TaskGroup with GroupID {GroupID} from a template Task", Request: entities.TaskGenerator{}, Response: responses.TaskIDsResponse{}},
+	{Method: "POST", Path: "/api/task/{ID}/clone", ID: "CloneTask", Tag: "Task", Summary: "Clones the Task with ID {ID} and returns the clone", Response: responses.TaskResponse{}},
+	{Method: "POST", Path: "/api/task/{ID}/start", ID: "StartTask", Tag: "Task", Summary: "Starts a Task", Response: responses.TaskResponse{}},
+	{Method: "POST", Path: "/api/task/{ID}/stop", ID: "StopTask", Tag: "Task", Summary: "Stops a Task", Response: responses.TaskResponse{}},
+	{Method: "GET", Path: "/api/task/{ID}/runs", ID: "GetTaskRuns", Tag: "Task", Summary: "Returns the runs of the Task with ID {ID}, most recent first", Response: responses.TaskRunsResponse{}},
+	{
+		Method: "GET", Path: "/api/task/group/{GroupID}/timeline", ID: "GetTaskGroupTimeline", Tag: "TaskGroup",
+		Summary:  "Returns the status transitions of the Tasks and Monitor of the TaskGroup with GroupID {GroupID}, in order",
+		Query:    []Parameter{{Name: "since", In: "query", Description: "Only return transitions at or after this Unix time in milliseconds", Schema: &Schema{Type: "integer", Format: "int64"}}},
+		Response: responses.TaskGroupTimelineResponse{},
+	},
+	{Method: "GET", Path: "/api/task/group/{GroupID}/schedule", ID: "GetTaskGroupSchedules", Tag: "TaskGroup", Summary: "Returns the schedules of the TaskGroup with GroupID {GroupID}", Response: responses.TaskGroupSchedulesResponse{}},
+	{Method: "POST", Path: "/api/task/group/{GroupID}/schedule", ID: "CreateTaskGroupSchedule", Tag: "TaskGroup", Summary: "Schedules the TaskGroup with GroupID {GroupID} to start", Request: entities.TaskGroupSchedule{}, Response: responses.TaskGroupSchedulesResponse{}},
+	{Method: "PUT", Path: "/api/task/group/{GroupID}/schedule/{ScheduleID}", ID: "UpdateTaskGroupSchedule", Tag: "TaskGroup", Summary: "Updates and returns the schedule with ID {ScheduleID}", Request: entities.TaskGroupSchedule{}, Response: responses.TaskGroupSchedulesResponse{}},
+	{Method: "DELETE", Path: "/api/task/group/{GroupID}/schedule/{ScheduleID}", ID: "RemoveTaskGroupSchedule", Tag: "TaskGroup", Summary: "Deletes and returns the schedule with ID {ScheduleID}", Response: responses.TaskGroupSchedulesResponse{}},
+
+	// Checkouts
+	{Method: "GET", Path: "/api/checkout", ID: "GetAllCheckouts", Tag: "Checkout", Summary: "Returns a page of Checkouts, most recent first by default", Query: append(page("time", "price", "msToCheckout"), checkoutFilter...), Response: responses.CheckoutResponse{}},
+	{
+		Method: "GET", Path: "/api/checkout/stats", ID: "GetCheckoutStats", Tag: "Checkout",
+		Summary:  "Returns the spend, unit count, order outcomes and checkout speed of the Checkouts that match the filters",
+		Query:    append([]Parameter{query("groupBy", "retailer (the default), profile, sku, day or week")}, checkoutFilter...),
+		Response: responses.CheckoutStatsResponse{},
+	},
+
+	// Settings
+	{Method: "GET", Path: "/api/settings", ID: "GetSettings", Tag: "Settings", Summary: "Returns the user's settings, with API keys and account passwords masked", Response: responses.SettingsResponse{}},
+	{Method: "GET", Path: "/api/settings/reveal", ID: "RevealSettings", Tag: "Settings", Summary: "Returns the user's settings, without masking API keys and account passwords", Response: responses.RevealedSettingsResponse{}},
+	{Method: "PUT", Path: "/api/settings", ID: "UpdateSettings", Tag: "Settings", Summary: "Updates and returns the user's settings", Request: entities.Settings{}, Response: responses.SettingsResponse{}},
+	{Method: "POST", Path: "/api/settings/rekey", ID: "RekeyDatabase", Tag: "Settings", Summary: "Re-encrypts the stored profiles, cards, credentials and API keys with a new key and returns the settings", Response: responses.SettingsResponse{}},
+	{Method: "GET", Path: "/api/settings/accounts", ID: "GetAccounts", Tag: "Settings", Summary: "Returns the retailer accounts with their login statuses and the tasks using them", Response: responses.AccountsResponse{}},
+	{Method: "POST", Path: "/api/settings/accounts", ID: "AddAccount", Tag: "Settings", Summary: "Adds a retailer account and returns the settings", Request: entities.Account{}, Response: responses.SettingsResponse{}},
+	{Method: "PUT", Path: "/api/settings/accounts/{ID}", ID: "UpdateAccount", Tag: "Settings", Summary: "Updates the retailer account with ID {ID} and returns the settings", Request: entities.Account{}, Response: responses.SettingsResponse{}},
+	{Method: "POST", Path: "/api/settings/accounts/remove", ID: "RemoveAccounts", Tag: "Settings", Summary: "Removes retailer accounts and returns the settings", Request: requests.DeleteAccountsRequest{}, Response: responses.SettingsResponse{}},
+
+	// Notifications
+	{
+		Method: "GET", Path: "/api/notification/outbox", ID: "GetOutbox", Tag: "Notifications",
+		Summary:  "Returns a page of the notifications that haven't been delivered yet and of the dead-lettered ones, oldest first by default",
+		Query:    append(page("creationDate", "nextAttempt", "attempts"), query("status", "pending or dead, only return the OutboxEntries with this status")),
+		Response: responses.OutboxResponse{},
+	},
+	{Method: "POST", Path: "/api/notification/outbox/replay", ID: "ReplayOutbox", Tag: "Notifications", Summary: "Queues every dead OutboxEntry to be delivered again and returns them", Response: responses.OutboxResponse{}},
+	{Method: "POST", Path: "/api/notification/outbox/{ID}/replay", ID: "ReplayOutboxEntry", Tag: "Notifications", Summary: "Queues the dead OutboxEntry with ID {ID} to be delivered again and returns it", Response: responses.OutboxResponse{}},
+	{Method: "DELETE", Path: "/api/notification/outbox/{ID}", ID: "RemoveOutboxEntry", Tag: "Notifications", Summary: "Deletes the OutboxEntry with ID {ID}, it won't be delivered", Response: responses.OutboxResponse{}},
+
+	// Miscellaneous
+	{Method: "POST", Path: "/api/settings/testWebhooks", ID: "TestWebhooks", Tag: "Settings", Summary: "Sends a test message to the success and failure Discord webhooks and to the notification sinks", Request: requests.TestWebhooksRequest{}, Response: responses.MiscellaneousResponse{}},
+	{Method: "POST", Path: "/api/setVersion", ID: "SetVersion", Tag: "Miscellaneous", Summary: "Sets the app version that's shown in the Discord activity", Request: requests.SetVersionRequest{}, Response: responses.MiscellaneousResponse{}},
+	{Method: "GET", Path: "/api/openapi.json", ID: "GetOpenAPI", Tag: "Miscellaneous", Summary: "Returns this OpenAPI document", ResponseFiles: []string{"application/json"}},
+
+	// Backup
+	{Method: "POST", Path: "/api/backup", ID: "Backup", Tag: "Backup", Summary: "Returns a backup of the workspace, encrypted with the passphrase", Request: requests.BackupRequest{}, Response: responses.BackupResponse{}},
+	{Method: "POST", Path: "/api/restore", ID: "Restore", Tag: "Backup", Summary: "Restores a backup and returns a report of the records that were restored", Request: requests.RestoreRequest{}, Response: responses.RestoreResponse{}},
+}
+
+// checkoutFilter are the query parameters of a CheckoutFilter
+var checkoutFilter = []Parameter{
+	query("retailer", "Only return Checkouts from this retailer"),
+	query("profile", "Only return Checkouts made with the Profile with this name"),
+	query("sku", "Only return Checkouts of this SKU"),
+	query("status", "Only return Checkouts with this status"),
+	{Name: "days", In: "query", Description: "Only return Checkouts from the last this many days", Schema: &Schema{Type: "integer", Format: "int32"}},
+	{Name: "from", In: "query", Description: "Only return Checkouts at or after this Unix time", Schema: &Schema{Type: "integer", Format: "int64"}},
+	{Name: "to", In: "query", Description: "Only return Checkouts at or before this Unix time", Schema: &Schema{Type: "integer", Format: "int64"}},
+}
+
+// page returns the query parameters of a paginated list that can be sorted by the sorts, the first of which is the default
+func page(sorts ...string) []Parameter {
+	return []Parameter{
+		{Name: "limit", In: "query", Description: fmt.Sprintf("The most items to return, at most %d, every item if it isn't given", pagination.MaxLimit), Schema: &Schema{Type: "integer", Format: "int32"}},
+		query("cursor", "The nextCursor of the previous page, with the same sort and order"),
+		query("sort", "What to sort by, "+strings.Join(sorts, ", ")+" (the default is "+sorts[0]+")"),
+		query("order", "asc or desc"),
+	}
+}
+
+// query returns an optional string query parameter
+func query(name string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: "string"}}
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strings"
+)
+
+// schemaGenerator generates the JSON schemas of Go types the way encoding/json encodes them. Named struct types become
+// component schemas that are referenced by name, so types that refer to themselves don't recurse forever.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schema returns the schema of the value's type
+func (generator *schemaGenerator) schema(value interface{}) *Schema {
+	return generator.typeSchema(reflect.TypeOf(value))
+}
+
+func (generator *schemaGenerator) typeSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return generator.typeSchema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes byte slices as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: generator.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: generator.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return generator.structSchema(t)
+		}
+		name, ok := generator.names[t]
+		if !ok {
+			name = generator.componentName(t)
+			generator.names[t] = name
+			// Reserve the name before generating the properties, in case the type refers to itself
+			generator.schemas[name] = &Schema{}
+			*generator.schemas[name] = *generator.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	// Interfaces can hold anything
+	return &Schema{}
+}
+
+// structSchema returns the object schema of the struct type's exported fields, with their JSON names
+func (generator *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for property, propertySchema := range generator.structSchema(embedded).Properties {
+					if _, ok := schema.Properties[property]; !ok {
+						schema.Properties[property] = propertySchema
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = generator.typeSchema(field.Type)
+	}
+	return schema
+}
+
+// componentName returns the type's name, prefixed with its package's name if another package's type already has it
+func (generator *schemaGenerator) componentName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := generator.schemas[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	return name
+}
@@ -0,0 +1,175 @@
+// Package pagination sorts the items of list requests and splits them into pages with opaque cursors. A cursor points
+// at the last item of a page by its sort key instead of its position, so items that are added or removed between
+// requests don't shift the following pages.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	e "errors"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// MaxLimit is the largest number of items that a page can have
+const MaxLimit = 1000
+
+// Order is the direction that a list is sorted in
+type Order = string
+
+const (
+	Ascending  Order = "asc"
+	Descending Order = "desc"
+)
+
+// Page is the pagination and sorting of a list request, which is read from its limit, cursor, sort and order
+// parameters. A Limit of 0 returns every item after the Cursor.
+type Page struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Order  Order
+}
+
+// Key is an item's place in a sort order. Items are sorted by their Number, then their Text, then their ID, which
+// should be unique so that pages don't skip items with the same Number and Text.
+type Key struct {
+	Number int64  `json:"number,omitempty"`
+	Text   string `json:"text,omitempty"`
+	ID     string `json:"id"`
+}
+
+// Sorts maps the fields that a list can be sorted by to a function that returns the Key of the list's i-th item
+type Sorts map[string]func(i int) Key
+
+// cursor is the decoded form of a Page's Cursor, it remembers the sort so it can't be used with a different one
+type cursor struct {
+	Sort  string `json:"sort"`
+	Order Order  `json:"order"`
+	Key   Key    `json:"key"`
+}
+
+// Parse returns the Page in the request's params, with the sort and order defaulting to defaultSort and defaultOrder
+func Parse(params url.Values, defaultSort string, defaultOrder Order) (Page, error) {
+	page := Page{
+		Cursor: params.Get("cursor"),
+		Sort:   params.Get("sort"),
+		Order:  params.Get("order"),
+	}
+	if limit := params.Get("limit"); limit != "" {
+		var err error
+		page.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return page, err
+		}
+		if page.Limit < 0 || page.Limit > MaxLimit {
+			return page, e.New("the limit must be between 0 and " + strconv.Itoa(MaxLimit))
+		}
+	}
+	if page.Sort == "" {
+		page.Sort = defaultSort
+	}
+	switch page.Order {
+	case "":
+		page.Order = defaultOrder
+	case Ascending, Descending:
+	default:
+		return page, e.New("the order must be asc or desc")
+	}
+	return page, nil
+}
+
+// Query returns the Page's parameters, leaving out the ones that aren't set
+func (page Page) Query() url.Values {
+	query := url.Values{}
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	if page.Cursor != "" {
+		query.Set("cursor", page.Cursor)
+	}
+	if page.Sort != "" {
+		query.Set("sort", page.Sort)
+	}
+	if page.Order != "" {
+		query.Set("order", page.Order)
+	}
+	return query
+}
+
+// Apply sorts a list of count items and returns the indices of the ones on the Page, in order, and the cursor of
+// the next page, which is empty if this is the last one
+func (page Page) Apply(count int, sorts Sorts) ([]int, string, error) {
+	key, ok := sorts[page.Sort]
+	if !ok {
+		return nil, "", e.New("the list can't be sorted by " + page.Sort)
+	}
+	keys := make([]Key, count)
+	indices := make([]int, count)
+	for i := range keys {
+		keys[i] = key(i)
+		indices[i] = i
+	}
+	descending := page.Order == Descending
+	sort.SliceStable(indices, func(i, j int) bool {
+		if descending {
+			return less(keys[indices[j]], keys[indices[i]])
+		}
+		return less(keys[indices[i]], keys[indices[j]])
+	})
+
+	start := 0
+	if page.Cursor != "" {
+		after, err := page.decodeCursor()
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(count, func(i int) bool {
+			if descending {
+				return less(keys[indices[i]], after)
+			}
+			return less(after, keys[indices[i]])
+		})
+	}
+	end := count
+	if page.Limit > 0 && start+page.Limit < count {
+		end = start + page.Limit
+	}
+
+	next := ""
+	if end < count {
+		next = page.encodeCursor(keys[indices[end-1]])
+	}
+	return indices[start:end], next, nil
+}
+
+func (page Page) encodeCursor(key Key) string {
+	data, _ := json.Marshal(cursor{Sort: page.Sort, Order: page.Order, Key: key})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (page Page) decodeCursor() (Key, error) {
+	data, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return Key{}, e.New("the cursor is invalid")
+	}
+	decoded := cursor{}
+	if json.Unmarshal(data, &decoded) != nil {
+		return Key{}, e.New("the cursor is invalid")
+	}
+	if decoded.Sort != page.Sort || decoded.Order != page.Order {
+		return Key{}, e.New("the cursor is from a list with a different sort or order")
+	}
+	return decoded.Key, nil
+}
+
+func less(a Key, b Key) bool {
+	if a.Number != b.Number {
+		return a.Number < b.Number
+	}
+	if a.Text != b.Text {
+		return a.Text < b.Text
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type item struct {
+	ID           string
+	Name         string
+	CreationDate int64
+}
+
+var items = []item{
+	{"a", "Walmart", 3},
+	{"b", "Target", 1},
+	{"c", "Amazon", 2},
+	{"d", "Target", 2},
+}
+
+var sorts = Sorts{
+	"creationDate": func(i int) Key { return Key{Number: items[i].CreationDate, ID: items[i].ID} },
+	"name":         func(i int) Key { return Key{Text: items[i].Name, ID: items[i].ID} },
+}
+
+// pages returns the IDs on every page of the list, following the cursors from the first page
+func pages(t *testing.T, params url.Values) [][]string {
+	result := [][]string{}
+	for {
+		page, err := Parse(params, "creationDate", Ascending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		indices, next, err := page.Apply(len(items), sorts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []string{}
+		for _, i := range indices {
+			ids = append(ids, items[i].ID)
+		}
+		result = append(result, ids)
+		if next == "" {
+			return result
+		}
+		params.Set("cursor", next)
+	}
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		params url.Values
+		pages  [][]string
+	}{
+		{url.Values{}, [][]string{{"b", "c", "d", "a"}}},
+		{url.Values{"limit": {"2"}}, [][]string{{"b", "c"}, {"d", "a"}}},
+		{url.Values{"limit": {"3"}, "order": {"desc"}}, [][]string{{"a", "d", "c"}, {"b"}}},
+		{url.Values{"limit": {"1"}, "sort": {"name"}}, [][]string{{"c"}, {"b"}, {"d"}, {"a"}}},
+		{url.Values{"limit": {"4"}}, [][]string{{"b", "c", "d", "a"}}},
+	}
+	for _, c := range cases {
+		query := c.params.Encode()
+		if result := pages(t, c.params); !reflect.DeepEqual(result, c.pages) {
+			t.Errorf("%s: expected %v, got %v", query, c.pages, result)
+		}
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	cases := []url.Values{
+		{"sort": {"price"}},
+		{"cursor": {"invalid"}},
+		{"cursor": {Page{Sort: "name", Order: Ascending}.encodeCursor(Key{ID: "a"})}},
+	}
+	for _, params := range cases {
+		page, err := Parse(params, "creationDate", Ascending)
+		if err == nil {
+			_, _, err = page.Apply(len(items), sorts)
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", params.Encode())
+		}
+	}
+
+	for _, params := range []url.Values{{"limit": {"-1"}}, {"limit": {"1001"}}, {"order": {"up"}}} {
+		if _, err := Parse(params, "creationDate", Ascending); err == nil {
+			t.Errorf("%s: expected an error", params.Encode())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// remoteCertificateValidity is how long a generated remote access certificate is valid for
+const remoteCertificateValidity = 10 * 365 * 24 * time.Hour
+
+// remoteCertificate loads the self-signed certificate for remote access from dir, generating it the first time, and returns
+// it with its SHA-256 fingerprint. No CA vouches for the certificate, so remote clients should pin the fingerprint.
+func remoteCertificate(dir string) (tls.Certificate, string, error) {
+	certificatePath := filepath.Join(dir, "remote-access.crt")
+	keyPath := filepath.Join(dir, "remote-access.key")
+
+	certificate, err := tls.LoadX509KeyPair(certificatePath, keyPath)
+	if os.IsNotExist(err) {
+		err = generateRemoteCertificate(certificatePath, keyPath)
+		if err == nil {
+			certificate, err = tls.LoadX509KeyPair(certificatePath, keyPath)
+		}
+	}
+	if err != nil {
+		return certificate, "", err
+	}
+
+	fingerprint := sha256.Sum256(certificate.Certificate[0])
+	return certificate, hex.EncodeToString(fingerprint[:]), nil
+}
+
+// generateRemoteCertificate writes a new self-signed certificate and its private key, which only the user can read
+func generateRemoteCertificate(certificatePath string, keyPath string) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{Organization: []string{"Juiced"}, CommonName: "Juiced remote access"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(remoteCertificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(certificatePath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0644)
+}
@@ -0,0 +1,17 @@
+package requests
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// BackupRequest is the body of the /api/backup request
+type BackupRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// RestoreRequest is the body of the /api/restore request, its Backup is the encrypted backup, base64 encoded
+type RestoreRequest struct {
+	Passphrase string            `json:"passphrase"`
+	Mode       enums.RestoreMode `json:"mode"`
+	Backup     []byte            `json:"backup"`
+}
@@ -0,0 +1,16 @@
+package requests
+
+import "backend.juicedbot.io/juiced.infrastructure/common/entities"
+
+// TestWebhooksRequest is the body of the /api/settings/testWebhooks request. The NotificationSinks are sent a test
+// message whatever their rules are, the secrets of saved ones can be masked.
+type TestWebhooksRequest struct {
+	SuccessWebhook    string                      `json:"successDiscordWebhook"`
+	FailureWebhook    string                      `json:"failureDiscordWebhook"`
+	NotificationSinks []entities.NotificationSink `json:"notificationSinks"`
+}
+
+// SetVersionRequest is the body of the /api/setVersion request
+type SetVersionRequest struct {
+	Version string `json:"version"`
+}
@@ -0,0 +1,20 @@
+package requests
+
+// UpdateProfileGroupRequest is the body of the PUT /api/profile/group/{GroupID} request
+type UpdateProfileGroupRequest struct {
+	Name string `json:"name"`
+}
+
+// ProfileIDsRequest is the body of the /api/profile/group/{GroupID}/add and /api/profile/group/{GroupID}/remove requests
+type ProfileIDsRequest struct {
+	ProfileIDs []string `json:"profileIDs"`
+}
+
+// ImportProfilesRequest is the JSON body of the /api/profile/import request, which imports the file at FilePath.
+// The file can also be uploaded as the file field of a multipart form, with the other fields alongside it.
+type ImportProfilesRequest struct {
+	FilePath string            `json:"filePath"`
+	GroupIDs []string          `json:"groupIDs"`
+	Format   string            `json:"format"`
+	Mapping  map[string]string `json:"mapping"`
+}
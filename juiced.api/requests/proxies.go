@@ -0,0 +1,9 @@
+package requests
+
+// TestProxyGroupRequest is the body of the /api/proxy/group/{GroupID}/test request. Concurrency is how many Proxies
+// are tested at a time and Timeout is how many seconds each one has to respond, both have defaults if they're 0.
+type TestProxyGroupRequest struct {
+	URL         string `json:"url"`
+	Concurrency int    `json:"concurrency"`
+	Timeout     int    `json:"timeout"`
+}
@@ -0,0 +1,6 @@
+package requests
+
+// DeleteAccountsRequest is the body of the /api/settings/accounts/remove request
+type DeleteAccountsRequest struct {
+	AccountIDs []string `json:"accountIDs"`
+}
@@ -0,0 +1,115 @@
+package requests
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// The <Retailer>UpdateInfo types hold the retailer-specific fields of an UpdateTaskGroupRequest
+type AmazonUpdateInfo struct {
+	MonitorType enums.MonitorType `json:"monitorType"`
+}
+type BestBuyUpdateInfo struct{}
+type BoxlunchUpdateInfo struct {
+	Sizes  string `json:"sizes"`
+	Colors string `json:"colors"`
+}
+type DisneyUpdateInfo struct {
+	Sizes  string `json:"sizes"`
+	Colors string `json:"colors"`
+}
+type GamestopUpdateInfo struct{}
+type NeweggUpdateInfo struct{}
+type PokemonCenterUpdateInfo struct{}
+type HottopicUpdateInfo struct {
+	Sizes  string `json:"sizes"`
+	Colors string `json:"colors"`
+}
+type ShopifyUpdateInfo struct {
+	MonitorType enums.MonitorType `json:"monitorType"`
+	Sizes       string            `json:"sizes"`
+}
+type ToppsUpdateInfo struct{}
+type TargetUpdateInfo struct {
+	MonitorType  enums.MonitorType  `json:"monitorType"`
+	CheckoutType enums.CheckoutType `json:"checkoutType"`
+	StoreID      string             `json:"storeID"`
+}
+type WalmartUpdateInfo struct {
+	SoldByWalmart bool `json:"soldByWalmart"`
+}
+
+// UpdateTaskGroupRequest is the body of the PUT /api/task/group/{GroupID} request
+type UpdateTaskGroupRequest struct {
+	Name                    string                  `json:"name"`
+	MonitorInput            string                  `json:"input"`
+	MonitorDelay            int                     `json:"delay"`
+	MonitorProxyGroupID     string                  `json:"proxyGroupId"`
+	MaxPrice                int                     `json:"maxPrice"`
+	AmazonUpdateInfo        AmazonUpdateInfo        `json:"amazonUpdateInfo"`
+	BestbuyUpdateInfo       BestBuyUpdateInfo       `json:"bestbuyUpdateInfo"`
+	BoxlunchUpdateInfo      BoxlunchUpdateInfo      `json:"boxlunchUpdateInfo"`
+	DisneyUpdateInfo        DisneyUpdateInfo        `json:"disneyUpdateInfo"`
+	GamestopUpdateInfo      GamestopUpdateInfo      `json:"gamestopUpdateInfo"`
+	HottopicUpdateInfo      HottopicUpdateInfo      `json:"hottopicUpdateInfo"`
+	NeweggUpdateInfo        NeweggUpdateInfo        `json:"neweggUpdateInfo"`
+	PokemonCenterUpdateInfo PokemonCenterUpdateInfo `json:"pokemoncenterUpdateInfo"`
+	ShopifyUpdateInfo       ShopifyUpdateInfo       `json:"shopifyUpdateInfo"`
+	TargetUpdateInfo        TargetUpdateInfo        `json:"targetUpdateInfo"`
+	ToppsUpdateInfo         ToppsUpdateInfo         `json:"toppsUpdateInfo"`
+	WalmartUpdateInfo       WalmartUpdateInfo       `json:"walmartUpdateInfo"`
+}
+
+// DeleteTasksRequest is the body of the /api/task/group/{GroupID}/removeTasks request
+type DeleteTasksRequest struct {
+	TaskIDs []string `json:"taskIDs"`
+}
+
+// CreateTaskRequest is the body of the POST /api/task/{GroupID} request
+type CreateTaskRequest struct {
+	NumTasksPerProfile    int                             `json:"numTasksPerProfile"`
+	ProfileIDs            []string                        `json:"profileIDs"`
+	ProfileGroupID        string                          `json:"profileGroupID"`
+	ProxyGroupID          string                          `json:"proxyGroupID"`
+	AccountID             string                          `json:"accountID"`
+	Retailer              string                          `json:"retailer"`
+	Sizes                 []string                        `json:"sizes"`
+	SizeFallback          enums.SizeFallback              `json:"sizeFallback"`
+	Quantity              int                             `json:"quantity"`
+	Delay                 int                             `json:"delay"`
+	AmazonTaskInfo        *entities.AmazonTaskInfo        `json:"amazonTaskInfo"`
+	BestbuyTaskInfo       *entities.BestbuyTaskInfo       `json:"bestbuyTaskInfo"`
+	BoxlunchTaskInfo      *entities.BoxlunchTaskInfo      `json:"boxlunchTaskInfo"`
+	DisneyTaskInfo        *entities.DisneyTaskInfo        `json:"disneyTaskInfo"`
+	GamestopTaskInfo      *entities.GamestopTaskInfo      `json:"gamestopTaskInfo"`
+	HottopicTaskInfo      *entities.HottopicTaskInfo      `json:"hottopicTaskInfo"`
+	NeweggTaskInfo        *entities.NeweggTaskInfo        `json:"neweggTaskInfo"`
+	PokemonCenterTaskInfo *entities.PokemonCenterTaskInfo `json:"pokemoncenterTaskInfo"`
+	ShopifyTaskInfo       *entities.ShopifyTaskInfo       `json:"shopifyTaskInfo"`
+	TargetTaskInfo        *entities.TargetTaskInfo        `json:"targetTaskInfo"`
+	ToppsTaskInfo         *entities.ToppsTaskInfo         `json:"toppsTaskInfo"`
+	WalmartTaskInfo       *entities.WalmartTaskInfo       `json:"walmartTaskInfo"`
+}
+
+// UpdateTasksRequest is the body of the /api/task/group/{GroupID}/updateTasks request, a nil Sizes doesn't change the tasks' sizes
+type UpdateTasksRequest struct {
+	TaskIDs               []string                       `json:"taskIDs"`
+	ProfileID             string                         `json:"profileID"`
+	ProxyGroupID          string                         `json:"proxyGroupID"`
+	AccountID             string                         `json:"accountID"`
+	Quantity              int                            `json:"quantity"`
+	Sizes                 []string                       `json:"sizes"`
+	SizeFallback          enums.SizeFallback             `json:"sizeFallback"`
+	AmazonTaskInfo        entities.AmazonTaskInfo        `json:"amazonTaskInfo"`
+	BestbuyTaskInfo       entities.BestbuyTaskInfo       `json:"bestbuyTaskInfo"`
+	BoxlunchTaskInfo      entities.BoxlunchTaskInfo      `json:"boxlunchTaskInfo"`
+	DisneyTaskInfo        entities.DisneyTaskInfo        `json:"disneyTaskInfo"`
+	GamestopTaskInfo      entities.GamestopTaskInfo      `json:"gamestopTaskInfo"`
+	HottopicTaskInfo      entities.HottopicTaskInfo      `json:"hottopicTaskInfo"`
+	NeweggTaskInfo        entities.NeweggTaskInfo        `json:"neweggTaskInfo"`
+	PokemonCenterTaskInfo entities.PokemonCenterTaskInfo `json:"pokemoncenterTaskInfo"`
+	ShopifyTaskInfo       entities.ShopifyTaskInfo       `json:"shopifyTaskInfo"`
+	TargetTaskInfo        entities.TargetTaskInfo        `json:"targetTaskInfo"`
+	ToppsTaskInfo         entities.ToppsTaskInfo         `json:"toppsTaskInfo"`
+	WalmartTaskInfo       entities.WalmartTaskInfo       `json:"walmartTaskInfo"`
+}
@@ -0,0 +1,21 @@
+package responses
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// BackupResponse is the response that the /api/backup request receives, its Data is the encrypted backup, base64 encoded
+type BackupResponse struct {
+	Success bool      `json:"success"`
+	Data    []byte    `json:"data"`
+	Errors  []string  `json:"errors"`
+	Code    ErrorCode `json:"code,omitempty"`
+}
+
+// RestoreResponse is the response that the /api/restore request receives
+type RestoreResponse struct {
+	Success bool                     `json:"success"`
+	Data    []entities.RestoreReport `json:"data"`
+	Errors  []string                 `json:"errors"`
+	Code    ErrorCode                `json:"code,omitempty"`
+}
@@ -6,7 +6,17 @@ import (
 
 // CheckoutResponse is the response that any /api/checkout request receives
 type CheckoutResponse struct {
-	Success bool                `json:"success"`
-	Data    []entities.Checkout `json:"data"`
-	Errors  []string            `json:"errors"`
+	Success    bool                `json:"success"`
+	Data       []entities.Checkout `json:"data"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+	Errors     []string            `json:"errors"`
+	Code       ErrorCode           `json:"code,omitempty"`
+}
+
+// CheckoutStatsResponse is the response that any /api/checkout/stats request receives
+type CheckoutStatsResponse struct {
+	Success bool                     `json:"success"`
+	Data    []entities.CheckoutStats `json:"data"`
+	Errors  []string                 `json:"errors"`
+	Code    ErrorCode                `json:"code,omitempty"`
 }
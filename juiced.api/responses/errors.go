@@ -0,0 +1,38 @@
+package responses
+
+import "net/http"
+
+// ErrorCode is a machine-readable reason that a request failed, which decides the response's status
+type ErrorCode = string
+
+const (
+	InvalidRequestCode   ErrorCode = "invalid_request"
+	UnauthorizedCode     ErrorCode = "unauthorized"
+	ForbiddenCode        ErrorCode = "forbidden"
+	NotFoundCode         ErrorCode = "not_found"
+	MethodNotAllowedCode ErrorCode = "method_not_allowed"
+	ConflictCode         ErrorCode = "conflict"
+	ValidationFailedCode ErrorCode = "validation_failed"
+	InternalErrorCode    ErrorCode = "internal_error"
+)
+
+// ErrorStatus returns the HTTP status that a response with the code has
+func ErrorStatus(code ErrorCode) int {
+	switch code {
+	case InvalidRequestCode:
+		return http.StatusBadRequest
+	case UnauthorizedCode:
+		return http.StatusUnauthorized
+	case ForbiddenCode:
+		return http.StatusForbidden
+	case NotFoundCode:
+		return http.StatusNotFound
+	case MethodNotAllowedCode:
+		return http.StatusMethodNotAllowed
+	case ConflictCode:
+		return http.StatusConflict
+	case ValidationFailedCode:
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
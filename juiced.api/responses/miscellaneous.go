@@ -1,6 +1,7 @@
 package responses
 
 type MiscellaneousResponse struct {
-	Success bool     `json:"success"`
-	Errors  []string `json:"errors"`
+	Success bool      `json:"success"`
+	Errors  []string  `json:"errors"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
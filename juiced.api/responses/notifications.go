@@ -0,0 +1,14 @@
+package responses
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// OutboxResponse is the response that any /api/notification/outbox request receives
+type OutboxResponse struct {
+	Success    bool                   `json:"success"`
+	Data       []entities.OutboxEntry `json:"data"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Errors     []string               `json:"errors"`
+	Code       ErrorCode              `json:"code,omitempty"`
+}
@@ -6,16 +6,20 @@ import (
 
 // ProfileGroupResponse is the response that any /api/profile/group request receives
 type ProfileGroupResponse struct {
-	Success bool                                `json:"success"`
-	Data    []entities.ProfileGroupWithProfiles `json:"data"`
-	Errors  []string                            `json:"errors"`
+	Success    bool                                `json:"success"`
+	Data       []entities.ProfileGroupWithProfiles `json:"data"`
+	NextCursor string                              `json:"nextCursor,omitempty"`
+	Errors     []string                            `json:"errors"`
+	Code       ErrorCode                           `json:"code,omitempty"`
 }
 
 // ProfileResponse is the response that any /api/profile request receives
 type ProfileResponse struct {
-	Success bool               `json:"success"`
-	Data    []entities.Profile `json:"data"`
-	Errors  []string           `json:"errors"`
+	Success    bool               `json:"success"`
+	Data       []entities.Profile `json:"data"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+	Errors     []string           `json:"errors"`
+	Code       ErrorCode          `json:"code,omitempty"`
 }
 
 // ImportProfileResponse is the response that the /api/profile/import request receives
@@ -24,6 +28,13 @@ type ImportProfileResponse struct {
 	NewProfiles     []entities.Profile                  `json:"newProfiles"`
 	SkippedProfiles int                                 `json:"skippedProfiles"`
 	SkippedGroups   int                                 `json:"skippedGroups"`
+	Results         []entities.ProfileImportResult      `json:"results"`
 	Data            []entities.ProfileGroupWithProfiles `json:"data"`
 	Errors          []string                            `json:"errors"`
+	Code            ErrorCode                           `json:"code,omitempty"`
+}
+
+// ProfilesFile is the JSON file that the /api/profile/export request receives, which /api/profile/import can read back
+type ProfilesFile struct {
+	Profiles []entities.Profile `json:"profiles"`
 }
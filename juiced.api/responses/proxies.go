@@ -6,7 +6,9 @@ import (
 
 // ProxyGroupResponse is the response that any /api/proxy/group request receives
 type ProxyGroupResponse struct {
-	Success bool                  `json:"success"`
-	Data    []entities.ProxyGroup `json:"data"`
-	Errors  []string              `json:"errors"`
+	Success    bool                  `json:"success"`
+	Data       []entities.ProxyGroup `json:"data"`
+	NextCursor string                `json:"nextCursor,omitempty"`
+	Errors     []string              `json:"errors"`
+	Code       ErrorCode             `json:"code,omitempty"`
 }
@@ -0,0 +1,13 @@
+package responses
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// TaskGroupSchedulesResponse is the response that any /api/task/group/{GroupID}/schedule request receives
+type TaskGroupSchedulesResponse struct {
+	Success bool                         `json:"success"`
+	Data    []entities.TaskGroupSchedule `json:"data"`
+	Errors  []string                     `json:"errors"`
+	Code    ErrorCode                    `json:"code,omitempty"`
+}
@@ -1,12 +1,72 @@
 package responses
 
 import (
+	"encoding/json"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 )
 
-// SettingsResponse is the response that any /api/settings request receives
+// SettingsResponse is the response that any /api/settings request receives, its secrets are masked
 type SettingsResponse struct {
 	Success bool              `json:"success"`
 	Data    entities.Settings `json:"data"`
 	Errors  []string          `json:"errors"`
+	Code    ErrorCode         `json:"code,omitempty"`
+}
+
+// RevealedSettingsResponse is the response that the /api/settings/reveal request receives, its secrets aren't masked
+type RevealedSettingsResponse SettingsResponse
+
+// MarshalJSON encodes the response with its Settings masked
+func (response SettingsResponse) MarshalJSON() ([]byte, error) {
+	type SettingsResponseAlias SettingsResponse
+	masked := SettingsResponseAlias(response)
+	masked.Data = MaskSettings(response.Data)
+	return json.Marshal(masked)
+}
+
+// AccountsResponse is the response that the GET /api/settings/accounts request receives, its passwords are masked
+type AccountsResponse struct {
+	Success bool               `json:"success"`
+	Data    []entities.Account `json:"data"`
+	Errors  []string           `json:"errors"`
+	Code    ErrorCode          `json:"code,omitempty"`
+}
+
+// MarshalJSON encodes the response with its Accounts' passwords masked
+func (response AccountsResponse) MarshalJSON() ([]byte, error) {
+	type AccountsResponseAlias AccountsResponse
+	masked := AccountsResponseAlias(response)
+	masked.Data = MaskSettings(entities.Settings{Accounts: response.Data}).Accounts
+	return json.Marshal(masked)
+}
+
+// MaskSettings returns a copy of the Settings with its API keys, remote access token, account passwords and
+// notification sinks' bot tokens and SMTP passwords masked
+func MaskSettings(settings entities.Settings) entities.Settings {
+	settings.TwoCaptchaAPIKey = common.MaskSecret(settings.TwoCaptchaAPIKey)
+	settings.AntiCaptchaAPIKey = common.MaskSecret(settings.AntiCaptchaAPIKey)
+	settings.CapMonsterAPIKey = common.MaskSecret(settings.CapMonsterAPIKey)
+	settings.AYCDAccessToken = common.MaskSecret(settings.AYCDAccessToken)
+	settings.AYCDAPIKey = common.MaskSecret(settings.AYCDAPIKey)
+	settings.RemoteAccessToken = common.MaskSecret(settings.RemoteAccessToken)
+	if settings.Accounts != nil {
+		accounts := make([]entities.Account, len(settings.Accounts))
+		for i, account := range settings.Accounts {
+			account.Password = common.MaskSecret(account.Password)
+			accounts[i] = account
+		}
+		settings.Accounts = accounts
+	}
+	if settings.NotificationSinks != nil {
+		sinks := make([]entities.NotificationSink, len(settings.NotificationSinks))
+		for i, sink := range settings.NotificationSinks {
+			sink.BotToken = common.MaskSecret(sink.BotToken)
+			sink.SMTPPassword = common.MaskSecret(sink.SMTPPassword)
+			sinks[i] = sink
+		}
+		settings.NotificationSinks = sinks
+	}
+	return settings
 }
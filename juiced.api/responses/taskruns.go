@@ -0,0 +1,21 @@
+package responses
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// TaskRunsResponse is the response that any /api/task/{ID}/runs request receives
+type TaskRunsResponse struct {
+	Success bool               `json:"success"`
+	Data    []entities.TaskRun `json:"data"`
+	Errors  []string           `json:"errors"`
+	Code    ErrorCode          `json:"code,omitempty"`
+}
+
+// TaskGroupTimelineResponse is the response that any /api/task/group/{GroupID}/timeline request receives
+type TaskGroupTimelineResponse struct {
+	Success bool                    `json:"success"`
+	Data    []entities.TaskRunEvent `json:"data"`
+	Errors  []string                `json:"errors"`
+	Code    ErrorCode               `json:"code,omitempty"`
+}
@@ -1,20 +1,119 @@
 package responses
 
 import (
+	"encoding/json"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 )
 
-// TaskGroupResponse is the response that any /api/task/group request receives
+// TaskGroupResponse is the response that any /api/task/group request receives, its Tasks' passwords are masked
 type TaskGroupResponse struct {
-	Success  bool                          `json:"success"`
-	Data     []entities.TaskGroupWithTasks `json:"data"`
-	Errors   []string                      `json:"errors"`
-	Warnings []string                      `json:"warnings"`
+	Success    bool                          `json:"success"`
+	Data       []entities.TaskGroupWithTasks `json:"data"`
+	NextCursor string                        `json:"nextCursor,omitempty"`
+	Errors     []string                      `json:"errors"`
+	Code       ErrorCode                     `json:"code,omitempty"`
+	Warnings   []string                      `json:"warnings"`
+}
+
+// MarshalJSON encodes the response with its Tasks masked
+func (response TaskGroupResponse) MarshalJSON() ([]byte, error) {
+	type TaskGroupResponseAlias TaskGroupResponse
+	masked := TaskGroupResponseAlias(response)
+	if response.Data != nil {
+		masked.Data = make([]entities.TaskGroupWithTasks, len(response.Data))
+		for i, taskGroup := range response.Data {
+			taskGroup.Tasks = MaskTasks(taskGroup.Tasks)
+			masked.Data[i] = taskGroup
+		}
+	}
+	return json.Marshal(masked)
 }
 
-// TaskResponse is the response that any /api/task request receives
+// TaskResponse is the response that any /api/task request receives, its Tasks' passwords are masked
 type TaskResponse struct {
-	Success bool            `json:"success"`
-	Data    []entities.Task `json:"data"`
-	Errors  []string        `json:"errors"`
+	Success    bool            `json:"success"`
+	Data       []entities.Task `json:"data"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	Errors     []string        `json:"errors"`
+	Code       ErrorCode       `json:"code,omitempty"`
+}
+
+// RevealedTaskResponse is the response that the /api/task/{ID}/reveal request receives, its Tasks' passwords aren't masked
+type RevealedTaskResponse TaskResponse
+
+// MarshalJSON encodes the response with its Tasks masked
+func (response TaskResponse) MarshalJSON() ([]byte, error) {
+	type TaskResponseAlias TaskResponse
+	masked := TaskResponseAlias(response)
+	masked.Data = MaskTasks(response.Data)
+	return json.Marshal(masked)
+}
+
+// MaskTasks returns a copy of the Tasks with their retailer account passwords masked
+func MaskTasks(tasks []entities.Task) []entities.Task {
+	if tasks == nil {
+		return nil
+	}
+	maskedTasks := make([]entities.Task, len(tasks))
+	for i, task := range tasks {
+		maskedTasks[i] = MaskTask(task)
+	}
+	return maskedTasks
+}
+
+// MaskTask returns a copy of the Task with its retailer account password masked
+func MaskTask(task entities.Task) entities.Task {
+	if task.AmazonTaskInfo != nil {
+		taskInfo := *task.AmazonTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.AmazonTaskInfo = &taskInfo
+	}
+	if task.BestbuyTaskInfo != nil {
+		taskInfo := *task.BestbuyTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.BestbuyTaskInfo = &taskInfo
+	}
+	if task.DisneyTaskInfo != nil {
+		taskInfo := *task.DisneyTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.DisneyTaskInfo = &taskInfo
+	}
+	if task.GamestopTaskInfo != nil {
+		taskInfo := *task.GamestopTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.GamestopTaskInfo = &taskInfo
+	}
+	if task.PokemonCenterTaskInfo != nil {
+		taskInfo := *task.PokemonCenterTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.PokemonCenterTaskInfo = &taskInfo
+	}
+	if task.ShopifyTaskInfo != nil && task.ShopifyTaskInfo.HotWheelsTaskInfo != nil {
+		shopifyTaskInfo := *task.ShopifyTaskInfo
+		taskInfo := *shopifyTaskInfo.HotWheelsTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		shopifyTaskInfo.HotWheelsTaskInfo = &taskInfo
+		task.ShopifyTaskInfo = &shopifyTaskInfo
+	}
+	if task.TargetTaskInfo != nil {
+		taskInfo := *task.TargetTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.TargetTaskInfo = &taskInfo
+	}
+	if task.ToppsTaskInfo != nil {
+		taskInfo := *task.ToppsTaskInfo
+		taskInfo.Password = common.MaskSecret(taskInfo.Password)
+		task.ToppsTaskInfo = &taskInfo
+	}
+	return task
+}
+
+// TaskIDsResponse is the response that the /api/task/group/{GroupID}/generate request receives, its Data is the IDs of the created Tasks
+type TaskIDsResponse struct {
+	Success bool      `json:"success"`
+	Data    []string  `json:"data"`
+	Errors  []string  `json:"errors"`
+	Code    ErrorCode `json:"code,omitempty"`
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"backend.juicedbot.io/juiced.api/endpoints"
+
+	"github.com/gorilla/mux"
+)
+
+// RouteBackupEndpoints routes endpoints that handle backups
+func RouteBackupEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/backup", endpoints.BackupEndpoint).Methods("POST")
+
+	router.HandleFunc("/api/restore", endpoints.RestoreEndpoint).Methods("POST")
+}
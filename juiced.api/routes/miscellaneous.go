@@ -9,4 +9,7 @@ import (
 func RouteMiscellaneousEndpoints(router *mux.Router) {
 	router.HandleFunc("/api/settings/testWebhooks", endpoints.TestWebhooksEndpoint).Methods("POST")
 	router.HandleFunc("/api/setVersion", endpoints.SetVersion).Methods("POST")
+
+	// The OpenAPI document is generated from openapi.Routes, which has to list every route that's added here
+	router.HandleFunc("/api/openapi.json", endpoints.GetOpenAPIEndpoint).Methods("GET")
 }
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"backend.juicedbot.io/juiced.api/endpoints"
+
+	"github.com/gorilla/mux"
+)
+
+// RouteNotificationsEndpoints routes endpoints that handle the notification outbox
+func RouteNotificationsEndpoints(router *mux.Router) {
+	router.HandleFunc("/api/notification/outbox", endpoints.GetOutboxEndpoint).Methods("GET")
+
+	router.HandleFunc("/api/notification/outbox/replay", endpoints.ReplayOutboxEndpoint).Methods("POST")
+
+	router.HandleFunc("/api/notification/outbox/{ID}/replay", endpoints.ReplayOutboxEntryEndpoint).Methods("POST")
+
+	router.HandleFunc("/api/notification/outbox/{ID}", endpoints.RemoveOutboxEntryEndpoint).Methods("DELETE")
+}
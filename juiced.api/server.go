@@ -1,29 +1,169 @@
 package api
 
 import (
+	"fmt"
+	"log"
+	"net"
+	"sync"
+
+	"backend.juicedbot.io/juiced.api/endpoints"
+	"backend.juicedbot.io/juiced.api/middleware"
 	"backend.juicedbot.io/juiced.api/routes"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	ws "backend.juicedbot.io/juiced.ws"
 
+	"crypto/tls"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/kirsle/configdir"
 	"github.com/rs/cors"
 )
 
-// StartServer launches the local server that hosts the API for communication between the app and the backend
+// LocalAddress is the loopback address that the API is served on for the app
+const LocalAddress = "127.0.0.1:10000"
+
+// StartServer launches the local server that hosts the API for communication between the app and the backend.
+// Every request needs the session token. If remote access is enabled, the API is also served over TLS on every interface.
 func StartServer() {
+	endpoints.RemoteAccessChanged = applyRemoteAccess
+	settings, err := queries.GetSettings()
+	if err != nil {
+		log.Println("Error checking the remote access settings: " + err.Error())
+	} else {
+		applyRemoteAccess(settings)
+	}
+	http.ListenAndServe(LocalAddress, newHandler(middleware.SessionTokens, false))
+}
+
+// newHandler returns every route behind CORS and the Recover, JSON and Authenticate middleware, which accepts the tokens that
+// acceptedTokens returns. Requests that don't match a route get a JSON error like every other failed request.
+// The remote handler also serves the websocket at /ws, since the websocket server is only on loopback.
+func newHandler(acceptedTokens func() []string, remote bool) http.Handler {
+	authenticate := middleware.Authenticate(acceptedTokens)
+	router := newRouter()
+	// The router's middleware doesn't run for requests that don't match a route, so these are authenticated themselves
+	router.NotFoundHandler = authenticate(middleware.NotFound())
+	router.MethodNotAllowedHandler = authenticate(middleware.MethodNotAllowed())
+	router.Use(middleware.Recover, middleware.JSON, authenticate)
+	if remote {
+		router.HandleFunc("/ws", ws.HandleConnections)
+	}
+	c := cors.New(cors.Options{
+		AllowedOrigins: middleware.AllowedOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+	})
+	return c.Handler(router)
+}
+
+// newRouter returns a router with every API route, which are the routes that the OpenAPI document describes
+func newRouter() *mux.Router {
 	router := mux.NewRouter()
-	sh := http.StripPrefix("/swaggerui/", http.FileServer(http.Dir("../swaggerui/")))
-	router.PathPrefix("/swaggerui/").Handler(sh)
 	routes.RouteProxiesEndpoints(router)
 	routes.RouteProfilesEndpoints(router)
 	routes.RouteTasksEndpoints(router)
 	routes.RouteCheckoutsEndpoints(router)
 	routes.RouteSettingsEndpoints(router)
+	routes.RouteNotificationsEndpoints(router)
 	routes.RouteMiscellaneousEndpoints(router)
-	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"http://localhost:3000"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-	})
-	handler := c.Handler(router)
-	http.ListenAndServe(":10000", handler)
+	routes.RouteBackupEndpoints(router)
+	return router
+}
+
+// remoteCertificateDir is where the remote access certificate is kept
+var remoteCertificateDir = configdir.LocalConfig("juiced")
+
+// remoteServer is the server that serves remote access, and the websocket connections that it has handed off
+type remoteServer struct {
+	server   *http.Server
+	port     int
+	token    string
+	mutex    sync.Mutex
+	hijacked map[net.Conn]bool
+}
+
+var currentRemoteServer *remoteServer
+var remoteServerMutex sync.Mutex
+
+// applyRemoteAccess makes remote access match the user's Settings. The token that's accepted changes straight away, and
+// the server is stopped if remote access is disabled, or restarted if its port or token changed so that websocket
+// connections made with the old token are closed. It only accepts the remote access token, not the session token.
+func applyRemoteAccess(settings entities.Settings) {
+	token := ""
+	if settings.RemoteAccessEnabled {
+		token = settings.RemoteAccessToken
+		if !middleware.StrongToken(token) {
+			log.Println("Remote access is enabled but its token is missing or too short, it won't be started")
+			token = ""
+		}
+	}
+	port := settings.RemoteAccessPort
+	if port == 0 {
+		port = entities.DefaultRemoteAccessPort
+	}
+	middleware.SetRemoteToken(token)
+
+	remoteServerMutex.Lock()
+	defer remoteServerMutex.Unlock()
+	if currentRemoteServer != nil {
+		if token != "" && currentRemoteServer.port == port && currentRemoteServer.token == token {
+			return
+		}
+		currentRemoteServer.close()
+		currentRemoteServer = nil
+	}
+	if token == "" {
+		return
+	}
+
+	certificate, fingerprint, err := remoteCertificate(remoteCertificateDir)
+	if err != nil {
+		log.Println("Error loading the remote access certificate: " + err.Error())
+		return
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log.Println("Error serving remote access: " + err.Error())
+		return
+	}
+	remote := &remoteServer{port: port, token: token, hijacked: make(map[net.Conn]bool)}
+	remote.server = &http.Server{
+		Handler: newHandler(middleware.RemoteTokens, true),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			MinVersion:   tls.VersionTLS12,
+		},
+		ConnState: remote.trackHijacked,
+	}
+	currentRemoteServer = remote
+	log.Printf("Serving remote access on port %d, the certificate's SHA-256 fingerprint is %s\n", port, fingerprint)
+	go func() {
+		err := remote.server.ServeTLS(listener, "", "")
+		if err != http.ErrServerClosed {
+			log.Println("Error serving remote access: " + err.Error())
+		}
+	}()
+}
+
+// trackHijacked keeps the connections that the server handed off to the websocket, which closing the server doesn't close
+func (remote *remoteServer) trackHijacked(conn net.Conn, state http.ConnState) {
+	if state != http.StateHijacked {
+		return
+	}
+	remote.mutex.Lock()
+	defer remote.mutex.Unlock()
+	remote.hijacked[conn] = true
+}
+
+// close stops the server and closes its websocket connections
+func (remote *remoteServer) close() {
+	remote.server.Close()
+	remote.mutex.Lock()
+	defer remote.mutex.Unlock()
+	for conn := range remote.hijacked {
+		conn.Close()
+	}
+	remote.hijacked = make(map[net.Conn]bool)
 }
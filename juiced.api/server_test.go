@@ -0,0 +1,120 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"backend.juicedbot.io/juiced.api/middleware"
+	"backend.juicedbot.io/juiced.api/openapi"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"github.com/gorilla/mux"
+)
+
+// TestRoutesMatchOpenAPIDocument fails when a route is added, removed or changed without updating openapi.Routes
+func TestRoutesMatchOpenAPIDocument(t *testing.T) {
+	documented := make(map[string]openapi.Route)
+	for _, route := range openapi.Routes {
+		key := route.Method + " " + route.Path
+		if _, ok := documented[key]; ok {
+			t.Errorf("%s is documented twice", key)
+		}
+		documented[key] = route
+	}
+
+	routed := make(map[string]bool)
+	err := newRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			t.Errorf("%s doesn't have a method", path)
+			return nil
+		}
+		handler := runtime.FuncForPC(reflect.ValueOf(route.GetHandler()).Pointer()).Name()
+		handler = strings.TrimSuffix(handler[strings.LastIndex(handler, ".")+1:], "Endpoint")
+		for _, method := range methods {
+			key := method + " " + path
+			routed[key] = true
+			documentedRoute, ok := documented[key]
+			if !ok {
+				t.Errorf("%s isn't in openapi.Routes", key)
+			} else if documentedRoute.ID != handler {
+				t.Errorf("%s is documented as %s, but it's handled by %s", key, documentedRoute.ID, handler)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key := range documented {
+		if !routed[key] {
+			t.Errorf("%s is in openapi.Routes, but isn't routed", key)
+		}
+	}
+}
+
+// TestApplyRemoteAccess checks that the remote access server follows the settings without a restart
+func TestApplyRemoteAccess(t *testing.T) {
+	remoteCertificateDir = t.TempDir()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+	t.Cleanup(func() { applyRemoteAccess(entities.Settings{}) })
+
+	// status returns the status of an authenticated request to a route that doesn't exist, or 0 if it can't connect
+	status := func(token string) int {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		}}
+		request, _ := http.NewRequest("GET", fmt.Sprintf("https://127.0.0.1:%d/api/missing", port), nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		response, err := client.Do(request)
+		if err != nil {
+			return 0
+		}
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	oldToken, _ := middleware.NewToken()
+	newToken, _ := middleware.NewToken()
+	applyRemoteAccess(entities.Settings{RemoteAccessEnabled: true, RemoteAccessPort: port, RemoteAccessToken: oldToken})
+	if code := status(oldToken); code != http.StatusNotFound {
+		t.Fatalf("expected the token to be accepted, got %d", code)
+	}
+	if code := status(middleware.GetSessionToken()); code != http.StatusUnauthorized {
+		t.Errorf("expected only the remote access token to be accepted, got %d", code)
+	}
+
+	applyRemoteAccess(entities.Settings{RemoteAccessEnabled: true, RemoteAccessPort: port, RemoteAccessToken: newToken})
+	if code := status(oldToken); code != http.StatusUnauthorized {
+		t.Errorf("expected the old token to be rejected once it's rotated, got %d", code)
+	}
+	if code := status(newToken); code != http.StatusNotFound {
+		t.Errorf("expected the new token to be accepted, got %d", code)
+	}
+
+	applyRemoteAccess(entities.Settings{RemoteAccessEnabled: false, RemoteAccessPort: port, RemoteAccessToken: newToken})
+	if code := status(newToken); code != 0 {
+		t.Errorf("expected the server to stop once remote access is disabled, got %d", code)
+	}
+
+	applyRemoteAccess(entities.Settings{RemoteAccessEnabled: true, RemoteAccessPort: port, RemoteAccessToken: "short"})
+	if code := status("short"); code != 0 {
+		t.Errorf("expected a short token not to be served, got %d", code)
+	}
+}
@@ -15,7 +15,7 @@ func UpdateProxy(client *http.Client, newProxy *entities.Proxy) error {
 		return nil
 	}
 	newProxy.AddCount()
-	dialer, err := newConnectDialer(common.ProxyCleaner(*newProxy))
+	dialer, err := newProxyDialer(common.ProxyCleaner(*newProxy))
 	if err != nil {
 		return err
 	}
@@ -27,7 +27,7 @@ func UpdateProxy(client *http.Client, newProxy *entities.Proxy) error {
 func NewClient(clientHello utls.ClientHelloID, proxyUrl ...string) (http.Client, error) {
 	if len(proxyUrl) > 0 {
 		if len(proxyUrl[0]) > 0 {
-			dialer, err := newConnectDialer(proxyUrl[0])
+			dialer, err := newProxyDialer(proxyUrl[0])
 			if err != nil {
 				return http.Client{}, err
 			}
@@ -39,6 +39,9 @@ type connectDialer struct {
 
 	Dialer net.Dialer // overridden dialer allow to control establishment of TCP connection
 
+	// TLSClientConfig is used to connect to https proxies if it's set, e.g. to trust a proxy's own certificate
+	TLSClientConfig *tls.Config
+
 	// overridden DialTLS allows user to control establishment of TLS connection
 	// MUST return connection with completed Handshake, and NegotiatedProtocol
 	DialTLS func(network string, address string) (net.Conn, string, error)
@@ -200,16 +203,22 @@ func (c *connectDialer) DialContext(ctx context.Context, network, address string
 				return nil, err
 			}
 		} else {
-			tlsConf := tls.Config{
-				NextProtos: []string{"h2", "http/1.1"},
-				ServerName: c.ProxyUrl.Hostname(),
+			tlsConf := &tls.Config{}
+			if c.TLSClientConfig != nil {
+				tlsConf = c.TLSClientConfig.Clone()
+			}
+			tlsConf.NextProtos = []string{"h2", "http/1.1"}
+			if tlsConf.ServerName == "" {
+				tlsConf.ServerName = c.ProxyUrl.Hostname()
 			}
-			tlsConn, err := tls.Dial(network, c.ProxyUrl.Host, &tlsConf)
+			tcpConn, err := c.Dialer.DialContext(ctx, network, c.ProxyUrl.Host)
 			if err != nil {
 				return nil, err
 			}
+			tlsConn := tls.Client(tcpConn, tlsConf)
 			err = tlsConn.Handshake()
 			if err != nil {
+				_ = tcpConn.Close()
 				return nil, err
 			}
 			negotiatedProtocol = tlsConn.ConnectionState().NegotiatedProtocol
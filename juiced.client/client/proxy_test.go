@@ -0,0 +1,241 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// startEchoServer starts a TCP server that writes back everything it reads, it stands in for a site
+func startEchoServer(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener
+}
+
+// startSOCKS5Server starts a SOCKS5 server that only accepts the username and password, it stands in for a proxy.
+// The addresses that clients asked it to connect to are sent to targets.
+func startSOCKS5Server(t *testing.T, username, password string) (net.Listener, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	targets := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				target, err := socks5Handshake(conn, username, password)
+				if err != nil {
+					return
+				}
+				targets <- target
+				targetConn, err := net.Dial("tcp", target)
+				if err != nil {
+					conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+					return
+				}
+				defer targetConn.Close()
+				conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+				go io.Copy(targetConn, conn)
+				io.Copy(conn, targetConn)
+			}()
+		}
+	}()
+	return listener, targets
+}
+
+// socks5Handshake reads the greeting, username/password authentication and CONNECT request from a SOCKS5 client,
+// and returns the address it asked to connect to
+func socks5Handshake(conn net.Conn, username, password string) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil || header[0] != 5 {
+		return "", errors.New("bad greeting")
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil || !bytes.Contains(methods, []byte{2}) {
+		conn.Write([]byte{5, 0xff})
+		return "", errors.New("no username/password authentication")
+	}
+	conn.Write([]byte{5, 2})
+
+	readField := func() string {
+		length := make([]byte, 1)
+		io.ReadFull(conn, length)
+		field := make([]byte, length[0])
+		io.ReadFull(conn, field)
+		return string(field)
+	}
+	version := make([]byte, 1)
+	io.ReadFull(conn, version)
+	if readField() != username || readField() != password {
+		conn.Write([]byte{1, 1})
+		return "", errors.New("bad credentials")
+	}
+	conn.Write([]byte{1, 0})
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil || request[1] != 1 {
+		return "", errors.New("bad request")
+	}
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, net.IPv4len)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	case 3:
+		host = readField()
+	case 4:
+		ip := make([]byte, net.IPv6len)
+		io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// expectEcho dials the echo server through the dialer and checks that what's written comes back
+func expectEcho(t *testing.T, dialer proxy.ContextDialer, address string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if _, err = conn.Write([]byte("ping")); err != nil {
+		t.Fatal(err)
+	}
+	reply := make([]byte, 4)
+	if _, err = io.ReadFull(conn, reply); err != nil {
+		t.Fatal(err)
+	}
+	if string(reply) != "ping" {
+		t.Errorf("expected the target to echo ping, got %q", reply)
+	}
+}
+
+func TestNewProxyDialer_SOCKS5(t *testing.T) {
+	echo := startEchoServer(t)
+	socks, targets := startSOCKS5Server(t, "user", "p@ss")
+
+	dialer, err := newProxyDialer("socks5://user:p%40ss@" + socks.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEcho(t, dialer, echo.Addr().String())
+	if target := <-targets; target != echo.Addr().String() {
+		t.Errorf("expected the proxy to connect to %s, got %s", echo.Addr(), target)
+	}
+
+	dialer, err = newProxyDialer("socks5://user:wrong@" + socks.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn, err := dialer.DialContext(context.Background(), "tcp", echo.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("expected the proxy to reject the wrong password")
+	}
+}
+
+func TestNewProxyDialer_Schemes(t *testing.T) {
+	for _, proxyURL := range []string{"http://127.0.0.1:8080", "https://127.0.0.1:8443", "socks5://127.0.0.1:1080", "socks5h://127.0.0.1"} {
+		if _, err := newProxyDialer(proxyURL); err != nil {
+			t.Errorf("expected %s to be supported, got %v", proxyURL, err)
+		}
+	}
+	for _, proxyURL := range []string{"socks4://127.0.0.1:1080", "socks5://", "127.0.0.1:8080"} {
+		if _, err := newProxyDialer(proxyURL); err == nil {
+			t.Errorf("expected %s to be rejected", proxyURL)
+		}
+	}
+}
+
+func TestConnectDialer_HTTPS(t *testing.T) {
+	echo := startEchoServer(t)
+	// The proxy speaks CONNECT over TLS with a certificate that's only trusted through TLSClientConfig
+	proxyServer := httptest.NewTLSServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		auth := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:pass"))
+		if r.Method != nethttp.MethodConnect || r.Header.Get("Proxy-Authorization") != auth {
+			w.WriteHeader(nethttp.StatusProxyAuthRequired)
+			return
+		}
+		targetConn, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(nethttp.StatusBadGateway)
+			return
+		}
+		defer targetConn.Close()
+		conn, _, err := w.(nethttp.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("HTTP/1.1 200 OK\r\n\r\n"))
+		go io.Copy(targetConn, conn)
+		io.Copy(conn, targetConn)
+	}))
+	defer proxyServer.Close()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(proxyServer.Certificate())
+
+	dialer, err := newProxyDialer("https://user:pass@" + proxyServer.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn, err := dialer.DialContext(context.Background(), "tcp", echo.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("expected the proxy's certificate not to be trusted")
+	}
+
+	dialer.(*connectDialer).TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	expectEcho(t, dialer, echo.Addr().String())
+
+	dialer, err = newProxyDialer("https://user:wrong@" + proxyServer.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dialer.(*connectDialer).TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	if conn, err := dialer.DialContext(context.Background(), "tcp", echo.Addr().String()); err == nil {
+		conn.Close()
+		t.Error("expected the proxy to reject the wrong password")
+	}
+}
@@ -0,0 +1,667 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// workspaceTables are the tables that hold a Backup's records, RestoreReplace empties them before restoring.
+// The settings are replaced by UpdateSettings instead, since there's always exactly one row.
+var workspaceTables = []string{
+	"accounts", "profileGroups", "profiles", "shippingAddresses", "billingAddresses", "cards", "proxyGroups", "proxys",
+	"taskGroups", "tasks", "taskGroupSchedules", "checkouts",
+	"amazonTaskInfos", "bestbuyTaskInfos", "boxlunchTaskInfos", "disneyTaskInfos", "gamestopTaskInfos", "hottopicTaskInfos",
+	"hotwheelsTaskInfos", "neweggTaskInfos", "pokemoncenterTaskInfos", "shopifyTaskInfos", "targetTaskInfos", "toppsTaskInfos",
+	"walmartTaskInfos",
+	"amazonMonitorInfos", "amazonSingleMonitorInfos", "bestbuyMonitorInfos", "bestbuySingleMonitorInfos",
+	"boxlunchMonitorInfos", "boxlunchSingleMonitorInfos", "disneyMonitorInfos", "disneySingleMonitorInfos",
+	"gamestopMonitorInfos", "gamestopSingleMonitorInfos", "hottopicMonitorInfos", "hottopicSingleMonitorInfos",
+	"neweggMonitorInfos", "neweggSingleMonitorInfos", "pokemoncenterMonitorInfos", "pokemoncenterSingleMonitorInfos",
+	"shopifyMonitorInfos", "shopifySingleMonitorInfos", "targetMonitorInfos", "targetSingleMonitorInfos",
+	"toppsMonitorInfos", "toppsSingleMonitorInfos", "walmartMonitorInfos", "walmartSingleMonitorInfos",
+}
+
+// restorer restores a Backup's records in one transaction, keeping track of the IDs they were restored with so that
+// references between them can be remapped. Records that can't be restored are reported instead of failing the whole restore.
+type restorer struct {
+	tx       *sqlx.Tx
+	mode     enums.RestoreMode
+	existing entities.Backup
+	report   *entities.RestoreReport
+	// ids maps each kind of record's IDs in the Backup to the IDs they were restored with, or the existing records used instead
+	ids map[entities.RestoreRecordKind]map[string]string
+}
+
+// RestoreBackup restores the Backup's records and returns a report of what it did. Nothing is changed if it returns an error.
+// RestoreReplace removes every existing record first and keeps the Backup's IDs, RestoreMerge restores each record
+// with a new ID and keeps existing records with the same name (or email, for Accounts) instead of restoring them.
+func RestoreBackup(backup entities.Backup, mode enums.RestoreMode) (entities.RestoreReport, error) {
+	report := entities.RestoreReport{
+		Mode:        mode,
+		Restored:    make(map[entities.RestoreRecordKind]int),
+		RemappedIDs: make(map[string]string),
+		Conflicts:   []entities.RestoreRecord{},
+		Skipped:     []entities.RestoreRecord{},
+	}
+	if mode != enums.RestoreMerge && mode != enums.RestoreReplace {
+		return report, fmt.Errorf("unknown restore mode %q", mode)
+	}
+	if backup.Version < 1 || backup.Version > entities.BackupVersion {
+		return report, fmt.Errorf("unsupported backup version %d", backup.Version)
+	}
+
+	database := common.GetDatabase()
+	if database == nil {
+		return report, errors.New("database not initialized")
+	}
+	existing, err := queries.GetBackup()
+	if err != nil {
+		return report, err
+	}
+
+	tx, err := database.Beginx()
+	if err != nil {
+		return report, err
+	}
+	if mode == enums.RestoreReplace {
+		err = clearWorkspace(tx)
+		if err != nil {
+			tx.Rollback()
+			return report, err
+		}
+		existing = entities.Backup{Settings: existing.Settings}
+	}
+
+	r := &restorer{
+		tx:       tx,
+		mode:     mode,
+		existing: existing,
+		report:   &report,
+		ids:      make(map[entities.RestoreRecordKind]map[string]string),
+	}
+	err = r.restoreSettings(backup.Settings)
+	if err != nil {
+		tx.Rollback()
+		return report, err
+	}
+	r.restoreAccounts(backup.Accounts)
+	r.restoreProfiles(backup.Profiles)
+	r.restoreProfileGroups(backup.ProfileGroups)
+	r.restoreProxyGroups(backup.ProxyGroups)
+	r.restoreTaskGroups(backup.TaskGroups, backup.Tasks)
+	r.restoreCheckouts(backup.Checkouts)
+	return report, tx.Commit()
+}
+
+// clearWorkspace removes every record that's part of a Backup, other than the settings
+func clearWorkspace(tx *sqlx.Tx) error {
+	for _, table := range workspaceTables {
+		_, err := tx.Exec(`DELETE FROM ` + table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insert runs the inserts for one record in a savepoint, so that a record that fails part of the way through is rolled
+// back without the rest of the restore
+func (r *restorer) insert(insert func() error) error {
+	_, err := r.tx.Exec(`SAVEPOINT record`)
+	if err != nil {
+		return err
+	}
+	err = insert()
+	if err != nil {
+		r.tx.Exec(`ROLLBACK TO record`)
+	}
+	r.tx.Exec(`RELEASE record`)
+	return err
+}
+
+// addProfilesToGroup adds the ProfileGroup to each of the Profiles that aren't in it yet
+func (r *restorer) addProfilesToGroup(groupID string, profileIDs []string) error {
+	for _, profileID := range profileIDs {
+		var profileGroupIDsJoined string
+		err := r.tx.Get(&profileGroupIDsJoined, `SELECT profileGroupIDsJoined FROM profiles WHERE ID = ?`, profileID)
+		if err != nil {
+			return err
+		}
+		profileGroupIDs := []string{}
+		if profileGroupIDsJoined != "" {
+			profileGroupIDs = strings.Split(profileGroupIDsJoined, ",")
+		}
+		if common.InSlice(profileGroupIDs, groupID) {
+			continue
+		}
+		profileGroupIDs = append(profileGroupIDs, groupID)
+		_, err = r.tx.Exec(`UPDATE profiles SET profileGroupIDsJoined = ? WHERE ID = ?`, strings.Join(profileGroupIDs, ","), profileID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newID returns the ID to restore a record with, or false if another record in the Backup was already restored with its ID
+func (r *restorer) newID(kind entities.RestoreRecordKind, ID string) (string, bool) {
+	if r.ids[kind] == nil {
+		r.ids[kind] = make(map[string]string)
+	}
+	if _, ok := r.ids[kind][ID]; ok && ID != "" {
+		return "", false
+	}
+
+	newID := ID
+	if r.mode == enums.RestoreMerge || ID == "" {
+		newID = uuid.New().String()
+	}
+	if ID != "" {
+		r.ids[kind][ID] = newID
+		if newID != ID {
+			r.report.RemappedIDs[ID] = newID
+		}
+	}
+	return newID, true
+}
+
+// useExisting records that references to the record in the Backup should use the existing record instead
+func (r *restorer) useExisting(kind entities.RestoreRecordKind, ID string, name string, existingID string) {
+	if r.ids[kind] == nil {
+		r.ids[kind] = make(map[string]string)
+	}
+	if ID != "" {
+		r.ids[kind][ID] = existingID
+	}
+	r.conflict(kind, ID, name, existingID, "a matching "+kind+" already exists, it was kept instead")
+}
+
+// resolve returns the ID that the record in the Backup was restored with, or false if it wasn't restored
+func (r *restorer) resolve(kind entities.RestoreRecordKind, ID string) (string, bool) {
+	newID, ok := r.ids[kind][ID]
+	return newID, ok
+}
+
+// forget removes the record's ID after it failed to restore, so that nothing references it
+func (r *restorer) forget(kind entities.RestoreRecordKind, ID string) {
+	delete(r.ids[kind], ID)
+	delete(r.report.RemappedIDs, ID)
+}
+
+func (r *restorer) restored(kind entities.RestoreRecordKind) {
+	r.report.Restored[kind]++
+}
+
+func (r *restorer) conflict(kind entities.RestoreRecordKind, ID string, name string, newID string, reason string) {
+	r.report.Conflicts = append(r.report.Conflicts, entities.RestoreRecord{Kind: kind, ID: ID, Name: name, NewID: newID, Reason: reason})
+}
+
+func (r *restorer) skip(kind entities.RestoreRecordKind, ID string, name string, reason string) {
+	r.report.Skipped = append(r.report.Skipped, entities.RestoreRecord{Kind: kind, ID: ID, Name: name, Reason: reason})
+}
+
+// skipDuplicate reports a record whose ID was already used by another record in the Backup
+func (r *restorer) skipDuplicate(kind entities.RestoreRecordKind, ID string, name string) {
+	r.skip(kind, ID, name, "another "+kind+" in the backup has the same ID")
+}
+
+// restoreSettings replaces the Settings in RestoreReplace, RestoreMerge keeps the current ones
+func (r *restorer) restoreSettings(settings entities.Settings) error {
+	if r.mode == enums.RestoreMerge {
+		r.conflict(entities.SettingsRecord, "", "", "", "merging keeps the current settings")
+		return nil
+	}
+	settings.Accounts = nil
+	err := updateSettings(r.tx, settings)
+	if err != nil {
+		return err
+	}
+	r.restored(entities.SettingsRecord)
+	return nil
+}
+
+func (r *restorer) restoreAccounts(accounts []entities.Account) {
+	for _, account := range accounts {
+		if existingID, ok := r.existingAccount(account); ok {
+			r.useExisting(entities.AccountRecord, account.ID, account.Email, existingID)
+			continue
+		}
+		ID, ok := r.newID(entities.AccountRecord, account.ID)
+		if !ok {
+			r.skipDuplicate(entities.AccountRecord, account.ID, account.Email)
+			continue
+		}
+
+		oldID := account.ID
+		account.ID = ID
+		err := r.insert(func() error { return addAccount(r.tx, account) })
+		if err != nil {
+			r.forget(entities.AccountRecord, oldID)
+			r.skip(entities.AccountRecord, oldID, account.Email, err.Error())
+			continue
+		}
+		r.restored(entities.AccountRecord)
+	}
+}
+
+func (r *restorer) existingAccount(account entities.Account) (string, bool) {
+	for _, existingAccount := range r.existing.Accounts {
+		if existingAccount.Retailer == account.Retailer && strings.EqualFold(existingAccount.Email, account.Email) {
+			return existingAccount.ID, true
+		}
+	}
+	return "", false
+}
+
+// restoreProfiles restores the Profiles without their ProfileGroups, which add themselves to their Profiles when they're restored
+func (r *restorer) restoreProfiles(profiles []entities.Profile) {
+	for _, profile := range profiles {
+		if existingID, ok := r.existingProfile(profile.Name); ok {
+			r.useExisting(entities.ProfileRecord, profile.ID, profile.Name, existingID)
+			continue
+		}
+		ID, ok := r.newID(entities.ProfileRecord, profile.ID)
+		if !ok {
+			r.skipDuplicate(entities.ProfileRecord, profile.ID, profile.Name)
+			continue
+		}
+
+		oldID := profile.ID
+		if ID != oldID {
+			profile.ShippingAddress.ID = uuid.New().String()
+			profile.BillingAddress.ID = uuid.New().String()
+			profile.CreditCard.ID = uuid.New().String()
+		}
+		profile.ID = ID
+		profile.ShippingAddress.ProfileID = ID
+		profile.BillingAddress.ProfileID = ID
+		profile.CreditCard.ProfileID = ID
+		profile.ProfileGroupIDs = []string{}
+		profile.ProfileGroupIDsJoined = ""
+		err := r.insert(func() error { return createProfile(r.tx, profile) })
+		if err != nil {
+			r.forget(entities.ProfileRecord, oldID)
+			r.skip(entities.ProfileRecord, oldID, profile.Name, err.Error())
+			continue
+		}
+		r.restored(entities.ProfileRecord)
+	}
+}
+
+func (r *restorer) existingProfile(name string) (string, bool) {
+	for _, existingProfile := range r.existing.Profiles {
+		if existingProfile.Name == name {
+			return existingProfile.ID, true
+		}
+	}
+	return "", false
+}
+
+// restoreProfileGroups restores the ProfileGroups with the Profiles that were restored. A restored Profile that belonged
+// to a ProfileGroup that already exists is added to the existing one.
+func (r *restorer) restoreProfileGroups(profileGroups []entities.ProfileGroup) {
+	for _, profileGroup := range profileGroups {
+		profileIDs := []string{}
+		for _, profileID := range profileGroup.ProfileIDs {
+			if newID, ok := r.resolve(entities.ProfileRecord, profileID); ok {
+				profileIDs = append(profileIDs, newID)
+			}
+		}
+
+		if existingGroup, ok := r.existingProfileGroup(profileGroup.Name); ok {
+			r.useExisting(entities.ProfileGroupRecord, profileGroup.GroupID, profileGroup.Name, existingGroup.GroupID)
+			existingGroup.AddProfileIDsToGroup(profileIDs)
+			err := r.insert(func() error {
+				_, err := r.tx.Exec(`UPDATE profileGroups SET profileIDsJoined = ? WHERE groupID = ?`, strings.Join(existingGroup.ProfileIDs, ","), existingGroup.GroupID)
+				if err != nil {
+					return err
+				}
+				return r.addProfilesToGroup(existingGroup.GroupID, profileIDs)
+			})
+			if err != nil {
+				r.skip(entities.ProfileGroupRecord, profileGroup.GroupID, profileGroup.Name, err.Error())
+			}
+			continue
+		}
+		ID, ok := r.newID(entities.ProfileGroupRecord, profileGroup.GroupID)
+		if !ok {
+			r.skipDuplicate(entities.ProfileGroupRecord, profileGroup.GroupID, profileGroup.Name)
+			continue
+		}
+
+		oldID := profileGroup.GroupID
+		profileGroup.GroupID = ID
+		profileGroup.ProfileIDs = profileIDs
+		err := r.insert(func() error {
+			err := createProfileGroup(r.tx, profileGroup)
+			if err != nil {
+				return err
+			}
+			return r.addProfilesToGroup(profileGroup.GroupID, profileIDs)
+		})
+		if err != nil {
+			r.forget(entities.ProfileGroupRecord, oldID)
+			r.skip(entities.ProfileGroupRecord, oldID, profileGroup.Name, err.Error())
+			continue
+		}
+		r.restored(entities.ProfileGroupRecord)
+	}
+}
+
+// existingProfileGroup returns the existing ProfileGroup with the name, with the Profiles it has so far in the restore
+func (r *restorer) existingProfileGroup(name string) (entities.ProfileGroup, bool) {
+	for _, existingGroup := range r.existing.ProfileGroups {
+		if existingGroup.Name == name {
+			var profileIDsJoined string
+			err := r.tx.Get(&profileIDsJoined, `SELECT profileIDsJoined FROM profileGroups WHERE groupID = ?`, existingGroup.GroupID)
+			if err != nil {
+				return existingGroup, true
+			}
+			existingGroup.ProfileIDs = []string{}
+			if profileIDsJoined != "" {
+				existingGroup.ProfileIDs = strings.Split(profileIDsJoined, ",")
+			}
+			return existingGroup, true
+		}
+	}
+	return entities.ProfileGroup{}, false
+}
+
+func (r *restorer) restoreProxyGroups(proxyGroups []entities.ProxyGroup) {
+	for _, proxyGroup := range proxyGroups {
+		if existingID, ok := r.existingProxyGroup(proxyGroup.Name); ok {
+			r.useExisting(entities.ProxyGroupRecord, proxyGroup.GroupID, proxyGroup.Name, existingID)
+			continue
+		}
+		ID, ok := r.newID(entities.ProxyGroupRecord, proxyGroup.GroupID)
+		if !ok {
+			r.skipDuplicate(entities.ProxyGroupRecord, proxyGroup.GroupID, proxyGroup.Name)
+			continue
+		}
+
+		oldID := proxyGroup.GroupID
+		proxyGroup.GroupID = ID
+		proxies := []*entities.Proxy{}
+		for _, proxy := range proxyGroup.Proxies {
+			if proxy == nil {
+				continue
+			}
+			restoredProxy := *proxy
+			if ID != oldID || restoredProxy.ID == "" {
+				restoredProxy.ID = uuid.New().String()
+			}
+			restoredProxy.ProxyGroupID = ID
+			restoredProxy.Count = 0
+			proxies = append(proxies, &restoredProxy)
+		}
+		proxyGroup.Proxies = proxies
+		err := r.insert(func() error { return createProxyGroup(r.tx, proxyGroup) })
+		if err != nil {
+			r.forget(entities.ProxyGroupRecord, oldID)
+			r.skip(entities.ProxyGroupRecord, oldID, proxyGroup.Name, err.Error())
+			continue
+		}
+		r.restored(entities.ProxyGroupRecord)
+	}
+}
+
+func (r *restorer) existingProxyGroup(name string) (string, bool) {
+	for _, existingGroup := range r.existing.ProxyGroups {
+		if existingGroup.Name == name {
+			return existingGroup.GroupID, true
+		}
+	}
+	return "", false
+}
+
+// restoreTaskGroups restores the TaskGroups with their Schedules, then their Tasks. A TaskGroup with the same name as an
+// existing one isn't restored in RestoreMerge, and neither are its Tasks.
+func (r *restorer) restoreTaskGroups(taskGroups []entities.TaskGroup, tasks []entities.Task) {
+	restoredGroups := []entities.TaskGroup{}
+	for _, taskGroup := range taskGroups {
+		if existingID, ok := r.existingTaskGroup(taskGroup.Name); ok {
+			// Its Tasks aren't restored, so the existing TaskGroup is only recorded for the report
+			r.conflict(entities.TaskGroupRecord, taskGroup.GroupID, taskGroup.Name, existingID, "a matching "+entities.TaskGroupRecord+" already exists, it was kept instead")
+			continue
+		}
+		ID, ok := r.newID(entities.TaskGroupRecord, taskGroup.GroupID)
+		if !ok {
+			r.skipDuplicate(entities.TaskGroupRecord, taskGroup.GroupID, taskGroup.Name)
+			continue
+		}
+
+		oldID := taskGroup.GroupID
+		if !hasMonitorInfo(taskGroup) {
+			r.forget(entities.TaskGroupRecord, oldID)
+			r.skip(entities.TaskGroupRecord, oldID, taskGroup.Name, "it's missing its "+taskGroup.MonitorRetailer+" monitor info")
+			continue
+		}
+		taskGroup.GroupID = ID
+		taskGroup.MonitorStatus = enums.MonitorIdle
+		taskGroup.MonitorProxyGroupID = r.resolveProxyGroup(entities.TaskGroupRecord, oldID, taskGroup.Name, taskGroup.MonitorProxyGroupID)
+		taskGroup.TaskIDs = []string{}
+		taskGroup.TaskIDsJoined = ""
+		err := r.insert(func() error { return createTaskGroup(r.tx, taskGroup) })
+		if err != nil {
+			r.forget(entities.TaskGroupRecord, oldID)
+			r.skip(entities.TaskGroupRecord, oldID, taskGroup.Name, err.Error())
+			continue
+		}
+		r.restored(entities.TaskGroupRecord)
+		restoredGroups = append(restoredGroups, taskGroup)
+
+		for _, schedule := range taskGroup.Schedules {
+			r.restoreSchedule(schedule, ID)
+		}
+	}
+
+	taskIDs := make(map[string][]string)
+	for _, task := range tasks {
+		groupID, ok := r.resolve(entities.TaskGroupRecord, task.TaskGroupID)
+		if !ok {
+			r.skip(entities.TaskRecord, task.ID, "", "its "+entities.TaskGroupRecord+" wasn't restored")
+			continue
+		}
+		profileID := task.TaskProfileID
+		if profileID != "" {
+			profileID, ok = r.resolve(entities.ProfileRecord, profileID)
+			if !ok {
+				r.skip(entities.TaskRecord, task.ID, "", "its "+entities.ProfileRecord+" wasn't restored")
+				continue
+			}
+		}
+		ID, ok := r.newID(entities.TaskRecord, task.ID)
+		if !ok {
+			r.skipDuplicate(entities.TaskRecord, task.ID, "")
+			continue
+		}
+
+		oldID := task.ID
+		if !hasTaskInfo(task) {
+			r.forget(entities.TaskRecord, oldID)
+			r.skip(entities.TaskRecord, oldID, "", "it's missing its "+task.TaskRetailer+" task info")
+			continue
+		}
+		task.ID = ID
+		task.TaskGroupID = groupID
+		task.TaskProfileID = profileID
+		task.TaskProxyGroupID = r.resolveProxyGroup(entities.TaskRecord, oldID, "", task.TaskProxyGroupID)
+		task.TaskAccountID = r.resolveAccount(oldID, task.TaskAccountID)
+		task.TaskStatus = enums.TaskIdle
+		err := r.insert(func() error { return createTask(r.tx, task) })
+		if err != nil {
+			r.forget(entities.TaskRecord, oldID)
+			r.skip(entities.TaskRecord, oldID, "", err.Error())
+			continue
+		}
+		r.restored(entities.TaskRecord)
+		taskIDs[groupID] = append(taskIDs[groupID], ID)
+	}
+
+	// The TaskGroups are restored before their Tasks, so their Tasks are added to them afterwards
+	for _, taskGroup := range restoredGroups {
+		if len(taskIDs[taskGroup.GroupID]) == 0 {
+			continue
+		}
+		_, err := r.tx.Exec(`UPDATE taskGroups SET taskIDsJoined = ? WHERE groupID = ?`, strings.Join(taskIDs[taskGroup.GroupID], ","), taskGroup.GroupID)
+		if err != nil {
+			r.skip(entities.TaskGroupRecord, taskGroup.GroupID, taskGroup.Name, "restoring its tasks failed: "+err.Error())
+		}
+	}
+}
+
+func (r *restorer) existingTaskGroup(name string) (string, bool) {
+	for _, existingGroup := range r.existing.TaskGroups {
+		if existingGroup.Name == name {
+			return existingGroup.GroupID, true
+		}
+	}
+	return "", false
+}
+
+// hasMonitorInfo returns true if the TaskGroup has the info that CreateMonitorInfos expects for its retailer,
+// which a Backup that was edited by hand may be missing
+func hasMonitorInfo(taskGroup entities.TaskGroup) bool {
+	switch taskGroup.MonitorRetailer {
+	case enums.Amazon:
+		return taskGroup.AmazonMonitorInfo != nil
+	case enums.BestBuy:
+		return taskGroup.BestbuyMonitorInfo != nil
+	case enums.BoxLunch:
+		return taskGroup.BoxlunchMonitorInfo != nil
+	case enums.Disney:
+		return taskGroup.DisneyMonitorInfo != nil
+	case enums.GameStop:
+		return taskGroup.GamestopMonitorInfo != nil
+	case enums.HotTopic:
+		return taskGroup.HottopicMonitorInfo != nil
+	case enums.Newegg:
+		return taskGroup.NeweggMonitorInfo != nil
+	case enums.PokemonCenter:
+		return taskGroup.PokemonCenterMonitorInfo != nil
+	case enums.Shopify:
+		return taskGroup.ShopifyMonitorInfo != nil
+	case enums.Target:
+		return taskGroup.TargetMonitorInfo != nil
+	case enums.Topps:
+		return taskGroup.ToppsMonitorInfo != nil
+	case enums.Walmart:
+		return taskGroup.WalmartMonitorInfo != nil
+	}
+	return true
+}
+
+// hasTaskInfo returns true if the Task has the info that CreateTaskInfos expects for its retailer
+func hasTaskInfo(task entities.Task) bool {
+	switch task.TaskRetailer {
+	case enums.Amazon:
+		return task.AmazonTaskInfo != nil
+	case enums.BestBuy:
+		return task.BestbuyTaskInfo != nil
+	case enums.BoxLunch:
+		return task.BoxlunchTaskInfo != nil
+	case enums.Disney:
+		return task.DisneyTaskInfo != nil
+	case enums.GameStop:
+		return task.GamestopTaskInfo != nil
+	case enums.HotTopic:
+		return task.HottopicTaskInfo != nil
+	case enums.Newegg:
+		return task.NeweggTaskInfo != nil
+	case enums.PokemonCenter:
+		return task.PokemonCenterTaskInfo != nil
+	case enums.Shopify:
+		return task.ShopifyTaskInfo != nil && (task.ShopifyTaskInfo.ShopifyRetailer != enums.HotWheels || task.ShopifyTaskInfo.HotWheelsTaskInfo != nil)
+	case enums.Target:
+		return task.TargetTaskInfo != nil
+	case enums.Topps:
+		return task.ToppsTaskInfo != nil
+	case enums.Walmart:
+		return task.WalmartTaskInfo != nil
+	}
+	return true
+}
+
+// resolveProxyGroup returns the ID of the ProxyGroup that a record in the Backup uses, or no ProxyGroup if it wasn't restored
+func (r *restorer) resolveProxyGroup(kind entities.RestoreRecordKind, ID string, name string, proxyGroupID string) string {
+	if proxyGroupID == "" {
+		return ""
+	}
+	newID, ok := r.resolve(entities.ProxyGroupRecord, proxyGroupID)
+	if !ok {
+		r.conflict(kind, ID, name, "", "its "+entities.ProxyGroupRecord+" wasn't restored, it was restored without one")
+		return ""
+	}
+	return newID
+}
+
+// resolveAccount returns the ID of the Account that a Task in the Backup uses, or any of the retailer's Accounts if it
+// wasn't restored
+func (r *restorer) resolveAccount(taskID string, accountID string) string {
+	if accountID == "" || accountID == enums.AnyAccount {
+		return accountID
+	}
+	newID, ok := r.resolve(entities.AccountRecord, accountID)
+	if !ok {
+		r.conflict(entities.TaskRecord, taskID, "", "", "its "+entities.AccountRecord+" wasn't restored, it was restored to use any free one")
+		return enums.AnyAccount
+	}
+	return newID
+}
+
+// restoreSchedule restores the TaskGroupSchedule for the restored TaskGroup. Its next start and stop times are worked out
+// again by the ScheduleStore once it's reloaded, since the ones in the Backup may have passed.
+func (r *restorer) restoreSchedule(schedule entities.TaskGroupSchedule, groupID string) {
+	ID, ok := r.newID(entities.TaskGroupScheduleRecord, schedule.ID)
+	if !ok {
+		r.skipDuplicate(entities.TaskGroupScheduleRecord, schedule.ID, "")
+		return
+	}
+
+	oldID := schedule.ID
+	schedule.ID = ID
+	schedule.TaskGroupID = groupID
+	schedule.NextStartTime = 0
+	schedule.NextStopTime = 0
+	err := r.insert(func() error { return createTaskGroupSchedule(r.tx, schedule) })
+	if err != nil {
+		r.forget(entities.TaskGroupScheduleRecord, oldID)
+		r.skip(entities.TaskGroupScheduleRecord, oldID, "", err.Error())
+		return
+	}
+	r.restored(entities.TaskGroupScheduleRecord)
+}
+
+// restoreCheckouts restores the Checkouts that aren't exactly the same as an existing one
+func (r *restorer) restoreCheckouts(checkouts []entities.Checkout) {
+	for _, checkout := range checkouts {
+		if r.existingCheckout(checkout) {
+			r.conflict(entities.CheckoutRecord, "", checkout.ItemName, "", "the same "+entities.CheckoutRecord+" already exists, it was kept instead")
+			continue
+		}
+		err := r.insert(func() error { return createCheckout(r.tx, checkout) })
+		if err != nil {
+			r.skip(entities.CheckoutRecord, "", checkout.ItemName, err.Error())
+			continue
+		}
+		r.existing.Checkouts = append(r.existing.Checkouts, checkout)
+		r.restored(entities.CheckoutRecord)
+	}
+}
+
+func (r *restorer) existingCheckout(checkout entities.Checkout) bool {
+	for _, existingCheckout := range r.existing.Checkouts {
+		if existingCheckout == checkout {
+			return true
+		}
+	}
+	return false
+}
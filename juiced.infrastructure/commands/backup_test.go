@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+)
+
+// createTestWorkspace adds a Profile in a ProfileGroup, an Account, a ProxyGroup and a TaskGroup with a Task to the database
+func createTestWorkspace(t *testing.T) {
+	profile := testProfile()
+	err := CreateProfile(profile)
+	if err == nil {
+		err = CreateProfileGroup(entities.ProfileGroup{GroupID: "profileGroup", Name: "Profile Group", ProfileIDs: []string{profile.ID}})
+	}
+	if err == nil {
+		err = AddAccount(entities.Account{ID: "account", Retailer: enums.Target, Email: "account@example.com", Password: "hunter2"})
+	}
+	if err == nil {
+		err = CreateProxyGroup(entities.ProxyGroup{GroupID: "proxyGroup", Name: "Proxy Group", Proxies: []*entities.Proxy{{ID: "proxy", Host: "127.0.0.1", Port: "8080"}}})
+	}
+	if err == nil {
+		err = CreateTaskGroup(entities.TaskGroup{GroupID: "taskGroup", Name: "Task Group", MonitorRetailer: enums.Target, MonitorProxyGroupID: "proxyGroup", TaskIDs: []string{"task"}, TargetMonitorInfo: &entities.TargetMonitorInfo{}})
+	}
+	if err == nil {
+		err = CreateTask(entities.Task{ID: "task", TaskGroupID: "taskGroup", TaskProfileID: profile.ID, TaskAccountID: "account", TaskRetailer: enums.Target, TaskQty: 1, TargetTaskInfo: &entities.TargetTaskInfo{}})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreBackupReplace(t *testing.T) {
+	openTestDatabase(t)
+	createTestWorkspace(t)
+	backup, err := queries.GetBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extraProfile := testProfile()
+	extraProfile.ID, extraProfile.Name = "extra", "Extra"
+	extraProfile.ShippingAddress.ID, extraProfile.BillingAddress.ID, extraProfile.CreditCard.ID = "extraShipping", "extraBilling", "extraCard"
+	if err = CreateProfile(extraProfile); err != nil {
+		t.Fatal(err)
+	}
+	err = CreateTaskGroup(entities.TaskGroup{GroupID: "extraGroup", Name: "Extra", MonitorRetailer: enums.Target, TargetMonitorInfo: &entities.TargetMonitorInfo{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := RestoreBackup(backup, enums.RestoreReplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Skipped) != 0 || len(report.RemappedIDs) != 0 {
+		t.Errorf("expected every record to be restored with its ID, got %+v", report)
+	}
+
+	profiles, err := queries.GetAllProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || profiles[0].ID != "profile" || profiles[0].CreditCard.CardNumber != testProfile().CreditCard.CardNumber {
+		t.Errorf("expected only the backup's Profile, got %+v", profiles)
+	} else if len(profiles[0].ProfileGroupIDs) != 1 || profiles[0].ProfileGroupIDs[0] != "profileGroup" {
+		t.Errorf("expected the Profile to be in its ProfileGroup, got %v", profiles[0].ProfileGroupIDs)
+	}
+	taskGroups, err := queries.GetAllTaskGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taskGroups) != 1 || taskGroups[0].GroupID != "taskGroup" || len(taskGroups[0].TaskIDs) != 1 || taskGroups[0].TaskIDs[0] != "task" {
+		t.Errorf("expected only the backup's TaskGroup with its Task, got %+v", taskGroups)
+	}
+	task, err := queries.GetTask("task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.TaskProfileID != "profile" || task.TaskAccountID != "account" || task.TaskProxyGroupID != "" {
+		t.Errorf("expected the Task to keep its references, got %+v", task)
+	}
+}
+
+func TestRestoreBackupMerge(t *testing.T) {
+	openTestDatabase(t)
+	createTestWorkspace(t)
+	backup, err := queries.GetBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The backup's new Profile is in a ProfileGroup with the same name as the existing one
+	newProfile := testProfile()
+	newProfile.ID, newProfile.Name = "new", "New"
+	backup.Profiles = append(backup.Profiles, newProfile)
+	backup.ProfileGroups[0].ProfileIDs = append(backup.ProfileGroups[0].ProfileIDs, newProfile.ID)
+
+	report, err := RestoreBackup(backup, enums.RestoreMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The existing TaskGroup is kept, so the Task in the backup's one is skipped
+	if report.Restored[entities.ProfileRecord] != 1 || report.Restored[entities.TaskGroupRecord] != 0 || len(report.Skipped) != 1 {
+		t.Errorf("expected only the new Profile to be restored, got %+v", report)
+	}
+	newID := report.RemappedIDs[newProfile.ID]
+	if newID == "" {
+		t.Fatalf("expected the new Profile to be restored with a new ID, got %+v", report.RemappedIDs)
+	}
+
+	profiles, err := queries.GetAllProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 {
+		t.Errorf("expected the existing Profile to be kept instead of restored again, got %+v", profiles)
+	}
+	profileGroup, err := queries.GetProfileGroup("profileGroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profileGroup.ProfileIDsJoined != "profile,"+newID {
+		t.Errorf("expected the new Profile to be added to the existing ProfileGroup, got %q", profileGroup.ProfileIDsJoined)
+	}
+	restoredProfile, err := queries.GetProfile(newID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restoredProfile.ProfileGroupIDsJoined != "profileGroup" {
+		t.Errorf("expected the new Profile to be in the existing ProfileGroup, got %q", restoredProfile.ProfileGroupIDsJoined)
+	}
+	taskGroups, err := queries.GetAllTaskGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(taskGroups) != 1 {
+		t.Errorf("expected the existing TaskGroup to be kept instead of restored again, got %+v", taskGroups)
+	}
+}
+
+func TestRestoreBackupRollsBackOnError(t *testing.T) {
+	openTestDatabase(t)
+	createTestWorkspace(t)
+	backup, err := queries.GetBackup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The settings can't be encrypted, which fails the restore after the workspace was cleared
+	enums.UserKey = "invalid"
+	_, err = RestoreBackup(backup, enums.RestoreReplace)
+	enums.UserKey = testAccountKey
+	if err == nil {
+		t.Fatal("expected the restore to fail")
+	}
+
+	profiles, err := queries.GetAllProfiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskGroups, err := queries.GetAllTaskGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 1 || len(taskGroups) != 1 {
+		t.Errorf("expected the workspace to be left as it was, got %d Profiles and %d TaskGroups", len(profiles), len(taskGroups))
+	}
+}
@@ -16,12 +16,17 @@ func CreateCheckout(checkout entities.Checkout) error {
 		return errors.New("database not initialized")
 	}
 
-	statement, err := database.Preparex(`INSERT INTO checkouts (itemName, imageURL, sku, price, quantity, retailer, profileName, msToCheckout, time) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	return createCheckout(database, checkout)
+}
+
+// createCheckout adds the Checkout object with the given database or transaction
+func createCheckout(database preparer, checkout entities.Checkout) error {
+	statement, err := database.Preparex(`INSERT INTO checkouts (itemName, imageURL, sku, price, quantity, retailer, profileName, msToCheckout, time, status) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
 
-	_, err = statement.Exec(checkout.ItemName, checkout.ImageURL, checkout.SKU, checkout.Price, checkout.Quantity, checkout.Retailer, checkout.ProfileName, checkout.MsToCheckout, checkout.Time)
+	_, err = statement.Exec(checkout.ItemName, checkout.ImageURL, checkout.SKU, checkout.Price, checkout.Quantity, checkout.Retailer, checkout.ProfileName, checkout.MsToCheckout, checkout.Time, checkout.Status)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,237 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// encryptedColumns lists the columns of each table that are stored encrypted with the user's key
+var encryptedColumns = []struct {
+	table   string
+	columns []string
+}{
+	{"profiles", []string{"email", "phoneNumber"}},
+	{"shippingAddresses", []string{"firstName", "lastName", "address1", "address2", "city", "zipCode", "stateCode", "countryCode"}},
+	{"billingAddresses", []string{"firstName", "lastName", "address1", "address2", "city", "zipCode", "stateCode", "countryCode"}},
+	{"cards", []string{"cardHolderName", "cardNumber", "expMonth", "expYear", "cvv", "cardType"}},
+	{"amazonTaskInfos", []string{"email", "password"}},
+	{"bestbuyTaskInfos", []string{"email", "password"}},
+	{"disneyTaskInfos", []string{"email", "password"}},
+	{"gamestopTaskInfos", []string{"email", "password"}},
+	{"hotwheelsTaskInfos", []string{"email", "password"}},
+	{"pokemoncenterTaskInfos", []string{"email", "password"}},
+	{"targetTaskInfos", []string{"email", "password"}},
+	{"toppsTaskInfos", []string{"email", "password"}},
+	{"accounts", []string{"email", "password"}},
+	{"settings", []string{"twoCaptchaAPIKey", "antiCaptchaAPIKey", "capMonsterAPIKey", "aycdAccessToken", "aycdAPIKey", "remoteAccessToken"}},
+}
+
+// LoadUserKey returns the key that the database is encrypted with. That's the accountKey from the auth server, unless
+// the database was rekeyed, in which case it's the key stored encrypted with accountKey.
+func LoadUserKey(accountKey string) (string, error) {
+	database := common.GetDatabase()
+	if database == nil {
+		return "", errors.New("database not initialized")
+	}
+
+	wrappedKeys := []string{}
+	err := database.Select(&wrappedKeys, "SELECT wrappedKey FROM userKey")
+	if err != nil || len(wrappedKeys) == 0 {
+		return accountKey, err
+	}
+	userKey, _, err := common.DecryptField(wrappedKeys[0], accountKey)
+	return userKey, err
+}
+
+// RekeyDatabase re-encrypts every encrypted value in the database with newKey in a single transaction, and then makes newKey
+// the user's key. newKey is stored encrypted with enums.AccountKey, so that LoadUserKey returns it from then on.
+// If any value can't be decrypted with the current key, nothing is changed and the error is returned.
+func RekeyDatabase(newKey string) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	tx, err := database.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, encrypted := range encryptedColumns {
+		err = rekeyTable(tx, encrypted.table, encrypted.columns, enums.UserKey, newKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	wrappedKey, err := common.EncryptField(newKey, enums.AccountKey)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM userKey")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO userKey (wrappedKey) VALUES (?)", wrappedKey)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	enums.UserKey = newKey
+	return nil
+}
+
+// rekeyTable decrypts the columns of every row in the table with oldKey and writes them back encrypted with newKey
+func rekeyTable(tx *sqlx.Tx, table string, columns []string, oldKey string, newKey string) error {
+	storedRows, err := readColumns(tx, table, columns)
+	if err != nil {
+		return err
+	}
+
+	setColumns := []string{}
+	for _, column := range columns {
+		setColumns = append(setColumns, column+" = ?")
+	}
+	for _, values := range storedRows {
+		args := []interface{}{}
+		for i, column := range columns {
+			plaintext, _, err := common.DecryptField(values[i+1], oldKey)
+			if err != nil {
+				return errors.New("decrypting " + column + " of " + table + " row " + values[0] + " failed: " + err.Error())
+			}
+			encryptedValue, err := common.EncryptField(plaintext, newKey)
+			if err != nil {
+				return err
+			}
+			args = append(args, encryptedValue)
+		}
+		_, err = tx.Exec("UPDATE "+table+" SET "+strings.Join(setColumns, ", ")+" WHERE rowid = ?", append(args, values[0])...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncryptPendingColumns encrypts the values of every column that a migration recorded as pending encryption, each column in its
+// own transaction. Migrations run before the user's key is known, so this runs once it is. A value that doesn't decrypt only
+// keeps its own column pending, the error lists every one of them.
+func EncryptPendingColumns() error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	pendingColumns := []struct {
+		TableName       string `db:"tableName"`
+		ColumnName      string `db:"columnName"`
+		LegacyEncrypted bool   `db:"legacyEncrypted"`
+	}{}
+	err := database.Select(&pendingColumns, "SELECT tableName, columnName, legacyEncrypted FROM pendingEncryption")
+	if err != nil {
+		return err
+	}
+
+	failures := []string{}
+	for _, pendingColumn := range pendingColumns {
+		undecryptable, err := encryptPendingColumn(database, pendingColumn.TableName, pendingColumn.ColumnName, pendingColumn.LegacyEncrypted, enums.UserKey)
+		if err != nil {
+			failures = append(failures, "encrypting "+pendingColumn.ColumnName+" of "+pendingColumn.TableName+" failed: "+err.Error())
+			continue
+		}
+		failures = append(failures, undecryptable...)
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// encryptPendingColumn encrypts every value in the column that isn't already an envelope, and removes the column from
+// pendingEncryption once they all are. If legacyEncrypted is set, the values are decrypted with Aes256Decrypt first, and one
+// that doesn't decrypt is left as it is and returned rather than encrypted as a plaintext value.
+func encryptPendingColumn(database *sqlx.DB, table string, column string, legacyEncrypted bool, key string) ([]string, error) {
+	tx, err := database.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	storedRows, err := readColumns(tx, table, []string{column})
+	if err != nil {
+		return nil, err
+	}
+
+	undecryptable := []string{}
+	for _, values := range storedRows {
+		plaintext := values[1]
+		if plaintext == "" || common.IsEnvelope(plaintext) {
+			continue
+		}
+		if legacyEncrypted {
+			decryptedValue, err := common.Aes256Decrypt(plaintext, key)
+			if err != nil {
+				undecryptable = append(undecryptable, "decrypting "+column+" of "+table+" row "+values[0]+" failed: "+err.Error())
+				continue
+			}
+			plaintext = decryptedValue
+		}
+
+		encryptedValue, err := common.EncryptField(plaintext, key)
+		if err != nil {
+			return nil, err
+		}
+		_, err = tx.Exec("UPDATE "+table+" SET "+column+" = ? WHERE rowid = ?", encryptedValue, values[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(undecryptable) == 0 {
+		_, err = tx.Exec("DELETE FROM pendingEncryption WHERE tableName = ? AND columnName = ?", table, column)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return undecryptable, tx.Commit()
+}
+
+// readColumns returns the rowid and the columns (NULLs as empty strings) of every row in the table. Every row
+// is read before any are written, since the table can't be written to while it's being read.
+func readColumns(tx *sqlx.Tx, table string, columns []string) ([][]string, error) {
+	storedRows := [][]string{}
+	selectColumns := []string{"rowid"}
+	for _, column := range columns {
+		selectColumns = append(selectColumns, "IFNULL("+column+", '')")
+	}
+
+	rows, err := tx.Queryx("SELECT " + strings.Join(selectColumns, ", ") + " FROM " + table)
+	if err != nil {
+		return storedRows, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		values := make([]string, len(selectColumns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return storedRows, err
+		}
+		storedRows = append(storedRows, values)
+	}
+	return storedRows, rows.Err()
+}
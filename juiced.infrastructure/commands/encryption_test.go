@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+)
+
+const testAccountKey = "0123456789abcdef0123456789abcdef"
+
+// openTestDatabase opens a migrated database in a temporary directory as the database singleton, with the user's key
+// set to testAccountKey, and returns its filename
+func openTestDatabase(t *testing.T) string {
+	filename := filepath.Join(t.TempDir(), "juiced.db")
+	err := common.OpenDatabase(filename, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { common.GetDatabase().Close() })
+	enums.AccountKey, enums.UserKey = testAccountKey, testAccountKey
+	return filename
+}
+
+func testProfile() entities.Profile {
+	return entities.Profile{
+		ID:              "profile",
+		Name:            "Profile",
+		Email:           "profile@example.com",
+		PhoneNumber:     "5555555555",
+		ShippingAddress: entities.Address{ID: "shipping", ProfileID: "profile", FirstName: "First", Address1: "1 Main St", City: "City", ZipCode: "12345", StateCode: "NY", CountryCode: "US"},
+		BillingAddress:  entities.Address{ID: "billing", ProfileID: "profile", FirstName: "First", Address1: "1 Main St", City: "City", ZipCode: "12345", StateCode: "NY", CountryCode: "US"},
+		CreditCard:      entities.Card{ID: "card", ProfileID: "profile", CardholderName: "First Last", CardNumber: "4111111111111111", ExpMonth: "01", ExpYear: "30", CVV: "123", CardType: "Visa"},
+	}
+}
+
+func TestRekeyDatabaseSurvivesRestart(t *testing.T) {
+	filename := openTestDatabase(t)
+	profile := testProfile()
+	account := entities.Account{ID: "account", Retailer: enums.Target, Email: "account@example.com", Password: "hunter2"}
+	if err := CreateProfile(profile); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddAccount(account); err != nil {
+		t.Fatal(err)
+	}
+
+	newKey, err := common.NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = RekeyDatabase(newKey); err != nil {
+		t.Fatal(err)
+	}
+	if enums.UserKey != newKey {
+		t.Fatal("expected the new key to be the user's key")
+	}
+
+	// Restarting gets the same key from the auth server, the rekeyed one is read from the database
+	common.GetDatabase().Close()
+	enums.UserKey = ""
+	if err = common.OpenDatabase(filename, ""); err != nil {
+		t.Fatal(err)
+	}
+	enums.UserKey, err = LoadUserKey(testAccountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enums.UserKey != newKey {
+		t.Fatalf("expected the rekeyed key to be loaded, got %q", enums.UserKey)
+	}
+
+	storedProfile, err := queries.GetProfile(profile.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedProfile.Email != profile.Email || !reflect.DeepEqual(storedProfile.CreditCard, profile.CreditCard) || storedProfile.ShippingAddress.Address1 != profile.ShippingAddress.Address1 {
+		t.Errorf("expected the profile to read back after restarting, got %+v", storedProfile)
+	}
+	storedAccount, err := queries.GetAccount(account.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if storedAccount.Email != account.Email || storedAccount.Password != account.Password {
+		t.Errorf("expected the account to read back after restarting, got %+v", storedAccount)
+	}
+
+	// The values aren't readable with the key from the auth server anymore
+	var storedCVV string
+	if err = common.GetDatabase().Get(&storedCVV, "SELECT cvv FROM cards"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = common.DecryptField(storedCVV, testAccountKey); err == nil {
+		t.Error("expected the card to be encrypted with the new key")
+	}
+}
+
+func TestEncryptPendingColumnsReportsUndecryptableValues(t *testing.T) {
+	openTestDatabase(t)
+	legacyPassword, err := common.Aes256Encrypt("hunter2", testAccountKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = common.GetDatabase().Exec("INSERT INTO accounts (ID, retailer, email, password) VALUES ('account', 'Target', 'plaintext@example.com', 'not a legacy value'), ('legacy', 'Target', '', ?)", legacyPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = common.GetDatabase().Exec("INSERT INTO pendingEncryption (tableName, columnName, legacyEncrypted) VALUES ('accounts', 'password', 1), ('accounts', 'email', 0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = EncryptPendingColumns(); err == nil {
+		t.Fatal("expected the value that doesn't decrypt to be reported")
+	}
+	var password string
+	if err = common.GetDatabase().Get(&password, "SELECT password FROM accounts WHERE ID = 'account'"); err != nil {
+		t.Fatal(err)
+	}
+	if password != "not a legacy value" {
+		t.Errorf("expected the value to be left as it was, got %q", password)
+	}
+
+	// The values that do decrypt, and the other pending columns, are still encrypted
+	var storedEmail, storedPassword string
+	if err = common.GetDatabase().Get(&storedEmail, "SELECT email FROM accounts WHERE ID = 'account'"); err != nil {
+		t.Fatal(err)
+	}
+	if !common.IsEnvelope(storedEmail) {
+		t.Errorf("expected the plaintext email to be encrypted, got %q", storedEmail)
+	}
+	if err = common.GetDatabase().Get(&storedPassword, "SELECT password FROM accounts WHERE ID = 'legacy'"); err != nil {
+		t.Fatal(err)
+	}
+	if plaintext, _, err := common.DecryptField(storedPassword, testAccountKey); !common.IsEnvelope(storedPassword) || err != nil || plaintext != "hunter2" {
+		t.Errorf("expected the legacy password to be encrypted, got %q", storedPassword)
+	}
+	var pendingColumns []string
+	if err = common.GetDatabase().Select(&pendingColumns, "SELECT DISTINCT tableName || '.' || columnName FROM pendingEncryption"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pendingColumns, []string{"accounts.password"}) {
+		t.Errorf("expected only the column with the undecryptable value to stay pending, got %v", pendingColumns)
+	}
+}
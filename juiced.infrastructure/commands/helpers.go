@@ -7,15 +7,26 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"github.com/google/uuid"
-	_ "github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// preparer is a database or a transaction, so that the same inserts can run on their own or as part of a transaction
+type preparer interface {
+	Preparex(query string) (*sqlx.Stmt, error)
+}
+
 func CreateMonitorInfos(taskGroup entities.TaskGroup) error {
 	database := common.GetDatabase()
 	if database == nil {
 		return errors.New("database not initialized")
 	}
+
+	return createMonitorInfos(database, taskGroup)
+}
+
+// createMonitorInfos adds the TaskGroup's retailer-specific info with the given database or transaction
+func createMonitorInfos(database preparer, taskGroup entities.TaskGroup) error {
 	monitorID := uuid.New().String()
 	switch taskGroup.MonitorRetailer {
 	case enums.Amazon:
@@ -222,13 +233,13 @@ func CreateMonitorInfos(taskGroup entities.TaskGroup) error {
 			return err
 		}
 		for _, monitor := range taskGroup.ShopifyMonitorInfo.Monitors {
-			statement, err := database.Preparex(`INSERT INTO shopifySingleMonitorInfos (monitorID, taskGroupID, variantID, maxPrice) VALUES (?, ?, ?, ?)`)
+			statement, err := database.Preparex(`INSERT INTO shopifySingleMonitorInfos (monitorID, taskGroupID, variantID, keywords, size, maxPrice, monitorType) VALUES (?, ?, ?, ?, ?, ?, ?)`)
 			if err != nil {
 				return err
 			}
 			monitor.MonitorID = monitorID
 			monitor.TaskGroupID = taskGroup.GroupID
-			_, err = statement.Exec(monitor.MonitorID, monitor.TaskGroupID, monitor.VariantID, monitor.MaxPrice)
+			_, err = statement.Exec(monitor.MonitorID, monitor.TaskGroupID, monitor.VariantID, monitor.Keywords, monitor.Size, monitor.MaxPrice, monitor.MonitorType)
 			if err != nil {
 				return err
 			}
@@ -246,13 +257,13 @@ func CreateMonitorInfos(taskGroup entities.TaskGroup) error {
 			return err
 		}
 		for _, monitor := range taskGroup.TargetMonitorInfo.Monitors {
-			statement, err := database.Preparex(`INSERT INTO targetSingleMonitorInfos (monitorID, taskGroupID, tcin, maxPrice, checkoutType) VALUES (?, ?, ?, ?, ?)`)
+			statement, err := database.Preparex(`INSERT INTO targetSingleMonitorInfos (monitorID, taskGroupID, tcin, url, keywords, maxPrice, checkoutType) VALUES (?, ?, ?, ?, ?, ?, ?)`)
 			if err != nil {
 				return err
 			}
 			monitor.MonitorID = monitorID
 			monitor.TaskGroupID = taskGroup.GroupID
-			_, err = statement.Exec(monitor.MonitorID, monitor.TaskGroupID, monitor.TCIN, monitor.MaxPrice, monitor.CheckoutType)
+			_, err = statement.Exec(monitor.MonitorID, monitor.TaskGroupID, monitor.TCIN, monitor.URL, monitor.Keywords, monitor.MaxPrice, monitor.CheckoutType)
 			if err != nil {
 				return err
 			}
@@ -389,6 +400,11 @@ func CreateTaskInfos(task entities.Task) error {
 		return errors.New("database not initialized")
 	}
 
+	return createTaskInfos(database, task)
+}
+
+// createTaskInfos adds the Task's retailer-specific info with the given database or transaction
+func createTaskInfos(database preparer, task entities.Task) error {
 	switch task.TaskRetailer {
 	case enums.Amazon:
 		statement, err := database.Preparex(`INSERT INTO amazonTaskInfos (taskID, taskGroupID, email, password, loginType) VALUES (?, ?, ?, ?, ?)`)
@@ -396,12 +412,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.AmazonTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.AmazonTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.AmazonTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.AmazonTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -417,12 +433,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.BestbuyTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.BestbuyTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.BestbuyTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.BestbuyTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -448,12 +464,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.DisneyTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.DisneyTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.DisneyTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.DisneyTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -469,12 +485,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.GamestopTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.GamestopTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.GamestopTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.GamestopTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -510,12 +526,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.PokemonCenterTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.PokemonCenterTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.PokemonCenterTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.PokemonCenterTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -541,12 +557,12 @@ func CreateTaskInfos(task entities.Task) error {
 				return err
 			}
 
-			encryptedEmail, err := common.Aes256Encrypt(task.ShopifyTaskInfo.HotWheelsTaskInfo.Email, enums.UserKey)
+			encryptedEmail, err := common.EncryptField(task.ShopifyTaskInfo.HotWheelsTaskInfo.Email, enums.UserKey)
 			if err != nil {
 				return err
 			}
 
-			encryptedPassword, err := common.Aes256Encrypt(task.ShopifyTaskInfo.HotWheelsTaskInfo.Password, enums.UserKey)
+			encryptedPassword, err := common.EncryptField(task.ShopifyTaskInfo.HotWheelsTaskInfo.Password, enums.UserKey)
 			if err != nil {
 				return err
 			}
@@ -563,12 +579,12 @@ func CreateTaskInfos(task entities.Task) error {
 			return err
 		}
 
-		encryptedEmail, err := common.Aes256Encrypt(task.TargetTaskInfo.Email, enums.UserKey)
+		encryptedEmail, err := common.EncryptField(task.TargetTaskInfo.Email, enums.UserKey)
 		if err != nil {
 			return err
 		}
 
-		encryptedPassword, err := common.Aes256Encrypt(task.TargetTaskInfo.Password, enums.UserKey)
+		encryptedPassword, err := common.EncryptField(task.TargetTaskInfo.Password, enums.UserKey)
 		if err != nil {
 			return err
 		}
@@ -583,7 +599,18 @@ func CreateTaskInfos(task entities.Task) error {
 		if err != nil {
 			return err
 		}
-		_, err = statement.Exec(task.ID, task.TaskGroupID, task.ToppsTaskInfo.Email, task.ToppsTaskInfo.Password, task.ToppsTaskInfo.TaskType)
+
+		encryptedEmail, err := common.EncryptField(task.ToppsTaskInfo.Email, enums.UserKey)
+		if err != nil {
+			return err
+		}
+
+		encryptedPassword, err := common.EncryptField(task.ToppsTaskInfo.Password, enums.UserKey)
+		if err != nil {
+			return err
+		}
+
+		_, err = statement.Exec(task.ID, task.TaskGroupID, encryptedEmail, encryptedPassword, task.ToppsTaskInfo.TaskType)
 		if err != nil {
 			return err
 		}
@@ -670,6 +697,11 @@ func CreateShippingAddresses(profile entities.Profile) error {
 		return errors.New("database not initialized")
 	}
 
+	return createShippingAddresses(database, profile)
+}
+
+func createShippingAddresses(database preparer, profile entities.Profile) error {
+
 	encryptedValues, err := common.EncryptValues(enums.UserKey, profile.ShippingAddress.FirstName, profile.ShippingAddress.LastName, profile.ShippingAddress.Address1, profile.ShippingAddress.Address2, profile.ShippingAddress.City, profile.ShippingAddress.ZipCode, profile.ShippingAddress.StateCode, profile.ShippingAddress.CountryCode)
 	if err != nil {
 		return err
@@ -693,6 +725,11 @@ func CreateBillingAddresses(profile entities.Profile) error {
 		return errors.New("database not initialized")
 	}
 
+	return createBillingAddresses(database, profile)
+}
+
+func createBillingAddresses(database preparer, profile entities.Profile) error {
+
 	encryptedValues, err := common.EncryptValues(enums.UserKey, profile.BillingAddress.FirstName, profile.BillingAddress.LastName, profile.BillingAddress.Address1, profile.BillingAddress.Address2, profile.BillingAddress.City, profile.BillingAddress.ZipCode, profile.BillingAddress.StateCode, profile.BillingAddress.CountryCode)
 	if err != nil {
 		return err
@@ -716,6 +753,11 @@ func CreateCards(profile entities.Profile) error {
 		return errors.New("database not initialized")
 	}
 
+	return createCards(database, profile)
+}
+
+func createCards(database preparer, profile entities.Profile) error {
+
 	encryptedValues, err := common.EncryptValues(enums.UserKey, profile.CreditCard.CardholderName, profile.CreditCard.CardNumber, profile.CreditCard.ExpMonth, profile.CreditCard.ExpYear, profile.CreditCard.CVV, profile.CreditCard.CardType)
 	if err != nil {
 		return err
@@ -735,15 +777,25 @@ func CreateCards(profile entities.Profile) error {
 }
 
 func CreateProfileInfos(profile entities.Profile) error {
-	err := CreateShippingAddresses(profile)
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	return createProfileInfos(database, profile)
+}
+
+// createProfileInfos adds the Profile's addresses and card with the given database or transaction
+func createProfileInfos(database preparer, profile entities.Profile) error {
+	err := createShippingAddresses(database, profile)
 	if err != nil {
 		return err
 	}
-	err = CreateBillingAddresses(profile)
+	err = createBillingAddresses(database, profile)
 	if err != nil {
 		return err
 	}
-	return CreateCards(profile)
+	return createCards(database, profile)
 }
 
 func DeleteShippingAddresses(ID string) error {
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// CreateOutboxEntry adds the OutboxEntry to the database, its Payload is encrypted
+func CreateOutboxEntry(entry entities.OutboxEntry) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	encryptedPayload, err := common.EncryptField(entry.Payload, enums.UserKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = database.Exec(`INSERT INTO outbox (ID, destination, event, payload, status, attempts, nextAttempt, lastError, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID, entry.Destination, entry.Event, encryptedPayload, entry.Status, entry.Attempts, entry.NextAttempt, entry.LastError, entry.CreationDate)
+	return err
+}
+
+// UpdateOutboxEntry updates the delivery state of the OutboxEntry with the same ID, its Payload can't change
+func UpdateOutboxEntry(entry entities.OutboxEntry) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`UPDATE outbox SET status = ?, attempts = ?, nextAttempt = ?, lastError = ? WHERE ID = ?`,
+		entry.Status, entry.Attempts, entry.NextAttempt, entry.LastError, entry.ID)
+	return err
+}
+
+// RemoveOutboxEntry removes the OutboxEntry with the given ID from the database
+func RemoveOutboxEntry(ID string) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`DELETE FROM outbox WHERE ID = ?`, ID)
+	return err
+}
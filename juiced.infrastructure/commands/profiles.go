@@ -19,13 +19,7 @@ func CreateProfileGroup(profileGroup entities.ProfileGroup) error {
 		return errors.New("database not initialized")
 	}
 
-	statement, err := database.Preparex(`INSERT INTO profileGroups (groupID, name, profileIDsJoined, creationDate) VALUES (?, ?, ?, ?)`)
-	if err != nil {
-		return err
-	}
-	profileIDsJoined := strings.Join(profileGroup.ProfileIDs, ",")
-
-	_, err = statement.Exec(profileGroup.GroupID, profileGroup.Name, profileIDsJoined, profileGroup.CreationDate)
+	err := createProfileGroup(database, profileGroup)
 	if err != nil {
 		return err
 	}
@@ -45,6 +39,18 @@ func CreateProfileGroup(profileGroup entities.ProfileGroup) error {
 	return err
 }
 
+// createProfileGroup adds the ProfileGroup object with the given database or transaction, without adding it to its Profiles
+func createProfileGroup(database preparer, profileGroup entities.ProfileGroup) error {
+	statement, err := database.Preparex(`INSERT INTO profileGroups (groupID, name, profileIDsJoined, creationDate) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	profileIDsJoined := strings.Join(profileGroup.ProfileIDs, ",")
+
+	_, err = statement.Exec(profileGroup.GroupID, profileGroup.Name, profileIDsJoined, profileGroup.CreationDate)
+	return err
+}
+
 // RemoveProfileGroup removes the ProfileGroup from the database with the given groupID and returns it (if it exists)
 func RemoveProfileGroup(groupID string) (entities.ProfileGroup, error) {
 	profileGroup := entities.ProfileGroup{}
@@ -108,11 +114,16 @@ func CreateProfile(profile entities.Profile) error {
 		return errors.New("database not initialized")
 	}
 
-	encryptedEmail, err := common.Aes256Encrypt(profile.Email, enums.UserKey)
+	return createProfile(database, profile)
+}
+
+// createProfile adds the Profile object with the given database or transaction
+func createProfile(database preparer, profile entities.Profile) error {
+	encryptedEmail, err := common.EncryptField(profile.Email, enums.UserKey)
 	if err != nil {
 		return err
 	}
-	encryptedPhoneNumber, err := common.Aes256Encrypt(profile.PhoneNumber, enums.UserKey)
+	encryptedPhoneNumber, err := common.EncryptField(profile.PhoneNumber, enums.UserKey)
 	if err != nil {
 		return err
 	}
@@ -127,7 +138,7 @@ func CreateProfile(profile entities.Profile) error {
 		return err
 	}
 
-	return CreateProfileInfos(profile)
+	return createProfileInfos(database, profile)
 }
 
 // RemoveProfile removes the Profile from the database with the given ID and returns it (if it exists)
@@ -17,6 +17,11 @@ func CreateProxyGroup(proxyGroup entities.ProxyGroup) error {
 		return errors.New("database not initialized")
 	}
 
+	return createProxyGroup(database, proxyGroup)
+}
+
+// createProxyGroup adds the ProxyGroup object with the given database or transaction
+func createProxyGroup(database preparer, proxyGroup entities.ProxyGroup) error {
 	statement, err := database.Preparex(`INSERT INTO proxyGroups (groupID, name, creationDate) VALUES (?, ?, ?)`)
 	if err != nil {
 		return err
@@ -28,11 +33,11 @@ func CreateProxyGroup(proxyGroup entities.ProxyGroup) error {
 	}
 
 	for _, proxy := range proxyGroup.Proxies {
-		statement, err := database.Preparex(`INSERT INTO proxys (ID, proxyGroupID, host, port, username, password) VALUES (?, ?, ?, ?, ?, ?)`)
+		statement, err := database.Preparex(`INSERT INTO proxys (ID, proxyGroupID, scheme, host, port, username, password, latency, statusCode, testError, testedAt, health) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 		if err != nil {
 			return err
 		}
-		_, err = statement.Exec(proxy.ID, proxyGroup.GroupID, proxy.Host, proxy.Port, proxy.Username, proxy.Password)
+		_, err = statement.Exec(proxy.ID, proxyGroup.GroupID, proxy.Scheme, proxy.Host, proxy.Port, proxy.Username, proxy.Password, proxy.Latency, proxy.StatusCode, proxy.TestError, proxy.TestedAt, proxy.Health)
 		if err != nil {
 			return err
 		}
@@ -72,13 +77,27 @@ func RemoveProxyGroup(groupID string) (entities.ProxyGroup, error) {
 	return proxyGroup, err
 }
 
-// UpdateProxyGroup updates the ProxyGroup from the database with the given groupID and returns it (if it exists)
+// UpdateProxyGroup updates the ProxyGroup from the database with the given groupID and returns it (if it exists).
+// Proxies that haven't been tested keep the test results of the Proxy they replace, if it has the same ID or address.
 func UpdateProxyGroup(groupID string, newProxyGroup entities.ProxyGroup) (entities.ProxyGroup, error) {
 	proxyGroup, err := RemoveProxyGroup(groupID)
 	if err != nil {
 		return proxyGroup, err
 	}
 
+	for _, newProxy := range newProxyGroup.Proxies {
+		if newProxy.TestedAt != 0 {
+			continue
+		}
+		for _, proxy := range proxyGroup.Proxies {
+			if proxy.ID == newProxy.ID || (proxy.Scheme == newProxy.Scheme && proxy.Host == newProxy.Host && proxy.Port == newProxy.Port && proxy.Username == newProxy.Username) {
+				newProxy.Latency, newProxy.StatusCode, newProxy.TestError = proxy.Latency, proxy.StatusCode, proxy.TestError
+				newProxy.TestedAt, newProxy.Health = proxy.TestedAt, proxy.Health
+				break
+			}
+		}
+	}
+
 	err = CreateProxyGroup(newProxyGroup)
 	if err != nil {
 		return proxyGroup, err
@@ -86,3 +105,15 @@ func UpdateProxyGroup(groupID string, newProxyGroup entities.ProxyGroup) (entiti
 
 	return queries.GetProxyGroup(groupID)
 }
+
+// UpdateProxyHealth saves the Proxy's last test results and Health
+func UpdateProxyHealth(proxy entities.Proxy) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`UPDATE proxys SET latency = ?, statusCode = ?, testError = ?, testedAt = ?, health = ? WHERE ID = ?`,
+		proxy.Latency, proxy.StatusCode, proxy.TestError, proxy.TestedAt, proxy.Health, proxy.ID)
+	return err
+}
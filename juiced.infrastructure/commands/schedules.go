@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// CreateTaskGroupSchedule adds the TaskGroupSchedule to the database
+func CreateTaskGroupSchedule(schedule entities.TaskGroupSchedule) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	return createTaskGroupSchedule(database, schedule)
+}
+
+// createTaskGroupSchedule adds the TaskGroupSchedule with the given database or transaction
+func createTaskGroupSchedule(database preparer, schedule entities.TaskGroupSchedule) error {
+	statement, err := database.Preparex(`INSERT INTO taskGroupSchedules (ID, taskGroupID, startTime, cron, stopAfterMinutes, stopTime, enabled, nextStartTime, nextStopTime, lastFiredTime, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	_, err = statement.Exec(schedule.ID, schedule.TaskGroupID, schedule.StartTime, schedule.Cron, schedule.StopAfterMinutes, schedule.StopTime, schedule.Enabled, schedule.NextStartTime, schedule.NextStopTime, schedule.LastFiredTime, schedule.CreationDate)
+	return err
+}
+
+// UpdateTaskGroupSchedule replaces the TaskGroupSchedule in the database with the one with the same ID
+func UpdateTaskGroupSchedule(schedule entities.TaskGroupSchedule) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`UPDATE taskGroupSchedules SET startTime = ?, cron = ?, stopAfterMinutes = ?, stopTime = ?, enabled = ?, nextStartTime = ?, nextStopTime = ?, lastFiredTime = ? WHERE ID = ?`,
+		schedule.StartTime, schedule.Cron, schedule.StopAfterMinutes, schedule.StopTime, schedule.Enabled, schedule.NextStartTime, schedule.NextStopTime, schedule.LastFiredTime, schedule.ID)
+	return err
+}
+
+// RemoveTaskGroupSchedule removes the TaskGroupSchedule with the given ID from the database
+func RemoveTaskGroupSchedule(ID string) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`DELETE FROM taskGroupSchedules WHERE ID = ?`, ID)
+	return err
+}
+
+// RemoveTaskGroupSchedules removes all of the TaskGroup's schedules from the database
+func RemoveTaskGroupSchedules(groupID string) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	_, err := database.Exec(`DELETE FROM taskGroupSchedules WHERE taskGroupID = ?`, groupID)
+	return err
+}
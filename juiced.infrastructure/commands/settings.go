@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
@@ -18,21 +19,45 @@ func UpdateSettings(settings entities.Settings) (entities.Settings, error) {
 		return settings, errors.New("database not initialized")
 	}
 
-	_, err := database.Exec("DELETE FROM settings")
+	err := updateSettings(database, settings)
 	if err != nil {
 		return settings, err
 	}
 
-	statement, err := database.Preparex(`INSERT INTO settings (id, successDiscordWebhook, failureDiscordWebhook, twoCaptchaAPIKey, antiCaptchaAPIKey, capMonsterAPIKey, aycdAccessToken, aycdAPIKey, darkMode, useAnimations) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return queries.GetSettings()
+}
+
+// updateSettings replaces the Settings with the given database or transaction
+func updateSettings(database preparer, settings entities.Settings) error {
+	notificationSinks := settings.NotificationSinks
+	if notificationSinks == nil {
+		notificationSinks = []entities.NotificationSink{}
+	}
+	notificationSinksJoined, err := json.Marshal(notificationSinks)
 	if err != nil {
-		return settings, err
+		return err
 	}
-	_, err = statement.Exec(0, settings.SuccessDiscordWebhook, settings.FailureDiscordWebhook, settings.TwoCaptchaAPIKey, settings.AntiCaptchaAPIKey, settings.CapMonsterAPIKey, settings.AYCDAccessToken, settings.AYCDAPIKey, settings.DarkMode, settings.UseAnimations)
+
+	encryptedValues, err := common.EncryptValues(enums.UserKey, settings.TwoCaptchaAPIKey, settings.AntiCaptchaAPIKey, settings.CapMonsterAPIKey, settings.AYCDAccessToken, settings.AYCDAPIKey, settings.RemoteAccessToken, string(notificationSinksJoined))
 	if err != nil {
-		return settings, err
+		return err
 	}
 
-	return queries.GetSettings()
+	statement, err := database.Preparex(`DELETE FROM settings`)
+	if err != nil {
+		return err
+	}
+	_, err = statement.Exec()
+	if err != nil {
+		return err
+	}
+
+	statement, err = database.Preparex(`INSERT INTO settings (id, successDiscordWebhook, failureDiscordWebhook, twoCaptchaAPIKey, antiCaptchaAPIKey, capMonsterAPIKey, aycdAccessToken, aycdAPIKey, darkMode, useAnimations, taskHistoryRetentionDays, taskHistoryMaxRuns, remoteAccessEnabled, remoteAccessPort, remoteAccessToken, notificationSinks) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	_, err = statement.Exec(0, settings.SuccessDiscordWebhook, settings.FailureDiscordWebhook, encryptedValues[0], encryptedValues[1], encryptedValues[2], encryptedValues[3], encryptedValues[4], settings.DarkMode, settings.UseAnimations, settings.TaskHistoryRetentionDays, settings.TaskHistoryMaxRuns, settings.RemoteAccessEnabled, settings.RemoteAccessPort, encryptedValues[5], encryptedValues[6])
+	return err
 }
 
 // AddAccount adds an Account object to the database
@@ -42,23 +67,39 @@ func AddAccount(account entities.Account) error {
 		return errors.New("database not initialized")
 	}
 
-	encryptedEmail, err := common.Aes256Encrypt(account.Email, enums.UserKey)
+	return addAccount(database, account)
+}
+
+// addAccount adds the Account object with the given database or transaction
+func addAccount(database preparer, account entities.Account) error {
+	encryptedEmail, err := common.EncryptField(account.Email, enums.UserKey)
 	if err != nil {
 		return err
 	}
 
-	encryptedPassword, err := common.Aes256Encrypt(account.Password, enums.UserKey)
+	encryptedPassword, err := common.EncryptField(account.Password, enums.UserKey)
 	if err != nil {
 		return err
 	}
 
-	statement, err := database.Preparex(`INSERT INTO accounts (ID, retailer, email, password, creationDate) VALUES (?, ?, ?, ?, ?)`)
+	statement, err := database.Preparex(`INSERT INTO accounts (ID, retailer, email, password, status, statusDate, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
 
-	_, err = statement.Exec(account.ID, account.Retailer, encryptedEmail, encryptedPassword, account.CreationDate)
+	_, err = statement.Exec(account.ID, account.Retailer, encryptedEmail, encryptedPassword, account.Status, account.StatusDate, account.CreationDate)
+
+	return err
+}
+
+// UpdateAccountStatus records how the last login with the Account with the given ID went
+func UpdateAccountStatus(ID string, status enums.AccountStatus, statusDate int64) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
 
+	_, err := database.Exec(`UPDATE accounts SET status = ?, statusDate = ? WHERE ID = ?`, status, statusDate, ID)
 	return err
 }
 
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	_ "github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// RecordTaskRuns adds each TaskRun's Events to the database and creates or updates the TaskRun with its latest status, in a single transaction
+func RecordTaskRuns(taskRuns []entities.TaskRun) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	tx, err := database.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, taskRun := range taskRuns {
+		result, err := tx.Exec(`UPDATE taskRuns SET proxyID = ?, status = ?, statusPercentage = ?, eventType = ?, updateTime = ? WHERE runID = ?`,
+			taskRun.ProxyID, taskRun.Status, taskRun.StatusPercentage, taskRun.EventType, taskRun.UpdateTime, taskRun.RunID)
+		if err != nil {
+			return err
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			_, err = tx.Exec(`INSERT INTO taskRuns (runID, kind, taskID, taskGroupID, retailer, proxyID, status, statusPercentage, eventType, startTime, updateTime) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				taskRun.RunID, taskRun.Kind, taskRun.TaskID, taskRun.TaskGroupID, taskRun.Retailer, taskRun.ProxyID, taskRun.Status, taskRun.StatusPercentage, taskRun.EventType, taskRun.StartTime, taskRun.UpdateTime)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, event := range taskRun.Events {
+			_, err = tx.Exec(`INSERT INTO taskRunEvents (runID, kind, taskID, taskGroupID, proxyID, status, statusPercentage, eventType, time) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				event.RunID, event.Kind, event.TaskID, event.TaskGroupID, event.ProxyID, event.Status, event.StatusPercentage, event.EventType, event.Time)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// PruneTaskRuns removes the TaskRuns (and their Events) that haven't been updated in retentionDays,
+// and all but the maxRuns most recent TaskRuns of each Task or Monitor
+func PruneTaskRuns(retentionDays int, maxRuns int) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+
+	cutoff := time.Now().Add(time.Duration(-retentionDays)*24*time.Hour).UnixNano() / int64(time.Millisecond)
+
+	tx, err := database.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`DELETE FROM taskRuns WHERE updateTime < ?`, cutoff)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM taskRuns WHERE runID IN (
+		SELECT runID FROM (
+			SELECT runID, ROW_NUMBER() OVER (PARTITION BY kind, taskID, taskGroupID ORDER BY startTime DESC) AS runNumber FROM taskRuns
+		) WHERE runNumber > ?
+	)`, maxRuns)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM taskRunEvents WHERE time < ? OR runID NOT IN (SELECT runID FROM taskRuns)`, cutoff)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"strings"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
@@ -18,6 +20,11 @@ func CreateTaskGroup(taskGroup entities.TaskGroup) error {
 		return errors.New("database not initialized")
 	}
 
+	return createTaskGroup(database, taskGroup)
+}
+
+// createTaskGroup adds the TaskGroup object with the given database or transaction
+func createTaskGroup(database preparer, taskGroup entities.TaskGroup) error {
 	statement, err := database.Preparex(`INSERT INTO taskGroups (groupID, name, proxyGroupID, retailer, input, delay, status, taskIDsJoined, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
@@ -29,7 +36,7 @@ func CreateTaskGroup(taskGroup entities.TaskGroup) error {
 		return err
 	}
 
-	return CreateMonitorInfos(taskGroup)
+	return createMonitorInfos(database, taskGroup)
 }
 
 // RemoveTaskGroup removes the TaskGroup from the database with the given groupID and returns it (if it exists)
@@ -90,19 +97,78 @@ func CreateTask(task entities.Task) error {
 		return errors.New("database not initialized")
 	}
 
-	statement, err := database.Preparex(`INSERT INTO tasks (ID, taskGroupID, profileID, proxyGroupID, retailer, sizeJoined, qty, status, taskDelay, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	return createTask(database, task)
+}
+
+// createTask adds the Task object with the given database or transaction
+func createTask(database preparer, task entities.Task) error {
+	statement, err := database.Preparex(`INSERT INTO tasks (ID, taskGroupID, profileID, proxyGroupID, accountID, retailer, sizeJoined, sizeFallback, qty, status, taskDelay, creationDate) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
 
 	sizeJoined := strings.Join(task.TaskSize, ",")
-	_, err = statement.Exec(task.ID, task.TaskGroupID, task.TaskProfileID, task.TaskProxyGroupID, task.TaskRetailer, sizeJoined, task.TaskQty, task.TaskStatus, task.TaskDelay, task.CreationDate)
+	_, err = statement.Exec(task.ID, task.TaskGroupID, task.TaskProfileID, task.TaskProxyGroupID, task.TaskAccountID, task.TaskRetailer, sizeJoined, task.TaskSizeFallback, task.TaskQty, task.TaskStatus, task.TaskDelay, task.CreationDate)
 	if err != nil {
 		return err
 	}
 
-	return CreateTaskInfos(task)
+	return createTaskInfos(database, task)
+}
+
+// CreateTasks adds the Tasks to the database and to the TaskGroup with the given groupID in one transaction,
+// if any of them can't be added then none of them are
+func CreateTasks(groupID string, tasks []entities.Task) error {
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
 
+	tx, err := database.Beginx()
+	if err != nil {
+		return err
+	}
+	var taskIDsJoined string
+	err = tx.Get(&taskIDsJoined, `SELECT taskIDsJoined FROM taskGroups WHERE groupID = ?`, groupID)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return errors.New("no TaskGroup has the given ID")
+		}
+		return err
+	}
+
+	taskIDs := []string{}
+	if taskIDsJoined != "" {
+		taskIDs = strings.Split(taskIDsJoined, ",")
+	}
+	for _, task := range tasks {
+		if common.InSlice(taskIDs, task.ID) {
+			tx.Rollback()
+			return fmt.Errorf("task %s is already in the TaskGroup", task.ID)
+		}
+		if task.TaskGroupID != groupID {
+			tx.Rollback()
+			return fmt.Errorf("task %s belongs to a different TaskGroup", task.ID)
+		}
+		if !hasTaskInfo(task) {
+			tx.Rollback()
+			return fmt.Errorf("task %s is missing its %s info", task.ID, task.TaskRetailer)
+		}
+		err = createTask(tx, task)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	_, err = tx.Exec(`UPDATE taskGroups SET taskIDsJoined = ? WHERE groupID = ?`, strings.Join(taskIDs, ","), groupID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
 }
 
 // RemoveTask removes the Task from the database with the given ID and returns it (if it exists)
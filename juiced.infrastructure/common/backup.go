@@ -0,0 +1,90 @@
+package common
+
+import (
+	"bytes"
+	crand "crypto/rand"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Backups are encrypted with a key derived from the user's passphrase instead of the machine's key, so that they can be
+// restored on another machine. An encrypted backup is backupMagic, the version byte and the scrypt salt, followed by the
+// nonce and the AES-GCM ciphertext and tag. The header is authenticated along with the ciphertext.
+const (
+	backupMagic    = "JUICEDBACKUP"
+	backupVersion  = byte(1)
+	backupSaltSize = 16
+	// The scrypt cost takes around 100ms, which only has to be paid once per backup
+	backupScryptN = 1 << 15
+	backupScryptR = 8
+	backupScryptP = 1
+)
+
+// MinBackupPassphraseLength is the shortest passphrase that a backup can be encrypted with
+const MinBackupPassphraseLength = 8
+
+// EncryptBackup encrypts the backup with a key derived from the passphrase
+func EncryptBackup(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, backupSaltSize)
+	if _, err := crand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := backupKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(string(key))
+	if err != nil {
+		return nil, err
+	}
+
+	headerLength := len(backupMagic) + 1 + backupSaltSize
+	archive := make([]byte, headerLength+gcm.NonceSize(), headerLength+gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	copy(archive, backupMagic)
+	archive[len(backupMagic)] = backupVersion
+	copy(archive[len(backupMagic)+1:], salt)
+	nonce := archive[headerLength:]
+	if _, err := crand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(archive, nonce, plaintext, archive[:headerLength]), nil
+}
+
+// DecryptBackup decrypts a backup written by EncryptBackup. A wrong passphrase and a backup that was tampered with
+// can't be told apart, both are an IncorrectBackupPassphraseError.
+func DecryptBackup(archive []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(archive, []byte(backupMagic)) || len(archive) < len(backupMagic)+1 {
+		return nil, &NotABackupError{}
+	}
+	if version := archive[len(backupMagic)]; version != backupVersion {
+		return nil, &UnsupportedBackupVersionError{Version: version}
+	}
+	headerLength := len(backupMagic) + 1 + backupSaltSize
+	if len(archive) < headerLength {
+		return nil, &NotABackupError{}
+	}
+
+	key, err := backupKey(passphrase, archive[len(backupMagic)+1:headerLength])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(string(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(archive) < headerLength+gcm.NonceSize()+gcm.Overhead() {
+		return nil, &NotABackupError{}
+	}
+	nonce := archive[headerLength : headerLength+gcm.NonceSize()]
+	plaintext, err := gcm.Open(nil, nonce, archive[headerLength+gcm.NonceSize():], archive[:headerLength])
+	if err != nil {
+		return nil, &IncorrectBackupPassphraseError{}
+	}
+	return plaintext, nil
+}
+
+// backupKey derives the AES-256 key for a backup from the passphrase and the backup's salt
+func backupKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, backupScryptN, backupScryptR, backupScryptP, 32)
+}
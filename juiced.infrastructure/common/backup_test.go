@@ -0,0 +1,67 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBackupRoundTrip(t *testing.T) {
+	plaintext := []byte(`{"version":1,"profiles":[]}`)
+	archive, err := EncryptBackup(plaintext, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(archive, plaintext) {
+		t.Error("expected the backup to be encrypted")
+	}
+
+	decrypted, err := DecryptBackup(archive, "correct horse")
+	if err != nil || !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("expected %q, got %q (err %v)", plaintext, decrypted, err)
+	}
+
+	if _, err = DecryptBackup(archive, "wrong horse"); err == nil {
+		t.Error("expected a wrong passphrase to be rejected")
+	} else if _, ok := err.(*IncorrectBackupPassphraseError); !ok {
+		t.Errorf("expected an IncorrectBackupPassphraseError, got %T", err)
+	}
+}
+
+func TestDecryptBackupRejectsDamagedBackups(t *testing.T) {
+	archive, err := EncryptBackup([]byte("{}"), "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := append([]byte{}, archive...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := DecryptBackup(tampered, "correct horse"); err == nil {
+		t.Error("expected a tampered backup to be rejected")
+	}
+
+	// The salt is authenticated, so it can't be swapped for another backup's
+	other, _ := EncryptBackup([]byte("{}"), "correct horse")
+	swapped := append([]byte{}, archive...)
+	copy(swapped[len(backupMagic)+1:len(backupMagic)+1+backupSaltSize], other[len(backupMagic)+1:])
+	if _, err := DecryptBackup(swapped, "correct horse"); err == nil {
+		t.Error("expected a backup with a swapped salt to be rejected")
+	}
+
+	if _, err := DecryptBackup([]byte("{}"), "correct horse"); err == nil {
+		t.Error("expected a file that isn't a backup to be rejected")
+	} else if _, ok := err.(*NotABackupError); !ok {
+		t.Errorf("expected a NotABackupError, got %T", err)
+	}
+
+	future := append([]byte{}, archive...)
+	future[len(backupMagic)] = backupVersion + 1
+	if _, err := DecryptBackup(future, "correct horse"); err == nil {
+		t.Error("expected a newer backup version to be rejected")
+	} else if _, ok := err.(*UnsupportedBackupVersionError); !ok {
+		t.Errorf("expected an UnsupportedBackupVersionError, got %T", err)
+	}
+
+	if _, err := DecryptBackup(archive[:len(backupMagic)+4], "correct horse"); err == nil {
+		t.Error("expected a truncated backup to be rejected")
+	}
+}
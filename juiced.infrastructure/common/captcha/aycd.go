@@ -41,9 +41,9 @@ func InitAycd() error {
 		retailer := retailerAndReCaptchaType[0]
 		reCaptchaType := retailerAndReCaptchaType[1]
 		if reCaptchaType == "V2" {
-			captchaStore.AYCDReCaptchaV2Tokens[retailer] = append(captchaStore.AYCDReCaptchaV2Tokens[retailer], token)
+			addReCaptchaToken(captchaStore.AYCDReCaptchaV2Tokens, retailer, token)
 		} else {
-			captchaStore.AYCDReCaptchaV3Tokens[retailer] = append(captchaStore.AYCDReCaptchaV3Tokens[retailer], token)
+			addReCaptchaToken(captchaStore.AYCDReCaptchaV3Tokens, retailer, token)
 		}
 	}
 
@@ -1,6 +1,7 @@
 package captcha
 
 import (
+	"context"
 	"errors"
 	"log"
 	"strconv"
@@ -26,6 +27,8 @@ type CaptchaStore struct {
 	AYCDReCaptchaV2Tokens map[enums.Retailer][]*entities.ReCaptchaToken
 	AYCDReCaptchaV3Tokens map[enums.Retailer][]*entities.ReCaptchaToken
 	EventBus              *events.EventBus
+	// mutex guards the token lists, which are appended to by the solver goroutines and taken from by the Tasks
+	mutex sync.Mutex
 }
 
 var captchaStore *CaptchaStore
@@ -48,6 +51,62 @@ func GetCaptchaStore() *CaptchaStore {
 	return captchaStore
 }
 
+// takeReCaptchaToken removes and returns a token for the given url and proxy from the retailer's list, or nil if there isn't one
+func takeReCaptchaToken(tokenMap map[enums.Retailer][]*entities.ReCaptchaToken, retailer enums.Retailer, url string, proxy entities.Proxy) *entities.ReCaptchaToken {
+	captchaStore.mutex.Lock()
+	defer captchaStore.mutex.Unlock()
+
+	tokens := tokenMap[retailer]
+	for index, token := range tokens {
+		if token.URL == url && token.Proxy.ID == proxy.ID {
+			tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
+			tokenMap[retailer] = tokens[:len(tokens)-1]
+			return token
+		}
+	}
+	return nil
+}
+
+// takeHCaptchaToken removes and returns a token for the given url and proxy from the retailer's list, or nil if there isn't one
+func takeHCaptchaToken(tokenMap map[enums.Retailer][]*entities.HCaptchaToken, retailer enums.Retailer, url string, proxy entities.Proxy) *entities.HCaptchaToken {
+	captchaStore.mutex.Lock()
+	defer captchaStore.mutex.Unlock()
+
+	tokens := tokenMap[retailer]
+	for index, token := range tokens {
+		if token.URL == url && token.Proxy.ID == proxy.ID {
+			tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
+			tokenMap[retailer] = tokens[:len(tokens)-1]
+			return token
+		}
+	}
+	return nil
+}
+
+// addReCaptchaToken appends the token to the retailer's list
+func addReCaptchaToken(tokenMap map[enums.Retailer][]*entities.ReCaptchaToken, retailer enums.Retailer, token *entities.ReCaptchaToken) {
+	captchaStore.mutex.Lock()
+	defer captchaStore.mutex.Unlock()
+
+	tokenMap[retailer] = append(tokenMap[retailer], token)
+}
+
+// addHCaptchaToken appends the token to the retailer's list
+func addHCaptchaToken(tokenMap map[enums.Retailer][]*entities.HCaptchaToken, retailer enums.Retailer, token *entities.HCaptchaToken) {
+	captchaStore.mutex.Lock()
+	defer captchaStore.mutex.Unlock()
+
+	tokenMap[retailer] = append(tokenMap[retailer], token)
+}
+
+// addGeeTestCaptchaToken appends the token to the retailer's list
+func addGeeTestCaptchaToken(tokenMap map[enums.Retailer][]*entities.GeeTestCaptchaToken, retailer enums.Retailer, token *entities.GeeTestCaptchaToken) {
+	captchaStore.mutex.Lock()
+	defer captchaStore.mutex.Unlock()
+
+	tokenMap[retailer] = append(tokenMap[retailer], token)
+}
+
 // TODO @silent: Instead of passing in all of these variables, create a struct called RequestCaptchaTokenInfo that has all of them (since some are not required for all Captcha types/retailers)
 // RequestCaptchaToken returns a Captcha token from the Store, or requests one if none are available
 func RequestCaptchaToken(captchaType enums.CaptchaType, retailer enums.Retailer, url, action string, minScore float64, proxy entities.Proxy, sitekey ...string) (interface{}, error) {
@@ -57,23 +116,13 @@ func RequestCaptchaToken(captchaType enums.CaptchaType, retailer enums.Retailer,
 	}
 	switch captchaType {
 	case enums.ReCaptchaV2:
-		tokens := captchaStore.ReCaptchaV2Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.ReCaptchaV2Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token, nil
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.ReCaptchaV2Tokens, retailer, url, proxy); token != nil {
+			return *token, nil
 		}
-		tokens = captchaStore.AYCDReCaptchaV2Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.AYCDReCaptchaV2Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token, nil
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.AYCDReCaptchaV2Tokens, retailer, url, proxy); token != nil {
+			return *token, nil
 		}
 		// Otherwise, request a token
 		tempSitekey, ok := enums.ReCaptchaSitekeys[retailer]
@@ -93,23 +142,13 @@ func RequestCaptchaToken(captchaType enums.CaptchaType, retailer enums.Retailer,
 		}
 		go RequestReCaptchaV2Token(retailerSitekey, url, proxy, retailer)
 	case enums.ReCaptchaV3:
-		tokens := captchaStore.ReCaptchaV3Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.ReCaptchaV3Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token, nil
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.ReCaptchaV3Tokens, retailer, url, proxy); token != nil {
+			return *token, nil
 		}
-		tokens = captchaStore.AYCDReCaptchaV3Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.AYCDReCaptchaV3Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token, nil
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.AYCDReCaptchaV3Tokens, retailer, url, proxy); token != nil {
+			return *token, nil
 		}
 		// Otherwise, request a token
 		tempSitekey, ok := enums.ReCaptchaSitekeys[retailer]
@@ -130,14 +169,9 @@ func RequestCaptchaToken(captchaType enums.CaptchaType, retailer enums.Retailer,
 
 		go RequestReCaptchaV3Token(retailerSitekey, action, url, minScore, proxy, retailer)
 	case enums.HCaptcha:
-		tokens := captchaStore.HCaptchaTokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.HCaptchaTokens[retailer] = tokens[:len(tokens)-1]
-				return *token, nil
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeHCaptchaToken(captchaStore.HCaptchaTokens, retailer, url, proxy); token != nil {
+			return *token, nil
 		}
 		// Otherwise, request a token
 		tempSitekey, ok := enums.HCaptchaSitekeys[retailer]
@@ -170,52 +204,27 @@ func PollCaptchaTokens(captchaType enums.CaptchaType, retailer enums.Retailer, u
 	}
 	switch captchaType {
 	case enums.ReCaptchaV2:
-		tokens := captchaStore.ReCaptchaV2Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.ReCaptchaV2Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.ReCaptchaV2Tokens, retailer, url, proxy); token != nil {
+			return *token
 		}
-		tokens = captchaStore.AYCDReCaptchaV2Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.AYCDReCaptchaV2Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.AYCDReCaptchaV2Tokens, retailer, url, proxy); token != nil {
+			return *token
 		}
 	case enums.ReCaptchaV3:
-		tokens := captchaStore.ReCaptchaV3Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.ReCaptchaV3Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.ReCaptchaV3Tokens, retailer, url, proxy); token != nil {
+			return *token
 		}
-		tokens = captchaStore.AYCDReCaptchaV3Tokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.AYCDReCaptchaV3Tokens[retailer] = tokens[:len(tokens)-1]
-				return *token
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeReCaptchaToken(captchaStore.AYCDReCaptchaV3Tokens, retailer, url, proxy); token != nil {
+			return *token
 		}
 	case enums.HCaptcha:
-		tokens := captchaStore.HCaptchaTokens[retailer]
-		for index, token := range tokens {
-			if token.URL == url && token.Proxy.ID == proxy.ID {
-				// If a valid token exists, remove it from the list of tokens and return it
-				tokens[len(tokens)-1], tokens[index] = tokens[index], tokens[len(tokens)-1]
-				captchaStore.HCaptchaTokens[retailer] = tokens[:len(tokens)-1]
-				return *token
-			}
+		// If a valid token exists, remove it from the list of tokens and return it
+		if token := takeHCaptchaToken(captchaStore.HCaptchaTokens, retailer, url, proxy); token != nil {
+			return *token
 		}
 	case enums.GeeTestCaptcha:
 		// TODO @silent
@@ -224,6 +233,25 @@ func PollCaptchaTokens(captchaType enums.CaptchaType, retailer enums.Retailer, u
 	return nil
 }
 
+// WaitForCaptchaToken polls the store until a Captcha token is available, it gives up with the context's error once ctx is cancelled
+func WaitForCaptchaToken(ctx context.Context, captchaType enums.CaptchaType, retailer enums.Retailer, url string, proxy entities.Proxy) (interface{}, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ticker := time.NewTicker(1 * time.Second / 10)
+	defer ticker.Stop()
+	for {
+		if token := PollCaptchaTokens(captchaType, retailer, url, proxy); token != nil {
+			return token, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // RequestReCaptchaV2Token requests a ReCaptchaV2 token from all available APIs and the frontend
 func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, retailer enums.Retailer) error {
 	settings, err := queries.GetSettings()
@@ -278,7 +306,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 				log.Println("Requesting 2Captcha token")
 				endpoint := "https://2captcha.com/in.php?key=" + settings.TwoCaptchaAPIKey + "&method=userrecaptcha&googlekey=" + sitekey + "&pageurl=" + url
 				if proxy.Host != "" {
-					endpoint += "&proxy=" + common.ProxyCleaner(proxy) + "&proxytype=http"
+					endpoint += "&proxy=" + common.ProxyCleaner(proxy) + "&proxytype=" + strings.ToUpper(ProxyType(proxy))
 				}
 				var token string
 				token, err = TwoCaptchaReq(settings.TwoCaptchaAPIKey, endpoint)
@@ -293,7 +321,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 					}
 					return
 				}
-				captchaStore.ReCaptchaV2Tokens[retailer] = append(captchaStore.ReCaptchaV2Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV2Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: token,
@@ -306,7 +334,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 				port := 0
 				proxyType := ""
 				if proxy.Port != "" {
-					proxyType = "http"
+					proxyType = AntiCaptchaProxyType(proxy)
 					port, err = strconv.Atoi(proxy.Port)
 					if err != nil {
 						log.Println(err.Error())
@@ -342,7 +370,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 					return
 				}
 
-				captchaStore.ReCaptchaV2Tokens[retailer] = append(captchaStore.ReCaptchaV2Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV2Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: antiCaptchaResponse.Solution.GRecaptchaResponse,
@@ -355,7 +383,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 				port := 0
 				proxyType := ""
 				if proxy.Port != "" {
-					proxyType = "http"
+					proxyType = AntiCaptchaProxyType(proxy)
 					port, err = strconv.Atoi(proxy.Port)
 					if err != nil {
 						log.Println(err.Error())
@@ -386,7 +414,7 @@ func RequestReCaptchaV2Token(sitekey string, url string, proxy entities.Proxy, r
 					return
 				}
 
-				captchaStore.ReCaptchaV2Tokens[retailer] = append(captchaStore.ReCaptchaV2Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV2Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: capMonsterResponse.Solution.GRecaptchaResponse,
@@ -471,7 +499,7 @@ func RequestReCaptchaV3Token(sitekey, action, url string, minScore float64, prox
 					}
 					return
 				}
-				captchaStore.ReCaptchaV3Tokens[retailer] = append(captchaStore.ReCaptchaV3Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV3Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: token,
@@ -501,7 +529,7 @@ func RequestReCaptchaV3Token(sitekey, action, url string, minScore float64, prox
 					return
 				}
 
-				captchaStore.ReCaptchaV3Tokens[retailer] = append(captchaStore.ReCaptchaV3Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV3Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: antiCaptchaResponse.Solution.GRecaptchaResponse,
@@ -530,7 +558,7 @@ func RequestReCaptchaV3Token(sitekey, action, url string, minScore float64, prox
 					return
 				}
 
-				captchaStore.ReCaptchaV3Tokens[retailer] = append(captchaStore.ReCaptchaV3Tokens[retailer], &entities.ReCaptchaToken{
+				addReCaptchaToken(captchaStore.ReCaptchaV3Tokens, retailer, &entities.ReCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: capMonsterResponse.Solution.GRecaptchaResponse,
@@ -570,7 +598,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 			go func() {
 				defer wg.Done()
 
-				token, err := TwoCaptchaReq(settings.TwoCaptchaAPIKey, "https://2captcha.com/in.php?key="+settings.TwoCaptchaAPIKey+"&method=hcaptcha&sitekey="+sitekey+"&pageurl="+url+"&proxy="+common.ProxyCleaner(proxy)+"&proxytype=http")
+				token, err := TwoCaptchaReq(settings.TwoCaptchaAPIKey, "https://2captcha.com/in.php?key="+settings.TwoCaptchaAPIKey+"&method=hcaptcha&sitekey="+sitekey+"&pageurl="+url+"&proxy="+common.ProxyCleaner(proxy)+"&proxytype="+strings.ToUpper(ProxyType(proxy)))
 				if err != nil {
 					log.Println("Error retrieving HCaptcha from 2Captcha: " + err.Error())
 					if err.Error() == "ERROR_KEY_DOES_NOT_EXIST" || err.Error() == "ERROR_WRONG_USER_KEY" {
@@ -582,7 +610,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 					}
 					return
 				}
-				captchaStore.HCaptchaTokens[retailer] = append(captchaStore.HCaptchaTokens[retailer], &entities.HCaptchaToken{
+				addHCaptchaToken(captchaStore.HCaptchaTokens, retailer, &entities.HCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: token,
@@ -599,7 +627,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 					Type:          "HCaptchaTask",
 					Websiteurl:    url,
 					Websitekey:    sitekey,
-					Proxytype:     "http",
+					Proxytype:     AntiCaptchaProxyType(proxy),
 					Proxyaddress:  proxy.Host,
 					Proxyport:     port,
 					Proxylogin:    proxy.Username,
@@ -618,7 +646,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 					return
 				}
 
-				captchaStore.HCaptchaTokens[retailer] = append(captchaStore.HCaptchaTokens[retailer], &entities.HCaptchaToken{
+				addHCaptchaToken(captchaStore.HCaptchaTokens, retailer, &entities.HCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: antiCaptchaResponse.Solution.GRecaptchaResponse,
@@ -635,7 +663,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 					Type:          "HCaptchaTask",
 					Websiteurl:    url,
 					Websitekey:    sitekey,
-					Proxytype:     "http",
+					Proxytype:     AntiCaptchaProxyType(proxy),
 					Proxyaddress:  proxy.Host,
 					Proxyport:     port,
 					Proxylogin:    proxy.Username,
@@ -654,7 +682,7 @@ func RequestHCaptchaToken(sitekey string, url string, proxy entities.Proxy, reta
 					return
 				}
 
-				captchaStore.HCaptchaTokens[retailer] = append(captchaStore.HCaptchaTokens[retailer], &entities.HCaptchaToken{
+				addHCaptchaToken(captchaStore.HCaptchaTokens, retailer, &entities.HCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: capMonsterResponse.Solution.GRecaptchaResponse,
@@ -704,7 +732,7 @@ func RequestGeeTestCaptchaToken(sitekey string, url string, challenge string, pr
 					return
 				}
 				valuesSplit := strings.Split(valuesJoined, "|")
-				captchaStore.GeeTestCaptchaTokens[retailer] = append(captchaStore.GeeTestCaptchaTokens[retailer], &entities.GeeTestCaptchaToken{
+				addGeeTestCaptchaToken(captchaStore.GeeTestCaptchaTokens, retailer, &entities.GeeTestCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: entities.GeeTestCaptchaTokenValues{
@@ -726,7 +754,7 @@ func RequestGeeTestCaptchaToken(sitekey string, url string, challenge string, pr
 					Websiteurl:    url,
 					Gt:            sitekey,
 					Challenge:     challenge,
-					Proxytype:     "http",
+					Proxytype:     AntiCaptchaProxyType(proxy),
 					Proxyaddress:  proxy.Host,
 					Proxyport:     port,
 					Proxylogin:    proxy.Username,
@@ -744,7 +772,7 @@ func RequestGeeTestCaptchaToken(sitekey string, url string, challenge string, pr
 					return
 				}
 
-				captchaStore.GeeTestCaptchaTokens[retailer] = append(captchaStore.GeeTestCaptchaTokens[retailer], &entities.GeeTestCaptchaToken{
+				addGeeTestCaptchaToken(captchaStore.GeeTestCaptchaTokens, retailer, &entities.GeeTestCaptchaToken{
 					URL:   url,
 					Proxy: proxy,
 					Token: entities.GeeTestCaptchaTokenValues{
@@ -339,3 +339,20 @@ func ParseProxy(proxyStr string) (entities.Proxy, error) {
 
 	return proxy, nil
 }
+
+// ProxyType returns the type of the proxy that the captcha services expect, http if the proxy doesn't have a scheme
+func ProxyType(proxy entities.Proxy) string {
+	if proxy.Scheme == "" {
+		return enums.ProxySchemeHTTP
+	}
+	return proxy.Scheme
+}
+
+// AntiCaptchaProxyType returns the type of the proxy that AntiCaptcha and CapMonster tasks expect. They only take http,
+// socks4 and socks5 proxies, so an https proxy is given to them as http.
+func AntiCaptchaProxyType(proxy entities.Proxy) string {
+	if proxy.Scheme == enums.ProxySchemeHTTPS {
+		return enums.ProxySchemeHTTP
+	}
+	return ProxyType(proxy)
+}
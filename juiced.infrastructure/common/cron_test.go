@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expression := range []string{"* * * * *", "*/15 9-17 * * 1-5", "0 0 1,15 * *", "5/20 0 * 12 7"} {
+		if _, err := ParseCron(expression); err != nil {
+			t.Errorf("expected %q to parse, got %v", expression, err)
+		}
+	}
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := ParseCron(expression); err == nil {
+			t.Errorf("expected %q to be rejected", expression)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Friday the 15th of October 2021
+	after := time.Date(2021, 10, 15, 10, 7, 30, 0, time.UTC)
+	cases := []struct {
+		expression string
+		next       time.Time
+	}{
+		{"* * * * *", time.Date(2021, 10, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2021, 10, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2021, 10, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2021, 10, 18, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Sunday can be written as 7
+		{"0 12 * * 7", time.Date(2021, 10, 17, 12, 0, 0, 0, time.UTC)},
+		// A day matches if either the day of the month or the day of the week does
+		{"0 0 20 * 6", time.Date(2021, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, c := range cases {
+		cron, err := ParseCron(c.expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next := cron.Next(after); !next.Equal(c.next) {
+			t.Errorf("%q: expected %v, got %v", c.expression, c.next, next)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Values stored encrypted in the database are envelopes: envelopePrefix followed by the base64 of the version byte,
+// the key ID's length and the key ID, then the nonce and the AES-GCM ciphertext and tag. The version and key ID are authenticated along with the ciphertext. Values written before envelopes existed
+// are the hex output of Aes256Encrypt (AES-CBC), which DecryptField still reads so that they can be migrated.
+const (
+	envelopePrefix  = "$"
+	envelopeVersion = byte(1)
+)
+
+// KeyID returns the identifier of the key that's stored in its envelopes, which doesn't reveal the key
+func KeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+// IsEnvelope returns true if the value was written by EncryptField
+func IsEnvelope(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix)
+}
+
+// EncryptField encrypts the plaintext with the key and returns it as an envelope
+func EncryptField(plaintext string, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	keyID := KeyID(key)
+	headerLength := 2 + len(keyID)
+	envelope := make([]byte, headerLength+gcm.NonceSize(), headerLength+gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	envelope[0] = envelopeVersion
+	envelope[1] = byte(len(keyID))
+	copy(envelope[2:], keyID)
+	nonce := envelope[headerLength:]
+	if _, err := crand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	envelope = gcm.Seal(envelope, nonce, []byte(plaintext), envelope[:headerLength])
+	return envelopePrefix + base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// DecryptField decrypts a value written by EncryptField, or by Aes256Encrypt before envelopes existed.
+// Empty values decrypt to an empty string. stale is true if the value should be re-encrypted with EncryptField
+// and written back. Values that fail authentication or were encrypted with another key are an error.
+func DecryptField(value string, key string) (plaintext string, stale bool, err error) {
+	if value == "" {
+		return "", false, nil
+	}
+	if !IsEnvelope(value) {
+		plaintext, err = Aes256Decrypt(value, key)
+		if err != nil {
+			return "", false, err
+		}
+		return plaintext, true, nil
+	}
+
+	envelope, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, envelopePrefix))
+	if err != nil || len(envelope) < 2 {
+		return "", false, &MalformedEnvelopeError{}
+	}
+	if envelope[0] != envelopeVersion {
+		return "", false, &UnsupportedEnvelopeVersionError{Version: envelope[0]}
+	}
+	headerLength := 2 + int(envelope[1])
+	if len(envelope) < headerLength {
+		return "", false, &MalformedEnvelopeError{}
+	}
+	if keyID := string(envelope[2:headerLength]); keyID != KeyID(key) {
+		return "", false, &EncryptionKeyMismatchError{KeyID: keyID}
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", false, err
+	}
+	if len(envelope) < headerLength+gcm.NonceSize() {
+		return "", false, &MalformedEnvelopeError{}
+	}
+	nonce := envelope[headerLength : headerLength+gcm.NonceSize()]
+	bPlaintext, err := gcm.Open(nil, nonce, envelope[headerLength+gcm.NonceSize():], envelope[:headerLength])
+	if err != nil {
+		return "", false, err
+	}
+	return string(bPlaintext), false, nil
+}
+
+// NewKey returns a random key for EncryptField
+func NewKey() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := crand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bytes), nil
+}
+
+func newGCM(key string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// MaskSecret hides all but the last 4 characters of the secret, for example ****abcd
+func MaskSecret(secret string) string {
+	runes := []rune(secret)
+	if len(runes) == 0 {
+		return ""
+	}
+	if len(runes) <= 4 {
+		return "****"
+	}
+	return "****" + string(runes[len(runes)-4:])
+}
+
+// UnmaskSecret returns the current secret if the new one is just the masked current one sent back unchanged, otherwise the new one
+func UnmaskSecret(secret string, currentSecret string) string {
+	if secret != "" && secret == MaskSecret(currentSecret) {
+		return currentSecret
+	}
+	return secret
+}
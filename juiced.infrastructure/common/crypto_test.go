@@ -0,0 +1,103 @@
+package common
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+const (
+	testKey      = "0123456789abcdef0123456789abcdef"
+	otherTestKey = "fedcba9876543210fedcba9876543210"
+)
+
+func TestEncryptFieldRoundTrip(t *testing.T) {
+	for _, plaintext := range []string{"", "4111111111111111", "Jöhn Døe"} {
+		encrypted, err := EncryptField(plaintext, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(encrypted, envelopePrefix) {
+			t.Errorf("unexpected envelope %q for %q", encrypted, plaintext)
+		}
+		decrypted, stale, err := DecryptField(encrypted, testKey)
+		if err != nil || stale || decrypted != plaintext {
+			t.Errorf("expected %q, got %q (stale %v, err %v)", plaintext, decrypted, stale, err)
+		}
+	}
+
+	first, _ := EncryptField("same", testKey)
+	second, _ := EncryptField("same", testKey)
+	if first == second {
+		t.Error("expected a new nonce for each encryption")
+	}
+}
+
+func TestDecryptFieldMigratesLegacyValues(t *testing.T) {
+	legacy, err := Aes256Encrypt("4111111111111111", testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, stale, err := DecryptField(legacy, testKey)
+	if err != nil || !stale || decrypted != "4111111111111111" {
+		t.Errorf("expected the legacy value to decrypt and be stale, got %q (stale %v, err %v)", decrypted, stale, err)
+	}
+
+	// Values that were never encrypted aren't mistaken for legacy ones
+	_, _, err = DecryptField("4111111111111111", testKey)
+	if err == nil {
+		t.Error("expected a plaintext value to be reported")
+	}
+}
+
+func TestDecryptFieldRejectsTamperedValues(t *testing.T) {
+	encrypted, err := EncryptField("4111111111111111", testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, envelopePrefix))
+
+	tamper := func(change func(envelope []byte)) string {
+		tampered := append([]byte{}, envelope...)
+		change(tampered)
+		return envelopePrefix + base64.StdEncoding.EncodeToString(tampered)
+	}
+	cases := map[string]string{
+		"ciphertext": tamper(func(envelope []byte) { envelope[len(envelope)-1] ^= 1 }),
+		"version":    tamper(func(envelope []byte) { envelope[0] = 2 }),
+		"truncated":  envelopePrefix + base64.StdEncoding.EncodeToString(envelope[:12]),
+		"encoding":   envelopePrefix + "not base64",
+	}
+	for name, value := range cases {
+		_, _, err := DecryptField(value, testKey)
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, _, err = DecryptField(encrypted, otherTestKey)
+	if _, ok := err.(*EncryptionKeyMismatchError); !ok {
+		t.Errorf("expected an EncryptionKeyMismatchError, got %v", err)
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	cases := map[string]string{
+		"":                 "",
+		"abc":              "****",
+		"abcd":             "****",
+		"0123456789abcdef": "****cdef",
+	}
+	for secret, expected := range cases {
+		if masked := MaskSecret(secret); masked != expected {
+			t.Errorf("expected %q to be masked as %q, got %q", secret, expected, masked)
+		}
+	}
+
+	if secret := UnmaskSecret("****cdef", "0123456789abcdef"); secret != "0123456789abcdef" {
+		t.Errorf("expected a masked secret sent back unchanged to keep the current secret, got %q", secret)
+	}
+	if secret := UnmaskSecret("new secret", "0123456789abcdef"); secret != "new secret" {
+		t.Errorf("expected a new secret to replace the current one, got %q", secret)
+	}
+}
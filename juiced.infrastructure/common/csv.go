@@ -0,0 +1,149 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// profileColumn is a column of a profile CSV file, its key is the name of the Profile field that it holds
+type profileColumn struct {
+	key   string
+	field func(profile *entities.Profile) *string
+}
+
+// profileColumns are the columns of an exported profile CSV file, in order
+var profileColumns = []profileColumn{
+	{"name", func(p *entities.Profile) *string { return &p.Name }},
+	{"email", func(p *entities.Profile) *string { return &p.Email }},
+	{"phoneNumber", func(p *entities.Profile) *string { return &p.PhoneNumber }},
+	{"shippingAddress.firstName", func(p *entities.Profile) *string { return &p.ShippingAddress.FirstName }},
+	{"shippingAddress.lastName", func(p *entities.Profile) *string { return &p.ShippingAddress.LastName }},
+	{"shippingAddress.address1", func(p *entities.Profile) *string { return &p.ShippingAddress.Address1 }},
+	{"shippingAddress.address2", func(p *entities.Profile) *string { return &p.ShippingAddress.Address2 }},
+	{"shippingAddress.city", func(p *entities.Profile) *string { return &p.ShippingAddress.City }},
+	{"shippingAddress.zipCode", func(p *entities.Profile) *string { return &p.ShippingAddress.ZipCode }},
+	{"shippingAddress.stateCode", func(p *entities.Profile) *string { return &p.ShippingAddress.StateCode }},
+	{"shippingAddress.countryCode", func(p *entities.Profile) *string { return &p.ShippingAddress.CountryCode }},
+	{"billingAddress.firstName", func(p *entities.Profile) *string { return &p.BillingAddress.FirstName }},
+	{"billingAddress.lastName", func(p *entities.Profile) *string { return &p.BillingAddress.LastName }},
+	{"billingAddress.address1", func(p *entities.Profile) *string { return &p.BillingAddress.Address1 }},
+	{"billingAddress.address2", func(p *entities.Profile) *string { return &p.BillingAddress.Address2 }},
+	{"billingAddress.city", func(p *entities.Profile) *string { return &p.BillingAddress.City }},
+	{"billingAddress.zipCode", func(p *entities.Profile) *string { return &p.BillingAddress.ZipCode }},
+	{"billingAddress.stateCode", func(p *entities.Profile) *string { return &p.BillingAddress.StateCode }},
+	{"billingAddress.countryCode", func(p *entities.Profile) *string { return &p.BillingAddress.CountryCode }},
+	{"creditCard.cardHolderName", func(p *entities.Profile) *string { return &p.CreditCard.CardholderName }},
+	{"creditCard.cardNumber", func(p *entities.Profile) *string { return &p.CreditCard.CardNumber }},
+	{"creditCard.expMonth", func(p *entities.Profile) *string { return &p.CreditCard.ExpMonth }},
+	{"creditCard.expYear", func(p *entities.Profile) *string { return &p.CreditCard.ExpYear }},
+	{"creditCard.cvv", func(p *entities.Profile) *string { return &p.CreditCard.CVV }},
+}
+
+// billingSameAsShippingColumn is an import-only column, a truthy value copies the shipping address to the billing address
+const billingSameAsShippingColumn = "billingSameAsShipping"
+
+// ParseProfilesCSV returns the Profiles in a CSV file, one for each record after the header. The mapping maps the
+// Profile fields (the keys of the exported columns, like "shippingAddress.firstName") to the CSV file's column names.
+// Fields that aren't in the mapping are read from the column with the same name, ignoring case, spaces and punctuation,
+// and columns that don't match a field are ignored.
+func ParseProfilesCSV(data []byte, mapping map[string]string) ([]entities.Profile, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+
+	headerIndexes := make(map[string]int)
+	for i, header := range records[0] {
+		if _, ok := headerIndexes[csvColumnName(header)]; !ok {
+			headerIndexes[csvColumnName(header)] = i
+		}
+	}
+
+	keys := []string{billingSameAsShippingColumn}
+	for _, column := range profileColumns {
+		keys = append(keys, column.key)
+	}
+	indexes := make(map[string]int)
+	for _, key := range keys {
+		if index, ok := headerIndexes[csvColumnName(key)]; ok {
+			indexes[key] = index
+		}
+	}
+	for key, header := range mapping {
+		if !InSlice(keys, key) {
+			return nil, fmt.Errorf("%q isn't a profile field", key)
+		}
+		index, ok := headerIndexes[csvColumnName(header)]
+		if !ok {
+			return nil, fmt.Errorf("the file doesn't have a %q column for %s", header, key)
+		}
+		indexes[key] = index
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("none of the file's columns match a profile field")
+	}
+
+	value := func(record []string, key string) string {
+		if index, ok := indexes[key]; ok && index < len(record) {
+			return record[index]
+		}
+		return ""
+	}
+	profiles := make([]entities.Profile, 0, len(records)-1)
+	for _, record := range records[1:] {
+		profile := entities.Profile{}
+		for _, column := range profileColumns {
+			*column.field(&profile) = value(record, column.key)
+		}
+		switch strings.ToLower(strings.TrimSpace(value(record, billingSameAsShippingColumn))) {
+		case "true", "yes", "y", "1", "x":
+			profile.BillingAddress = profile.ShippingAddress
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// ProfilesToCSV returns a CSV file with a header and a record for each Profile, which ParseProfilesCSV can read back
+func ProfilesToCSV(profiles []entities.Profile) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	header := make([]string, len(profileColumns))
+	for i, column := range profileColumns {
+		header[i] = column.key
+	}
+	writer.Write(header)
+	for i := range profiles {
+		record := make([]string, len(profileColumns))
+		for j, column := range profileColumns {
+			record[j] = *column.field(&profiles[i])
+		}
+		writer.Write(record)
+	}
+
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// csvColumnName returns the column name lower-cased and without spaces or punctuation, so that "Shipping Address First Name",
+// "shipping_address_first_name" and "shippingAddress.firstName" are the same column
+func csvColumnName(name string) string {
+	return strings.Map(func(char rune) rune {
+		if unicode.IsLetter(char) || unicode.IsDigit(char) {
+			return unicode.ToLower(char)
+		}
+		return -1
+	}, name)
+}
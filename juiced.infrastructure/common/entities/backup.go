@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"encoding/json"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// BackupVersion is the version of the Backup format that's written, Backups up to this version can be restored
+const BackupVersion = 1
+
+// Backup is a snapshot of the whole workspace. A TaskGroup's Schedules are restored with it, and Accounts are kept
+// separately from the Settings. Task history and the user's license aren't backed up, they belong to the machine.
+type Backup struct {
+	Version       int            `json:"version"`
+	CreationDate  int64          `json:"creationDate"`
+	Settings      Settings       `json:"settings"`
+	Accounts      []Account      `json:"accounts"`
+	ProfileGroups []ProfileGroup `json:"profileGroups"`
+	Profiles      []Profile      `json:"profiles"`
+	ProxyGroups   []ProxyGroup   `json:"proxyGroups"`
+	TaskGroups    []TaskGroup    `json:"taskGroups"`
+	Tasks         []Task         `json:"tasks"`
+	Checkouts     []Checkout     `json:"checkouts"`
+}
+
+// RestoreRecordKind is the kind of record in a RestoreRecord
+type RestoreRecordKind = string
+
+const (
+	SettingsRecord          RestoreRecordKind = "settings"
+	AccountRecord           RestoreRecordKind = "account"
+	ProfileGroupRecord      RestoreRecordKind = "profileGroup"
+	ProfileRecord           RestoreRecordKind = "profile"
+	ProxyGroupRecord        RestoreRecordKind = "proxyGroup"
+	TaskGroupRecord         RestoreRecordKind = "taskGroup"
+	TaskRecord              RestoreRecordKind = "task"
+	TaskGroupScheduleRecord RestoreRecordKind = "taskGroupSchedule"
+	CheckoutRecord          RestoreRecordKind = "checkout"
+)
+
+// RestoreRecord is a record from a Backup that wasn't restored as it was, and why
+type RestoreRecord struct {
+	Kind RestoreRecordKind `json:"kind"`
+	ID   string            `json:"ID"`
+	Name string            `json:"name,omitempty"`
+	// NewID is the ID of the existing record that's used instead of it
+	NewID  string `json:"newID,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// RestoreReport describes what restoring a Backup did. Restored counts the records restored of each kind, and
+// RemappedIDs maps the IDs in the Backup to the new IDs they were restored with. Conflicts are records that clashed with
+// an existing record or referenced one that wasn't restored, with how that was resolved, and Skipped records weren't restored.
+type RestoreReport struct {
+	Mode        enums.RestoreMode         `json:"mode"`
+	Restored    map[RestoreRecordKind]int `json:"restored"`
+	RemappedIDs map[string]string         `json:"remappedIDs"`
+	Conflicts   []RestoreRecord           `json:"conflicts"`
+	Skipped     []RestoreRecord           `json:"skipped"`
+}
+
+// ParseBackup returns a Backup object parsed from a JSON bytes array
+func ParseBackup(backup *Backup, data []byte) error {
+	err := json.Unmarshal(data, &backup)
+	return err
+}
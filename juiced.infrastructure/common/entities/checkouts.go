@@ -6,13 +6,38 @@ import (
 
 // Checkout contains all the info that is stored in the local db
 type Checkout struct {
-	ItemName     string         `json:"itemName" db:"itemName"`
-	ImageURL     string         `json:"imageURL" db:"imageURL"`
-	SKU          string         `json:"sku" db:"sku"`
-	Price        int            `json:"price" db:"price"`
-	Quantity     int            `json:"quantity" db:"quantity"`
-	Retailer     enums.Retailer `json:"retailer" db:"retailer"`
-	ProfileName  string         `json:"profileName" db:"profileName"`
-	MsToCheckout int64          `json:"msToCheckout" db:"msToCheckout"`
-	Time         int64          `json:"time" db:"time"`
+	ItemName     string            `json:"itemName" db:"itemName"`
+	ImageURL     string            `json:"imageURL" db:"imageURL"`
+	SKU          string            `json:"sku" db:"sku"`
+	Price        int               `json:"price" db:"price"`
+	Quantity     int               `json:"quantity" db:"quantity"`
+	Retailer     enums.Retailer    `json:"retailer" db:"retailer"`
+	ProfileName  string            `json:"profileName" db:"profileName"`
+	MsToCheckout int64             `json:"msToCheckout" db:"msToCheckout"`
+	Time         int64             `json:"time" db:"time"`
+	Status       enums.OrderStatus `json:"status" db:"status"`
+}
+
+// CheckoutFilter narrows down the Checkouts a query returns, fields left empty don't filter anything
+type CheckoutFilter struct {
+	Retailer    enums.Retailer
+	ProfileName string
+	SKU         string
+	Status      enums.OrderStatus
+	// From and To are inclusive Unix times, 0 leaves that end of the range open
+	From int64
+	To   int64
+}
+
+// CheckoutStats aggregates the Checkouts that share a Key, which depends on how they were grouped
+type CheckoutStats struct {
+	Key             string `json:"key" db:"key"`
+	Orders          int    `json:"orders" db:"orders"`
+	Successes       int    `json:"successes" db:"successes"`
+	Declines        int    `json:"declines" db:"declines"`
+	Failures        int    `json:"failures" db:"failures"`
+	TotalSpend      int    `json:"totalSpend" db:"totalSpend"`
+	Units           int    `json:"units" db:"units"`
+	P50MsToCheckout int64  `json:"p50MsToCheckout"`
+	P90MsToCheckout int64  `json:"p90MsToCheckout"`
 }
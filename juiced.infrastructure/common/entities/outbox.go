@@ -0,0 +1,18 @@
+package entities
+
+import "backend.juicedbot.io/juiced.infrastructure/common/enums"
+
+// OutboxEntry is a notification waiting to be delivered to its Destination, which is a Discord webhook in the Settings
+// or the ID of a NotificationSink. Entries are delivered oldest first per Destination and retried until they succeed
+// or are dead-lettered. Times are Unix timestamps in seconds.
+type OutboxEntry struct {
+	ID           string                  `json:"ID" db:"ID"`
+	Destination  string                  `json:"destination" db:"destination"`
+	Event        enums.NotificationEvent `json:"event" db:"event"`
+	Payload      string                  `json:"-" db:"payload"`
+	Status       enums.OutboxStatus      `json:"status" db:"status"`
+	Attempts     int                     `json:"attempts" db:"attempts"`
+	NextAttempt  int64                   `json:"nextAttempt" db:"nextAttempt"`
+	LastError    string                  `json:"lastError" db:"lastError"`
+	CreationDate int64                   `json:"creationDate" db:"creationDate"`
+}
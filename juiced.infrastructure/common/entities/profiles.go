@@ -98,6 +98,16 @@ func ParseProfile(profile *Profile, data []byte) error {
 	return err
 }
 
+// ProfileImportResult is the result of importing one of the Profiles in an import file. Row is the Profile's position in
+// the file, counting from 1 (for CSV files, the first record after the header is row 1).
+type ProfileImportResult struct {
+	Row       int      `json:"row"`
+	Name      string   `json:"name"`
+	Imported  bool     `json:"imported"`
+	ProfileID string   `json:"profileID,omitempty"`
+	Errors    []string `json:"errors"`
+}
+
 // ProfileGroupWithProfiles is a class that holds a list of Profiles
 type ProfileGroupWithProfiles struct {
 	GroupID  string    `json:"groupID" db:"groupID"`
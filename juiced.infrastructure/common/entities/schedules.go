@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"encoding/json"
+)
+
+// TaskGroupSchedule starts a TaskGroup once at StartTime, or every time the Cron expression matches, and stops it
+// StopAfterMinutes after it starts or at StopTime. Times are Unix timestamps in seconds, 0 if not set.
+type TaskGroupSchedule struct {
+	ID               string `json:"ID" db:"ID"`
+	TaskGroupID      string `json:"taskGroupID" db:"taskGroupID"`
+	StartTime        int64  `json:"startTime" db:"startTime"`
+	Cron             string `json:"cron" db:"cron"`
+	StopAfterMinutes int    `json:"stopAfterMinutes" db:"stopAfterMinutes"`
+	StopTime         int64  `json:"stopTime" db:"stopTime"`
+	Enabled          bool   `json:"enabled" db:"enabled"`
+	NextStartTime    int64  `json:"nextStartTime" db:"nextStartTime"`
+	NextStopTime     int64  `json:"nextStopTime" db:"nextStopTime"`
+	LastFiredTime    int64  `json:"lastFiredTime" db:"lastFiredTime"`
+	CreationDate     int64  `json:"creationDate" db:"creationDate"`
+}
+
+// ParseTaskGroupSchedule returns a TaskGroupSchedule object parsed from a JSON bytes array
+func ParseTaskGroupSchedule(schedule *TaskGroupSchedule, data []byte) error {
+	err := json.Unmarshal(data, &schedule)
+	return err
+}
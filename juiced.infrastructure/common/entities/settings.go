@@ -6,29 +6,83 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 )
 
+// DefaultRemoteAccessPort is the port the API is served on over TLS when remote access is enabled and Settings.RemoteAccessPort isn't set
+const DefaultRemoteAccessPort = 10443
+
 // Settings is a class that holds details about a user's settings
 type Settings struct {
-	ID                    int       `json:"id" db:"id"`
-	SuccessDiscordWebhook string    `json:"successDiscordWebhook" db:"successDiscordWebhook"`
-	FailureDiscordWebhook string    `json:"failureDiscordWebhook" db:"failureDiscordWebhook"`
-	TwoCaptchaAPIKey      string    `json:"twoCaptchaAPIKey" db:"twoCaptchaAPIKey"`
-	AntiCaptchaAPIKey     string    `json:"antiCaptchaAPIKey" db:"antiCaptchaAPIKey"`
-	CapMonsterAPIKey      string    `json:"capMonsterAPIKey" db:"capMonsterAPIKey"`
-	AYCDAccessToken       string    `json:"aycdAccessToken" db:"aycdAccessToken"`
-	AYCDAPIKey            string    `json:"aycdAPIKey" db:"aycdAPIKey"`
-	DarkModeUpdate        bool      `json:"darkModeUpdate"`
-	DarkMode              bool      `json:"darkMode" db:"darkMode"`
-	UseAnimationsUpdate   bool      `json:"useAnimationsUpdate"`
-	UseAnimations         bool      `json:"useAnimations" db:"useAnimations"`
-	Accounts              []Account `json:"accounts"`
+	ID                       int                `json:"id" db:"id"`
+	SuccessDiscordWebhook    string             `json:"successDiscordWebhook" db:"successDiscordWebhook"`
+	FailureDiscordWebhook    string             `json:"failureDiscordWebhook" db:"failureDiscordWebhook"`
+	TwoCaptchaAPIKey         string             `json:"twoCaptchaAPIKey" db:"twoCaptchaAPIKey"`
+	AntiCaptchaAPIKey        string             `json:"antiCaptchaAPIKey" db:"antiCaptchaAPIKey"`
+	CapMonsterAPIKey         string             `json:"capMonsterAPIKey" db:"capMonsterAPIKey"`
+	AYCDAccessToken          string             `json:"aycdAccessToken" db:"aycdAccessToken"`
+	AYCDAPIKey               string             `json:"aycdAPIKey" db:"aycdAPIKey"`
+	DarkModeUpdate           bool               `json:"darkModeUpdate"`
+	DarkMode                 bool               `json:"darkMode" db:"darkMode"`
+	UseAnimationsUpdate      bool               `json:"useAnimationsUpdate"`
+	UseAnimations            bool               `json:"useAnimations" db:"useAnimations"`
+	TaskHistoryUpdate        bool               `json:"taskHistoryUpdate"`
+	TaskHistoryRetentionDays int                `json:"taskHistoryRetentionDays" db:"taskHistoryRetentionDays"`
+	TaskHistoryMaxRuns       int                `json:"taskHistoryMaxRuns" db:"taskHistoryMaxRuns"`
+	RemoteAccessUpdate       bool               `json:"remoteAccessUpdate"`
+	RemoteAccessEnabled      bool               `json:"remoteAccessEnabled" db:"remoteAccessEnabled"`
+	RemoteAccessPort         int                `json:"remoteAccessPort" db:"remoteAccessPort"`
+	RemoteAccessToken        string             `json:"remoteAccessToken" db:"remoteAccessToken"`
+	NotificationSinksUpdate  bool               `json:"notificationSinksUpdate"`
+	NotificationSinks        []NotificationSink `json:"notificationSinks"`
+	NotificationSinksJoined  string             `json:"-" db:"notificationSinks"`
+	Accounts                 []Account          `json:"accounts"`
+}
+
+// NotificationSink is somewhere that notifications are sent, it only receives the events that one of its Rules matches.
+// Which of the other fields it uses depends on its Type.
+type NotificationSink struct {
+	ID      string                     `json:"ID"`
+	Name    string                     `json:"name"`
+	Type    enums.NotificationSinkType `json:"type"`
+	Enabled bool                       `json:"enabled"`
+	Rules   []NotificationRule         `json:"rules"`
+	// URL is where webhook and slack sinks post to
+	URL string `json:"url,omitempty"`
+	// BotToken is the Telegram bot that telegram sinks send as, to the chat with ChatID
+	BotToken string `json:"botToken,omitempty"`
+	ChatID   string `json:"chatID,omitempty"`
+	// SMTPHost is the server that smtp sinks send their emails through, from EmailFrom to every address in EmailTo
+	SMTPHost     string   `json:"smtpHost,omitempty"`
+	SMTPPort     int      `json:"smtpPort,omitempty"`
+	SMTPUsername string   `json:"smtpUsername,omitempty"`
+	SMTPPassword string   `json:"smtpPassword,omitempty"`
+	EmailFrom    string   `json:"emailFrom,omitempty"`
+	EmailTo      []string `json:"emailTo,omitempty"`
 }
 
+// NotificationRule sends a NotificationSink the notifications for the Event from the Retailers, or from every retailer
+// if it doesn't have any. The Template is a text/template that renders the notification, the event's default is used
+// if it's empty.
+type NotificationRule struct {
+	Event     enums.NotificationEvent `json:"event"`
+	Retailers []enums.Retailer        `json:"retailers,omitempty"`
+	Template  string                  `json:"template,omitempty"`
+}
+
+// Account is a retailer account that Tasks log in with. Status is how its last login went, at StatusDate, and TaskID
+// is the running Task that it's allocated to, if it's in use.
 type Account struct {
-	ID           string         `json:"ID" db:"ID"`
-	Retailer     enums.Retailer `json:"retailer" db:"retailer"`
-	Email        string         `json:"email" db:"email"`
-	Password     string         `json:"password" db:"password"`
-	CreationDate int64          `json:"creationDate" db:"creationDate"`
+	ID           string              `json:"ID" db:"ID"`
+	Retailer     enums.Retailer      `json:"retailer" db:"retailer"`
+	Email        string              `json:"email" db:"email"`
+	Password     string              `json:"password" db:"password"`
+	Status       enums.AccountStatus `json:"status" db:"status"`
+	StatusDate   int64               `json:"statusDate" db:"statusDate"`
+	TaskID       string              `json:"taskID,omitempty" db:"-"`
+	CreationDate int64               `json:"creationDate" db:"creationDate"`
+}
+
+// Usable returns false if the last login with the Account showed that it's locked or its credentials are wrong
+func (account Account) Usable() bool {
+	return account.Status != enums.AccountStatusLocked && account.Status != enums.AccountStatusBadCredentials
 }
 
 // ParseSettings returns a Settings object parsed from a JSON bytes array
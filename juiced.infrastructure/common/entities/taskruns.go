@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// TaskRunKind is whether a TaskRun belongs to a Task or to a TaskGroup's Monitor
+type TaskRunKind = string
+
+const (
+	TaskRunKindTask    TaskRunKind = "task"
+	TaskRunKindMonitor TaskRunKind = "monitor"
+)
+
+// DefaultTaskHistoryRetentionDays is how long task run history is kept for when Settings.TaskHistoryRetentionDays isn't set
+const DefaultTaskHistoryRetentionDays = 7
+
+// DefaultTaskHistoryMaxRuns is how many runs of each Task or Monitor are kept when Settings.TaskHistoryMaxRuns isn't set
+const DefaultTaskHistoryMaxRuns = 50
+
+// TaskRun is a single run of a Task or Monitor, from the first status it published to the latest one
+type TaskRun struct {
+	RunID            string         `json:"runID" db:"runID"`
+	Kind             TaskRunKind    `json:"kind" db:"kind"`
+	TaskID           string         `json:"taskID" db:"taskID"`
+	TaskGroupID      string         `json:"taskGroupID" db:"taskGroupID"`
+	Retailer         enums.Retailer `json:"retailer" db:"retailer"`
+	ProxyID          string         `json:"proxyID" db:"proxyID"`
+	Status           string         `json:"status" db:"status"`
+	StatusPercentage int            `json:"statusPercentage" db:"statusPercentage"`
+	EventType        string         `json:"eventType" db:"eventType"`
+	StartTime        int64          `json:"startTime" db:"startTime"`
+	UpdateTime       int64          `json:"updateTime" db:"updateTime"`
+	Events           []TaskRunEvent `json:"events"`
+}
+
+// TaskRunEvent is a status transition published during a TaskRun
+type TaskRunEvent struct {
+	RunID            string      `json:"runID" db:"runID"`
+	Kind             TaskRunKind `json:"kind" db:"kind"`
+	TaskID           string      `json:"taskID" db:"taskID"`
+	TaskGroupID      string      `json:"taskGroupID" db:"taskGroupID"`
+	ProxyID          string      `json:"proxyID" db:"proxyID"`
+	Status           string      `json:"status" db:"status"`
+	StatusPercentage int         `json:"statusPercentage" db:"statusPercentage"`
+	EventType        string      `json:"eventType" db:"eventType"`
+	Time             int64       `json:"time" db:"time"`
+}
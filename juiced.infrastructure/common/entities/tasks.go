@@ -2,22 +2,29 @@ package entities
 
 import (
 	"encoding/json"
+	"strings"
+	"sync"
+	"time"
 
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"github.com/google/uuid"
 )
 
-// Task is a class that holds details about a single bot task
+// Task is a class that holds details about a single bot task. If it has a TaskAccountID, it logs in with that Account,
+// or with any free Account for its retailer if it's enums.AnyAccount, instead of the email and password in its TaskInfo.
 type Task struct {
-	ID                    string           `json:"ID" db:"ID"`
-	TaskGroupID           string           `json:"taskGroupID" db:"taskGroupID"`
-	TaskProfileID         string           `json:"profileID" db:"profileID"`
-	TaskProxyGroupID      string           `json:"proxyGroupID" db:"proxyGroupID"`
-	TaskRetailer          enums.Retailer   `json:"retailer" db:"retailer"`
-	TaskSize              []string         `json:"size"`
-	TaskSizeJoined        string           `json:"sizeJoined" db:"sizeJoined"`
-	TaskQty               int              `json:"qty" db:"qty"`
-	TaskStatus            enums.TaskStatus `json:"status" db:"status"`
-	TaskDelay             int              `json:"taskDelay" db:"taskDelay"`
+	ID                    string             `json:"ID" db:"ID"`
+	TaskGroupID           string             `json:"taskGroupID" db:"taskGroupID"`
+	TaskProfileID         string             `json:"profileID" db:"profileID"`
+	TaskProxyGroupID      string             `json:"proxyGroupID" db:"proxyGroupID"`
+	TaskAccountID         string             `json:"accountID" db:"accountID"`
+	TaskRetailer          enums.Retailer     `json:"retailer" db:"retailer"`
+	TaskSize              []string           `json:"size"`
+	TaskSizeJoined        string             `json:"sizeJoined" db:"sizeJoined"`
+	TaskSizeFallback      enums.SizeFallback `json:"sizeFallback" db:"sizeFallback"`
+	TaskQty               int                `json:"qty" db:"qty"`
+	TaskStatus            enums.TaskStatus   `json:"status" db:"status"`
+	TaskDelay             int                `json:"taskDelay" db:"taskDelay"`
 	UpdateTask            bool
 	CreationDate          int64                  `json:"creationDate" db:"creationDate"`
 	AmazonTaskInfo        *AmazonTaskInfo        `json:"amazonTaskInfo,omitempty"`
@@ -142,17 +149,156 @@ func (task *Task) SetTaskProfileID(TaskProfileID string) {
 	task.TaskProfileID = TaskProfileID
 }
 
+// statusMutex guards the TaskStatus of running Tasks and the MonitorStatus of running TaskGroups, which their sitescripts
+// update while the stores read them. It's shared so that copying a Task or TaskGroup doesn't copy a lock.
+var statusMutex sync.RWMutex
+
 // SetTaskStatus updates the Tasks's TaskStatus
 func (task *Task) SetTaskStatus(TaskStatus enums.TaskStatus) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
 	task.TaskStatus = TaskStatus
 }
 
+// GetTaskStatus returns the Task's TaskStatus, it's safe to call while the Task is running
+func (task *Task) GetTaskStatus() enums.TaskStatus {
+	statusMutex.RLock()
+	defer statusMutex.RUnlock()
+	return task.TaskStatus
+}
+
+// SetAccount sets the email and password in the Task's TaskInfo to the Account's. The TaskInfo is copied first, so
+// that other copies of the Task keep their own.
+func (task *Task) SetAccount(account Account) {
+	switch task.TaskRetailer {
+	case enums.Amazon:
+		if task.AmazonTaskInfo != nil {
+			taskInfo := *task.AmazonTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.AmazonTaskInfo = &taskInfo
+		}
+	case enums.BestBuy:
+		if task.BestbuyTaskInfo != nil {
+			taskInfo := *task.BestbuyTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.BestbuyTaskInfo = &taskInfo
+		}
+	case enums.Disney:
+		if task.DisneyTaskInfo != nil {
+			taskInfo := *task.DisneyTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.DisneyTaskInfo = &taskInfo
+		}
+	case enums.GameStop:
+		if task.GamestopTaskInfo != nil {
+			taskInfo := *task.GamestopTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.GamestopTaskInfo = &taskInfo
+		}
+	case enums.PokemonCenter:
+		if task.PokemonCenterTaskInfo != nil {
+			taskInfo := *task.PokemonCenterTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.PokemonCenterTaskInfo = &taskInfo
+		}
+	case enums.Shopify:
+		if task.ShopifyTaskInfo != nil && task.ShopifyTaskInfo.HotWheelsTaskInfo != nil {
+			taskInfo := *task.ShopifyTaskInfo
+			hotWheelsTaskInfo := *taskInfo.HotWheelsTaskInfo
+			hotWheelsTaskInfo.Email, hotWheelsTaskInfo.Password = account.Email, account.Password
+			taskInfo.HotWheelsTaskInfo = &hotWheelsTaskInfo
+			task.ShopifyTaskInfo = &taskInfo
+		}
+	case enums.Target:
+		if task.TargetTaskInfo != nil {
+			taskInfo := *task.TargetTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.TargetTaskInfo = &taskInfo
+		}
+	case enums.Topps:
+		if task.ToppsTaskInfo != nil {
+			taskInfo := *task.ToppsTaskInfo
+			taskInfo.Email, taskInfo.Password = account.Email, account.Password
+			task.ToppsTaskInfo = &taskInfo
+		}
+	}
+}
+
 // ParseTask returns a Task object parsed from a JSON bytes array
 func ParseTask(task *Task, data []byte) error {
 	err := json.Unmarshal(data, &task)
 	return err
 }
 
+// MaxGeneratedTasks is the most Tasks that a TaskGenerator can generate at once
+const MaxGeneratedTasks = 1000
+
+// TaskGenerator describes Tasks to generate from a template Task for the Profiles in a ProfileGroup. It generates Count
+// Tasks, using the Profiles in turn, or one Task for each Profile if OnePerProfile is set. When it has Sizes, each Task
+// gets one of them, in turn for Count Tasks or one Task for each Profile and size with OnePerProfile.
+type TaskGenerator struct {
+	Template       Task     `json:"template"`
+	ProfileGroupID string   `json:"profileGroupID"`
+	ProxyGroupID   string   `json:"proxyGroupID"`
+	Count          int      `json:"count"`
+	OnePerProfile  bool     `json:"onePerProfile"`
+	Sizes          []string `json:"sizes"`
+}
+
+// NumTasks returns the number of Tasks that the TaskGenerator generates for the given number of Profiles
+func (generator TaskGenerator) NumTasks(numProfiles int) int {
+	if !generator.OnePerProfile {
+		return generator.Count
+	}
+	if len(generator.Sizes) > 0 {
+		return numProfiles * len(generator.Sizes)
+	}
+	return numProfiles
+}
+
+// Generate returns the Tasks for the TaskGroup with the given groupID and the given Profiles, each a copy of the
+// template with a new ID
+func (generator TaskGenerator) Generate(groupID string, profileIDs []string) []Task {
+	numTasks := generator.NumTasks(len(profileIDs))
+	if len(profileIDs) == 0 || numTasks <= 0 {
+		return []Task{}
+	}
+
+	tasks := make([]Task, numTasks)
+	creationDate := time.Now().Unix()
+	for i := range tasks {
+		task := generator.Template
+		task.ID = uuid.New().String()
+		task.TaskGroupID = groupID
+		task.TaskStatus = enums.TaskIdle
+		task.UpdateTask = false
+		task.CreationDate = creationDate
+		if generator.ProxyGroupID != "" {
+			task.TaskProxyGroupID = generator.ProxyGroupID
+		}
+
+		profileIndex, sizeIndex := i%len(profileIDs), i
+		if generator.OnePerProfile && len(generator.Sizes) > 0 {
+			profileIndex, sizeIndex = i/len(generator.Sizes), i%len(generator.Sizes)
+		}
+		task.TaskProfileID = profileIDs[profileIndex]
+		if len(generator.Sizes) > 0 {
+			task.TaskSize = []string{generator.Sizes[sizeIndex%len(generator.Sizes)]}
+		} else {
+			task.TaskSize = append([]string{}, generator.Template.TaskSize...)
+		}
+		task.TaskSizeJoined = strings.Join(task.TaskSize, ",")
+		tasks[i] = task
+	}
+	return tasks
+}
+
+// ParseTaskGenerator returns a TaskGenerator object parsed from a JSON bytes array
+func ParseTaskGenerator(generator *TaskGenerator, data []byte) error {
+	err := json.Unmarshal(data, &generator)
+	return err
+}
+
 // TaskGroupWithTasks is a class that holds a list of Tasks and a Monitor
 type TaskGroupWithTasks struct {
 	GroupID                  string                    `json:"groupID" db:"groupID"`
@@ -162,6 +308,7 @@ type TaskGroupWithTasks struct {
 	MonitorDelay             int                       `json:"delay" db:"delay"`
 	MonitorStatus            enums.MonitorStatus       `json:"status" db:"status"`
 	Tasks                    []Task                    `json:"tasks"`
+	Schedules                []TaskGroupSchedule       `json:"schedules"`
 	AmazonMonitorInfo        *AmazonMonitorInfo        `json:"amazonMonitorInfo,omitempty"`
 	BestbuyMonitorInfo       *BestbuyMonitorInfo       `json:"bestbuyMonitorInfo,omitempty"`
 	BoxlunchMonitorInfo      *BoxlunchMonitorInfo      `json:"boxlunchMonitorInfo,omitempty"`
@@ -196,6 +343,7 @@ type TaskGroup struct {
 	TaskIDsJoined            string              `json:"taskIDsJoined" db:"taskIDsJoined"`
 	UpdateMonitor            bool
 	CreationDate             int64                     `json:"creationDate" db:"creationDate"`
+	Schedules                []TaskGroupSchedule       `json:"schedules"`
 	AmazonMonitorInfo        *AmazonMonitorInfo        `json:"amazonMonitorInfo,omitempty"`
 	BestbuyMonitorInfo       *BestbuyMonitorInfo       `json:"bestbuyMonitorInfo,omitempty"`
 	BoxlunchMonitorInfo      *BoxlunchMonitorInfo      `json:"boxlunchMonitorInfo,omitempty"`
@@ -325,11 +473,16 @@ type PokemonCenterMonitorInfo struct {
 	Monitors    []PokemonCenterSingleMonitorInfo `json:"monitors"`
 }
 
+// ShopifySingleMonitorInfo watches a single variant ID, or for a KEYWORD_MONITOR the variants of every product whose title
+// matches its keywords (like "+dunk,-kids") in one of its sizes (comma-separated, empty for every size)
 type ShopifySingleMonitorInfo struct {
-	MonitorID   string `json:"monitorID" db:"monitorID"`
-	TaskGroupID string `json:"taskGroupID" db:"taskGroupID"`
-	VariantID   string `json:"variantID" db:"variantID"`
-	MaxPrice    int    `json:"maxPrice" db:"maxPrice"`
+	MonitorID   string            `json:"monitorID" db:"monitorID"`
+	TaskGroupID string            `json:"taskGroupID" db:"taskGroupID"`
+	VariantID   string            `json:"variantID" db:"variantID"`
+	Keywords    string            `json:"keywords" db:"keywords"`
+	Size        string            `json:"size" db:"size"`
+	MaxPrice    int               `json:"maxPrice" db:"maxPrice"`
+	MonitorType enums.MonitorType `json:"monitorType" db:"monitorType"`
 }
 
 type ShopifyMonitorInfo struct {
@@ -340,10 +493,14 @@ type ShopifyMonitorInfo struct {
 	Monitors     []ShopifySingleMonitorInfo `json:"monitors"`
 }
 
+// TargetSingleMonitorInfo watches a TCIN, a product page URL for a URL_MONITOR or the search results for keywords
+// (like "+pokemon,-plush") for a KEYWORD_MONITOR, depending on its TargetMonitorInfo's MonitorType
 type TargetSingleMonitorInfo struct {
 	MonitorID    string             `json:"monitorID" db:"monitorID"`
 	TaskGroupID  string             `json:"taskGroupID" db:"taskGroupID"`
 	TCIN         string             `json:"tcin" db:"tcin"`
+	URL          string             `json:"url" db:"url"`
+	Keywords     string             `json:"keywords" db:"keywords"`
 	MaxPrice     int                `json:"maxPrice" db:"maxPrice"`
 	CheckoutType enums.CheckoutType `json:"checkoutType" db:"checkoutType"`
 }
@@ -416,9 +573,18 @@ func (taskGroup *TaskGroup) SetMonitorRetailer(MonitorRetailer enums.Retailer) {
 
 // SetMonitorStatus updates the TaskGroup's MonitorStatus
 func (taskGroup *TaskGroup) SetMonitorStatus(MonitorStatus enums.MonitorStatus) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
 	taskGroup.MonitorStatus = MonitorStatus
 }
 
+// GetMonitorStatus returns the TaskGroup's MonitorStatus, it's safe to call while its Monitor is running
+func (taskGroup *TaskGroup) GetMonitorStatus() enums.MonitorStatus {
+	statusMutex.RLock()
+	defer statusMutex.RUnlock()
+	return taskGroup.MonitorStatus
+}
+
 // ParseTaskGroup returns a TaskGroup object parsed from a JSON bytes array
 func ParseTaskGroup(taskGroup *TaskGroup, data []byte) error {
 	err := json.Unmarshal(data, &taskGroup)
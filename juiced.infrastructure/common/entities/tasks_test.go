@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+func TestTaskGeneratorGenerate(t *testing.T) {
+	template := Task{TaskRetailer: enums.Shopify, TaskSize: []string{"9"}, TaskProxyGroupID: "template", ShopifyTaskInfo: &ShopifyTaskInfo{}}
+	profileIDs := []string{"a", "b"}
+
+	tasks := TaskGenerator{Template: template, Count: 3, ProxyGroupID: "proxies"}.Generate("group", profileIDs)
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	IDs := map[string]bool{}
+	for i, task := range tasks {
+		IDs[task.ID] = true
+		if task.TaskGroupID != "group" || task.TaskProxyGroupID != "proxies" || task.TaskStatus != enums.TaskIdle {
+			t.Errorf("expected the task to be in the group with the proxy group, got %+v", task)
+		}
+		if task.TaskProfileID != profileIDs[i%2] || !reflect.DeepEqual(task.TaskSize, []string{"9"}) || task.TaskSizeJoined != "9" {
+			t.Errorf("expected the task to use profile %s and the template's size, got %+v", profileIDs[i%2], task)
+		}
+	}
+	if len(IDs) != 3 {
+		t.Error("expected every task to have a new ID")
+	}
+
+	tasks = TaskGenerator{Template: template, OnePerProfile: true, Sizes: []string{"9", "10", "11"}}.Generate("group", profileIDs)
+	if len(tasks) != 6 {
+		t.Fatalf("expected a task for each profile and size, got %d", len(tasks))
+	}
+	for i, task := range tasks {
+		profileID, size := profileIDs[i/3], []string{"9", "10", "11"}[i%3]
+		if task.TaskProfileID != profileID || task.TaskSizeJoined != size || task.TaskProxyGroupID != "template" {
+			t.Errorf("expected profile %s with size %s and the template's proxy group, got %+v", profileID, size, task)
+		}
+	}
+
+	if tasks := (TaskGenerator{Template: template, OnePerProfile: true}).Generate("group", nil); len(tasks) != 0 {
+		t.Errorf("expected no tasks without profiles, got %d", len(tasks))
+	}
+}
+
+func TestTaskSetAccount(t *testing.T) {
+	task := Task{TaskRetailer: enums.Target, TargetTaskInfo: &TargetTaskInfo{Email: "old@example.com", Password: "old"}}
+	pooledTask := task
+	pooledTask.SetAccount(Account{Email: "pool@example.com", Password: "secret"})
+
+	if pooledTask.TargetTaskInfo.Email != "pool@example.com" || pooledTask.TargetTaskInfo.Password != "secret" {
+		t.Errorf("expected the account's credentials, got %+v", pooledTask.TargetTaskInfo)
+	}
+	if task.TargetTaskInfo.Email != "old@example.com" || task.TargetTaskInfo.Password != "old" {
+		t.Errorf("expected the original task to keep its credentials, got %+v", task.TargetTaskInfo)
+	}
+
+	usable := map[enums.AccountStatus]bool{
+		enums.AccountStatusUnknown:        true,
+		enums.AccountStatusLoggedIn:       true,
+		enums.AccountStatusLocked:         false,
+		enums.AccountStatusBadCredentials: false,
+	}
+	for status, expected := range usable {
+		if (Account{Status: status}).Usable() != expected {
+			t.Errorf("%q: expected usable to be %v", status, expected)
+		}
+	}
+}
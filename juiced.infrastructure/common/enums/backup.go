@@ -0,0 +1,11 @@
+package enums
+
+// RestoreMode is a list of possible ways to restore a Backup
+type RestoreMode = string
+
+const (
+	// RestoreMerge adds the Backup's records to the ones that already exist
+	RestoreMerge RestoreMode = "merge"
+	// RestoreReplace removes every existing record before restoring the Backup's
+	RestoreReplace RestoreMode = "replace"
+)
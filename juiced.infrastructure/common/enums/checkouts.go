@@ -0,0 +1,12 @@
+package enums
+
+// CheckoutStatsGroupBy is a list of possible ways to group Checkouts when aggregating them
+type CheckoutStatsGroupBy = string
+
+const (
+	CheckoutStatsByRetailer CheckoutStatsGroupBy = "retailer"
+	CheckoutStatsByProfile  CheckoutStatsGroupBy = "profile"
+	CheckoutStatsBySKU      CheckoutStatsGroupBy = "sku"
+	CheckoutStatsByDay      CheckoutStatsGroupBy = "day"
+	CheckoutStatsByWeek     CheckoutStatsGroupBy = "week"
+)
@@ -0,0 +1,43 @@
+package enums
+
+// NotificationSinkType is a list of possible services that notifications can be sent to
+type NotificationSinkType = string
+
+const (
+	// NotificationSinkWebhook posts the notification and its fields as JSON to any URL
+	NotificationSinkWebhook NotificationSinkType = "webhook"
+	// NotificationSinkSlack posts the notification to a Slack incoming webhook
+	NotificationSinkSlack NotificationSinkType = "slack"
+	// NotificationSinkTelegram sends the notification to a Telegram chat through a bot
+	NotificationSinkTelegram NotificationSinkType = "telegram"
+	// NotificationSinkSMTP emails the notification through an SMTP server
+	NotificationSinkSMTP NotificationSinkType = "smtp"
+)
+
+// NotificationEvent is a list of possible events that notifications are sent for
+type NotificationEvent = string
+
+const (
+	NotificationCheckoutSuccess NotificationEvent = "checkoutSuccess"
+	NotificationCheckoutDecline NotificationEvent = "checkoutDecline"
+	NotificationTaskFailure     NotificationEvent = "taskFailure"
+	NotificationMonitorRestock  NotificationEvent = "monitorRestock"
+	// NotificationTest is only sent by the test webhooks request, every sink receives it whatever its rules are
+	NotificationTest NotificationEvent = "test"
+)
+
+// OutboxStatus is a list of possible statuses that an OutboxEntry can have
+type OutboxStatus = string
+
+const (
+	// OutboxPending entries are delivered once their next attempt is due
+	OutboxPending OutboxStatus = "pending"
+	// OutboxDead entries failed too many times, or in a way that retrying can't fix, and wait to be replayed
+	OutboxDead OutboxStatus = "dead"
+)
+
+// The OutboxEntry destinations of the Discord webhooks in the Settings, the others are the IDs of NotificationSinks
+const (
+	DiscordSuccessDestination = "discordSuccess"
+	DiscordFailureDestination = "discordFailure"
+)
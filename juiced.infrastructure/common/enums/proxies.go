@@ -1 +1,11 @@
 package enums
+
+// ProxyScheme is the protocol that requests are sent through a Proxy with
+type ProxyScheme = string
+
+// HTTP proxies tunnel with CONNECT in plain text, HTTPS proxies do the same over TLS
+const (
+	ProxySchemeHTTP   ProxyScheme = "http"
+	ProxySchemeHTTPS  ProxyScheme = "https"
+	ProxySchemeSOCKS5 ProxyScheme = "socks5"
+)
@@ -0,0 +1,10 @@
+package enums
+
+// ScheduleEventType is a list of possible things a TaskGroupSchedule can do when it fires
+type ScheduleEventType = string
+
+const (
+	ScheduleStart       ScheduleEventType = "ScheduleStart"
+	ScheduleStop        ScheduleEventType = "ScheduleStop"
+	ScheduleMissedStart ScheduleEventType = "ScheduleMissedStart"
+)
@@ -1,3 +1,19 @@
 package enums
 
+// AccountKey is the key that the auth server gives the user, and UserKey is the key that the database is encrypted with.
+// They're the same until the database is rekeyed, then UserKey is stored in the database encrypted with AccountKey.
+var AccountKey string
 var UserKey string
+
+// AnyAccount is the AccountID of a Task that logs in with any free Account for its retailer
+const AnyAccount = "any"
+
+// AccountStatus is how the last login with an Account went
+type AccountStatus = string
+
+const (
+	AccountStatusUnknown        AccountStatus = ""
+	AccountStatusLoggedIn       AccountStatus = "LOGGED_IN"
+	AccountStatusLocked         AccountStatus = "LOCKED"
+	AccountStatusBadCredentials AccountStatus = "BAD_CREDENTIALS"
+)
@@ -177,6 +177,20 @@ const (
 	CheckoutTypeEITHER CheckoutType = "EITHER"
 )
 
+// SizeFallback is used to choose what a task does when none of its preferred sizes are in stock (keep waiting / take any size)
+type SizeFallback = string
+
+const (
+	SizeFallbackWait SizeFallback = "WAIT"
+	SizeFallbackAny  SizeFallback = "ANY"
+)
+
+// RandomSize and AnySize are preferred sizes that match every size
+const (
+	RandomSize = "random"
+	AnySize    = "any"
+)
+
 // TaskType is used to choose how the task will checkout (account / guest)
 type TaskType = string
 
@@ -0,0 +1,7 @@
+package errors
+
+// OriginNotAllowedError is the error when a request comes from a browser origin that isn't allowed to use the API
+const OriginNotAllowedError = "Requests from this origin are not allowed"
+
+// InvalidSessionTokenError is the error when a request doesn't have a valid bearer token
+const InvalidSessionTokenError = "The request is missing a valid bearer token"
@@ -0,0 +1,37 @@
+package errors
+
+// ParseBackupRequestError is the error encountered when parsing JSON into a backup request returns an error
+const ParseBackupRequestError = "Parsing the JSON into a backup request returned an error: "
+
+// ParseRestoreRequestError is the error encountered when parsing JSON into a restore request returns an error
+const ParseRestoreRequestError = "Parsing the JSON into a restore request returned an error: "
+
+// BackupPassphraseTooShortError is the error encountered when a backup's passphrase is too short
+const BackupPassphraseTooShortError = "The backup's passphrase is too short, the minimum number of characters is "
+
+// CreateBackupError is the error encountered when creating a Backup of the workspace returns an error
+const CreateBackupError = "Creating the backup returned an error: "
+
+// EncryptBackupError is the error encountered when encrypting a Backup returns an error
+const EncryptBackupError = "Encrypting the backup returned an error: "
+
+// DecryptBackupError is the error encountered when decrypting a Backup returns an error
+const DecryptBackupError = "Decrypting the backup returned an error: "
+
+// ParseBackupError is the error encountered when parsing a decrypted Backup returns an error
+const ParseBackupError = "Parsing the backup returned an error: "
+
+// MissingBackupError is the error encountered when a restore request doesn't include a Backup
+const MissingBackupError = "The request doesn't include a backup to restore"
+
+// InvalidRestoreModeError is the error encountered when a restore request's mode isn't merge or replace
+const InvalidRestoreModeError = "The restore mode must be merge or replace: "
+
+// RestoreWhileTasksRunningError is the error encountered when restoring a Backup while Tasks are running
+const RestoreWhileTasksRunningError = "A backup can't be restored while tasks are running, stop them first"
+
+// RestoreBackupError is the error encountered when restoring a Backup returns an error
+const RestoreBackupError = "Restoring the backup returned an error: "
+
+// RefreshStoresError is the error encountered when reloading the stores after a restore returns an error
+const RefreshStoresError = "The backup was restored, but reloading it into the running bot returned an error: "
@@ -2,3 +2,12 @@ package errors
 
 // GetAllCheckoutsError is the error encountered when retrieving all Checkouts from the DB returns an error
 const GetAllCheckoutsError = "Retrieving all Checkouts returned an error: "
+
+// GetCheckoutStatsError is the error encountered when aggregating Checkouts from the DB returns an error
+const GetCheckoutStatsError = "Retrieving the Checkout stats returned an error: "
+
+// ParseCheckoutFilterError is the error encountered when a Checkout filter URL parameter isn't valid
+const ParseCheckoutFilterError = "Parsing the Checkout filter returned an error: "
+
+// InvalidCheckoutStatsGroupByError is the error when the groupBy URL parameter isn't retailer, profile, sku, day or week
+const InvalidCheckoutStatsGroupByError = "the groupBy parameter must be one of retailer, profile, sku, day or week"
@@ -5,3 +5,15 @@ const IOUtilReadAllError = "ioutil.ReadAll() function returned an error: "
 
 // MissingParameterError is the error when a URL Parameter can not be found
 const MissingParameterError = "URL is missing a parameter"
+
+// InternalServerError is the error when a request handler panics
+const InternalServerError = "The request couldn't be handled because of an internal error"
+
+// RouteNotFoundError is the error when a request's path doesn't match any route
+const RouteNotFoundError = "There is no route with the given path"
+
+// MethodNotAllowedError is the error when a request's path matches a route, but not with the request's method
+const MethodNotAllowedError = "The route doesn't allow the request's method"
+
+// ParsePageError is the error encountered when parsing the pagination parameters of a list request returns an error
+const ParsePageError = "Parsing the pagination parameters returned an error: "
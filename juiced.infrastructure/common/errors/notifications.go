@@ -0,0 +1,19 @@
+package errors
+
+// GetOutboxError is the error encountered when retrieving the OutboxEntries from the DB returns an error
+const GetOutboxError = "Retrieving the notification outbox returned an error: "
+
+// InvalidOutboxStatusError is the error encountered when filtering the OutboxEntries by a status that doesn't exist
+const InvalidOutboxStatusError = "The outbox status has to be pending or dead"
+
+// OutboxEntryNotFoundError is the error encountered when there isn't an OutboxEntry with the given ID
+const OutboxEntryNotFoundError = "There isn't an OutboxEntry with the given ID"
+
+// OutboxEntryNotDeadError is the error encountered when replaying an OutboxEntry that is still pending
+const OutboxEntryNotDeadError = "Only dead OutboxEntries can be replayed"
+
+// ReplayOutboxEntryError is the error encountered when queueing an OutboxEntry to be delivered again returns an error
+const ReplayOutboxEntryError = "Replaying the OutboxEntry returned an error: "
+
+// RemoveOutboxEntryError is the error encountered when removing an OutboxEntry from the DB returns an error
+const RemoveOutboxEntryError = "Removing the OutboxEntry with the given ID returned an error: "
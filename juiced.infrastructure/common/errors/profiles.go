@@ -47,3 +47,57 @@ const ReadFileError = "Reading the file returned an error: "
 
 // ParseImportProfilesFileError is the error encountered when parsing JSON into a list of profiles returns an error
 const ParseImportProfilesFileError = "Parsing the JSON contents of the import file into a list profiles returned an error: "
+
+// ParseImportProfilesCSVError is the error encountered when parsing the CSV contents of the import file returns an error
+const ParseImportProfilesCSVError = "Parsing the CSV contents of the import file into a list of profiles returned an error: "
+
+// ParseProfileColumnMappingError is the error encountered when parsing the JSON column mapping of a CSV import returns an error
+const ParseProfileColumnMappingError = "Parsing the JSON into a column mapping returned an error: "
+
+// ParseImportProfilesFormError is the error encountered when parsing a multipart import request returns an error
+const ParseImportProfilesFormError = "Parsing the multipart import request returned an error: "
+
+// InvalidProfileFileFormatError is the error encountered when an import or export's format isn't csv or json
+const InvalidProfileFileFormatError = "The file format must be csv or json: "
+
+// ExportProfilesError is the error encountered when exporting Profiles returns an error
+const ExportProfilesError = "Exporting the profiles returned an error: "
+
+// ProfileNameTakenError is the per-row error for an imported Profile whose name is already used by another Profile
+const ProfileNameTakenError = "A profile with this name already exists"
+
+// MissingProfileFieldError is the per-row error for an imported Profile that's missing a required field
+const MissingProfileFieldError = "Missing a required field: "
+
+// InvalidEmailError is the per-row error for an imported Profile whose email address is invalid
+const InvalidEmailError = "The email address is invalid"
+
+// InvalidPhoneNumberError is the per-row error for an imported Profile whose phone number is invalid
+const InvalidPhoneNumberError = "The phone number is invalid, it should have 10 digits for the US and Canada or 7 to 15 digits elsewhere"
+
+// InvalidCountryCodeError is the per-row error for an imported Profile with an address whose country isn't an ISO 3166 country code
+const InvalidCountryCodeError = "The country must be a two letter ISO 3166 country code: "
+
+// InvalidStateCodeError is the per-row error for an imported Profile with an address whose state isn't a state or province code of its country
+const InvalidStateCodeError = "The state must be a two letter state or province code: "
+
+// InvalidCardNumberError is the per-row error for an imported Profile whose card number fails the Luhn check
+const InvalidCardNumberError = "The card number is invalid"
+
+// UnsupportedCardTypeError is the per-row error for an imported Profile whose card type can't be detected
+const UnsupportedCardTypeError = "The card type isn't supported"
+
+// InvalidCardExpiryError is the per-row error for an imported Profile whose card expiry month or year is invalid
+const InvalidCardExpiryError = "The card's expiry month or year is invalid"
+
+// CardExpiredError is the per-row error for an imported Profile whose card has expired
+const CardExpiredError = "The card has expired"
+
+// InvalidCVVError is the per-row error for an imported Profile whose CVV is invalid
+const InvalidCVVError = "The CVV is invalid, it should have 3 digits, or 4 for AMEX"
+
+// ProfileNotFoundError is the error when there isn't a Profile with the given ID
+const ProfileNotFoundError = "There is no Profile with the given ID"
+
+// ProfileGroupNotFoundError is the error when there isn't a ProfileGroup with the given ID
+const ProfileGroupNotFoundError = "There is no ProfileGroup with the given ID"
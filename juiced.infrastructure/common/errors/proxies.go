@@ -17,3 +17,18 @@ const RemoveProxyGroupError = "Removing the ProxyGroup with the given ID returne
 
 // UpdateProxyGroupError is the error encountered when updating a ProxyGroup from the DB returns an error
 const UpdateProxyGroupError = "Updating the ProxyGroup with the given ID returned an error: "
+
+// ProxyGroupNotFoundError is the error when there isn't a ProxyGroup with the given ID
+const ProxyGroupNotFoundError = "There is no ProxyGroup with the given ID"
+
+// ParseTestProxyGroupRequestError is the error encountered when parsing JSON into a TestProxyGroupRequest returns an error
+const ParseTestProxyGroupRequestError = "Parsing the JSON into a TestProxyGroupRequest returned an error: "
+
+// InvalidProxyTestURLError is the error encountered when the URL to test a ProxyGroup against isn't an http or https URL
+const InvalidProxyTestURLError = "The URL to test the proxies against has to be an http or https URL"
+
+// InvalidProxyTestOptionsError is the error encountered when the concurrency or timeout of a proxy test is out of range
+const InvalidProxyTestOptionsError = "The concurrency has to be between 1 and 100 and the timeout between 1 and 60 seconds"
+
+// TestProxyGroupError is the error encountered when testing a ProxyGroup returns an error
+const TestProxyGroupError = "Testing the ProxyGroup with the given ID returned an error: "
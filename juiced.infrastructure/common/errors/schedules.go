@@ -0,0 +1,43 @@
+package errors
+
+// ParseTaskGroupScheduleError is the error encountered when parsing JSON into a TaskGroupSchedule returns an error
+const ParseTaskGroupScheduleError = "Parsing the JSON into a TaskGroupSchedule returned an error: "
+
+// GetTaskGroupSchedulesError is the error encountered when retrieving a TaskGroup's schedules from the DB returns an error
+const GetTaskGroupSchedulesError = "Retrieving the TaskGroup's schedules returned an error: "
+
+// CreateTaskGroupScheduleError is the error encountered when inserting a TaskGroupSchedule into the DB returns an error
+const CreateTaskGroupScheduleError = "Inserting the TaskGroupSchedule into the DB returned an error: "
+
+// UpdateTaskGroupScheduleError is the error encountered when updating a TaskGroupSchedule in the DB returns an error
+const UpdateTaskGroupScheduleError = "Updating the TaskGroupSchedule with the given ID returned an error: "
+
+// RemoveTaskGroupScheduleError is the error encountered when removing a TaskGroupSchedule from the DB returns an error
+const RemoveTaskGroupScheduleError = "Removing the TaskGroupSchedule with the given ID returned an error: "
+
+// TaskGroupScheduleNotFoundError is the error encountered when the TaskGroup doesn't have a TaskGroupSchedule with the given ID
+const TaskGroupScheduleNotFoundError = "The TaskGroup doesn't have a TaskGroupSchedule with the given ID"
+
+// InvalidCronError is the error encountered when a TaskGroupSchedule's cron expression can't be parsed
+const InvalidCronError = "The schedule's cron expression is invalid: "
+
+// NoScheduledActionError is the error encountered when a TaskGroupSchedule has nothing to start or stop
+const NoScheduledActionError = "A schedule needs a start time, a cron expression or a stop time"
+
+// ConflictingStartTimesError is the error encountered when a TaskGroupSchedule has both a start time and a cron expression
+const ConflictingStartTimesError = "A schedule can't have both a start time and a cron expression"
+
+// ConflictingStopTimesError is the error encountered when a TaskGroupSchedule has both a stop time and a number of minutes to stop after
+const ConflictingStopTimesError = "A schedule can't stop both after a number of minutes and at a stop time"
+
+// StopAfterWithoutStartError is the error encountered when a TaskGroupSchedule stops after a number of minutes but never starts
+const StopAfterWithoutStartError = "A schedule can only stop after a number of minutes if it has a start time or a cron expression"
+
+// InvalidStopAfterMinutesError is the error encountered when a TaskGroupSchedule's number of minutes to stop after is negative
+const InvalidStopAfterMinutesError = "A schedule's number of minutes to stop after can't be negative"
+
+// StopTimeBeforeStartTimeError is the error encountered when a TaskGroupSchedule stops before it starts
+const StopTimeBeforeStartTimeError = "A schedule's stop time must be after its start time"
+
+// ScheduleTimeInPastError is the error encountered when a TaskGroupSchedule's start or stop time has already passed
+const ScheduleTimeInPastError = "A schedule's start and stop times must be in the future"
@@ -9,6 +9,15 @@ const GetSettingsError = "Retrieving settings returned an error: "
 // UpdateSettingsError is the error encountered when updating the Settings object from the DB returns an error
 const UpdateSettingsError = "Updating settings returned an error: "
 
+// RemoteAccessTokenTooShortError is the error when the remote access token is too short to be accepted
+const RemoteAccessTokenTooShortError = "The remote access token must be at least 32 characters"
+
+// RekeyDatabaseError is the error encountered when re-encrypting the database with a new key returns an error
+const RekeyDatabaseError = "Re-encrypting the database returned an error: "
+
+// RekeyWhileTasksRunningError is the error encountered when rekeying the database while Tasks are running
+const RekeyWhileTasksRunningError = "The database can't be re-encrypted while tasks are running, stop them first"
+
 // ParseAccountError is the error encountered when parsing JSON into a Account object returns an error
 const ParseAccountError = "Parsing the account returned an error: "
 
@@ -29,3 +38,24 @@ const TestSuccessWebhookError = "Error while sending success webhook"
 
 // TestFailureWebhookError is the error when sending the webhook to the failure webhook url fails
 const TestFailureWebhookError = "Error while sending failure webhook"
+
+// InvalidNotificationSinkError is the error when a NotificationSink is missing a field or has an invalid rule
+const InvalidNotificationSinkError = "Invalid notification sink "
+
+// TestNotificationSinkError is the error when sending the test notification to a NotificationSink fails
+const TestNotificationSinkError = "Error while sending the test notification to "
+
+// AccountNotFoundError is the error when there isn't an Account with the given ID
+const AccountNotFoundError = "There is no Account with the given ID"
+
+// GetAccountsError is the error encountered when retrieving the Account objects from the DB returns an error
+const GetAccountsError = "Retrieving the accounts returned an error: "
+
+// AccountInUseError is the error when an Account is allocated to another running Task
+const AccountInUseError = "The account is in use by another task"
+
+// NoFreeAccountError is the error when every usable Account for a retailer is allocated to another running Task
+const NoFreeAccountError = "There is no free account for "
+
+// AccountRetailerMismatchError is the error when a Task references an Account for another retailer
+const AccountRetailerMismatchError = "The account is for a different retailer: "
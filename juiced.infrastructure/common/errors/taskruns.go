@@ -0,0 +1,10 @@
+package errors
+
+// GetTaskRunsError is the error encountered when retrieving a Task's runs from the DB returns an error
+const GetTaskRunsError = "Retrieving the Task's runs returned an error: "
+
+// GetTaskGroupTimelineError is the error encountered when retrieving a TaskGroup's timeline from the DB returns an error
+const GetTaskGroupTimelineError = "Retrieving the TaskGroup's timeline returned an error: "
+
+// ParseSinceError is the error encountered when the since URL parameter isn't a valid timestamp
+const ParseSinceError = "Parsing the since parameter returned an error: "
@@ -92,8 +92,41 @@ const MissingMonitorFieldsError = "the Monitor is missing required fields"
 // NoMonitorsError is returned when the TaskGroup's <Retailer>MonitorInfo.Monitors is an empty slice
 const NoMonitorsError = "the TaskGroup has no monitors attached to it"
 
+// MissingKeywordsError is returned when a keyword monitor doesn't have any positive keywords to match product titles against
+const MissingKeywordsError = "the keyword monitor needs at least one keyword that product titles must contain"
+
+// InvalidMonitorTypeError is returned when the TaskGroup's MonitorType isn't one that the retailer's monitor supports
+const InvalidMonitorTypeError = "invalid monitor type: "
+
 // CreateMonitorError is returned when calling the sitescript's Create<Retailer>Monitor function returns an error
 const CreateMonitorError = "creating the monitor failed: "
 
 // InvalidMonitorRetailerError is returned when the TaskGroup's MonitorRetailer value is not one of our supported retailers
 const InvalidMonitorRetailerError = "the TaskGroup's retailer is not supported"
+
+// TaskNotFoundError is the error when there isn't a Task with the given ID
+const TaskNotFoundError = "There is no Task with the given ID"
+
+// TaskGroupNotFoundError is the error when there isn't a TaskGroup with the given ID
+const TaskGroupNotFoundError = "There is no TaskGroup with the given ID"
+
+// ParseTaskGeneratorError is the error encountered when parsing JSON into a TaskGenerator returns an error
+const ParseTaskGeneratorError = "Parsing the JSON into a TaskGenerator returned an error: "
+
+// InvalidTaskGeneratorModeError is the error encountered when a TaskGenerator has both or neither of a count and one per profile
+const InvalidTaskGeneratorModeError = "The generator needs either a count of tasks or one task per profile"
+
+// TooManyGeneratedTasksError is the error encountered when a TaskGenerator would generate too many Tasks at once
+const TooManyGeneratedTasksError = "The generator can't create more tasks at once than "
+
+// TaskRetailerMismatchError is the error encountered when a Task's retailer doesn't match its TaskGroup's retailer
+const TaskRetailerMismatchError = "The task's retailer must match the TaskGroup's retailer: "
+
+// EmptyProfileGroupError is the error encountered when generating Tasks for a ProfileGroup without any Profiles
+const EmptyProfileGroupError = "The ProfileGroup doesn't have any profiles"
+
+// GenerateTasksError is the error encountered when inserting generated Tasks into the DB returns an error, none of them are inserted
+const GenerateTasksError = "Inserting the generated tasks into the DB returned an error, none of them were created: "
+
+// InvalidSizeFallbackError is the error encountered when a Task's size fallback isn't WAIT or ANY
+const InvalidSizeFallbackError = "The task's size fallback must be WAIT or ANY: "
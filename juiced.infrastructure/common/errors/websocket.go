@@ -0,0 +1,19 @@
+package errors
+
+// UnsupportedProtocolVersionError is the error when a WebSocket frame uses a protocol version the backend doesn't speak
+const UnsupportedProtocolVersionError = "The frame's protocol version is not supported: "
+
+// UnknownRouteError is the error when a WebSocket frame's route doesn't exist
+const UnknownRouteError = "The frame's route does not exist: "
+
+// ParseFrameError is the error encountered when parsing a WebSocket message into a Frame returns an error
+const ParseFrameError = "Parsing the message into a Frame returned an error: "
+
+// ParseFramePayloadError is the error encountered when parsing a Frame's payload returns an error
+const ParseFramePayloadError = "Parsing the frame's payload returned an error: "
+
+// MissingFrameIDError is the error when a WebSocket request frame doesn't have an ID to correlate the reply with
+const MissingFrameIDError = "The frame is missing an ID"
+
+// InvalidTopicError is the error when a subscription asks for a topic that doesn't exist
+const InvalidTopicError = "The topic does not exist: "
@@ -1,104 +1,120 @@
 package events
 
 import (
-	"backend.juicedbot.io/juiced.infrastructure/common/enums"
-
 	"sync"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 )
 
-// EventBus stores the information about subscribers
+// EventBus stores the information about subscribers, publishing never blocks on them
 type EventBus struct {
-	Subscribers []EventChannel
-	RM          sync.RWMutex
+	subscribers []*Subscriber
+	mutex       sync.RWMutex
 }
 
-// Unsubscribe removes the EventChannel from the EventBus's subscribers
-func (eb *EventBus) Unsubscribe(ch EventChannel) {
-	eb.RM.Lock()
-	newSubscribers := []EventChannel{}
-	for _, subscriber := range eb.Subscribers {
-		if ch != subscriber {
-			newSubscribers = append(newSubscribers, subscriber)
+// AddSubscriber adds a Subscriber that receives the Events matching the options
+func (eb *EventBus) AddSubscriber(options SubscriberOptions) *Subscriber {
+	subscriber := newSubscriber(options, make(EventChannel))
+	eb.mutex.Lock()
+	eb.subscribers = append(eb.subscribers, subscriber)
+	eb.mutex.Unlock()
+	return subscriber
+}
+
+// RemoveSubscriber removes the Subscriber from the EventBus and closes its channel
+func (eb *EventBus) RemoveSubscriber(subscriber *Subscriber) {
+	eb.mutex.Lock()
+	removed := false
+	newSubscribers := []*Subscriber{}
+	for _, s := range eb.subscribers {
+		if s != subscriber {
+			newSubscribers = append(newSubscribers, s)
+		} else {
+			removed = true
 		}
 	}
-	eb.Subscribers = newSubscribers
-	close(ch)
-	eb.RM.Unlock()
+	eb.subscribers = newSubscribers
+	eb.mutex.Unlock()
+
+	if removed {
+		subscriber.stop()
+	}
 }
 
-// Subscribe adds the EventChannel to the EventBus's subscribers
+// Subscribe adds the EventChannel to the EventBus's subscribers, it receives every Event
 func (eb *EventBus) Subscribe(ch EventChannel) {
-	eb.RM.Lock()
-	eb.Subscribers = append(eb.Subscribers, ch)
-	eb.RM.Unlock()
+	subscriber := newSubscriber(SubscriberOptions{}, ch)
+	eb.mutex.Lock()
+	eb.subscribers = append(eb.subscribers, subscriber)
+	eb.mutex.Unlock()
+}
+
+// Unsubscribe removes the EventChannel from the EventBus's subscribers and closes it
+func (eb *EventBus) Unsubscribe(ch EventChannel) {
+	eb.mutex.RLock()
+	var subscriber *Subscriber
+	for _, s := range eb.subscribers {
+		if s.channel == ch {
+			subscriber = s
+			break
+		}
+	}
+	eb.mutex.RUnlock()
+
+	if subscriber != nil {
+		eb.RemoveSubscriber(subscriber)
+	}
+}
+
+// Publish hands the Event to every Subscriber whose options match it. TaskEvents and MonitorEvents are stamped with the
+// time they're published, since Subscribers like the HistoryStore may only handle them much later.
+func (eb *EventBus) Publish(event Event) {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	if event.EventType == TaskEventType && event.TaskEvent.Time == 0 {
+		event.TaskEvent.Time = now
+	} else if event.EventType == MonitorEventType && event.MonitorEvent.Time == 0 {
+		event.MonitorEvent.Time = now
+	}
+
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	for _, subscriber := range eb.subscribers {
+		if subscriber.options.matches(event) {
+			subscriber.push(event)
+		}
+	}
 }
 
 // PublishConnectEvent publishes a ConnectEvent
 func (eb *EventBus) PublishConnectEvent() {
-	eb.RM.RLock()
-	// Will panic if any channel is closed
-	go func(event Event, channels []EventChannel) {
-		defer func() {
-			recover()
-		}()
-		for _, ch := range channels {
-			ch <- event
-		}
-	}(Event{
+	eb.Publish(Event{
 		EventType:    ConnectEventType,
 		ConnectEvent: ConnectEvent{},
-	}, eb.Subscribers)
-	eb.RM.RUnlock()
+	})
 }
 
 // PublishAuthEvent publishes an AuthEvent
 func (eb *EventBus) PublishAuthEvent() {
-	eb.RM.RLock()
-	// Will panic if any channel is closed
-	go func(event Event, channels []EventChannel) {
-		defer func() {
-			recover()
-		}()
-		for _, ch := range channels {
-			ch <- event
-		}
-	}(Event{
+	eb.Publish(Event{
 		EventType: AuthEventType,
 		AuthEvent: AuthEvent{},
-	}, eb.Subscribers)
-	eb.RM.RUnlock()
+	})
 }
 
 // PublishCloseEvent publishes a CloseEvent
 func (eb *EventBus) PublishCloseEvent() {
-	eb.RM.RLock()
-	// Will panic if any channel is closed
-	go func(event Event, channels []EventChannel) {
-		defer func() {
-			recover()
-		}()
-		for _, ch := range channels {
-			ch <- event
-		}
-	}(Event{
+	eb.Publish(Event{
 		EventType:  CloseEventType,
 		CloseEvent: CloseEvent{},
-	}, eb.Subscribers)
-	eb.RM.RUnlock()
+	})
 }
 
 // PublishMonitorEvent publishes a MonitorEvent
 func (eb *EventBus) PublishMonitorEvent(monitorStatus enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}, monitorID string) {
-	eb.RM.RLock()
-	// Will panic if any channel is closed
-	go func(event Event, channels []EventChannel) {
-		defer func() {
-			recover()
-		}()
-		for _, ch := range channels {
-			ch <- event
-		}
-	}(Event{
+	eb.Publish(Event{
 		EventType: MonitorEventType,
 		MonitorEvent: MonitorEvent{
 			Status:    monitorStatus,
@@ -106,22 +122,28 @@ func (eb *EventBus) PublishMonitorEvent(monitorStatus enums.MonitorStatus, event
 			Data:      data,
 			MonitorID: monitorID,
 		},
-	}, eb.Subscribers)
-	eb.RM.RUnlock()
+	})
+}
+
+// PublishMonitorEventFor publishes a MonitorEvent for a run of the TaskGroup's Monitor, including its retailer so that Subscribers can filter on it
+func (eb *EventBus) PublishMonitorEventFor(taskGroup *entities.TaskGroup, runID string, proxyID string, monitorStatus enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
+	eb.Publish(Event{
+		EventType: MonitorEventType,
+		MonitorEvent: MonitorEvent{
+			Status:    monitorStatus,
+			EventType: eventType,
+			Data:      data,
+			MonitorID: taskGroup.GroupID,
+			Retailer:  taskGroup.MonitorRetailer,
+			RunID:     runID,
+			ProxyID:   proxyID,
+		},
+	})
 }
 
 // PublishTaskEvent publishes a TaskEvent
 func (eb *EventBus) PublishTaskEvent(taskStatus enums.TaskStatus, statusPercentage int, eventType enums.TaskEventType, data interface{}, taskID string) {
-	eb.RM.RLock()
-	// Will panic if any channel is closed
-	go func(event Event, channels []EventChannel) {
-		defer func() {
-			recover()
-		}()
-		for _, ch := range channels {
-			ch <- event
-		}
-	}(Event{
+	eb.Publish(Event{
 		EventType: TaskEventType,
 		TaskEvent: TaskEvent{
 			Status:           taskStatus,
@@ -130,15 +152,56 @@ func (eb *EventBus) PublishTaskEvent(taskStatus enums.TaskStatus, statusPercenta
 			Data:             data,
 			TaskID:           taskID,
 		},
-	}, eb.Subscribers)
-	eb.RM.RUnlock()
+	})
+}
+
+// PublishTaskEventFor publishes a TaskEvent for a run of the Task, including its TaskGroup and retailer so that Subscribers can filter on them
+func (eb *EventBus) PublishTaskEventFor(task *entities.Task, runID string, proxyID string, taskStatus enums.TaskStatus, statusPercentage int, eventType enums.TaskEventType, data interface{}) {
+	eb.Publish(Event{
+		EventType: TaskEventType,
+		TaskEvent: TaskEvent{
+			Status:           taskStatus,
+			StatusPercentage: statusPercentage,
+			EventType:        eventType,
+			Data:             data,
+			TaskID:           task.ID,
+			TaskGroupID:      task.TaskGroupID,
+			Retailer:         task.TaskRetailer,
+			RunID:            runID,
+			ProxyID:          proxyID,
+		},
+	})
+}
+
+// PublishCheckoutEvent publishes a CheckoutEvent
+func (eb *EventBus) PublishCheckoutEvent(checkoutEvent CheckoutEvent) {
+	eb.Publish(Event{
+		EventType:     CheckoutEventType,
+		CheckoutEvent: checkoutEvent,
+	})
+}
+
+// PublishScheduleEvent publishes a ScheduleEvent
+func (eb *EventBus) PublishScheduleEvent(scheduleEvent ScheduleEvent) {
+	eb.Publish(Event{
+		EventType:     ScheduleEventType,
+		ScheduleEvent: scheduleEvent,
+	})
+}
+
+// PublishProxyTestEvent publishes a ProxyTestEvent
+func (eb *EventBus) PublishProxyTestEvent(proxyTestEvent ProxyTestEvent) {
+	eb.Publish(Event{
+		EventType:      ProxyTestEventType,
+		ProxyTestEvent: proxyTestEvent,
+	})
 }
 
 var eventBus *EventBus
 
 // InitEventBus initializes the singleton instance of the EventBus
 func InitEventBus() {
-	eventBus = &EventBus{Subscribers: []EventChannel{}}
+	eventBus = &EventBus{subscribers: []*Subscriber{}}
 }
 
 // GetEventBus returns the singleton instance of the EventBus
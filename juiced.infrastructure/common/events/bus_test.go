@@ -0,0 +1,252 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// bufferOnly returns a Subscriber without its delivery goroutine, so that its buffer can be inspected deterministically
+func bufferOnly(options SubscriberOptions) *Subscriber {
+	return &Subscriber{
+		options: options,
+		buffer:  make([]Event, options.BufferSize),
+		ready:   make(chan struct{}, 1),
+	}
+}
+
+func taskEvent(taskID string, statusPercentage int) Event {
+	return Event{
+		EventType: TaskEventType,
+		TaskEvent: TaskEvent{TaskID: taskID, StatusPercentage: statusPercentage},
+	}
+}
+
+func drain(subscriber *Subscriber) []Event {
+	var events []Event
+	for {
+		event, ok := subscriber.pop()
+		if !ok {
+			return events
+		}
+		events = append(events, event)
+	}
+}
+
+func TestPublishDoesNotBlockOnSlowSubscribers(t *testing.T) {
+	eb := &EventBus{}
+	slow := eb.AddSubscriber(SubscriberOptions{BufferSize: 10})
+	defer eb.RemoveSubscriber(slow)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			eb.PublishTaskEvent(enums.SettingUp, i, enums.TaskUpdate, nil, "task")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish blocked on a subscriber that isn't reading")
+	}
+	if slow.Dropped() == 0 {
+		t.Error("expected the slow subscriber to drop events")
+	}
+}
+
+func TestDropOldest(t *testing.T) {
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 3, Policy: DropOldest})
+	for i := 0; i < 5; i++ {
+		subscriber.push(taskEvent("task", i))
+	}
+
+	events := drain(subscriber)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 buffered events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.TaskEvent.StatusPercentage != i+2 {
+			t.Errorf("event %d: expected status percentage %d, got %d", i, i+2, event.TaskEvent.StatusPercentage)
+		}
+	}
+	if subscriber.Dropped() != 2 {
+		t.Errorf("expected 2 dropped events, got %d", subscriber.Dropped())
+	}
+}
+
+func TestCoalesceLatestStatus(t *testing.T) {
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 3, Policy: CoalesceLatestStatus})
+	subscriber.push(taskEvent("a", 1))
+	subscriber.push(taskEvent("b", 1))
+	subscriber.push(taskEvent("a", 2))
+	// The buffer is full, so these replace the latest buffered status of the same task
+	subscriber.push(taskEvent("b", 2))
+	subscriber.push(taskEvent("a", 3))
+
+	events := drain(subscriber)
+	want := []string{"a:1", "b:2", "a:3"}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d buffered events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		got := fmt.Sprintf("%s:%d", event.TaskEvent.TaskID, event.TaskEvent.StatusPercentage)
+		if got != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+}
+
+func TestCoalesceKeepsEventsWithData(t *testing.T) {
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 2, Policy: CoalesceLatestStatus})
+	withData := taskEvent("a", 1)
+	withData.TaskEvent.Data = "card info"
+	subscriber.push(withData)
+	subscriber.push(taskEvent("b", 1))
+	// Nothing for "c" is buffered, so the oldest is dropped instead
+	subscriber.push(taskEvent("c", 1))
+
+	events := drain(subscriber)
+	if len(events) != 2 || events[0].TaskEvent.TaskID != "b" || events[1].TaskEvent.TaskID != "c" {
+		t.Errorf("unexpected buffered events: %+v", events)
+	}
+}
+
+func TestPerTaskOrdering(t *testing.T) {
+	eb := &EventBus{}
+	subscriber := eb.AddSubscriber(SubscriberOptions{BufferSize: 100000})
+
+	const taskCount = 20
+	const eventsPerTask = 500
+	var wg sync.WaitGroup
+	for i := 0; i < taskCount; i++ {
+		wg.Add(1)
+		go func(taskID string) {
+			defer wg.Done()
+			for j := 0; j < eventsPerTask; j++ {
+				eb.PublishTaskEvent(enums.SettingUp, j, enums.TaskUpdate, nil, taskID)
+			}
+		}(fmt.Sprint(i))
+	}
+
+	last := make(map[string]int)
+	for received := 0; received < taskCount*eventsPerTask; received++ {
+		event := <-subscriber.Events()
+		previous, ok := last[event.TaskEvent.TaskID]
+		if ok && event.TaskEvent.StatusPercentage != previous+1 {
+			t.Fatalf("task %s: event %d arrived after event %d", event.TaskEvent.TaskID, event.TaskEvent.StatusPercentage, previous)
+		}
+		last[event.TaskEvent.TaskID] = event.TaskEvent.StatusPercentage
+	}
+	wg.Wait()
+	eb.RemoveSubscriber(subscriber)
+}
+
+func TestSubscriberFilters(t *testing.T) {
+	task := &entities.Task{ID: "task", TaskGroupID: "group", TaskRetailer: enums.Target}
+	otherTask := &entities.Task{ID: "other", TaskGroupID: "other_group", TaskRetailer: enums.Walmart}
+	taskGroup := &entities.TaskGroup{GroupID: "group", MonitorRetailer: enums.Target}
+
+	eb := &EventBus{}
+	byGroup := bufferOnly(SubscriberOptions{BufferSize: 10, TaskGroupIDs: []string{"group"}})
+	byRetailer := bufferOnly(SubscriberOptions{BufferSize: 10, Topics: []Topic{TaskTopic}, Retailers: []enums.Retailer{enums.Walmart}})
+	systemOnly := bufferOnly(SubscriberOptions{BufferSize: 10, Topics: []Topic{SystemTopic}})
+	eb.subscribers = []*Subscriber{byGroup, byRetailer, systemOnly}
+
+	eb.PublishTaskEventFor(task, "run", "", enums.SettingUp, 0, enums.TaskStart, nil)
+	eb.PublishTaskEventFor(otherTask, "run", "", enums.SettingUp, 0, enums.TaskStart, nil)
+	eb.PublishMonitorEventFor(taskGroup, "run", "", enums.SettingUpMonitor, enums.MonitorStart, nil)
+	eb.PublishCloseEvent()
+
+	if events := drain(byGroup); len(events) != 3 || events[0].TaskEvent.TaskID != "task" || events[1].EventType != MonitorEventType || events[2].EventType != CloseEventType {
+		t.Errorf("task group subscriber got unexpected events: %+v", events)
+	}
+	if events := drain(byRetailer); len(events) != 1 || events[0].TaskEvent.TaskID != "other" {
+		t.Errorf("retailer subscriber got unexpected events: %+v", events)
+	}
+	if events := drain(systemOnly); len(events) != 1 || events[0].EventType != CloseEventType {
+		t.Errorf("system subscriber got unexpected events: %+v", events)
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	eb := &EventBus{}
+	channel := make(EventChannel)
+	eb.Subscribe(channel)
+	eb.PublishConnectEvent()
+	if event := <-channel; event.EventType != ConnectEventType {
+		t.Fatalf("expected a connect event, got %s", event.EventType)
+	}
+
+	// Leave an event undelivered so that Unsubscribe has to interrupt the delivery goroutine
+	eb.PublishAuthEvent()
+	eb.Unsubscribe(channel)
+	for range channel {
+	}
+	eb.PublishCloseEvent()
+}
+
+func TestPublishStampsTime(t *testing.T) {
+	eb := &EventBus{}
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 10})
+	eb.subscribers = append(eb.subscribers, subscriber)
+
+	before := time.Now().UnixNano() / int64(time.Millisecond)
+	eb.PublishTaskEvent(enums.SettingUp, 0, enums.TaskStart, nil, "task")
+	eb.PublishMonitorEvent(enums.SettingUpMonitor, enums.MonitorStart, nil, "group")
+	eb.Publish(Event{EventType: TaskEventType, TaskEvent: TaskEvent{TaskID: "task", Time: 1}})
+
+	events := drain(subscriber)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	if events[0].TaskEvent.Time < before || events[1].MonitorEvent.Time < before {
+		t.Errorf("expected the events to be stamped with the time they were published, got %+v", events[:2])
+	}
+	if events[2].TaskEvent.Time != 1 {
+		t.Errorf("expected an event's own time to be kept, got %d", events[2].TaskEvent.Time)
+	}
+}
+
+func TestCoalesceKeepsTaskOrderWithData(t *testing.T) {
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 3, Policy: CoalesceLatestStatus})
+	subscriber.push(taskEvent("a", 1))
+	withData := taskEvent("a", 2)
+	withData.TaskEvent.Data = "product info"
+	subscriber.push(withData)
+	subscriber.push(taskEvent("b", 1))
+	// The newest buffered Event for "a" has Data, so replacing the status before it would deliver this one first
+	subscriber.push(taskEvent("a", 3))
+
+	events := drain(subscriber)
+	want := []string{"a:2", "b:1", "a:3"}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d buffered events, got %d", len(want), len(events))
+	}
+	for i, event := range events {
+		got := fmt.Sprintf("%s:%d", event.TaskEvent.TaskID, event.TaskEvent.StatusPercentage)
+		if got != want[i] {
+			t.Errorf("event %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+}
+
+func TestCoalesceKeepsStopEvents(t *testing.T) {
+	subscriber := bufferOnly(SubscriberOptions{BufferSize: 2, Policy: CoalesceLatestStatus})
+	stop := taskEvent("a", 1)
+	stop.TaskEvent.EventType = enums.TaskStop
+	subscriber.push(taskEvent("b", 1))
+	subscriber.push(stop)
+	// The Stop Event can't be replaced, so the oldest is dropped instead
+	subscriber.push(taskEvent("a", 2))
+
+	events := drain(subscriber)
+	if len(events) != 2 || events[0].TaskEvent.EventType != enums.TaskStop || events[1].TaskEvent.StatusPercentage != 2 {
+		t.Errorf("unexpected buffered events: %+v", events)
+	}
+}
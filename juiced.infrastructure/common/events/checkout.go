@@ -0,0 +1,17 @@
+package events
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// CheckoutEvent is fired whenever a Task finishes a checkout attempt
+type CheckoutEvent struct {
+	TaskID      string            `json:"taskID"`
+	TaskGroupID string            `json:"taskGroupID"`
+	Retailer    enums.Retailer    `json:"retailer"`
+	Status      enums.OrderStatus `json:"status"`
+	ItemName    string            `json:"itemName"`
+	SKU         string            `json:"sku"`
+	Price       float64           `json:"price"`
+	Quantity    int               `json:"quantity"`
+}
@@ -1,23 +1,123 @@
 package events
 
+import "backend.juicedbot.io/juiced.infrastructure/common/enums"
+
 type EventType = string
 
 const (
-	ConnectEventType EventType = "CONNECT_EVENT"
-	AuthEventType    EventType = "AUTH_EVENT"
-	CloseEventType   EventType = "CLOSE_EVENT"
-	TaskEventType    EventType = "TASK_EVENT"
-	MonitorEventType EventType = "MONITOR_EVENT"
+	ConnectEventType   EventType = "CONNECT_EVENT"
+	AuthEventType      EventType = "AUTH_EVENT"
+	CloseEventType     EventType = "CLOSE_EVENT"
+	TaskEventType      EventType = "TASK_EVENT"
+	MonitorEventType   EventType = "MONITOR_EVENT"
+	CheckoutEventType  EventType = "CHECKOUT_EVENT"
+	ScheduleEventType  EventType = "SCHEDULE_EVENT"
+	ProxyTestEventType EventType = "PROXY_TEST_EVENT"
+)
+
+// Topic is a group of EventTypes that can be subscribed to together
+type Topic = string
+
+const (
+	TaskTopic     Topic = "task"
+	MonitorTopic  Topic = "monitor"
+	CheckoutTopic Topic = "checkout"
+	ScheduleTopic Topic = "schedule"
+	ProxyTopic    Topic = "proxy"
+	SystemTopic   Topic = "system"
 )
 
 // Event is any event that needs to be broadcasted
 type Event struct {
-	EventType    EventType    `json:"eventType"`
-	ConnectEvent ConnectEvent `json:"connectEvent"`
-	CloseEvent   CloseEvent   `json:"closeEvent"`
-	AuthEvent    AuthEvent    `json:"authEvent"`
-	TaskEvent    TaskEvent    `json:"taskEvent"`
-	MonitorEvent MonitorEvent `json:"monitorEvent"`
+	EventType      EventType      `json:"eventType"`
+	ConnectEvent   ConnectEvent   `json:"connectEvent"`
+	CloseEvent     CloseEvent     `json:"closeEvent"`
+	AuthEvent      AuthEvent      `json:"authEvent"`
+	TaskEvent      TaskEvent      `json:"taskEvent"`
+	MonitorEvent   MonitorEvent   `json:"monitorEvent"`
+	CheckoutEvent  CheckoutEvent  `json:"checkoutEvent"`
+	ScheduleEvent  ScheduleEvent  `json:"scheduleEvent"`
+	ProxyTestEvent ProxyTestEvent `json:"proxyTestEvent"`
+}
+
+// Topic returns the Topic that the Event is published on
+func (event Event) Topic() Topic {
+	switch event.EventType {
+	case TaskEventType:
+		return TaskTopic
+	case MonitorEventType:
+		return MonitorTopic
+	case CheckoutEventType:
+		return CheckoutTopic
+	case ScheduleEventType:
+		return ScheduleTopic
+	case ProxyTestEventType:
+		return ProxyTopic
+	}
+	return SystemTopic
+}
+
+// taskGroupID returns the ID of the TaskGroup that the Event belongs to, or "" if it isn't known
+func (event Event) taskGroupID() string {
+	switch event.EventType {
+	case TaskEventType:
+		return event.TaskEvent.TaskGroupID
+	case MonitorEventType:
+		return event.MonitorEvent.MonitorID
+	case CheckoutEventType:
+		return event.CheckoutEvent.TaskGroupID
+	case ScheduleEventType:
+		return event.ScheduleEvent.TaskGroupID
+	}
+	return ""
+}
+
+// retailer returns the retailer that the Event belongs to, or "" if it isn't known
+func (event Event) retailer() string {
+	switch event.EventType {
+	case TaskEventType:
+		return event.TaskEvent.Retailer
+	case MonitorEventType:
+		return event.MonitorEvent.Retailer
+	case CheckoutEventType:
+		return event.CheckoutEvent.Retailer
+	}
+	return ""
+}
+
+// ownerKey returns the key of the Task or Monitor that the Event belongs to, or "" if it doesn't belong to one
+func (event Event) ownerKey() string {
+	switch event.EventType {
+	case TaskEventType:
+		return "task:" + event.TaskEvent.TaskID
+	case MonitorEventType:
+		return "monitor:" + event.MonitorEvent.MonitorID
+	}
+	return ""
+}
+
+// statusKey returns the key that a newer status Event for the same Task or Monitor replaces the Event under, or "" if it can't be replaced.
+// Events with Data and the ones that end a run (Subscribers like ManageEvents act on them) are never replaced.
+func (event Event) statusKey() string {
+	switch event.EventType {
+	case TaskEventType:
+		switch event.TaskEvent.EventType {
+		case enums.TaskStop, enums.TaskFail, enums.TaskComplete:
+			return ""
+		}
+		if event.TaskEvent.Data == nil {
+			return event.ownerKey()
+		}
+	case MonitorEventType:
+		switch event.MonitorEvent.EventType {
+		case enums.MonitorStop, enums.MonitorFail, enums.MonitorComplete:
+			return ""
+		}
+		if event.MonitorEvent.Data == nil {
+			return event.ownerKey()
+		}
+	}
+	return ""
 }
 
 // EventChannel is a channel that can accept an Event
@@ -10,4 +10,8 @@ type MonitorEvent struct {
 	EventType enums.MonitorEventType `json:"eventType"`
 	Data      interface{}            `json:"data"`
 	MonitorID string                 `json:"monitorID"`
+	Retailer  enums.Retailer         `json:"retailer,omitempty"`
+	RunID     string                 `json:"runID,omitempty"`
+	ProxyID   string                 `json:"proxyID,omitempty"`
+	Time      int64                  `json:"time,omitempty"` // when the MonitorEvent was published, in milliseconds since the Unix epoch
 }
@@ -0,0 +1,16 @@
+package events
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// ProxyTestEvent is fired whenever a Proxy of a ProxyGroup that's being tested finishes its test, Tested out of Total
+// Proxies have been tested so far. The last one has Finished set.
+type ProxyTestEvent struct {
+	ProxyGroupID string                   `json:"proxyGroupID"`
+	Result       entities.ProxyTestResult `json:"result"`
+	Health       int                      `json:"health"`
+	Tested       int                      `json:"tested"`
+	Total        int                      `json:"total"`
+	Finished     bool                     `json:"finished"`
+}
@@ -0,0 +1,14 @@
+package events
+
+import (
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// ScheduleEvent is fired whenever a TaskGroupSchedule fires, Error is set if starting or stopping the TaskGroup failed
+type ScheduleEvent struct {
+	ScheduleID  string                  `json:"scheduleID"`
+	TaskGroupID string                  `json:"taskGroupID"`
+	EventType   enums.ScheduleEventType `json:"eventType"`
+	Warnings    []string                `json:"warnings,omitempty"`
+	Error       string                  `json:"error,omitempty"`
+}
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// DefaultBufferSize is the number of Events a Subscriber buffers when SubscriberOptions.BufferSize isn't set
+const DefaultBufferSize = 256
+
+// OverflowPolicy decides which Event a Subscriber gives up when its buffer is full
+type OverflowPolicy int
+
+const (
+	// DropOldest drops the oldest buffered Event to make room for the new one
+	DropOldest OverflowPolicy = iota
+	// CoalesceLatestStatus replaces the newest buffered Event for the same Task or Monitor with the new one if they're both
+	// status updates, and falls back to DropOldest otherwise
+	CoalesceLatestStatus
+)
+
+// SubscriberOptions decides which Events a Subscriber receives and how it buffers them
+type SubscriberOptions struct {
+	// Topics to receive, all Topics if empty
+	Topics []Topic
+	// TaskGroupIDs to receive task, monitor and checkout Events for, all TaskGroups if empty
+	TaskGroupIDs []string
+	// Retailers to receive task, monitor and checkout Events for, all retailers if empty
+	Retailers []enums.Retailer
+	// Filter is checked after the other options, all Events pass if it's nil
+	Filter     func(event Event) bool
+	BufferSize int
+	Policy     OverflowPolicy
+}
+
+// matches returns true if the Event should be delivered to a Subscriber with these options
+func (options *SubscriberOptions) matches(event Event) bool {
+	topic := event.Topic()
+	if len(options.Topics) > 0 && !containsString(options.Topics, topic) {
+		return false
+	}
+	// System Events don't belong to a TaskGroup or retailer, so every Subscriber to the topic gets them
+	if topic != SystemTopic {
+		if len(options.TaskGroupIDs) > 0 && !containsString(options.TaskGroupIDs, event.taskGroupID()) {
+			return false
+		}
+		if len(options.Retailers) > 0 && !containsString(options.Retailers, event.retailer()) {
+			return false
+		}
+	}
+	return options.Filter == nil || options.Filter(event)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscriber receives the Events that match its options through a bounded buffer,
+// so a slow Subscriber only ever delays (or drops) its own Events.
+// Events for the same Task or Monitor are delivered in the order they were published.
+type Subscriber struct {
+	options SubscriberOptions
+	channel EventChannel
+	dropped uint64
+
+	mutex  sync.Mutex
+	buffer []Event
+	head   int
+	count  int
+	ready  chan struct{}
+	done   chan struct{}
+	exited chan struct{}
+}
+
+func newSubscriber(options SubscriberOptions, channel EventChannel) *Subscriber {
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultBufferSize
+	}
+	subscriber := &Subscriber{
+		options: options,
+		channel: channel,
+		buffer:  make([]Event, options.BufferSize),
+		ready:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		exited:  make(chan struct{}),
+	}
+	go subscriber.deliver()
+	return subscriber
+}
+
+// Events returns the channel that the Subscriber's Events are delivered on, it's closed once the Subscriber is removed
+func (subscriber *Subscriber) Events() <-chan Event {
+	return subscriber.channel
+}
+
+// Dropped returns the number of Events the Subscriber has given up because its buffer was full
+func (subscriber *Subscriber) Dropped() uint64 {
+	return atomic.LoadUint64(&subscriber.dropped)
+}
+
+// push buffers the Event without blocking, applying the overflow policy if the buffer is full
+func (subscriber *Subscriber) push(event Event) {
+	subscriber.mutex.Lock()
+	if subscriber.count == len(subscriber.buffer) {
+		atomic.AddUint64(&subscriber.dropped, 1)
+		if subscriber.options.Policy == CoalesceLatestStatus && subscriber.coalesce(event) {
+			subscriber.mutex.Unlock()
+			return
+		}
+		subscriber.head = (subscriber.head + 1) % len(subscriber.buffer)
+		subscriber.count--
+	}
+	subscriber.buffer[(subscriber.head+subscriber.count)%len(subscriber.buffer)] = event
+	subscriber.count++
+	subscriber.mutex.Unlock()
+
+	select {
+	case subscriber.ready <- struct{}{}:
+	default:
+	}
+}
+
+// coalesce replaces the newest buffered Event for the same Task or Monitor with the Event and returns true if that one is a status
+// Event that can be replaced, replacing an older one would deliver the Event before the ones that were published ahead of it
+func (subscriber *Subscriber) coalesce(event Event) bool {
+	key := event.statusKey()
+	if key == "" {
+		return false
+	}
+	for i := subscriber.count - 1; i >= 0; i-- {
+		index := (subscriber.head + i) % len(subscriber.buffer)
+		if subscriber.buffer[index].ownerKey() != key {
+			continue
+		}
+		if subscriber.buffer[index].statusKey() != key {
+			return false
+		}
+		subscriber.buffer[index] = event
+		return true
+	}
+	return false
+}
+
+// pop removes and returns the oldest buffered Event
+func (subscriber *Subscriber) pop() (Event, bool) {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+
+	if subscriber.count == 0 {
+		return Event{}, false
+	}
+	event := subscriber.buffer[subscriber.head]
+	subscriber.buffer[subscriber.head] = Event{}
+	subscriber.head = (subscriber.head + 1) % len(subscriber.buffer)
+	subscriber.count--
+	return event, true
+}
+
+// deliver sends the buffered Events to the Subscriber's channel in order until the Subscriber is removed
+func (subscriber *Subscriber) deliver() {
+	defer close(subscriber.exited)
+	for {
+		event, ok := subscriber.pop()
+		if !ok {
+			select {
+			case <-subscriber.ready:
+				continue
+			case <-subscriber.done:
+				return
+			}
+		}
+		select {
+		case subscriber.channel <- event:
+		case <-subscriber.done:
+			return
+		}
+	}
+}
+
+// stop ends delivery and closes the Subscriber's channel once nothing can send on it anymore
+func (subscriber *Subscriber) stop() {
+	close(subscriber.done)
+	<-subscriber.exited
+	close(subscriber.channel)
+}
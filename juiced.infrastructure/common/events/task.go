@@ -11,6 +11,11 @@ type TaskEvent struct {
 	EventType        enums.TaskEventType `json:"eventType"`
 	Data             interface{}         `json:"data"`
 	TaskID           string              `json:"taskID"`
+	TaskGroupID      string              `json:"taskGroupID,omitempty"`
+	Retailer         enums.Retailer      `json:"retailer,omitempty"`
+	RunID            string              `json:"runID,omitempty"`
+	ProxyID          string              `json:"proxyID,omitempty"`
+	Time             int64               `json:"time,omitempty"` // when the TaskEvent was published, in milliseconds since the Unix epoch
 }
 
 // ProductInfo is sent when publishing the SendingProductInfoToTasks event
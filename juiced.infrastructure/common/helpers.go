@@ -3,6 +3,7 @@ package common
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	crand "crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -128,49 +129,19 @@ func InitDatabase() error {
 	if err != nil {
 		return err
 	}
-	filename := filepath.Join(configPath, "juiced.db")
+	return OpenDatabase(filepath.Join(configPath, "juiced.db"), configPath)
+}
+
+// OpenDatabase opens the database at filename as the database singleton and migrates it, backing it up to backupDir
+// first if it needs to change
+func OpenDatabase(filename string, backupDir string) error {
+	var err error
 	database, err = sqlx.Connect("sqlite3", "file:"+filename+"?cache=shared&mode=rwc")
 	if err != nil {
 		return err
 	}
 
-	for _, schema := range schemas {
-		_, err = database.Exec(schema)
-		if err != nil {
-			fmt.Println(err)
-		}
-		tableName, _ := FindInString(schema, "EXISTS ", " \\(")
-		missing, extra := CompareColumns(ParseColumns(schema), GetCurrentColumns(schema))
-		for i := range extra {
-			extraSplit := strings.Split(extra[i], "|")
-			_, err = database.Exec(fmt.Sprintf("ALTER TABLE %v DROP COLUMN %v", tableName, extraSplit[0]))
-			if err != nil {
-				fmt.Println(err)
-				return err
-			}
-		}
-
-		for i := range missing {
-			missingSplit := strings.Split(missing[i], "|")
-			if strings.Contains(missing[i], "TEXT") {
-				_, err = database.Exec(fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v %v DEFAULT ''", tableName, missingSplit[0], missingSplit[1]))
-			} else if strings.Contains(missing[i], "INTEGER") {
-				_, err = database.Exec(fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v %v DEFAULT 0", tableName, missingSplit[0], missingSplit[1]))
-			} else {
-				_, err = database.Exec(fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v %v", tableName, missingSplit[0], missingSplit[1]))
-			}
-			if err != nil {
-				fmt.Println(err)
-				return err
-			}
-		}
-
-	}
-	_, err = database.Exec("DELETE FROM checkouts WHERE time < 1625782953")
-	if err != nil {
-		fmt.Println(err)
-	}
-	return err
+	return Migrate(database, LatestSchemaVersion(), backupDir)
 }
 
 // GetDatabase retrieves the database connection
@@ -179,14 +150,7 @@ func GetDatabase() *sqlx.DB {
 }
 
 func ProxyCleaner(proxyDirty entities.Proxy) string {
-	if proxyDirty.Host == "" {
-		return ""
-	}
-	if proxyDirty.Username == "" && proxyDirty.Password == "" {
-		return fmt.Sprintf("http://%s:%s", proxyDirty.Host, proxyDirty.Port)
-	} else {
-		return fmt.Sprintf("http://%s:%s@%s:%s", proxyDirty.Username, proxyDirty.Password, proxyDirty.Host, proxyDirty.Port)
-	}
+	return proxyDirty.URL()
 }
 
 func ParseColumns(schema string) (columnNames []string) {
@@ -203,54 +167,6 @@ func ParseColumns(schema string) (columnNames []string) {
 	return
 }
 
-func GetCurrentColumns(schema string) (columnNames []string) {
-	tableName, _ := FindInString(schema, "EXISTS ", " \\(")
-	rows, _ := database.Queryx("PRAGMA table_info(" + tableName + ");")
-
-	defer rows.Close()
-	for rows.Next() {
-		column, _ := rows.SliceScan()
-		columnNames = append(columnNames, column[1].(string)+"|"+column[2].(string))
-	}
-	return
-}
-
-func CompareColumns(x []string, y []string) ([]string, []string) {
-	var missing []string
-	var extra []string
-
-	for _, extraColumn1 := range x {
-		var inside bool
-		extraColumnSplit1 := strings.Split(extraColumn1, "|")
-		for _, extraColumn2 := range y {
-			extraColumnSplit2 := strings.Split(extraColumn2, "|")
-			if extraColumnSplit2[0] == extraColumnSplit1[0] && extraColumnSplit2[1] == extraColumnSplit1[1] {
-				inside = true
-			}
-		}
-		if !inside {
-			missing = append(missing, extraColumn1)
-		}
-	}
-
-	for _, missingColumn1 := range y {
-		var inside bool
-		missingColumnSplit1 := strings.Split(missingColumn1, "|")
-		for _, missingColumn2 := range x {
-
-			missingColumnSplit2 := strings.Split(missingColumn2, "|")
-			if missingColumnSplit2[0] == missingColumnSplit1[0] && missingColumnSplit2[1] == missingColumnSplit1[1] {
-				inside = true
-			}
-		}
-		if !inside {
-			extra = append(extra, missingColumn1)
-		}
-	}
-
-	return missing, extra
-}
-
 func DetectCardType(cardNumber []byte) string {
 	matched, _ := regexp.Match(`^4`, cardNumber)
 	if matched {
@@ -308,7 +224,7 @@ func Aes256Encrypt(plaintext string, key string) (string, error) {
 	}
 	cipherText := make([]byte, aes.BlockSize+len(bPlaintext))
 	bIV := cipherText[:aes.BlockSize]
-	if _, err := rand.Read(bIV); err != nil {
+	if _, err := crand.Read(bIV); err != nil {
 		return "", err
 	}
 
@@ -448,9 +364,10 @@ func ValidCardType(cardNumber []byte, retailer enums.Retailer) bool {
 	return false
 }
 
+// EncryptValues encrypts each value with EncryptField
 func EncryptValues(key string, values ...string) (encryptedValues []string, _ error) {
 	for _, value := range values {
-		e, err := Aes256Encrypt(value, key)
+		e, err := EncryptField(value, key)
 		if err != nil {
 			return encryptedValues, err
 		}
@@ -0,0 +1,609 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Migration is a numbered change to the database schema, Up applies it and Down reverts it
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *sqlx.Tx) error
+	Down    func(tx *sqlx.Tx) error
+}
+
+// migrations is every Migration in order of Version, starting at 1 with no gaps.
+// Never edit a Migration once it has been released, add a new one instead.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "baseline",
+		Up:      baselineUp,
+		Down:    baselineDown,
+	},
+	{
+		Version: 2,
+		Name:    "task run history",
+		Up: func(tx *sqlx.Tx) error {
+			err := execStatements(tx, taskRunsSchema, taskRunEventsSchema)
+			if err != nil {
+				return err
+			}
+			err = addColumn(tx, "settings", "taskHistoryRetentionDays", "INTEGER")
+			if err != nil {
+				return err
+			}
+			return addColumn(tx, "settings", "taskHistoryMaxRuns", "INTEGER")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"DROP TABLE IF EXISTS taskRuns",
+				"DROP TABLE IF EXISTS taskRunEvents",
+				"ALTER TABLE settings DROP COLUMN taskHistoryRetentionDays",
+				"ALTER TABLE settings DROP COLUMN taskHistoryMaxRuns",
+			)
+		},
+	},
+	{
+		Version: 3,
+		Name:    "remove pre-release checkouts",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "DELETE FROM checkouts WHERE time < 1625782953")
+		},
+		// The removed checkouts can't be restored, reverting only lowers the version
+		Down: func(tx *sqlx.Tx) error {
+			return nil
+		},
+	},
+	{
+		Version: 4,
+		Name:    "checkout status and indexes",
+		Up: func(tx *sqlx.Tx) error {
+			err := addColumn(tx, "checkouts", "status", "TEXT")
+			if err != nil {
+				return err
+			}
+			// Only successful checkouts were recorded before
+			return execStatements(tx,
+				"UPDATE checkouts SET status = 'SUCCESS' WHERE status = ''",
+				"CREATE INDEX IF NOT EXISTS checkouts_time ON checkouts (time)",
+				"CREATE INDEX IF NOT EXISTS checkouts_retailer_time ON checkouts (retailer, time)",
+				"CREATE INDEX IF NOT EXISTS checkouts_status_time ON checkouts (status, time)",
+			)
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"DROP INDEX IF EXISTS checkouts_time",
+				"DROP INDEX IF EXISTS checkouts_retailer_time",
+				"DROP INDEX IF EXISTS checkouts_status_time",
+				"ALTER TABLE checkouts DROP COLUMN status",
+			)
+		},
+	},
+	{
+		Version: 5,
+		Name:    "credentials pending encryption",
+		// Migrations run before the user's key is known, so the columns are only recorded here
+		// and commands.EncryptPendingColumns encrypts their values once the key is known
+		Up: func(tx *sqlx.Tx) error {
+			err := execStatements(tx, pendingEncryptionSchema)
+			if err != nil {
+				return err
+			}
+			// Retailer credentials were encrypted with Aes256Encrypt, except for Topps'
+			credentials := []struct {
+				table           string
+				legacyEncrypted bool
+			}{
+				{"amazonTaskInfos", true},
+				{"bestbuyTaskInfos", true},
+				{"disneyTaskInfos", true},
+				{"gamestopTaskInfos", true},
+				{"hotwheelsTaskInfos", true},
+				{"pokemoncenterTaskInfos", true},
+				{"targetTaskInfos", true},
+				{"toppsTaskInfos", false},
+				{"accounts", true},
+			}
+			for _, credential := range credentials {
+				_, err = tx.Exec("INSERT INTO pendingEncryption (tableName, columnName, legacyEncrypted) VALUES (?, 'email', ?), (?, 'password', ?)",
+					credential.table, credential.legacyEncrypted, credential.table, credential.legacyEncrypted)
+				if err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"twoCaptchaAPIKey", "antiCaptchaAPIKey", "capMonsterAPIKey", "aycdAccessToken", "aycdAPIKey"} {
+				_, err = tx.Exec("INSERT INTO pendingEncryption (tableName, columnName, legacyEncrypted) VALUES ('settings', ?, 0)", column)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		// Values that have already been encrypted stay encrypted, they can't be decrypted without the user's key
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "DROP TABLE IF EXISTS pendingEncryption")
+		},
+	},
+	{
+		Version: 6,
+		Name:    "task group schedules",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				taskGroupSchedulesSchema,
+				"CREATE INDEX IF NOT EXISTS taskGroupSchedules_taskGroupID ON taskGroupSchedules (taskGroupID)",
+			)
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "DROP TABLE IF EXISTS taskGroupSchedules")
+		},
+	},
+	{
+		Version: 7,
+		Name:    "remote access settings",
+		Up: func(tx *sqlx.Tx) error {
+			err := addColumn(tx, "settings", "remoteAccessEnabled", "INTEGER")
+			if err != nil {
+				return err
+			}
+			err = addColumn(tx, "settings", "remoteAccessPort", "INTEGER")
+			if err != nil {
+				return err
+			}
+			return addColumn(tx, "settings", "remoteAccessToken", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"ALTER TABLE settings DROP COLUMN remoteAccessEnabled",
+				"ALTER TABLE settings DROP COLUMN remoteAccessPort",
+				"ALTER TABLE settings DROP COLUMN remoteAccessToken",
+			)
+		},
+	},
+	{
+		Version: 8,
+		Name:    "task size fallback",
+		Up: func(tx *sqlx.Tx) error {
+			return addColumn(tx, "tasks", "sizeFallback", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "ALTER TABLE tasks DROP COLUMN sizeFallback")
+		},
+	},
+	{
+		Version: 9,
+		Name:    "shopify keyword monitors",
+		Up: func(tx *sqlx.Tx) error {
+			err := addColumn(tx, "shopifySingleMonitorInfos", "keywords", "TEXT")
+			if err != nil {
+				return err
+			}
+			err = addColumn(tx, "shopifySingleMonitorInfos", "size", "TEXT")
+			if err != nil {
+				return err
+			}
+			return addColumn(tx, "shopifySingleMonitorInfos", "monitorType", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"ALTER TABLE shopifySingleMonitorInfos DROP COLUMN keywords",
+				"ALTER TABLE shopifySingleMonitorInfos DROP COLUMN size",
+				"ALTER TABLE shopifySingleMonitorInfos DROP COLUMN monitorType",
+			)
+		},
+	},
+	{
+		Version: 10,
+		Name:    "target url and keyword monitors",
+		Up: func(tx *sqlx.Tx) error {
+			err := addColumn(tx, "targetSingleMonitorInfos", "url", "TEXT")
+			if err != nil {
+				return err
+			}
+			return addColumn(tx, "targetSingleMonitorInfos", "keywords", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"ALTER TABLE targetSingleMonitorInfos DROP COLUMN url",
+				"ALTER TABLE targetSingleMonitorInfos DROP COLUMN keywords",
+			)
+		},
+	},
+	{
+		Version: 11,
+		Name:    "notification sinks",
+		Up: func(tx *sqlx.Tx) error {
+			return addColumn(tx, "settings", "notificationSinks", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "ALTER TABLE settings DROP COLUMN notificationSinks")
+		},
+	},
+	{
+		Version: 12,
+		Name:    "notification outbox",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				outboxSchema,
+				"CREATE INDEX IF NOT EXISTS outbox_status_nextAttempt ON outbox (status, nextAttempt)",
+			)
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "DROP TABLE IF EXISTS outbox")
+		},
+	},
+	{
+		Version: 13,
+		Name:    "proxy health",
+		Up: func(tx *sqlx.Tx) error {
+			for _, column := range [][2]string{{"latency", "INTEGER"}, {"statusCode", "INTEGER"}, {"testError", "TEXT"}, {"testedAt", "INTEGER"}, {"health", "INTEGER"}} {
+				err := addColumn(tx, "proxys", column[0], column[1])
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"ALTER TABLE proxys DROP COLUMN latency",
+				"ALTER TABLE proxys DROP COLUMN statusCode",
+				"ALTER TABLE proxys DROP COLUMN testError",
+				"ALTER TABLE proxys DROP COLUMN testedAt",
+				"ALTER TABLE proxys DROP COLUMN health",
+			)
+		},
+	},
+	{
+		Version: 14,
+		Name:    "proxy schemes",
+		Up: func(tx *sqlx.Tx) error {
+			return addColumn(tx, "proxys", "scheme", "TEXT")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "ALTER TABLE proxys DROP COLUMN scheme")
+		},
+	},
+	{
+		Version: 15,
+		Name:    "account pool",
+		Up: func(tx *sqlx.Tx) error {
+			for _, column := range [][3]string{{"tasks", "accountID", "TEXT"}, {"accounts", "status", "TEXT"}, {"accounts", "statusDate", "INTEGER"}} {
+				err := addColumn(tx, column[0], column[1], column[2])
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"ALTER TABLE tasks DROP COLUMN accountID",
+				"ALTER TABLE accounts DROP COLUMN status",
+				"ALTER TABLE accounts DROP COLUMN statusDate",
+			)
+		},
+	},
+	{
+		Version: 16,
+		Name:    "rekeyed user key",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx, userKeySchema)
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "DROP TABLE IF EXISTS userKey")
+		},
+	},
+	{
+		Version: 17,
+		Name:    "task run history indexes",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"CREATE INDEX IF NOT EXISTS taskRunEvents_taskID ON taskRunEvents (taskID)",
+				"CREATE INDEX IF NOT EXISTS taskRunEvents_taskGroupID_time ON taskRunEvents (taskGroupID, time)",
+				"CREATE INDEX IF NOT EXISTS taskRuns_runID ON taskRuns (runID)",
+			)
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return execStatements(tx,
+				"DROP INDEX IF EXISTS taskRunEvents_taskID",
+				"DROP INDEX IF EXISTS taskRunEvents_taskGroupID_time",
+				"DROP INDEX IF EXISTS taskRuns_runID",
+			)
+		},
+	},
+}
+
+var migrationsSchema = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT,
+		appliedAt INTEGER
+	)
+`
+
+var taskRunsSchema = `
+	CREATE TABLE IF NOT EXISTS taskRuns (
+		runID TEXT,
+		kind TEXT,
+		taskID TEXT,
+		taskGroupID TEXT,
+		retailer TEXT,
+		proxyID TEXT,
+		status TEXT,
+		statusPercentage INTEGER,
+		eventType TEXT,
+		startTime INTEGER,
+		updateTime INTEGER
+	)
+`
+
+var taskRunEventsSchema = `
+	CREATE TABLE IF NOT EXISTS taskRunEvents (
+		runID TEXT,
+		kind TEXT,
+		taskID TEXT,
+		taskGroupID TEXT,
+		proxyID TEXT,
+		status TEXT,
+		statusPercentage INTEGER,
+		eventType TEXT,
+		time INTEGER
+	)
+`
+
+// pendingEncryptionSchema holds the columns whose values are still plaintext, or in the
+// legacy format if legacyEncrypted is set, until commands.EncryptPendingColumns runs
+var pendingEncryptionSchema = `
+	CREATE TABLE IF NOT EXISTS pendingEncryption (
+		tableName TEXT,
+		columnName TEXT,
+		legacyEncrypted INTEGER
+	)
+`
+
+// userKeySchema holds the key that the database was rekeyed with, encrypted with the key from the auth server
+var userKeySchema = `
+	CREATE TABLE IF NOT EXISTS userKey (
+		wrappedKey TEXT
+	)
+`
+
+var taskGroupSchedulesSchema = `
+	CREATE TABLE IF NOT EXISTS taskGroupSchedules (
+		ID TEXT,
+		taskGroupID TEXT,
+		startTime INTEGER,
+		cron TEXT,
+		stopAfterMinutes INTEGER,
+		stopTime INTEGER,
+		enabled INTEGER,
+		nextStartTime INTEGER,
+		nextStopTime INTEGER,
+		lastFiredTime INTEGER,
+		creationDate INTEGER
+	)
+`
+
+// outboxSchema holds the notifications that haven't been delivered yet, and the dead-lettered ones
+var outboxSchema = `
+	CREATE TABLE IF NOT EXISTS outbox (
+		ID TEXT,
+		destination TEXT,
+		event TEXT,
+		payload TEXT,
+		status TEXT,
+		attempts INTEGER,
+		nextAttempt INTEGER,
+		lastError TEXT,
+		creationDate INTEGER
+	)
+`
+
+// LatestSchemaVersion returns the Version of the last Migration
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// SchemaVersion returns the Version of the last Migration applied to the database, 0 if none have been
+func SchemaVersion(db *sqlx.DB) (int, error) {
+	_, err := db.Exec(migrationsSchema)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int
+	err = db.Get(&version, "SELECT IFNULL(MAX(version), 0) FROM schema_migrations")
+	return version, err
+}
+
+// Migrate applies or reverts Migrations, each in its own transaction, until the database is at the target version.
+// If anything needs to change in a database that already has tables, it's first backed up to backupDir (unless backupDir is empty).
+func Migrate(db *sqlx.DB, target int, backupDir string) error {
+	if target < 0 || target > LatestSchemaVersion() {
+		return fmt.Errorf("unknown schema version %d", target)
+	}
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return fmt.Errorf("migration %q has version %d, expected %d", migration.Name, migration.Version, i+1)
+		}
+	}
+
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if current > LatestSchemaVersion() {
+		return fmt.Errorf("the database is at schema version %d, which is newer than this version supports (%d)", current, LatestSchemaVersion())
+	}
+	if current == target {
+		return nil
+	}
+
+	if backupDir != "" {
+		err = backupDatabase(db, filepath.Join(backupDir, fmt.Sprintf("juiced.db.v%d.%d.bak", current, time.Now().Unix())))
+		if err != nil {
+			return errors.New("backing up the database failed: " + err.Error())
+		}
+	}
+
+	for current < target {
+		migration := migrations[current]
+		err = runMigration(db, migration.Up, func(tx *sqlx.Tx) error {
+			_, err := tx.Exec("INSERT INTO schema_migrations (version, name, appliedAt) VALUES (?, ?, ?)", migration.Version, migration.Name, time.Now().Unix())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("applying migration %d (%s) failed: %s", migration.Version, migration.Name, err.Error())
+		}
+		current++
+	}
+	for current > target {
+		migration := migrations[current-1]
+		err = runMigration(db, migration.Down, func(tx *sqlx.Tx) error {
+			_, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("reverting migration %d (%s) failed: %s", migration.Version, migration.Name, err.Error())
+		}
+		current--
+	}
+
+	return nil
+}
+
+// runMigration runs the migration step and then the bookkeeping step in one transaction, and rolls both back if either fails
+func runMigration(db *sqlx.DB, step func(tx *sqlx.Tx) error, record func(tx *sqlx.Tx) error) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = step(tx)
+	if err != nil {
+		return err
+	}
+	err = record(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// backupDatabase writes a consistent copy of the database to path, unless the database doesn't have any tables yet
+func backupDatabase(db *sqlx.DB, path string) error {
+	var tables int
+	err := db.Get(&tables, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name != 'schema_migrations'")
+	if err != nil || tables == 0 {
+		return err
+	}
+
+	_, err = db.Exec("VACUUM INTO ?", path)
+	return err
+}
+
+// baselineUp creates every table in schemas. Databases created before migrations existed already have
+// the tables, but may be missing columns that were added since they were created, so those are added too.
+func baselineUp(tx *sqlx.Tx) error {
+	for _, schema := range schemas {
+		_, err := tx.Exec(schema)
+		if err != nil {
+			return err
+		}
+		tableName, err := FindInString(schema, "EXISTS ", " \\(")
+		if err != nil {
+			return err
+		}
+		for _, column := range ParseColumns(schema) {
+			columnSplit := strings.Split(column, "|")
+			err = addColumn(tx, tableName, columnSplit[0], columnSplit[1])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// baselineDown drops every table in schemas
+func baselineDown(tx *sqlx.Tx) error {
+	for _, schema := range schemas {
+		tableName, err := FindInString(schema, "EXISTS ", " \\(")
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("DROP TABLE IF EXISTS " + tableName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execStatements executes each statement in order
+func execStatements(tx *sqlx.Tx, statements ...string) error {
+	for _, statement := range statements {
+		_, err := tx.Exec(statement)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumn adds the column to the table unless it already has it, existing rows get an empty
+// TEXT or a 0 INTEGER so that they can still be scanned into non-pointer fields
+func addColumn(tx *sqlx.Tx, tableName string, columnName string, columnType string) error {
+	columns, err := tableColumns(tx, tableName)
+	if err != nil {
+		return err
+	}
+	if InSlice(columns, columnName) {
+		return nil
+	}
+
+	statement := fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v %v", tableName, columnName, columnType)
+	switch columnType {
+	case "TEXT":
+		statement += " DEFAULT ''"
+	case "INTEGER":
+		statement += " DEFAULT 0"
+	}
+	_, err = tx.Exec(statement)
+	return err
+}
+
+// tableColumns returns the names of the table's columns
+func tableColumns(tx *sqlx.Tx, tableName string) ([]string, error) {
+	columns := []string{}
+	rows, err := tx.Queryx("PRAGMA table_info(" + tableName + ")")
+	if err != nil {
+		return columns, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		column := struct {
+			CID          int         `db:"cid"`
+			Name         string      `db:"name"`
+			Type         string      `db:"type"`
+			NotNull      int         `db:"notnull"`
+			DefaultValue interface{} `db:"dflt_value"`
+			PrimaryKey   int         `db:"pk"`
+		}{}
+		err = rows.StructScan(&column)
+		if err != nil {
+			return columns, err
+		}
+		columns = append(columns, column.Name)
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openFixture returns a database in a temporary directory loaded with testdata/legacy.sql, and the directory
+func openFixture(t *testing.T) (*sqlx.DB, string) {
+	dir := t.TempDir()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(dir, "juiced.db")+"?mode=rwc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	fixture, err := ioutil.ReadFile(filepath.Join("testdata", "legacy.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(string(fixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, dir
+}
+
+func countRows(t *testing.T, db *sqlx.DB, query string, args ...interface{}) int {
+	var count int
+	err := db.Get(&count, query, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestMigrationsAreNumberedInOrder(t *testing.T) {
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("migration %q has version %d, expected %d", migration.Name, migration.Version, i+1)
+		}
+		if migration.Up == nil || migration.Down == nil {
+			t.Errorf("migration %d (%s) needs both an Up and a Down", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestMigrateLegacyFixture(t *testing.T) {
+	db, dir := openFixture(t)
+
+	err := Migrate(db, LatestSchemaVersion(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	// Existing rows are kept, and columns missing from the legacy tables are added with defaults
+	if countRows(t, db, "SELECT COUNT(*) FROM tasks WHERE ID = 'task'") != 1 {
+		t.Error("the legacy task was lost")
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM settings WHERE successDiscordWebhook != '' AND darkMode = 0 AND useAnimations = 0 AND taskHistoryRetentionDays = 0") != 1 {
+		t.Error("the legacy settings weren't migrated")
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM taskRuns") != 0 {
+		t.Error("expected an empty taskRuns table")
+	}
+	// Only the pre-release checkout is removed, and the other is marked as successful
+	if count := countRows(t, db, "SELECT COUNT(*) FROM checkouts WHERE status = 'SUCCESS'"); count != 1 {
+		t.Errorf("expected 1 successful checkout, got %d", count)
+	}
+
+	// Credentials are left for commands.EncryptPendingColumns to encrypt once the user's key is known
+	if count := countRows(t, db, "SELECT COUNT(*) FROM pendingEncryption WHERE tableName = 'settings' AND legacyEncrypted = 0"); count != 5 {
+		t.Errorf("expected the 5 settings secrets to be pending encryption, got %d", count)
+	}
+
+	backups, err := filepath.Glob(filepath.Join(dir, "juiced.db.v0.*.bak"))
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("expected a backup of the legacy database, got %v (%v)", backups, err)
+	}
+	backup, err := sqlx.Connect("sqlite3", "file:"+backups[0]+"?mode=ro")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backup.Close()
+	if countRows(t, backup, "SELECT COUNT(*) FROM checkouts") != 2 {
+		t.Error("expected the backup to have both legacy checkouts")
+	}
+
+	// Migrating again doesn't change anything, or take another backup
+	err = Migrate(db, LatestSchemaVersion(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backups, _ = filepath.Glob(filepath.Join(dir, "*.bak"))
+	if len(backups) != 1 {
+		t.Errorf("expected no new backups, got %v", backups)
+	}
+}
+
+func TestMigrateDownAndUp(t *testing.T) {
+	db, _ := openFixture(t)
+
+	err := Migrate(db, LatestSchemaVersion(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Migrate(db, 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'taskRuns'") != 0 {
+		t.Error("expected reverting to version 1 to drop taskRuns")
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM pragma_table_info('settings') WHERE name = 'taskHistoryMaxRuns'") != 0 {
+		t.Error("expected reverting to version 1 to drop settings.taskHistoryMaxRuns")
+	}
+
+	err = Migrate(db, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := countRows(t, db, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name != 'schema_migrations'"); count != 0 {
+		t.Errorf("expected reverting to version 0 to drop every table, %d are left", count)
+	}
+
+	err = Migrate(db, LatestSchemaVersion(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, _ := SchemaVersion(db)
+	if version != LatestSchemaVersion() {
+		t.Errorf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name LIKE 'taskRun%'") != 3 {
+		t.Error("expected the task run history to be indexed")
+	}
+}
+
+func TestFailedMigrationRollsBack(t *testing.T) {
+	db, _ := openFixture(t)
+	err := Migrate(db, LatestSchemaVersion(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	original := migrations
+	defer func() { migrations = original }()
+	migrations = append(append([]Migration{}, original...), Migration{
+		Version: len(original) + 1,
+		Name:    "broken",
+		Up: func(tx *sqlx.Tx) error {
+			return execStatements(tx, "CREATE TABLE halfMigrated (ID TEXT)", "NOT VALID SQL")
+		},
+		Down: func(tx *sqlx.Tx) error {
+			return nil
+		},
+	})
+
+	err = Migrate(db, LatestSchemaVersion(), "")
+	if err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+	version, _ := SchemaVersion(db)
+	if version != len(original) {
+		t.Errorf("expected schema version %d after the failed migration, got %d", len(original), version)
+	}
+	if countRows(t, db, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'halfMigrated'") != 0 {
+		t.Error("expected the failed migration's changes to be rolled back")
+	}
+}
@@ -1,5 +1,7 @@
 package common
 
+import "fmt"
+
 type CipherTextTooShortError struct{}
 
 func (e *CipherTextTooShortError) Error() string {
@@ -11,3 +13,45 @@ type CipherTextNotMultipleOfBlockSizeError struct{}
 func (e *CipherTextNotMultipleOfBlockSizeError) Error() string {
 	return "cipher text not a multiple of the block size"
 }
+
+type MalformedEnvelopeError struct{}
+
+func (e *MalformedEnvelopeError) Error() string {
+	return "encrypted value is malformed"
+}
+
+type UnsupportedEnvelopeVersionError struct {
+	Version byte
+}
+
+func (e *UnsupportedEnvelopeVersionError) Error() string {
+	return fmt.Sprintf("encrypted value has unsupported version %d", e.Version)
+}
+
+type EncryptionKeyMismatchError struct {
+	KeyID string
+}
+
+func (e *EncryptionKeyMismatchError) Error() string {
+	return "encrypted value was encrypted with a different key (" + e.KeyID + ")"
+}
+
+type NotABackupError struct{}
+
+func (e *NotABackupError) Error() string {
+	return "the file is not a backup"
+}
+
+type UnsupportedBackupVersionError struct {
+	Version byte
+}
+
+func (e *UnsupportedBackupVersionError) Error() string {
+	return fmt.Sprintf("the backup has unsupported version %d", e.Version)
+}
+
+type IncorrectBackupPassphraseError struct{}
+
+func (e *IncorrectBackupPassphraseError) Error() string {
+	return "the passphrase is incorrect or the backup is damaged"
+}
@@ -0,0 +1,200 @@
+// Package notifications sends notifications about checkouts, failed tasks and restocks to the NotificationSinks in
+// the user's Settings, which can be JSON webhooks, Slack, Telegram or email.
+package notifications
+
+import (
+	e "errors"
+	"log"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+)
+
+// Message is what a notification is about. Its fields are named after the ones of the ProcessCheckoutInfo that
+// checkouts are processed with, so templates use the same fields for every event. Fields that don't apply to the
+// Event are empty.
+type Message struct {
+	Event        enums.NotificationEvent `json:"event"`
+	Success      bool                    `json:"success"`
+	Status       string                  `json:"status"`
+	ItemName     string                  `json:"itemName"`
+	ImageURL     string                  `json:"imageURL"`
+	Sku          string                  `json:"sku"`
+	Retailer     enums.Retailer          `json:"retailer"`
+	Price        float64                 `json:"price"`
+	Quantity     int                     `json:"quantity"`
+	MsToCheckout int64                   `json:"msToCheckout"`
+	ProfileName  string                  `json:"profileName"`
+	TaskID       string                  `json:"taskID"`
+	TaskGroupID  string                  `json:"taskGroupID"`
+}
+
+// Notifier sends notifications to a NotificationSink, text is the Message rendered with the sink's template
+type Notifier interface {
+	Notify(message Message, text string) error
+}
+
+// New returns the Notifier for the sink's Type, or an error if the sink is missing a field that its Type needs
+func New(sink entities.NotificationSink) (Notifier, error) {
+	switch sink.Type {
+	case enums.NotificationSinkWebhook:
+		if sink.URL == "" {
+			return nil, e.New("webhook sinks need a url")
+		}
+		return &webhookNotifier{url: sink.URL}, nil
+	case enums.NotificationSinkSlack:
+		if sink.URL == "" {
+			return nil, e.New("slack sinks need a url")
+		}
+		return &slackNotifier{url: sink.URL}, nil
+	case enums.NotificationSinkTelegram:
+		if sink.BotToken == "" || sink.ChatID == "" {
+			return nil, e.New("telegram sinks need a botToken and a chatID")
+		}
+		return &telegramNotifier{botToken: sink.BotToken, chatID: sink.ChatID}, nil
+	case enums.NotificationSinkSMTP:
+		if sink.SMTPHost == "" || sink.EmailFrom == "" || len(sink.EmailTo) == 0 {
+			return nil, e.New("smtp sinks need an smtpHost, an emailFrom and at least one emailTo")
+		}
+		return newSMTPNotifier(sink), nil
+	}
+	return nil, e.New("unknown sink type " + sink.Type)
+}
+
+// Validate returns an error if the sink can't send notifications, because it's missing a field or has a rule for an
+// unknown event or with a template that doesn't render, like one that uses a field that a Message doesn't have
+func Validate(sink entities.NotificationSink) error {
+	_, err := New(sink)
+	if err != nil {
+		return err
+	}
+	for _, rule := range sink.Rules {
+		if _, ok := DefaultTemplates[rule.Event]; !ok || rule.Event == enums.NotificationTest {
+			return e.New("unknown event " + rule.Event)
+		}
+		if rule.Template != "" {
+			_, err = Render(rule.Template, Message{Event: rule.Event})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Route returns the sink's first rule that matches the Message, test Messages match every sink with the default template
+func Route(sink entities.NotificationSink, message Message) (entities.NotificationRule, bool) {
+	if message.Event == enums.NotificationTest {
+		return entities.NotificationRule{Event: enums.NotificationTest}, true
+	}
+	for _, rule := range sink.Rules {
+		if rule.Event != message.Event {
+			continue
+		}
+		if len(rule.Retailers) == 0 {
+			return rule, true
+		}
+		for _, retailer := range rule.Retailers {
+			if retailer == message.Retailer {
+				return rule, true
+			}
+		}
+	}
+	return entities.NotificationRule{}, false
+}
+
+// Send renders the Message with the template of the sink's rule for it and sends it to the sink.
+// It doesn't send anything if none of the sink's rules match the Message.
+func Send(sink entities.NotificationSink, message Message) error {
+	rule, ok := Route(sink, message)
+	if !ok {
+		return nil
+	}
+	notifier, err := New(sink)
+	if err != nil {
+		return err
+	}
+	text, err := Render(rule.Template, message)
+	if err != nil {
+		return err
+	}
+	return notifier.Notify(message, text)
+}
+
+// Notify queues the Message in the outbox for every enabled sink that has a rule for it, errors are logged
+func Notify(sinks []entities.NotificationSink, message Message) {
+	for _, sink := range sinks {
+		if !sink.Enabled {
+			continue
+		}
+		entry, ok, err := Entry(sink, message)
+		if err == nil && ok {
+			err = queue(entry)
+		}
+		if err != nil {
+			log.Println("Error queueing a " + message.Event + " notification for " + sink.Name + ": " + err.Error())
+		}
+	}
+}
+
+// Listen queues notifications for the failed tasks and restocks that are published on the EventBus, to the sinks that
+// getSinks returns at the time. It returns once the EventBus removes its Subscriber.
+func Listen(eventBus *events.EventBus, getSinks func() ([]entities.NotificationSink, error)) {
+	subscriber := eventBus.AddSubscriber(events.SubscriberOptions{
+		Topics: []events.Topic{events.TaskTopic, events.MonitorTopic},
+		Filter: func(event events.Event) bool {
+			_, ok := EventMessage(event)
+			return ok
+		},
+	})
+	for event := range subscriber.Events() {
+		message, _ := EventMessage(event)
+		sinks, err := getSinks()
+		if err != nil {
+			log.Println("Error getting the notification sinks: " + err.Error())
+			continue
+		}
+		Notify(sinks, message)
+	}
+}
+
+// EventMessage returns the Message for a task failure or restock Event, or false if the Event isn't one
+func EventMessage(event events.Event) (Message, bool) {
+	switch event.EventType {
+	case events.TaskEventType:
+		if event.TaskEvent.EventType != enums.TaskFail {
+			return Message{}, false
+		}
+		return Message{
+			Event:       enums.NotificationTaskFailure,
+			Status:      event.TaskEvent.Status,
+			Retailer:    event.TaskEvent.Retailer,
+			TaskID:      event.TaskEvent.TaskID,
+			TaskGroupID: event.TaskEvent.TaskGroupID,
+		}, true
+	case events.MonitorEventType:
+		if event.MonitorEvent.Status != enums.SendingProductInfoToTasks {
+			return Message{}, false
+		}
+		message := Message{
+			Event:       enums.NotificationMonitorRestock,
+			Status:      event.MonitorEvent.Status,
+			Retailer:    event.MonitorEvent.Retailer,
+			TaskGroupID: event.MonitorEvent.MonitorID,
+		}
+		var productInfo events.ProductInfo
+		switch data := event.MonitorEvent.Data.(type) {
+		case events.ProductInfo:
+			productInfo = data
+		case *events.ProductInfo:
+			productInfo = *data
+		}
+		if len(productInfo.Products) > 0 {
+			message.ItemName = productInfo.Products[0].ProductName
+			message.ImageURL = productInfo.Products[0].ProductImageURL
+		}
+		return message, true
+	}
+	return Message{}, false
+}
@@ -0,0 +1,176 @@
+package notifications
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+)
+
+var checkout = Message{
+	Event:        enums.NotificationCheckoutSuccess,
+	Success:      true,
+	Status:       enums.OrderStatusSuccess,
+	ItemName:     "Pokemon Booster Box",
+	Sku:          "81114477",
+	Retailer:     enums.Target,
+	Price:        143.99,
+	Quantity:     1,
+	MsToCheckout: 2310,
+	ProfileName:  "Main",
+}
+
+// recorder returns a server that records the path and JSON body of every request it receives
+func recorder(t *testing.T, status int) (*httptest.Server, *[]string, *[]map[string]interface{}) {
+	paths := []string{}
+	bodies := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("%s: expected a JSON body, got %s", r.URL.Path, data)
+		}
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		w.WriteHeader(status)
+	}))
+	return server, &paths, &bodies
+}
+
+func TestRender(t *testing.T) {
+	for event := range DefaultTemplates {
+		message := checkout
+		message.Event = event
+		if _, err := Render("", message); err != nil {
+			t.Errorf("%s: the default template doesn't render: %s", event, err.Error())
+		}
+	}
+
+	text, err := Render(`{{.ItemName}} for ${{printf "%.2f" .Price}}`, checkout)
+	if err != nil || text != "Pokemon Booster Box for $143.99" {
+		t.Errorf("expected the custom template to render, got %q, %v", text, err)
+	}
+	if _, err = Render(`{{.Size}}`, checkout); err == nil {
+		t.Error("expected an error rendering a field that a Message doesn't have")
+	}
+}
+
+func TestRoute(t *testing.T) {
+	sink := entities.NotificationSink{Rules: []entities.NotificationRule{
+		{Event: enums.NotificationCheckoutSuccess, Retailers: []enums.Retailer{enums.Walmart}, Template: "walmart"},
+		{Event: enums.NotificationCheckoutSuccess, Template: "any"},
+		{Event: enums.NotificationTaskFailure},
+	}}
+	cases := []struct {
+		event    enums.NotificationEvent
+		retailer enums.Retailer
+		template string
+		ok       bool
+	}{
+		{enums.NotificationCheckoutSuccess, enums.Walmart, "walmart", true},
+		{enums.NotificationCheckoutSuccess, enums.Target, "any", true},
+		{enums.NotificationTaskFailure, enums.Target, "", true},
+		{enums.NotificationCheckoutDecline, enums.Target, "", false},
+		{enums.NotificationTest, "", "", true},
+	}
+	for _, c := range cases {
+		rule, ok := Route(sink, Message{Event: c.event, Retailer: c.retailer})
+		if ok != c.ok || rule.Template != c.template {
+			t.Errorf("%s from %s: expected %v with template %q, got %v with %q", c.event, c.retailer, c.ok, c.template, ok, rule.Template)
+		}
+	}
+}
+
+func TestSinks(t *testing.T) {
+	server, paths, bodies := recorder(t, http.StatusOK)
+	defer server.Close()
+	telegramAPIURL = server.URL
+	rules := []entities.NotificationRule{{Event: enums.NotificationCheckoutSuccess, Template: "{{.ItemName}}"}}
+
+	sinks := []entities.NotificationSink{
+		{Type: enums.NotificationSinkWebhook, URL: server.URL + "/webhook", Rules: rules},
+		{Type: enums.NotificationSinkSlack, URL: server.URL + "/slack", Rules: rules},
+		{Type: enums.NotificationSinkTelegram, BotToken: "123:abc", ChatID: "42", Rules: rules},
+	}
+	for _, sink := range sinks {
+		if err := Send(sink, checkout); err != nil {
+			t.Errorf("%s: %s", sink.Type, err.Error())
+		}
+	}
+	expectedPaths := []string{"/webhook", "/slack", "/bot123:abc/sendMessage"}
+	if strings.Join(*paths, " ") != strings.Join(expectedPaths, " ") {
+		t.Fatalf("expected requests to %v, got %v", expectedPaths, *paths)
+	}
+	webhook, slack, telegram := (*bodies)[0], (*bodies)[1], (*bodies)[2]
+	if webhook["event"] != enums.NotificationCheckoutSuccess || webhook["text"] != checkout.ItemName || webhook["data"].(map[string]interface{})["sku"] != checkout.Sku {
+		t.Errorf("expected the webhook to have the event, text and Message, got %v", webhook)
+	}
+	if slack["text"] != checkout.ItemName {
+		t.Errorf("expected the Slack message to have the text, got %v", slack)
+	}
+	if telegram["chat_id"] != "42" || telegram["text"] != checkout.ItemName {
+		t.Errorf("expected the Telegram message to have the chat and text, got %v", telegram)
+	}
+
+	var address, from string
+	var email []byte
+	sendMail = func(addr string, auth smtp.Auth, f string, to []string, msg []byte) error {
+		address, from, email = addr, f, msg
+		return nil
+	}
+	defer func() { sendMail = smtp.SendMail }()
+	sink := entities.NotificationSink{Type: enums.NotificationSinkSMTP, SMTPHost: "smtp.example.com", EmailFrom: "bot@example.com", EmailTo: []string{"me@example.com"}, Rules: rules}
+	if err := Send(sink, checkout); err != nil {
+		t.Fatal(err)
+	}
+	if address != "smtp.example.com:587" || from != "bot@example.com" || !strings.Contains(string(email), "Subject: Juiced: Checkout succeeded\r\n") || !strings.HasSuffix(string(email), "\r\n\r\n"+checkout.ItemName+"\r\n") {
+		t.Errorf("expected an email to %s from %s with the subject and text, got %s from %s:\n%s", "smtp.example.com:587", "bot@example.com", address, from, email)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	server, _, _ := recorder(t, http.StatusForbidden)
+	defer server.Close()
+	sink := entities.NotificationSink{Type: enums.NotificationSinkSlack, URL: server.URL, Rules: []entities.NotificationRule{{Event: enums.NotificationCheckoutSuccess}}}
+	if err := Send(sink, checkout); err == nil {
+		t.Error("expected an error when the sink responds with 403")
+	}
+	if err := Validate(entities.NotificationSink{Type: enums.NotificationSinkTelegram, BotToken: "123:abc"}); err == nil {
+		t.Error("expected telegram sinks without a chatID to be invalid")
+	}
+	sink.Rules = []entities.NotificationRule{{Event: "restock"}}
+	if err := Validate(sink); err == nil {
+		t.Error("expected rules for unknown events to be invalid")
+	}
+}
+
+func TestEventMessage(t *testing.T) {
+	failure := events.Event{EventType: events.TaskEventType, TaskEvent: events.TaskEvent{EventType: enums.TaskFail, Status: "Bad Proxy", TaskID: "task", Retailer: enums.Target}}
+	message, ok := EventMessage(failure)
+	if !ok || message.Event != enums.NotificationTaskFailure || message.Status != "Bad Proxy" || message.TaskID != "task" {
+		t.Errorf("expected a task failure Message, got %+v", message)
+	}
+
+	restock := events.Event{EventType: events.MonitorEventType, MonitorEvent: events.MonitorEvent{
+		Status:    enums.SendingProductInfoToTasks,
+		MonitorID: "group",
+		Retailer:  enums.BestBuy,
+		Data:      events.ProductInfo{Products: []events.Product{{ProductName: "RTX 3080"}}},
+	}}
+	message, ok = EventMessage(restock)
+	if !ok || message.Event != enums.NotificationMonitorRestock || message.ItemName != "RTX 3080" || message.TaskGroupID != "group" {
+		t.Errorf("expected a restock Message, got %+v", message)
+	}
+
+	update := events.Event{EventType: events.TaskEventType, TaskEvent: events.TaskEvent{EventType: enums.TaskUpdate}}
+	if _, ok = EventMessage(update); ok {
+		t.Error("expected task updates not to be notified")
+	}
+}
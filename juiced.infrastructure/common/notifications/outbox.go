@@ -0,0 +1,165 @@
+package notifications
+
+import (
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"github.com/google/uuid"
+)
+
+// ErrNoDestination is returned by Deliver when the OutboxEntry's destination was removed or disabled since it was
+// queued, so there's nowhere left to deliver it to
+var ErrNoDestination = e.New("the destination was removed from the settings")
+
+// StatusError is returned when a destination responds with a status other than 2xx. RetryAfter is how long the
+// destination asked to wait before trying again, if it did.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration
+}
+
+func (err *StatusError) Error() string {
+	return "the sink responded with " + err.Status
+}
+
+// permanentError is an error that delivering again won't fix, like a sink that's missing a field
+type permanentError struct {
+	err error
+}
+
+func (err *permanentError) Error() string {
+	return err.err.Error()
+}
+
+// Permanent returns true if delivering again can't succeed after the error, so the OutboxEntry shouldn't be retried.
+// That's the case for 4xx statuses other than timeouts and rate limits, and for SMTP servers rejecting an email.
+func Permanent(err error) bool {
+	var permanent *permanentError
+	if e.As(err, &permanent) {
+		return true
+	}
+	var statusError *StatusError
+	if e.As(err, &statusError) {
+		return statusError.StatusCode >= 400 && statusError.StatusCode < 500 &&
+			statusError.StatusCode != http.StatusRequestTimeout && statusError.StatusCode != http.StatusTooManyRequests
+	}
+	var smtpError *textproto.Error
+	if e.As(err, &smtpError) {
+		return smtpError.Code >= 500
+	}
+	return false
+}
+
+// RetryAfter returns how long the destination asked to wait before delivering again, or 0 if it didn't
+func RetryAfter(err error) time.Duration {
+	var statusError *StatusError
+	if e.As(err, &statusError) {
+		return statusError.RetryAfter
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header, which is a number of seconds or an HTTP date.
+// Discord sends fractional seconds, which are rounded up.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(header, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+	}
+	if date, err := http.ParseTime(header); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// outboxPayload is the Payload of the OutboxEntries of NotificationSinks. The text is rendered when the entry is
+// queued, so editing a template doesn't change notifications that were already sent once.
+type outboxPayload struct {
+	Message Message `json:"message"`
+	Text    string  `json:"text"`
+}
+
+// Entry returns a pending OutboxEntry that delivers the Message to the sink, rendered with the template of the
+// sink's rule for it. It returns false if none of the sink's rules match the Message.
+func Entry(sink entities.NotificationSink, message Message) (entities.OutboxEntry, bool, error) {
+	rule, ok := Route(sink, message)
+	if !ok {
+		return entities.OutboxEntry{}, false, nil
+	}
+	text, err := Render(rule.Template, message)
+	if err != nil {
+		return entities.OutboxEntry{}, false, err
+	}
+	payload, err := json.Marshal(outboxPayload{Message: message, Text: text})
+	if err != nil {
+		return entities.OutboxEntry{}, false, err
+	}
+	return NewOutboxEntry(sink.ID, message.Event, string(payload)), true, nil
+}
+
+// NewOutboxEntry returns a pending OutboxEntry with the payload that can be delivered straight away
+func NewOutboxEntry(destination string, event enums.NotificationEvent, payload string) entities.OutboxEntry {
+	now := time.Now().Unix()
+	return entities.OutboxEntry{
+		ID:           uuid.New().String(),
+		Destination:  destination,
+		Event:        event,
+		Payload:      payload,
+		Status:       enums.OutboxPending,
+		NextAttempt:  now,
+		CreationDate: now,
+	}
+}
+
+// Deliver sends the OutboxEntry to its destination in the settings. It returns ErrNoDestination if the destination
+// isn't in the settings anymore, and an error that Permanent returns true for if the entry can't ever be delivered.
+func Deliver(entry entities.OutboxEntry, settings entities.Settings) error {
+	switch entry.Destination {
+	case enums.DiscordSuccessDestination, enums.DiscordFailureDestination:
+		webhookURL := settings.SuccessDiscordWebhook
+		if entry.Destination == enums.DiscordFailureDestination {
+			webhookURL = settings.FailureDiscordWebhook
+		}
+		if webhookURL == "" {
+			return ErrNoDestination
+		}
+		return postJSON(webhookURL, json.RawMessage(entry.Payload))
+	}
+
+	for _, sink := range settings.NotificationSinks {
+		if sink.ID != entry.Destination {
+			continue
+		}
+		if !sink.Enabled {
+			return ErrNoDestination
+		}
+		notifier, err := New(sink)
+		if err != nil {
+			return &permanentError{err: err}
+		}
+		payload := outboxPayload{}
+		err = json.Unmarshal([]byte(entry.Payload), &payload)
+		if err != nil {
+			return &permanentError{err: fmt.Errorf("the payload is invalid: %w", err)}
+		}
+		return notifier.Notify(payload.Message, payload.Text)
+	}
+	return ErrNoDestination
+}
+
+// queue adds the OutboxEntry to the database, the OutboxStore delivers it
+var queue = commands.CreateOutboxEntry
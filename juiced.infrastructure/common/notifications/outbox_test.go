@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 10, 15, 10, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"30":                            30 * time.Second,
+		"1.4":                           time.Second,
+		"-5":                            0,
+		"soon":                          0,
+		"Fri, 15 Oct 2021 10:02:00 GMT": 2 * time.Minute,
+		"Fri, 15 Oct 2021 09:00:00 GMT": 0,
+	}
+	for header, expected := range cases {
+		if retryAfter := parseRetryAfter(header, now); retryAfter != expected {
+			t.Errorf("%q: expected %s, got %s", header, expected, retryAfter)
+		}
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	cases := []struct {
+		err       error
+		permanent bool
+	}{
+		{&StatusError{StatusCode: http.StatusNotFound}, true},
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, false},
+		{&StatusError{StatusCode: http.StatusRequestTimeout}, false},
+		{&StatusError{StatusCode: http.StatusBadGateway}, false},
+		{&textproto.Error{Code: 550}, true},
+		{&textproto.Error{Code: 421}, false},
+		{&permanentError{err: errors.New("invalid")}, true},
+		{errors.New("connection refused"), false},
+	}
+	for _, c := range cases {
+		if Permanent(c.err) != c.permanent {
+			t.Errorf("%#v: expected Permanent to be %v", c.err, c.permanent)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.Header().Set("Retry-After", "12")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	sink := entities.NotificationSink{ID: "sink", Enabled: true, Type: enums.NotificationSinkSlack, URL: server.URL, Rules: []entities.NotificationRule{{Event: enums.NotificationCheckoutSuccess}}}
+	settings := entities.Settings{SuccessDiscordWebhook: server.URL + "/limited", NotificationSinks: []entities.NotificationSink{sink}}
+
+	entry, ok, err := Entry(sink, checkout)
+	if err != nil || !ok || entry.Destination != "sink" || entry.Status != enums.OutboxPending {
+		t.Fatalf("expected a pending entry for the sink, got %+v, %v, %v", entry, ok, err)
+	}
+	if err = Deliver(entry, settings); err != nil {
+		t.Errorf("expected the entry to be delivered, got %v", err)
+	}
+
+	discord := NewOutboxEntry(enums.DiscordSuccessDestination, "", `{"embeds":[]}`)
+	if err = Deliver(discord, settings); RetryAfter(err) != 12*time.Second || Permanent(err) {
+		t.Errorf("expected a rate limit to be retried after 12s, got %v", err)
+	}
+	discord.Destination = enums.DiscordFailureDestination
+	if err = Deliver(discord, settings); err != ErrNoDestination {
+		t.Errorf("expected ErrNoDestination without a failure webhook, got %v", err)
+	}
+
+	settings.NotificationSinks[0].URL = ""
+	if err = Deliver(entry, settings); !Permanent(err) {
+		t.Errorf("expected an invalid sink to be permanent, got %v", err)
+	}
+	settings.NotificationSinks = nil
+	if err = Deliver(entry, settings); err != ErrNoDestination {
+		t.Errorf("expected ErrNoDestination for a removed sink, got %v", err)
+	}
+}
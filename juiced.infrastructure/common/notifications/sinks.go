@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// defaultSMTPPort is the submission port that smtp sinks use if they don't have an SMTPPort
+const defaultSMTPPort = 587
+
+// telegramAPIURL is the Telegram Bot API that telegram sinks send through
+var telegramAPIURL = "https://api.telegram.org"
+
+// sendMail sends the emails of smtp sinks
+var sendMail = smtp.SendMail
+
+var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// webhookNotifier posts the text and the Message's fields as JSON
+type webhookNotifier struct {
+	url string
+}
+
+type webhookBody struct {
+	Event string  `json:"event"`
+	Text  string  `json:"text"`
+	Data  Message `json:"data"`
+}
+
+func (notifier *webhookNotifier) Notify(message Message, text string) error {
+	return postJSON(notifier.url, webhookBody{Event: message.Event, Text: text, Data: message})
+}
+
+// slackNotifier posts the text to a Slack incoming webhook
+type slackNotifier struct {
+	url string
+}
+
+func (notifier *slackNotifier) Notify(message Message, text string) error {
+	return postJSON(notifier.url, map[string]string{"text": text})
+}
+
+// telegramNotifier sends the text to a Telegram chat with the Bot API's sendMessage method
+type telegramNotifier struct {
+	botToken string
+	chatID   string
+}
+
+func (notifier *telegramNotifier) Notify(message Message, text string) error {
+	return postJSON(telegramAPIURL+"/bot"+notifier.botToken+"/sendMessage", map[string]string{"chat_id": notifier.chatID, "text": text})
+}
+
+// smtpNotifier emails the text through an SMTP server, authenticating if it has a username
+type smtpNotifier struct {
+	address  string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func newSMTPNotifier(sink entities.NotificationSink) *smtpNotifier {
+	port := sink.SMTPPort
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	return &smtpNotifier{
+		address:  fmt.Sprintf("%s:%d", sink.SMTPHost, port),
+		host:     sink.SMTPHost,
+		username: sink.SMTPUsername,
+		password: sink.SMTPPassword,
+		from:     sink.EmailFrom,
+		to:       sink.EmailTo,
+	}
+}
+
+func (notifier *smtpNotifier) Notify(message Message, text string) error {
+	var auth smtp.Auth
+	if notifier.username != "" {
+		auth = smtp.PlainAuth("", notifier.username, notifier.password, notifier.host)
+	}
+	return sendMail(notifier.address, auth, notifier.from, notifier.to, notifier.email(message, text))
+}
+
+// email returns the email with the text as its body
+func (notifier *smtpNotifier) email(message Message, text string) []byte {
+	email := strings.Builder{}
+	email.WriteString("From: " + notifier.from + "\r\n")
+	email.WriteString("To: " + strings.Join(notifier.to, ", ") + "\r\n")
+	email.WriteString("Subject: " + subjects[message.Event] + "\r\n")
+	email.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	email.WriteString("MIME-Version: 1.0\r\n")
+	email.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	email.WriteString("\r\n")
+	email.WriteString(strings.ReplaceAll(text, "\n", "\r\n") + "\r\n")
+	return []byte(email.String())
+}
+
+// postJSON posts the body as JSON and returns a StatusError if the response doesn't have a 2xx status. Errors don't
+// include the URL, since it can have a secret like a bot token in it.
+func postJSON(postURL string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	response, err := httpClient.Post(postURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		if urlErr, ok := err.(*url.Error); ok {
+			return urlErr.Err
+		}
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &StatusError{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			RetryAfter: parseRetryAfter(response.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+	return nil
+}
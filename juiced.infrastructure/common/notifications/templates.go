@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"strings"
+	"text/template"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// DefaultTemplates are the templates of the rules that don't have their own, they're text/templates of a Message
+var DefaultTemplates = map[enums.NotificationEvent]string{
+	enums.NotificationCheckoutSuccess: `Checked out {{.Quantity}}x {{.ItemName}} ({{.Sku}}) from {{.Retailer}} for ${{printf "%.2f" .Price}} with {{.ProfileName}} in {{.MsToCheckout}}ms`,
+	enums.NotificationCheckoutDecline: `Card declined checking out {{.ItemName}} ({{.Sku}}) from {{.Retailer}} with {{.ProfileName}}`,
+	enums.NotificationTaskFailure:     `A {{.Retailer}} task failed: {{.Status}}`,
+	enums.NotificationMonitorRestock:  `{{if .ItemName}}{{.ItemName}}{{else}}A product{{end}} is in stock at {{.Retailer}}`,
+	enums.NotificationTest:            `This is a test notification from Juiced`,
+}
+
+// subjects are the subjects of the emails that smtp sinks send for each event
+var subjects = map[enums.NotificationEvent]string{
+	enums.NotificationCheckoutSuccess: "Juiced: Checkout succeeded",
+	enums.NotificationCheckoutDecline: "Juiced: Checkout declined",
+	enums.NotificationTaskFailure:     "Juiced: Task failed",
+	enums.NotificationMonitorRestock:  "Juiced: Restock",
+	enums.NotificationTest:            "Juiced: Test notification",
+}
+
+// Render returns the Message rendered with the template, or with the default template of its Event if it's empty
+func Render(text string, message Message) (string, error) {
+	if text == "" {
+		text = DefaultTemplates[message.Event]
+	}
+	tmpl, err := template.New("notification").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	rendered := strings.Builder{}
+	err = tmpl.Execute(&rendered, message)
+	return rendered.String(), err
+}
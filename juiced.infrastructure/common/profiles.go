@@ -0,0 +1,197 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// countryCodes are the ISO 3166-1 alpha-2 country codes
+var countryCodes = strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+	CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR
+	GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO
+	JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR
+	MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO
+	RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV
+	TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)
+
+// stateCodes are the state and province codes of the countries whose states are validated, the states, territories and
+// military addresses of the US and the provinces and territories of Canada
+var stateCodes = map[string][]string{
+	"US": strings.Fields(`
+		AL AK AZ AR CA CO CT DE DC FL GA HI ID IL IN IA KS KY LA ME MD MA MI MN MS MO MT NE NV NH NJ NM NY NC ND OH OK
+		OR PA RI SC SD TN TX UT VT VA WA WV WI WY AS GU MP PR VI UM AA AE AP
+	`),
+	"CA": strings.Fields(`AB BC MB NB NL NS NT NU ON PE QC SK YT`),
+}
+
+// NormalizeProfile trims the spaces around the Profile's fields, upper-cases its state and country codes and removes the
+// spaces and dashes from its card number
+func NormalizeProfile(profile *entities.Profile) {
+	for _, field := range []*string{&profile.Name, &profile.Email, &profile.PhoneNumber} {
+		*field = strings.TrimSpace(*field)
+	}
+	for _, address := range []*entities.Address{&profile.ShippingAddress, &profile.BillingAddress} {
+		for _, field := range []*string{
+			&address.FirstName, &address.LastName, &address.Address1, &address.Address2, &address.City, &address.ZipCode,
+		} {
+			*field = strings.TrimSpace(*field)
+		}
+		address.StateCode = strings.ToUpper(strings.TrimSpace(address.StateCode))
+		address.CountryCode = strings.ToUpper(strings.TrimSpace(address.CountryCode))
+	}
+	card := &profile.CreditCard
+	for _, field := range []*string{&card.CardholderName, &card.ExpMonth, &card.ExpYear, &card.CVV} {
+		*field = strings.TrimSpace(*field)
+	}
+	card.CardNumber = strings.NewReplacer(" ", "", "-", "").Replace(strings.TrimSpace(card.CardNumber))
+}
+
+// ValidateProfile returns every problem that would stop the Profile from checking out, or nil if there aren't any
+func ValidateProfile(profile entities.Profile, now time.Time) []string {
+	problems := []string{}
+	missing := func(field string, value string) bool {
+		if value == "" {
+			problems = append(problems, errors.MissingProfileFieldError+field)
+			return true
+		}
+		return false
+	}
+
+	missing("name", profile.Name)
+	if !missing("email", profile.Email) && !emailRegex.MatchString(profile.Email) {
+		problems = append(problems, errors.InvalidEmailError)
+	}
+	if !missing("phoneNumber", profile.PhoneNumber) && !ValidPhoneNumber(profile.PhoneNumber, profile.ShippingAddress.CountryCode) {
+		problems = append(problems, errors.InvalidPhoneNumberError)
+	}
+
+	for _, field := range []struct {
+		prefix  string
+		address entities.Address
+	}{
+		{"shippingAddress.", profile.ShippingAddress},
+		{"billingAddress.", profile.BillingAddress},
+	} {
+		prefix, address := field.prefix, field.address
+		missing(prefix+"firstName", address.FirstName)
+		missing(prefix+"lastName", address.LastName)
+		missing(prefix+"address1", address.Address1)
+		missing(prefix+"city", address.City)
+		missing(prefix+"zipCode", address.ZipCode)
+		if missing(prefix+"countryCode", address.CountryCode) {
+			continue
+		}
+		if !InSlice(countryCodes, address.CountryCode) {
+			problems = append(problems, errors.InvalidCountryCodeError+prefix+"countryCode")
+			continue
+		}
+		if states, ok := stateCodes[address.CountryCode]; ok && !missing(prefix+"stateCode", address.StateCode) && !InSlice(states, address.StateCode) {
+			problems = append(problems, errors.InvalidStateCodeError+prefix+"stateCode")
+		}
+	}
+
+	card := profile.CreditCard
+	missing("creditCard.cardHolderName", card.CardholderName)
+	cardType := ""
+	if !missing("creditCard.cardNumber", card.CardNumber) {
+		if !ValidCardNumber(card.CardNumber) {
+			problems = append(problems, errors.InvalidCardNumberError)
+		} else if cardType = DetectCardType([]byte(card.CardNumber)); cardType == "" {
+			problems = append(problems, errors.UnsupportedCardTypeError)
+		}
+	}
+	if !missing("creditCard.expMonth", card.ExpMonth) && !missing("creditCard.expYear", card.ExpYear) {
+		expiry, ok := CardExpiry(card.ExpMonth, card.ExpYear)
+		if !ok {
+			problems = append(problems, errors.InvalidCardExpiryError)
+		} else if !now.Before(expiry) {
+			problems = append(problems, errors.CardExpiredError)
+		}
+	}
+	if !missing("creditCard.cvv", card.CVV) {
+		cvvLength := 3
+		if cardType == "AMEX" {
+			cvvLength = 4
+		}
+		if len(card.CVV) != cvvLength || !onlyDigits(card.CVV) {
+			problems = append(problems, errors.InvalidCVVError)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// ValidCardNumber returns true if the card number is between 12 and 19 digits long and passes the Luhn check
+func ValidCardNumber(cardNumber string) bool {
+	if len(cardNumber) < 12 || len(cardNumber) > 19 || !onlyDigits(cardNumber) {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(cardNumber) - 1; i >= 0; i-- {
+		digit := int(cardNumber[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// CardExpiry returns the time that a card with the given expiry month and year expires, the start of the month after it.
+// The year can have two or four digits.
+func CardExpiry(expMonth string, expYear string) (time.Time, bool) {
+	month, err := strconv.Atoi(expMonth)
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, false
+	}
+	year, err := strconv.Atoi(expYear)
+	if err != nil || (len(expYear) != 2 && len(expYear) != 4) {
+		return time.Time{}, false
+	}
+	if len(expYear) == 2 {
+		year += 2000
+	}
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// ValidPhoneNumber returns true if the phone number has 10 digits (after an optional leading 1) for the US and Canada, or
+// between 7 and 15 digits for other countries. Spaces, dashes, dots, brackets and a leading + are ignored.
+func ValidPhoneNumber(phoneNumber string, countryCode string) bool {
+	digits := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimPrefix(phoneNumber, "+"))
+	if !onlyDigits(digits) {
+		return false
+	}
+	if countryCode == "US" || countryCode == "CA" {
+		return len(digits) == 10 || (len(digits) == 11 && digits[0] == '1')
+	}
+	return len(digits) >= 7 && len(digits) <= 15
+}
+
+func onlyDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, char := range value {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+var testNow = time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+func validTestProfile() entities.Profile {
+	address := entities.Address{
+		FirstName: "Jane", LastName: "Doe", Address1: "1 Main St", City: "Springfield", ZipCode: "12345",
+		StateCode: "NY", CountryCode: "US",
+	}
+	return entities.Profile{
+		Name:            "Main",
+		Email:           "jane@example.com",
+		PhoneNumber:     "(555) 123-4567",
+		ShippingAddress: address,
+		BillingAddress:  address,
+		CreditCard: entities.Card{
+			CardholderName: "Jane Doe", CardNumber: "4111111111111111", ExpMonth: "06", ExpYear: "2024", CVV: "123",
+		},
+	}
+}
+
+func TestValidCardNumber(t *testing.T) {
+	for cardNumber, valid := range map[string]bool{
+		"4111111111111111": true,
+		"378282246310005":  true,
+		"5555555555554444": true,
+		"4111111111111112": false,
+		"41111111111":      false,
+		"4111-1111-1111":   false,
+		"":                 false,
+	} {
+		if ValidCardNumber(cardNumber) != valid {
+			t.Errorf("%q: expected valid to be %v", cardNumber, valid)
+		}
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	if problems := ValidateProfile(validTestProfile(), testNow); problems != nil {
+		t.Fatalf("expected a valid profile, got %v", problems)
+	}
+
+	cases := []struct {
+		change  func(profile *entities.Profile)
+		problem string
+	}{
+		{func(p *entities.Profile) { p.Name = "" }, errors.MissingProfileFieldError + "name"},
+		{func(p *entities.Profile) { p.Email = "jane" }, errors.InvalidEmailError},
+		{func(p *entities.Profile) { p.PhoneNumber = "555-1234" }, errors.InvalidPhoneNumberError},
+		{func(p *entities.Profile) { p.ShippingAddress.CountryCode = "USA" }, errors.InvalidCountryCodeError + "shippingAddress.countryCode"},
+		{func(p *entities.Profile) { p.BillingAddress.StateCode = "XX" }, errors.InvalidStateCodeError + "billingAddress.stateCode"},
+		{func(p *entities.Profile) { p.BillingAddress.StateCode = "" }, errors.MissingProfileFieldError + "billingAddress.stateCode"},
+		{func(p *entities.Profile) { p.CreditCard.CardNumber = "4111111111111112" }, errors.InvalidCardNumberError},
+		{func(p *entities.Profile) { p.CreditCard.ExpMonth = "13" }, errors.InvalidCardExpiryError},
+		{func(p *entities.Profile) { p.CreditCard.ExpMonth, p.CreditCard.ExpYear = "05", "24" }, errors.CardExpiredError},
+		{func(p *entities.Profile) { p.CreditCard.CVV = "1234" }, errors.InvalidCVVError},
+	}
+	for _, c := range cases {
+		profile := validTestProfile()
+		c.change(&profile)
+		problems := ValidateProfile(profile, testNow)
+		if len(problems) != 1 || problems[0] != c.problem {
+			t.Errorf("expected %q, got %v", c.problem, problems)
+		}
+	}
+
+	// States are only checked for the countries that they're known for
+	profile := validTestProfile()
+	profile.ShippingAddress.CountryCode, profile.ShippingAddress.StateCode = "GB", ""
+	profile.PhoneNumber = "+44 20 7946 0958"
+	if problems := ValidateProfile(profile, testNow); problems != nil {
+		t.Errorf("expected a valid profile, got %v", problems)
+	}
+}
+
+func TestNormalizeProfile(t *testing.T) {
+	profile := validTestProfile()
+	profile.Name = " Main "
+	profile.ShippingAddress.StateCode = "ny "
+	profile.CreditCard.CardNumber = "4111 1111-1111 1111"
+	NormalizeProfile(&profile)
+	if profile.Name != "Main" || profile.ShippingAddress.StateCode != "NY" || profile.CreditCard.CardNumber != "4111111111111111" {
+		t.Errorf("expected the profile to be normalized, got %+v", profile)
+	}
+}
+
+func TestProfilesCSVRoundTrip(t *testing.T) {
+	profiles := []entities.Profile{validTestProfile(), validTestProfile()}
+	profiles[1].Name = `Second, "quoted"`
+	data, err := ProfilesToCSV(profiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseProfilesCSV(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 {
+		t.Fatalf("expected 2 profiles, got %d", len(parsed))
+	}
+	for i := range parsed {
+		if !reflect.DeepEqual(parsed[i], profiles[i]) {
+			t.Errorf("expected %+v, got %+v", profiles[i], parsed[i])
+		}
+	}
+}
+
+func TestParseProfilesCSVMapping(t *testing.T) {
+	data := "\ufeffProfile Name,E-mail,Ship First,Billing Same As Shipping,Unused\n" +
+		"Main,jane@example.com,Jane,yes,x\n" +
+		"Short row\n"
+	profiles, err := ParseProfilesCSV([]byte(data), map[string]string{"shippingAddress.firstName": "ship first", "name": "Profile Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("expected 2 profiles, got %d", len(profiles))
+	}
+	if profiles[0].Name != "Main" || profiles[0].Email != "jane@example.com" || profiles[0].BillingAddress.FirstName != "Jane" {
+		t.Errorf("expected the columns to be mapped, got %+v", profiles[0])
+	}
+	if profiles[1].Name != "Short row" || profiles[1].Email != "" {
+		t.Errorf("expected missing fields to be empty, got %+v", profiles[1])
+	}
+
+	if _, err = ParseProfilesCSV([]byte(data), map[string]string{"nickname": "Profile Name"}); err == nil || !strings.Contains(err.Error(), "nickname") {
+		t.Errorf("expected an unknown field to be rejected, got %v", err)
+	}
+	if _, err = ParseProfilesCSV([]byte(data), map[string]string{"name": "Full Name"}); err == nil {
+		t.Error("expected a missing column to be rejected")
+	}
+}
@@ -496,6 +496,7 @@ var accountsSchema = `
 	)
 `
 
+// schemas is every table as of the baseline migration, later changes to the schema are made by migrations
 var schemas = []string{
 
 	// UserInfo
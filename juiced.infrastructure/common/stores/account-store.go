@@ -0,0 +1,183 @@
+package stores
+
+import (
+	e "errors"
+	"sort"
+	"sync"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+// AccountStore hands out the Accounts in the settings to the Tasks that use the account pool, so that no two running
+// Tasks log in with the same Account. It's safe for concurrent use.
+type AccountStore struct {
+	// allocated maps the IDs of the Accounts in use to the IDs of the Tasks using them
+	allocated map[string]string
+	// sessions maps the IDs of TaskGroups to the logged in sessions that their running Tasks share with the monitor,
+	// by the IDs of the Tasks
+	sessions map[string]map[string]interface{}
+	mutex    sync.Mutex
+}
+
+// Allocate allocates an Account for the retailer to the Task with the given ID and returns it. accountID is the ID of
+// the Account to allocate, or enums.AnyAccount for the first free Account that isn't locked and has good credentials.
+func (accountStore *AccountStore) Allocate(taskID string, retailer enums.Retailer, accountID string) (entities.Account, error) {
+	accounts, err := queries.GetAccounts()
+	if err != nil {
+		return entities.Account{}, err
+	}
+
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	return allocateAccount(accountStore.allocated, accounts, taskID, retailer, accountID)
+}
+
+// Release gives back the Account allocated to the Task with the given ID, if it has one
+func (accountStore *AccountStore) Release(taskID string) {
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	for accountID, holder := range accountStore.allocated {
+		if holder == taskID {
+			delete(accountStore.allocated, accountID)
+		}
+	}
+}
+
+// InUse returns true if the Account with the given ID is allocated to a Task
+func (accountStore *AccountStore) InUse(accountID string) bool {
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	_, ok := accountStore.allocated[accountID]
+	return ok
+}
+
+// GetAccounts returns the Accounts in the settings, each with the ID of the Task that it's allocated to
+func (accountStore *AccountStore) GetAccounts() ([]entities.Account, error) {
+	accounts, err := queries.GetAccounts()
+	if err != nil {
+		return accounts, err
+	}
+
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	for i := range accounts {
+		accounts[i].TaskID = accountStore.allocated[accounts[i].ID]
+	}
+	return accounts, nil
+}
+
+// AddSession shares the logged in session of the Task with the given ID with its TaskGroup's monitor
+func (accountStore *AccountStore) AddSession(groupID string, taskID string, session interface{}) {
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	if accountStore.sessions[groupID] == nil {
+		accountStore.sessions[groupID] = make(map[string]interface{})
+	}
+	accountStore.sessions[groupID][taskID] = session
+}
+
+// GetSessions returns the logged in sessions that the TaskGroup's running Tasks share, sorted by the IDs of the Tasks
+func (accountStore *AccountStore) GetSessions(groupID string) []interface{} {
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	taskIDs := make([]string, 0, len(accountStore.sessions[groupID]))
+	for taskID := range accountStore.sessions[groupID] {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Strings(taskIDs)
+	sessions := make([]interface{}, len(taskIDs))
+	for i, taskID := range taskIDs {
+		sessions[i] = accountStore.sessions[groupID][taskID]
+	}
+	return sessions
+}
+
+// RemoveSession stops sharing the logged in session of the Task with the given ID, if it has one
+func (accountStore *AccountStore) RemoveSession(taskID string) {
+	accountStore.mutex.Lock()
+	defer accountStore.mutex.Unlock()
+
+	for groupID, sessions := range accountStore.sessions {
+		delete(sessions, taskID)
+		if len(sessions) == 0 {
+			delete(accountStore.sessions, groupID)
+		}
+	}
+}
+
+// allocateAccount allocates one of the accounts to the Task and records it in allocated. A Task that's given an
+// Account again, like when it's updated while it's running, keeps the one it has if it can.
+func allocateAccount(allocated map[string]string, accounts []entities.Account, taskID string, retailer enums.Retailer, accountID string) (entities.Account, error) {
+	held := ""
+	for ID, holder := range allocated {
+		if holder == taskID {
+			held = ID
+		}
+	}
+
+	chosen := -1
+	if accountID == enums.AnyAccount {
+		for i, account := range accounts {
+			if account.Retailer != retailer || !account.Usable() {
+				continue
+			}
+			if account.ID == held {
+				chosen = i
+				break
+			}
+			if _, inUse := allocated[account.ID]; !inUse && chosen < 0 {
+				chosen = i
+			}
+		}
+		if chosen < 0 {
+			return entities.Account{}, e.New(errors.NoFreeAccountError + retailer)
+		}
+	} else {
+		for i, account := range accounts {
+			if account.ID == accountID {
+				chosen = i
+			}
+		}
+		if chosen < 0 {
+			return entities.Account{}, e.New(errors.AccountNotFoundError)
+		}
+		if accounts[chosen].Retailer != retailer {
+			return entities.Account{}, e.New(errors.AccountRetailerMismatchError + accounts[chosen].Retailer)
+		}
+		if holder, inUse := allocated[accountID]; inUse && holder != taskID {
+			return entities.Account{}, e.New(errors.AccountInUseError)
+		}
+	}
+
+	delete(allocated, held)
+	account := accounts[chosen]
+	account.TaskID = taskID
+	allocated[account.ID] = taskID
+	return account, nil
+}
+
+var accountStore *AccountStore
+
+// InitAccountStore initializes the singleton instance of the AccountStore
+func InitAccountStore() {
+	accountStore = &AccountStore{
+		allocated: make(map[string]string),
+		sessions:  make(map[string]map[string]interface{}),
+	}
+	base.SetAccountSessions(accountStore)
+}
+
+// GetAccountStore returns the singleton instance of the AccountStore
+func GetAccountStore() *AccountStore {
+	return accountStore
+}
@@ -0,0 +1,104 @@
+package stores
+
+import (
+	"strings"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+func TestAllocateAccount(t *testing.T) {
+	accounts := []entities.Account{
+		{ID: "locked", Retailer: enums.Target, Status: enums.AccountStatusLocked},
+		{ID: "target1", Retailer: enums.Target, Status: enums.AccountStatusLoggedIn},
+		{ID: "topps", Retailer: enums.Topps},
+		{ID: "target2", Retailer: enums.Target},
+	}
+	allocated := make(map[string]string)
+
+	account, err := allocateAccount(allocated, accounts, "task1", enums.Target, enums.AnyAccount)
+	if err != nil || account.ID != "target1" || account.TaskID != "task1" {
+		t.Fatalf("expected task1 to get the first usable target account, got %+v (%v)", account, err)
+	}
+	account, err = allocateAccount(allocated, accounts, "task2", enums.Target, enums.AnyAccount)
+	if err != nil || account.ID != "target2" {
+		t.Fatalf("expected task2 to get the next free target account, got %+v (%v)", account, err)
+	}
+	_, err = allocateAccount(allocated, accounts, "task3", enums.Target, enums.AnyAccount)
+	if err == nil || err.Error() != errors.NoFreeAccountError+enums.Target {
+		t.Errorf("expected there to be no free target account, got %v", err)
+	}
+
+	// A Task that's given an Account again keeps the one it has
+	account, err = allocateAccount(allocated, accounts, "task1", enums.Target, enums.AnyAccount)
+	if err != nil || account.ID != "target1" || len(allocated) != 2 {
+		t.Errorf("expected task1 to keep its account, got %+v (%v) and %v", account, err, allocated)
+	}
+
+	_, err = allocateAccount(allocated, accounts, "task3", enums.Target, "target2")
+	if err == nil || err.Error() != errors.AccountInUseError {
+		t.Errorf("expected target2 to be in use, got %v", err)
+	}
+	_, err = allocateAccount(allocated, accounts, "task3", enums.Target, "topps")
+	if err == nil || !strings.HasPrefix(err.Error(), errors.AccountRetailerMismatchError) {
+		t.Errorf("expected the topps account to be rejected for a target task, got %v", err)
+	}
+	_, err = allocateAccount(allocated, accounts, "task3", enums.Target, "missing")
+	if err == nil || err.Error() != errors.AccountNotFoundError {
+		t.Errorf("expected a missing account to be rejected, got %v", err)
+	}
+
+	// A locked Account can still be chosen by its ID, and the Task's old Account is given back
+	account, err = allocateAccount(allocated, accounts, "task2", enums.Target, "locked")
+	if err != nil || account.ID != "locked" || allocated["target2"] != "" || allocated["locked"] != "task2" {
+		t.Errorf("expected task2 to swap target2 for the locked account, got %+v (%v) and %v", account, err, allocated)
+	}
+}
+
+func TestAccountStoreRelease(t *testing.T) {
+	InitAccountStore()
+	accountStore := GetAccountStore()
+	accountStore.allocated["target1"] = "task1"
+	accountStore.allocated["target2"] = "task2"
+
+	accountStore.Release("task1")
+	if accountStore.InUse("target1") || !accountStore.InUse("target2") {
+		t.Errorf("expected only task1's account to be given back, got %v", accountStore.allocated)
+	}
+	accountStore.Release("task1")
+	if len(accountStore.allocated) != 1 {
+		t.Errorf("expected releasing twice to do nothing, got %v", accountStore.allocated)
+	}
+}
+
+func TestAccountStoreSessions(t *testing.T) {
+	InitAccountStore()
+	task1 := base.Task{Task: &entities.Task{ID: "task1", TaskGroupID: "group"}}
+	task2 := base.Task{Task: &entities.Task{ID: "task2", TaskGroupID: "group"}}
+	monitor := base.Monitor{TaskGroup: &entities.TaskGroup{GroupID: "group"}}
+	otherMonitor := base.Monitor{TaskGroup: &entities.TaskGroup{GroupID: "other"}}
+
+	task2.ShareSession("session2")
+	task1.ShareSession("session1")
+	sessions := monitor.Sessions()
+	if len(sessions) != 2 || sessions[0] != "session1" || sessions[1] != "session2" {
+		t.Errorf("expected the monitor to get both tasks' sessions, got %v", sessions)
+	}
+	if len(otherMonitor.Sessions()) != 0 {
+		t.Errorf("expected another TaskGroup's monitor to get no sessions, got %v", otherMonitor.Sessions())
+	}
+
+	// A task's session is removed when its run exits
+	GetAccountStore().RemoveSession("task1")
+	sessions = monitor.Sessions()
+	if len(sessions) != 1 || sessions[0] != "session2" {
+		t.Errorf("expected only task2's session to be left, got %v", sessions)
+	}
+	GetAccountStore().RemoveSession("task2")
+	if len(GetAccountStore().sessions) != 0 {
+		t.Errorf("expected the TaskGroup's sessions to be removed, got %v", GetAccountStore().sessions)
+	}
+}
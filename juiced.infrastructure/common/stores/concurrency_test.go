@@ -0,0 +1,251 @@
+package stores
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+const fakeRetailer = "fakeretailer"
+
+type fakeTask struct {
+	Task      base.Task
+	StockData string
+	running   int32
+	unwinding chan struct{}
+}
+
+func (task *fakeTask) GetTask() *base.Task { return &task.Task }
+
+func (task *fakeTask) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+	atomic.AddInt32(&task.running, 1)
+	defer atomic.AddInt32(&task.running, -1)
+
+	// Like a sitescript, it updates its status and reads its proxy while it waits for the monitor's stock data
+	task.Task.ResetStock(func() { task.StockData = "" })
+	for ctx.Err() == nil && !task.Task.TakeStock(func() bool { return task.StockData != "" }) {
+		task.Task.Task.SetTaskStatus(enums.WaitingForMonitor)
+		task.Task.Proxy()
+		task.Task.Sleep(common.MS_TO_WAIT)
+	}
+	task.Task.Task.SetTaskStatus(enums.AddingToCart)
+
+	// Stands in for a long TaskDelay, it has to return as soon as the task is stopped
+	task.Task.Sleep(time.Hour)
+
+	// Like a sitescript, it's idle once it's stopped, but it may take a while to unwind
+	task.Task.Task.SetTaskStatus(enums.TaskIdle)
+	if task.unwinding != nil {
+		<-task.unwinding
+	}
+}
+
+type fakeMonitor struct {
+	Monitor base.Monitor
+	InStock []string
+}
+
+func (monitor *fakeMonitor) GetMonitor() *base.Monitor { return &monitor.Monitor }
+
+func (monitor *fakeMonitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+	for ctx.Err() == nil {
+		monitor.Monitor.TaskGroup.SetMonitorStatus(enums.SendingProductInfoToTasks)
+		monitor.Monitor.Proxy()
+		monitor.Monitor.StockMutex.Lock()
+		monitor.InStock = append(monitor.InStock[:0], "item")
+		monitor.Monitor.StockMutex.Unlock()
+		monitor.Monitor.Sleep(common.MS_TO_WAIT)
+	}
+}
+
+func (monitor *fakeMonitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+func (monitor *fakeMonitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
+
+type fakeRetailerImpl struct{}
+
+func (fakeRetailerImpl) Name() enums.Retailer { return fakeRetailer }
+
+func (fakeRetailerImpl) RequiresProfile() bool { return false }
+
+func (fakeRetailerImpl) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	return &fakeTask{Task: base.Task{Task: task, EventBus: eventBus}}, nil
+}
+
+func (fakeRetailerImpl) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	return &fakeMonitor{Monitor: base.Monitor{TaskGroup: taskGroup, EventBus: eventBus}}, nil
+}
+
+func (fakeRetailerImpl) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*fakeMonitor)
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	if len(monitor.InStock) > 0 {
+		runnableTask.(*fakeTask).StockData = monitor.InStock[0]
+	}
+}
+
+func init() {
+	base.RegisterRetailer(fakeRetailerImpl{})
+}
+
+// TestConcurrentStartStop starts and stops hundreds of Tasks and Monitors in parallel while their statuses, stock and
+// proxies change, run it with -race
+func TestConcurrentStartStop(t *testing.T) {
+	InitProxyStore(events.GetEventBus())
+	InitTaskStore(events.GetEventBus())
+	InitMonitorStore(events.GetEventBus())
+
+	const groupCount = 10
+	const tasksPerGroup = 30
+
+	var wg sync.WaitGroup
+	for i := 0; i < groupCount; i++ {
+		taskGroup := &entities.TaskGroup{
+			GroupID:         fmt.Sprintf("group-%d", i),
+			MonitorRetailer: fakeRetailer,
+			MonitorStatus:   enums.MonitorIdle,
+		}
+		for j := 0; j < tasksPerGroup; j++ {
+			taskGroup.TaskIDs = append(taskGroup.TaskIDs, fmt.Sprintf("task-%d-%d", i, j))
+		}
+
+		for _, taskID := range taskGroup.TaskIDs {
+			wg.Add(1)
+			go func(taskGroup *entities.TaskGroup, taskID string) {
+				defer wg.Done()
+				task := &entities.Task{
+					ID:           taskID,
+					TaskGroupID:  taskGroup.GroupID,
+					TaskRetailer: fakeRetailer,
+					TaskStatus:   enums.TaskIdle,
+				}
+				if err := taskStore.AddTaskToStore(task); err != nil {
+					t.Error(err)
+					return
+				}
+				// Two callers racing to start the same Task must only start it once
+				taskStore.runTaskIfIdle(taskID)
+				taskStore.runTaskIfIdle(taskID)
+				if !taskStore.TasksRunning([]string{taskID}) {
+					t.Errorf("task %s should be running", taskID)
+				}
+				// Give the monitor some time to hand off its stock while the proxies are changed and the statuses are read
+				for k := 0; k < 10; k++ {
+					taskStore.UpdateTaskProxy(task, &entities.Proxy{})
+					monitorStore.UpdateMonitorProxy(taskGroup, &entities.Proxy{})
+					GetTaskStatuses()
+					GetMonitorStatus(taskGroup.GroupID)
+					time.Sleep(common.MS_TO_WAIT)
+				}
+				monitorStore.CheckMonitorTasksRunning()
+				runnableTask, _ := taskStore.GetRunnableTask(taskID)
+				wasRunning, err := taskStore.StopTask(task)
+				if err != nil {
+					t.Error(err)
+				}
+				// StopTask only returns once the task's goroutine has exited
+				if running := atomic.LoadInt32(&runnableTask.(*fakeTask).running); wasRunning && running != 0 {
+					t.Errorf("task %s still has %d running goroutines after StopTask returned", taskID, running)
+				}
+			}(taskGroup, taskID)
+		}
+
+		for j := 0; j < 2; j++ {
+			wg.Add(1)
+			go func(taskGroup entities.TaskGroup) {
+				defer wg.Done()
+				if err := monitorStore.StartMonitor(&taskGroup); err != nil {
+					t.Error(err)
+				}
+				GetMonitorStatus(taskGroup.GroupID)
+			}(*taskGroup)
+		}
+	}
+	wg.Wait()
+
+	for taskID, runnableTask := range taskStore.GetRunnableTasks() {
+		if !runnableTask.GetTask().StopFlag() {
+			t.Errorf("task %s should be stopped", taskID)
+		}
+	}
+	monitorStore.CheckMonitorTasksRunning()
+	for groupID, runnableMonitor := range monitorStore.GetRunnableMonitors() {
+		if !runnableMonitor.GetMonitor().StopFlag() {
+			t.Errorf("monitor %s should be stopped", groupID)
+		}
+	}
+	if len(taskStore.GetRunnableTasks()) != groupCount*tasksPerGroup {
+		t.Errorf("expected %d tasks in the store, got %d", groupCount*tasksPerGroup, len(taskStore.GetRunnableTasks()))
+	}
+}
+
+// TestRestartUnwindingTask restarts a Task whose stopped run is still unwinding, the restart has to wait for it to exit
+// without blocking the rest of the TaskStore
+func TestRestartUnwindingTask(t *testing.T) {
+	InitTaskStore(events.GetEventBus())
+
+	task := &entities.Task{ID: "unwinding-task", TaskRetailer: fakeRetailer, TaskStatus: enums.TaskIdle}
+	if err := taskStore.AddTaskToStore(task); err != nil {
+		t.Fatal(err)
+	}
+	runnableTask, _ := taskStore.GetRunnableTask(task.ID)
+	unwinding := make(chan struct{})
+	runnableTask.(*fakeTask).unwinding = unwinding
+
+	taskStore.runTaskIfIdle(task.ID)
+	runnableTask.GetTask().Stop()
+	for runnableTask.GetTask().Task.GetTaskStatus() != enums.TaskIdle {
+		time.Sleep(common.MS_TO_WAIT)
+	}
+
+	restarted := make(chan struct{})
+	go func() {
+		taskStore.runTaskIfIdle(task.ID)
+		close(restarted)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	statuses := make(chan map[string]string)
+	go func() {
+		statuses <- GetTaskStatuses()
+	}()
+	select {
+	case <-statuses:
+	case <-time.After(time.Second):
+		t.Fatal("GetTaskStatuses was blocked while the restart waited for the previous run to exit")
+	}
+	select {
+	case <-restarted:
+		t.Fatal("the task was restarted before its previous run exited")
+	default:
+	}
+
+	close(unwinding)
+	<-restarted
+	if !taskStore.TasksRunning([]string{task.ID}) {
+		t.Error("the task should be running once its previous run exited")
+	}
+	if _, err := taskStore.StopTask(task); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,285 +1,103 @@
 package stores
 
 import (
-	e "errors"
-
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
-	"backend.juicedbot.io/juiced.infrastructure/common/enums"
-	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.sitescripts/base"
 )
 
-func (taskStore *TaskStore) SetStopFlag(retailer enums.Retailer, ID string, flag bool) error {
-	switch retailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		if amazonTask, ok := taskStore.AmazonTasks[ID]; ok {
-			amazonTask.Task.StopFlag = flag
-		}
-
-	case enums.BestBuy:
-		if bestbuyTask, ok := taskStore.BestbuyTasks[ID]; ok {
-			bestbuyTask.Task.StopFlag = flag
-		}
-
-	case enums.BoxLunch:
-		if boxlunchTask, ok := taskStore.BoxlunchTasks[ID]; ok {
-			boxlunchTask.Task.StopFlag = flag
-		}
-
-	case enums.Disney:
-		if disneyTask, ok := taskStore.DisneyTasks[ID]; ok {
-			disneyTask.Task.StopFlag = flag
-		}
-
-	case enums.GameStop:
-		if gamestopTask, ok := taskStore.GamestopTasks[ID]; ok {
-			gamestopTask.Task.StopFlag = flag
-		}
-
-	case enums.HotTopic:
-		if hottopicTask, ok := taskStore.HottopicTasks[ID]; ok {
-			hottopicTask.Task.StopFlag = flag
-		}
-
-	case enums.Newegg:
-		if neweggTask, ok := taskStore.NeweggTasks[ID]; ok {
-			neweggTask.Task.StopFlag = flag
+// stopTasks cancels each of the given Tasks that are in the store, then waits for all of them to exit
+func (taskStore *TaskStore) stopTasks(IDs []string) {
+	var runnableTasks []base.RunnableTask
+	for _, ID := range IDs {
+		if runnableTask, ok := taskStore.GetRunnableTask(ID); ok {
+			runnableTask.GetTask().Stop()
+			runnableTasks = append(runnableTasks, runnableTask)
 		}
-
-	case enums.PokemonCenter:
-		if pokemonCenterTask, ok := taskStore.PokemonCenterTasks[ID]; ok {
-			pokemonCenterTask.Task.StopFlag = flag
-		}
-
-	case enums.Shopify:
-		if shopifyTask, ok := taskStore.ShopifyTasks[ID]; ok {
-			shopifyTask.Task.StopFlag = flag
-		}
-
-	case enums.Target:
-		if targetTask, ok := taskStore.TargetTasks[ID]; ok {
-			targetTask.Task.StopFlag = flag
-		}
-
-	case enums.Topps:
-		if toppsTask, ok := taskStore.ToppsTasks[ID]; ok {
-			toppsTask.Task.StopFlag = flag
-		}
-
-	case enums.Walmart:
-		if walmartTask, ok := taskStore.WalmartTasks[ID]; ok {
-			walmartTask.Task.StopFlag = flag
-		}
-
-	default:
-		return e.New(errors.InvalidTaskRetailerError)
-
 	}
-
-	return nil
+	for _, runnableTask := range runnableTasks {
+		runnableTask.GetTask().Wait()
+	}
 }
 
-func (taskStore *TaskStore) SetDontPublishEvents(retailer enums.Retailer, ID string, flag bool) error {
-	switch retailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		if amazonTask, ok := taskStore.AmazonTasks[ID]; ok {
-			amazonTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.BestBuy:
-		if bestbuyTask, ok := taskStore.BestbuyTasks[ID]; ok {
-			bestbuyTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.BoxLunch:
-		if boxlunchTask, ok := taskStore.BoxlunchTasks[ID]; ok {
-			boxlunchTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Disney:
-		if disneyTask, ok := taskStore.DisneyTasks[ID]; ok {
-			disneyTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.GameStop:
-		if gamestopTask, ok := taskStore.GamestopTasks[ID]; ok {
-			gamestopTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.HotTopic:
-		if hottopicTask, ok := taskStore.HottopicTasks[ID]; ok {
-			hottopicTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Newegg:
-		if neweggTask, ok := taskStore.NeweggTasks[ID]; ok {
-			neweggTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.PokemonCenter:
-		if pokemonCenterTask, ok := taskStore.PokemonCenterTasks[ID]; ok {
-			pokemonCenterTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Shopify:
-		if shopifyTask, ok := taskStore.ShopifyTasks[ID]; ok {
-			shopifyTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Target:
-		if targetTask, ok := taskStore.TargetTasks[ID]; ok {
-			targetTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Topps:
-		if toppsTask, ok := taskStore.ToppsTasks[ID]; ok {
-			toppsTask.Task.DontPublishEvents = flag
-		}
-
-	case enums.Walmart:
-		if walmartTask, ok := taskStore.WalmartTasks[ID]; ok {
-			walmartTask.Task.DontPublishEvents = flag
-		}
-
-	default:
-		return e.New(errors.InvalidTaskRetailerError)
+// SetDontPublishEvents sets the DontPublishEvents flag for the given Task if it's in the store
+func (taskStore *TaskStore) SetDontPublishEvents(ID string, flag bool) {
+	if runnableTask, ok := taskStore.GetRunnableTask(ID); ok {
+		runnableTask.GetTask().SetDontPublishEvents(flag)
+	}
+}
 
+// GetTask returns the Task entity for the given Task if it's in the store
+func (taskStore *TaskStore) GetTask(ID string) *entities.Task {
+	if runnableTask, ok := taskStore.GetRunnableTask(ID); ok {
+		return runnableTask.GetTask().Task
 	}
 
 	return nil
 }
 
-func (taskStore *TaskStore) GetTask(retailer enums.Retailer, ID string) *entities.Task {
-	switch retailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		if amazonTask, ok := taskStore.AmazonTasks[ID]; ok {
-			return amazonTask.Task.Task
-		}
-
-	case enums.BestBuy:
-		if bestbuyTask, ok := taskStore.BestbuyTasks[ID]; ok {
-			return bestbuyTask.Task.Task
-		}
-
-	case enums.BoxLunch:
-		if boxlunchTask, ok := taskStore.BoxlunchTasks[ID]; ok {
-			return boxlunchTask.Task.Task
-		}
-
-	case enums.Disney:
-		if disneyTask, ok := taskStore.DisneyTasks[ID]; ok {
-			return disneyTask.Task.Task
-		}
-
-	case enums.GameStop:
-		if gamestopTask, ok := taskStore.GamestopTasks[ID]; ok {
-			return gamestopTask.Task.Task
-		}
+// GetRunnableTask returns the sitescript's Task for the given Task if it's in the store
+func (taskStore *TaskStore) GetRunnableTask(ID string) (base.RunnableTask, bool) {
+	taskStore.mutex.RLock()
+	defer taskStore.mutex.RUnlock()
 
-	case enums.HotTopic:
-		if hottopicTask, ok := taskStore.HottopicTasks[ID]; ok {
-			return hottopicTask.Task.Task
-		}
-
-	case enums.Newegg:
-		if neweggTask, ok := taskStore.NeweggTasks[ID]; ok {
-			return neweggTask.Task.Task
-		}
+	runnableTask, ok := taskStore.tasks[ID]
+	return runnableTask, ok
+}
 
-	case enums.PokemonCenter:
-		if pokemonCenterTask, ok := taskStore.PokemonCenterTasks[ID]; ok {
-			return pokemonCenterTask.Task.Task
-		}
+// GetRunnableTasks returns a snapshot of the store's Tasks that is safe to iterate over while the store changes
+func (taskStore *TaskStore) GetRunnableTasks() map[string]base.RunnableTask {
+	taskStore.mutex.RLock()
+	defer taskStore.mutex.RUnlock()
 
-	case enums.Shopify:
-		if shopifyTask, ok := taskStore.ShopifyTasks[ID]; ok {
-			return shopifyTask.Task.Task
-		}
+	runnableTasks := make(map[string]base.RunnableTask, len(taskStore.tasks))
+	for ID, runnableTask := range taskStore.tasks {
+		runnableTasks[ID] = runnableTask
+	}
+	return runnableTasks
+}
 
-	case enums.Target:
-		if targetTask, ok := taskStore.TargetTasks[ID]; ok {
-			return targetTask.Task.Task
-		}
+// RemoveTask removes the given Task from the store, it should only be called once the Task has stopped
+func (taskStore *TaskStore) RemoveTask(ID string) {
+	taskStore.mutex.Lock()
+	defer taskStore.mutex.Unlock()
 
-	case enums.Topps:
-		if toppsTask, ok := taskStore.ToppsTasks[ID]; ok {
-			return toppsTask.Task.Task
-		}
+	delete(taskStore.tasks, ID)
+}
 
-	case enums.Walmart:
-		if walmartTask, ok := taskStore.WalmartTasks[ID]; ok {
-			return walmartTask.Task.Task
-		}
+// GetMonitor returns the TaskGroup entity for the given Monitor if it's in the store
+func (monitorStore *MonitorStore) GetMonitor(ID string) *entities.TaskGroup {
+	if runnableMonitor, ok := monitorStore.GetRunnableMonitor(ID); ok {
+		return runnableMonitor.GetMonitor().TaskGroup
 	}
 
 	return nil
 }
 
-func (monitorStore *MonitorStore) GetMonitor(retailer enums.Retailer, ID string) *entities.TaskGroup {
-	switch retailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		if amazonMonitor, ok := monitorStore.AmazonMonitors[ID]; ok {
-			return amazonMonitor.Monitor.TaskGroup
-		}
-
-	case enums.BestBuy:
-		if bestbuyMonitor, ok := monitorStore.BestbuyMonitors[ID]; ok {
-			return bestbuyMonitor.Monitor.TaskGroup
-		}
-
-	case enums.BoxLunch:
-		if boxlunchMonitor, ok := monitorStore.BoxlunchMonitors[ID]; ok {
-			return boxlunchMonitor.Monitor.TaskGroup
-		}
-
-	case enums.Disney:
-		if disneyMonitor, ok := monitorStore.DisneyMonitors[ID]; ok {
-			return disneyMonitor.Monitor.TaskGroup
-		}
-
-	case enums.GameStop:
-		if gamestopMonitor, ok := monitorStore.GamestopMonitors[ID]; ok {
-			return gamestopMonitor.Monitor.TaskGroup
-		}
-
-	case enums.HotTopic:
-		if hottopicMonitor, ok := monitorStore.HottopicMonitors[ID]; ok {
-			return hottopicMonitor.Monitor.TaskGroup
-		}
-
-	case enums.Newegg:
-		if neweggMonitor, ok := monitorStore.NeweggMonitors[ID]; ok {
-			return neweggMonitor.Monitor.TaskGroup
-		}
-
-	case enums.PokemonCenter:
-		if pokemoncenterMonitor, ok := monitorStore.PokemonCenterMonitors[ID]; ok {
-			return pokemoncenterMonitor.Monitor.TaskGroup
-		}
+// GetRunnableMonitor returns the sitescript's Monitor for the given TaskGroup if it's in the store
+func (monitorStore *MonitorStore) GetRunnableMonitor(ID string) (base.RunnableMonitor, bool) {
+	monitorStore.mutex.RLock()
+	defer monitorStore.mutex.RUnlock()
 
-	case enums.Shopify:
-		if shopifyMonitor, ok := monitorStore.ShopifyMonitors[ID]; ok {
-			return shopifyMonitor.Monitor.TaskGroup
-		}
-
-	case enums.Target:
-		if targetMonitor, ok := monitorStore.TargetMonitors[ID]; ok {
-			return targetMonitor.Monitor.TaskGroup
-		}
+	runnableMonitor, ok := monitorStore.monitors[ID]
+	return runnableMonitor, ok
+}
 
-	case enums.Topps:
-		if toppsMonitor, ok := monitorStore.ToppsMonitors[ID]; ok {
-			return toppsMonitor.Monitor.TaskGroup
-		}
+// GetRunnableMonitors returns a snapshot of the store's Monitors that is safe to iterate over while the store changes
+func (monitorStore *MonitorStore) GetRunnableMonitors() map[string]base.RunnableMonitor {
+	monitorStore.mutex.RLock()
+	defer monitorStore.mutex.RUnlock()
 
-	case enums.Walmart:
-		if walmartMonitor, ok := monitorStore.WalmartMonitors[ID]; ok {
-			return walmartMonitor.Monitor.TaskGroup
-		}
+	runnableMonitors := make(map[string]base.RunnableMonitor, len(monitorStore.monitors))
+	for ID, runnableMonitor := range monitorStore.monitors {
+		runnableMonitors[ID] = runnableMonitor
 	}
+	return runnableMonitors
+}
 
-	return nil
+// RemoveMonitor removes the given Monitor from the store, it should only be called once the Monitor has stopped
+func (monitorStore *MonitorStore) RemoveMonitor(ID string) {
+	monitorStore.mutex.Lock()
+	defer monitorStore.mutex.Unlock()
+
+	delete(monitorStore.monitors, ID)
 }
@@ -0,0 +1,192 @@
+package stores
+
+import (
+	"log"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"github.com/google/uuid"
+)
+
+// historyBufferSize is large so that bursts of status updates aren't dropped before they're written
+const historyBufferSize = 16384
+
+// historyBatchSize is the most Events written to the database in one transaction
+const historyBatchSize = 512
+
+// historyPruneInterval is how often the retention policy is applied
+const historyPruneInterval = time.Hour
+
+// HistoryStore records every Task and Monitor status transition published on the EventBus as task run history
+type HistoryStore struct {
+	EventBus   *events.EventBus
+	subscriber *events.Subscriber
+	// lastRunIDs holds the run ID of each Task or Monitor that's running, for Events published without one
+	lastRunIDs map[string]string
+}
+
+// record writes the subscribed Events to the database in batches and prunes old history until the subscriber is removed
+func (historyStore *HistoryStore) record() {
+	pruneTicker := time.NewTicker(historyPruneInterval)
+	defer pruneTicker.Stop()
+
+	historyStore.prune()
+	for {
+		select {
+		case event, ok := <-historyStore.subscriber.Events():
+			if !ok {
+				return
+			}
+			batch := historyStore.drain([]events.Event{event})
+			err := commands.RecordTaskRuns(historyStore.taskRunsFor(batch))
+			if err != nil {
+				log.Println("Error recording task run history: " + err.Error())
+			}
+
+		case <-pruneTicker.C:
+			historyStore.prune()
+		}
+	}
+}
+
+// drain appends the Events that are already waiting to the batch, up to historyBatchSize
+func (historyStore *HistoryStore) drain(batch []events.Event) []events.Event {
+	for len(batch) < historyBatchSize {
+		select {
+		case event, ok := <-historyStore.subscriber.Events():
+			if !ok {
+				return batch
+			}
+			batch = append(batch, event)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// taskRunsFor groups the Events by run, in the order each run was first seen, with each run's status set to its latest Event.
+// Each run starts at the time its first Event was published and is updated at the time of its latest one.
+func (historyStore *HistoryStore) taskRunsFor(batch []events.Event) []entities.TaskRun {
+	taskRuns := []entities.TaskRun{}
+	runIndexes := make(map[string]int)
+
+	for _, event := range batch {
+		var taskRun entities.TaskRun
+		var timestamp int64
+		runEnded := false
+		switch event.EventType {
+		case events.TaskEventType:
+			taskRun = entities.TaskRun{
+				RunID:            event.TaskEvent.RunID,
+				Kind:             entities.TaskRunKindTask,
+				TaskID:           event.TaskEvent.TaskID,
+				TaskGroupID:      event.TaskEvent.TaskGroupID,
+				Retailer:         event.TaskEvent.Retailer,
+				ProxyID:          event.TaskEvent.ProxyID,
+				Status:           event.TaskEvent.Status,
+				StatusPercentage: event.TaskEvent.StatusPercentage,
+				EventType:        event.TaskEvent.EventType,
+			}
+			timestamp = event.TaskEvent.Time
+			runEnded = event.TaskEvent.EventType == enums.TaskStop || event.TaskEvent.EventType == enums.TaskComplete
+		case events.MonitorEventType:
+			taskRun = entities.TaskRun{
+				RunID:       event.MonitorEvent.RunID,
+				Kind:        entities.TaskRunKindMonitor,
+				TaskGroupID: event.MonitorEvent.MonitorID,
+				Retailer:    event.MonitorEvent.Retailer,
+				ProxyID:     event.MonitorEvent.ProxyID,
+				Status:      event.MonitorEvent.Status,
+				EventType:   event.MonitorEvent.EventType,
+			}
+			timestamp = event.MonitorEvent.Time
+			runEnded = event.MonitorEvent.EventType == enums.MonitorStop || event.MonitorEvent.EventType == enums.MonitorComplete
+		default:
+			continue
+		}
+
+		key := taskRun.Kind + ":" + taskRun.TaskID + ":" + taskRun.TaskGroupID
+		if taskRun.RunID == "" {
+			taskRun.RunID = historyStore.lastRunIDs[key]
+			if taskRun.RunID == "" {
+				taskRun.RunID = uuid.New().String()
+			}
+		}
+		if runEnded {
+			delete(historyStore.lastRunIDs, key)
+		} else {
+			historyStore.lastRunIDs[key] = taskRun.RunID
+		}
+		taskRun.StartTime, taskRun.UpdateTime = timestamp, timestamp
+
+		runEvent := entities.TaskRunEvent{
+			RunID:            taskRun.RunID,
+			Kind:             taskRun.Kind,
+			TaskID:           taskRun.TaskID,
+			TaskGroupID:      taskRun.TaskGroupID,
+			ProxyID:          taskRun.ProxyID,
+			Status:           taskRun.Status,
+			StatusPercentage: taskRun.StatusPercentage,
+			EventType:        taskRun.EventType,
+			Time:             timestamp,
+		}
+
+		index, ok := runIndexes[taskRun.RunID]
+		if !ok {
+			runIndexes[taskRun.RunID] = len(taskRuns)
+			taskRun.Events = []entities.TaskRunEvent{runEvent}
+			taskRuns = append(taskRuns, taskRun)
+			continue
+		}
+		taskRun.StartTime = taskRuns[index].StartTime
+		taskRun.Events = append(taskRuns[index].Events, runEvent)
+		taskRuns[index] = taskRun
+	}
+
+	return taskRuns
+}
+
+// prune applies the retention policy from the user's Settings
+func (historyStore *HistoryStore) prune() {
+	retentionDays, maxRuns := entities.DefaultTaskHistoryRetentionDays, entities.DefaultTaskHistoryMaxRuns
+	settings, err := queries.GetSettings()
+	if err == nil {
+		if settings.TaskHistoryRetentionDays > 0 {
+			retentionDays = settings.TaskHistoryRetentionDays
+		}
+		if settings.TaskHistoryMaxRuns > 0 {
+			maxRuns = settings.TaskHistoryMaxRuns
+		}
+	}
+
+	err = commands.PruneTaskRuns(retentionDays, maxRuns)
+	if err != nil {
+		log.Println("Error pruning task run history: " + err.Error())
+	}
+}
+
+var historyStore *HistoryStore
+
+// InitHistoryStore initializes the singleton instance of the HistoryStore and starts recording
+func InitHistoryStore(eventBus *events.EventBus) {
+	historyStore = &HistoryStore{
+		EventBus: eventBus,
+		subscriber: eventBus.AddSubscriber(events.SubscriberOptions{
+			Topics:     []events.Topic{events.TaskTopic, events.MonitorTopic},
+			BufferSize: historyBufferSize,
+			Policy:     events.DropOldest,
+		}),
+		lastRunIDs: make(map[string]string),
+	}
+	go historyStore.record()
+}
+
+// GetHistoryStore returns the singleton instance of the HistoryStore
+func GetHistoryStore() *HistoryStore {
+	return historyStore
+}
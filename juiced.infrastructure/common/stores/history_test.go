@@ -0,0 +1,74 @@
+package stores
+
+import (
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+)
+
+func TestTaskRunsFor(t *testing.T) {
+	historyStore := &HistoryStore{lastRunIDs: make(map[string]string)}
+	taskEvent := func(runID string, status enums.TaskStatus, eventType enums.TaskEventType, time int64) events.Event {
+		return events.Event{
+			EventType: events.TaskEventType,
+			TaskEvent: events.TaskEvent{TaskID: "task", TaskGroupID: "group", RunID: runID, ProxyID: "proxy", Status: status, EventType: eventType, Time: time},
+		}
+	}
+
+	batch := []events.Event{
+		taskEvent("first", enums.SettingUp, enums.TaskStart, 1000),
+		{EventType: events.MonitorEventType, MonitorEvent: events.MonitorEvent{MonitorID: "group", RunID: "monitor", Status: enums.SettingUpMonitor, EventType: enums.MonitorStart, Time: 1500}},
+		taskEvent("first", enums.TaskIdle, enums.TaskStop, 2000),
+		taskEvent("second", enums.SettingUp, enums.TaskStart, 3000),
+		// Events published without a run ID belong to the Task's most recent run
+		taskEvent("", enums.CheckingOut, enums.TaskUpdate, 4000),
+		{EventType: events.CloseEventType},
+	}
+	taskRuns := historyStore.taskRunsFor(batch)
+
+	if len(taskRuns) != 3 {
+		t.Fatalf("expected 3 runs, got %d: %+v", len(taskRuns), taskRuns)
+	}
+	want := []struct {
+		runID      string
+		kind       entities.TaskRunKind
+		status     string
+		events     int
+		startTime  int64
+		updateTime int64
+	}{
+		{"first", entities.TaskRunKindTask, enums.TaskIdle, 2, 1000, 2000},
+		{"monitor", entities.TaskRunKindMonitor, enums.SettingUpMonitor, 1, 1500, 1500},
+		{"second", entities.TaskRunKindTask, enums.CheckingOut, 2, 3000, 4000},
+	}
+	for i, taskRun := range taskRuns {
+		if taskRun.RunID != want[i].runID || taskRun.Kind != want[i].kind || taskRun.Status != want[i].status || len(taskRun.Events) != want[i].events {
+			t.Errorf("run %d: expected %+v, got %+v", i, want[i], taskRun)
+		}
+		if taskRun.StartTime != want[i].startTime || taskRun.UpdateTime != want[i].updateTime {
+			t.Errorf("run %d: expected it to start at %d and be updated at %d, got %d and %d", i, want[i].startTime, want[i].updateTime, taskRun.StartTime, taskRun.UpdateTime)
+		}
+		if taskRun.TaskGroupID != "group" {
+			t.Errorf("run %d: expected task group ID group, got %s", i, taskRun.TaskGroupID)
+		}
+		for _, event := range taskRun.Events {
+			if event.RunID != taskRun.RunID {
+				t.Errorf("run %d: unexpected event %+v", i, event)
+			}
+		}
+	}
+	if taskRuns[0].Events[0].Status != enums.SettingUp || taskRuns[0].Events[1].Status != enums.TaskIdle || taskRuns[0].Events[1].Time != 2000 {
+		t.Errorf("expected the first run's events in the order they were published, got %+v", taskRuns[0].Events)
+	}
+
+	// A run's ID is forgotten once it ends
+	historyStore.taskRunsFor([]events.Event{
+		taskEvent("second", enums.TaskIdle, enums.TaskStop, 5000),
+		{EventType: events.MonitorEventType, MonitorEvent: events.MonitorEvent{MonitorID: "group", RunID: "monitor", Status: enums.MonitorIdle, EventType: enums.MonitorStop, Time: 5000}},
+	})
+	if len(historyStore.lastRunIDs) != 0 {
+		t.Errorf("expected the ended runs' IDs to be removed, got %v", historyStore.lastRunIDs)
+	}
+}
@@ -0,0 +1,250 @@
+package stores
+
+import (
+	e "errors"
+	"log"
+	"sync"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+)
+
+// outboxCheckInterval is how often the OutboxStore checks for OutboxEntries that are due
+const outboxCheckInterval = time.Second
+
+// discordWebhookSpacing and sinkSpacing are the least time between two deliveries to the same destination,
+// Discord rate limits webhooks to about 30 messages a minute
+const (
+	discordWebhookSpacing = 2*time.Second + time.Second/2
+	sinkSpacing           = time.Second
+)
+
+// outboxFirstBackoff is how long the first retry waits, every retry after it waits twice as long up to outboxMaxBackoff
+const (
+	outboxFirstBackoff = 5 * time.Second
+	outboxMaxBackoff   = 30 * time.Minute
+)
+
+// outboxMaxAttempts is how many times an OutboxEntry is tried before it's dead-lettered
+const outboxMaxAttempts = 10
+
+// OutboxStore delivers the pending OutboxEntries in the database. Each destination's entries are delivered one at a
+// time and in order, so a destination that is down or rate limited holds up its own entries but not the others'.
+// It's safe for concurrent use.
+type OutboxStore struct {
+	delivering map[string]bool
+	lastSent   map[string]time.Time
+	mutex      sync.Mutex
+}
+
+// GetEntries returns the OutboxEntries with the status, or every one if it's empty
+func (outboxStore *OutboxStore) GetEntries(status enums.OutboxStatus) ([]entities.OutboxEntry, error) {
+	return queries.GetOutboxEntries(status)
+}
+
+// Replay queues the dead OutboxEntry with the given ID to be delivered again, with all of its attempts
+func (outboxStore *OutboxStore) Replay(ID string) (entities.OutboxEntry, error) {
+	entry, ok, err := queries.GetOutboxEntry(ID)
+	if err != nil {
+		return entry, err
+	}
+	if !ok {
+		return entry, e.New(errors.OutboxEntryNotFoundError)
+	}
+	if entry.Status != enums.OutboxDead {
+		return entry, e.New(errors.OutboxEntryNotDeadError)
+	}
+	entry.Status = enums.OutboxPending
+	entry.Attempts = 0
+	entry.NextAttempt = time.Now().Unix()
+	return entry, commands.UpdateOutboxEntry(entry)
+}
+
+// ReplayAll queues every dead OutboxEntry to be delivered again, and returns them
+func (outboxStore *OutboxStore) ReplayAll() ([]entities.OutboxEntry, error) {
+	entries, err := queries.GetOutboxEntries(enums.OutboxDead)
+	if err != nil {
+		return entries, err
+	}
+	now := time.Now().Unix()
+	for i := range entries {
+		entries[i].Status = enums.OutboxPending
+		entries[i].Attempts = 0
+		entries[i].NextAttempt = now
+		err = commands.UpdateOutboxEntry(entries[i])
+		if err != nil {
+			return entries[:i], err
+		}
+	}
+	return entries, nil
+}
+
+// Remove removes the OutboxEntry with the given ID, it won't be delivered
+func (outboxStore *OutboxStore) Remove(ID string) error {
+	_, ok, err := queries.GetOutboxEntry(ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return e.New(errors.OutboxEntryNotFoundError)
+	}
+	return commands.RemoveOutboxEntry(ID)
+}
+
+// run starts delivering to every destination that has an OutboxEntry due, every outboxCheckInterval
+func (outboxStore *OutboxStore) run() {
+	ticker := time.NewTicker(outboxCheckInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		entries, err := queries.GetOutboxEntries(enums.OutboxPending)
+		if err != nil {
+			log.Println("Error getting the notification outbox: " + err.Error())
+			continue
+		}
+		destinations, queues := groupOutboxEntries(entries)
+		for _, destination := range destinations {
+			if queues[destination][0].NextAttempt > now.Unix() || !outboxStore.startDelivering(destination) {
+				continue
+			}
+			go outboxStore.deliver(destination, queues[destination])
+		}
+	}
+}
+
+// startDelivering returns false if the destination's entries are already being delivered
+func (outboxStore *OutboxStore) startDelivering(destination string) bool {
+	outboxStore.mutex.Lock()
+	defer outboxStore.mutex.Unlock()
+
+	if outboxStore.delivering[destination] {
+		return false
+	}
+	outboxStore.delivering[destination] = true
+	return true
+}
+
+// deliver delivers the destination's entries in order, until one of them has to wait to be retried
+func (outboxStore *OutboxStore) deliver(destination string, entries []entities.OutboxEntry) {
+	defer func() {
+		outboxStore.mutex.Lock()
+		delete(outboxStore.delivering, destination)
+		outboxStore.mutex.Unlock()
+	}()
+
+	spacing := sinkSpacing
+	if destination == enums.DiscordSuccessDestination || destination == enums.DiscordFailureDestination {
+		spacing = discordWebhookSpacing
+	}
+	for _, entry := range entries {
+		if entry.NextAttempt > time.Now().Unix() {
+			return
+		}
+		// The entry might have been removed, or dead-lettered and replayed, since the entries were read
+		current, ok, err := queries.GetOutboxEntry(entry.ID)
+		if err != nil || !ok || current.Status != enums.OutboxPending {
+			continue
+		}
+
+		outboxStore.mutex.Lock()
+		wait := time.Until(outboxStore.lastSent[destination].Add(spacing))
+		outboxStore.mutex.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+
+		// The settings are read for every entry so that changed webhooks and sinks are used straight away
+		settings, err := queries.GetSettings()
+		if err != nil {
+			log.Println("Error getting the settings to deliver notifications: " + err.Error())
+			return
+		}
+		err = notifications.Deliver(current, settings)
+		outboxStore.mutex.Lock()
+		outboxStore.lastSent[destination] = time.Now()
+		outboxStore.mutex.Unlock()
+
+		if err == nil || err == notifications.ErrNoDestination {
+			err = commands.RemoveOutboxEntry(current.ID)
+			if err != nil {
+				log.Println("Error removing a delivered notification: " + err.Error())
+				return
+			}
+			continue
+		}
+
+		failOutboxEntry(&current, err, time.Now())
+		updateErr := commands.UpdateOutboxEntry(current)
+		if updateErr != nil {
+			log.Println("Error saving a notification that failed: " + updateErr.Error())
+			return
+		}
+		// Later entries wait for this one, so that a destination receives its notifications in order
+		if current.Status == enums.OutboxPending {
+			return
+		}
+	}
+}
+
+// failOutboxEntry records the failed attempt to deliver the entry at now. The entry is dead-lettered if the error is
+// permanent or it's out of attempts, otherwise it's retried after a backoff or when the destination asked.
+func failOutboxEntry(entry *entities.OutboxEntry, err error, now time.Time) {
+	entry.Attempts++
+	entry.LastError = err.Error()
+	if notifications.Permanent(err) || entry.Attempts >= outboxMaxAttempts {
+		entry.Status = enums.OutboxDead
+		return
+	}
+	entry.NextAttempt = now.Add(outboxBackoff(entry.Attempts, notifications.RetryAfter(err))).Unix()
+}
+
+// outboxBackoff returns how long to wait after the given number of failed attempts, or retryAfter if it's longer
+func outboxBackoff(attempts int, retryAfter time.Duration) time.Duration {
+	backoff := outboxFirstBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	if retryAfter > backoff {
+		return retryAfter
+	}
+	return backoff
+}
+
+// groupOutboxEntries returns the destinations of the entries in the order they first appear, and each destination's
+// entries in the order they're in
+func groupOutboxEntries(entries []entities.OutboxEntry) ([]string, map[string][]entities.OutboxEntry) {
+	destinations := []string{}
+	queues := make(map[string][]entities.OutboxEntry)
+	for _, entry := range entries {
+		if _, ok := queues[entry.Destination]; !ok {
+			destinations = append(destinations, entry.Destination)
+		}
+		queues[entry.Destination] = append(queues[entry.Destination], entry)
+	}
+	return destinations, queues
+}
+
+var outboxStore *OutboxStore
+
+// InitOutboxStore initializes the singleton instance of the OutboxStore and starts delivering the OutboxEntries in the
+// database, including the ones that were still pending when the app was closed
+func InitOutboxStore() {
+	outboxStore = &OutboxStore{
+		delivering: make(map[string]bool),
+		lastSent:   make(map[string]time.Time),
+	}
+	go outboxStore.run()
+}
+
+// GetOutboxStore returns the singleton instance of the OutboxStore
+func GetOutboxStore() *OutboxStore {
+	return outboxStore
+}
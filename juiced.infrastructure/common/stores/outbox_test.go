@@ -0,0 +1,72 @@
+package stores
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
+)
+
+func TestOutboxBackoff(t *testing.T) {
+	cases := []struct {
+		attempts   int
+		retryAfter time.Duration
+		expected   time.Duration
+	}{
+		{1, 0, 5 * time.Second},
+		{2, 0, 10 * time.Second},
+		{4, 0, 40 * time.Second},
+		{9, 0, 21*time.Minute + 20*time.Second},
+		{20, 0, outboxMaxBackoff},
+		{1, time.Minute, time.Minute},
+		{4, time.Second, 40 * time.Second},
+	}
+	for _, c := range cases {
+		if backoff := outboxBackoff(c.attempts, c.retryAfter); backoff != c.expected {
+			t.Errorf("%d attempts, retry after %s: expected %s, got %s", c.attempts, c.retryAfter, c.expected, backoff)
+		}
+	}
+}
+
+func TestFailOutboxEntry(t *testing.T) {
+	now := time.Date(2021, 10, 15, 10, 0, 0, 0, time.UTC)
+
+	entry := entities.OutboxEntry{Status: enums.OutboxPending}
+	failOutboxEntry(&entry, &notifications.StatusError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests", RetryAfter: time.Minute}, now)
+	if entry.Status != enums.OutboxPending || entry.Attempts != 1 || entry.NextAttempt != now.Add(time.Minute).Unix() || entry.LastError == "" {
+		t.Errorf("expected a rate limited entry to be retried when the destination asked, got %+v", entry)
+	}
+
+	entry = entities.OutboxEntry{Status: enums.OutboxPending}
+	failOutboxEntry(&entry, &notifications.StatusError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}, now)
+	if entry.Status != enums.OutboxDead {
+		t.Errorf("expected an entry that can't be delivered to be dead-lettered, got %+v", entry)
+	}
+
+	entry = entities.OutboxEntry{Status: enums.OutboxPending, Attempts: outboxMaxAttempts - 1}
+	failOutboxEntry(&entry, errors.New("connection refused"), now)
+	if entry.Status != enums.OutboxDead || entry.Attempts != outboxMaxAttempts {
+		t.Errorf("expected an entry that's out of attempts to be dead-lettered, got %+v", entry)
+	}
+}
+
+func TestGroupOutboxEntries(t *testing.T) {
+	entries := []entities.OutboxEntry{
+		{ID: "1", Destination: enums.DiscordSuccessDestination},
+		{ID: "2", Destination: "sink"},
+		{ID: "3", Destination: enums.DiscordSuccessDestination},
+		{ID: "4", Destination: "sink"},
+	}
+	destinations, queues := groupOutboxEntries(entries)
+	if !reflect.DeepEqual(destinations, []string{enums.DiscordSuccessDestination, "sink"}) {
+		t.Errorf("expected the destinations in the order they first appear, got %v", destinations)
+	}
+	if queues["sink"][0].ID != "2" || queues["sink"][1].ID != "4" || queues[enums.DiscordSuccessDestination][1].ID != "3" {
+		t.Errorf("expected each destination's entries in order, got %+v", queues)
+	}
+}
@@ -1,40 +1,175 @@
 package stores
 
 import (
+	e "errors"
+	"log"
+	"sync"
+	"time"
+
+	"backend.juicedbot.io/juiced.client/client"
+	"backend.juicedbot.io/juiced.client/utls"
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
 )
 
-// ProxyStore stores information about loaded proxies
+// ProxyStore stores information about loaded proxies, it's safe for concurrent use
 type ProxyStore struct {
-	ProxyGroups map[string]*entities.ProxyGroup
+	EventBus    *events.EventBus
+	proxyGroups map[string]*entities.ProxyGroup
+	mutex       sync.RWMutex
 }
 
 func (proxyStore *ProxyStore) AddProxyGroup(proxyGroup *entities.ProxyGroup) {
-	proxyStore.ProxyGroups[proxyGroup.GroupID] = proxyGroup
+	proxyStore.mutex.Lock()
+	defer proxyStore.mutex.Unlock()
+
+	proxyStore.proxyGroups[proxyGroup.GroupID] = proxyGroup
 }
 
 func (proxyStore *ProxyStore) UpdateProxyGroup(groupID string, proxyGroup *entities.ProxyGroup) {
+	proxyStore.mutex.Lock()
+	defer proxyStore.mutex.Unlock()
+
 	// @silent: This will strictly update the group, want to also add it if it doesn't exist?
-	if _, ok := proxyStore.ProxyGroups[groupID]; ok {
-		proxyStore.ProxyGroups[groupID] = proxyGroup
+	if _, ok := proxyStore.proxyGroups[groupID]; ok {
+		proxyStore.proxyGroups[groupID] = proxyGroup
 	}
 }
 
 func (proxyStore *ProxyStore) RemoveProxyGroup(groupID string) {
-	delete(proxyStore.ProxyGroups, groupID)
+	proxyStore.mutex.Lock()
+	defer proxyStore.mutex.Unlock()
+
+	delete(proxyStore.proxyGroups, groupID)
 }
 
 func (proxyStore *ProxyStore) GetProxyGroup(groupID string) (*entities.ProxyGroup, bool) {
-	proxyGroup, ok := proxyStore.ProxyGroups[groupID]
+	proxyStore.mutex.RLock()
+	defer proxyStore.mutex.RUnlock()
+
+	proxyGroup, ok := proxyStore.proxyGroups[groupID]
 	return proxyGroup, ok
 }
 
+// GetProxyGroups returns a snapshot of the store's ProxyGroups that is safe to iterate over while the store changes
+func (proxyStore *ProxyStore) GetProxyGroups() map[string]*entities.ProxyGroup {
+	proxyStore.mutex.RLock()
+	defer proxyStore.mutex.RUnlock()
+
+	proxyGroups := make(map[string]*entities.ProxyGroup, len(proxyStore.proxyGroups))
+	for groupID, proxyGroup := range proxyStore.proxyGroups {
+		proxyGroups[groupID] = proxyGroup
+	}
+	return proxyGroups
+}
+
+// TestProxyGroup sends a request to the URL through every Proxy of the ProxyGroup, concurrency of them at a time, and
+// records the results in the Proxies' Health. A ProxyTestEvent is published as each Proxy finishes. It returns the
+// tested ProxyGroup once every Proxy has been tested.
+func (proxyStore *ProxyStore) TestProxyGroup(groupID string, testURL string, concurrency int, timeout time.Duration) (entities.ProxyGroup, error) {
+	// Tasks use the ProxyGroups in the store, so it's those Proxies whose Health is updated
+	proxyGroup, ok := proxyStore.GetProxyGroup(groupID)
+	if !ok {
+		loadedProxyGroup, err := queries.GetProxyGroup(groupID)
+		if err != nil {
+			return entities.ProxyGroup{}, err
+		}
+		if loadedProxyGroup.GroupID == "" {
+			return entities.ProxyGroup{}, e.New(errors.ProxyGroupNotFoundError)
+		}
+		proxyGroup = &loadedProxyGroup
+		proxyStore.AddProxyGroup(proxyGroup)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	proxyStore.mutex.RLock()
+	proxies := make([]*entities.Proxy, len(proxyGroup.Proxies))
+	copy(proxies, proxyGroup.Proxies)
+	proxyStore.mutex.RUnlock()
+
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var progressMutex sync.Mutex
+	tested := 0
+	for _, proxy := range proxies {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(proxy *entities.Proxy) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			result := testProxy(proxy.Copy(), testURL, timeout)
+			proxy.RecordTest(result, time.Now().Unix())
+			proxyCopy := proxy.Copy()
+			err := commands.UpdateProxyHealth(proxyCopy)
+			if err != nil {
+				log.Println("Error saving the health of proxy " + proxyCopy.ID + ": " + err.Error())
+			}
+
+			progressMutex.Lock()
+			tested++
+			if proxyStore.EventBus != nil {
+				proxyStore.EventBus.PublishProxyTestEvent(events.ProxyTestEvent{
+					ProxyGroupID: groupID,
+					Result:       result,
+					Health:       proxyCopy.Health,
+					Tested:       tested,
+					Total:        len(proxies),
+					Finished:     tested == len(proxies),
+				})
+			}
+			progressMutex.Unlock()
+		}(proxy)
+	}
+	wg.Wait()
+
+	proxyStore.mutex.RLock()
+	defer proxyStore.mutex.RUnlock()
+	testedProxyGroup := *proxyGroup
+	testedProxyGroup.Proxies = make([]*entities.Proxy, len(proxies))
+	for i, proxy := range proxies {
+		proxyCopy := proxy.Copy()
+		testedProxyGroup.Proxies[i] = &proxyCopy
+	}
+	return testedProxyGroup, nil
+}
+
+// testProxy sends a GET request to the URL through the Proxy and returns how long it took and how it went
+var testProxy = func(proxy entities.Proxy, testURL string, timeout time.Duration) entities.ProxyTestResult {
+	result := entities.ProxyTestResult{ProxyID: proxy.ID}
+	proxyClient, err := client.NewClient(utls.HelloChrome_90, common.ProxyCleaner(proxy))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	proxyClient.Timeout = timeout
+
+	start := time.Now()
+	response, err := proxyClient.Get(testURL)
+	result.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	response.Body.Close()
+	result.StatusCode = response.StatusCode
+	return result
+}
+
 var proxyStore *ProxyStore
 
 // InitProxyStore initializes the singleton instance of the ProxyStore
-func InitProxyStore() {
+func InitProxyStore(eventBus *events.EventBus) {
 	proxyStore = &ProxyStore{
-		ProxyGroups: make(map[string]*entities.ProxyGroup),
+		EventBus:    eventBus,
+		proxyGroups: make(map[string]*entities.ProxyGroup),
 	}
 }
 
@@ -0,0 +1,64 @@
+package stores
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+)
+
+func TestTestProxyGroup(t *testing.T) {
+	events.InitEventBus()
+	eventBus := events.GetEventBus()
+	subscriber := eventBus.AddSubscriber(events.SubscriberOptions{Topics: []events.Topic{events.ProxyTopic}, BufferSize: 10})
+	InitProxyStore(eventBus)
+
+	proxyGroup := &entities.ProxyGroup{GroupID: "group", Proxies: []*entities.Proxy{{ID: "fast"}, {ID: "dead"}, {ID: "slow"}}}
+	GetProxyStore().AddProxyGroup(proxyGroup)
+
+	var mutex sync.Mutex
+	running, mostRunning := 0, 0
+	testProxy = func(proxy entities.Proxy, testURL string, timeout time.Duration) entities.ProxyTestResult {
+		mutex.Lock()
+		running++
+		if running > mostRunning {
+			mostRunning = running
+		}
+		mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+
+		switch proxy.ID {
+		case "dead":
+			return entities.ProxyTestResult{ProxyID: proxy.ID, Error: "proxyconnect tcp: connection refused"}
+		case "slow":
+			return entities.ProxyTestResult{ProxyID: proxy.ID, Latency: 8000, StatusCode: 200}
+		}
+		return entities.ProxyTestResult{ProxyID: proxy.ID, Latency: 300, StatusCode: 200}
+	}
+
+	tested, err := GetProxyStore().TestProxyGroup("group", "https://example.com", 2, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mostRunning != 2 {
+		t.Errorf("expected 2 proxies to be tested at a time, got %d", mostRunning)
+	}
+	healthy := map[string]bool{"fast": true, "dead": false, "slow": false}
+	for i, proxy := range tested.Proxies {
+		if proxy.Healthy() != healthy[proxy.ID] || proxyGroup.Proxies[i].Health != proxy.Health || proxy.TestedAt == 0 {
+			t.Errorf("%s: expected the store's proxy to be tested and healthy to be %v, got %+v", proxy.ID, healthy[proxy.ID], proxy)
+		}
+	}
+
+	for i := 1; i <= 3; i++ {
+		event := <-subscriber.Events()
+		if event.ProxyTestEvent.Tested != i || event.ProxyTestEvent.Total != 3 || event.ProxyTestEvent.Finished != (i == 3) {
+			t.Errorf("expected progress %d of 3, got %+v", i, event.ProxyTestEvent)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package stores
+
+import (
+	e "errors"
+	"log"
+	"sync"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"github.com/google/uuid"
+)
+
+// scheduleCheckInterval is how often the ScheduleStore checks for schedules that are due
+const scheduleCheckInterval = time.Second
+
+// scheduleMissedStartGrace is how late a scheduled start can still fire, e.g. after a restart or after the computer wakes up.
+// Later starts are skipped, since the drop they were scheduled for has most likely passed.
+const scheduleMissedStartGrace = 5 * time.Minute
+
+// ScheduleStore starts and stops TaskGroups at the times set by their TaskGroupSchedules, it's safe for concurrent use
+type ScheduleStore struct {
+	EventBus  *events.EventBus
+	schedules map[string]*entities.TaskGroupSchedule
+	mutex     sync.Mutex
+}
+
+// firedSchedule is a TaskGroupSchedule that was due, with what it needs to do in order
+type firedSchedule struct {
+	schedule   entities.TaskGroupSchedule
+	eventTypes []enums.ScheduleEventType
+}
+
+// AddSchedule validates the TaskGroupSchedule, adds it to the database and schedules it
+func (scheduleStore *ScheduleStore) AddSchedule(schedule *entities.TaskGroupSchedule) error {
+	now := time.Now()
+	if (schedule.StartTime != 0 && schedule.StartTime <= now.Unix()) || (schedule.StopTime != 0 && schedule.StopTime <= now.Unix()) {
+		return e.New(errors.ScheduleTimeInPastError)
+	}
+	err := prepareSchedule(schedule, now)
+	if err != nil {
+		return err
+	}
+	schedule.ID = uuid.New().String()
+	schedule.CreationDate = now.Unix()
+	schedule.LastFiredTime = 0
+
+	err = commands.CreateTaskGroupSchedule(*schedule)
+	if err != nil {
+		return err
+	}
+	scheduleStore.mutex.Lock()
+	scheduleStore.schedules[schedule.ID] = copySchedule(*schedule)
+	scheduleStore.mutex.Unlock()
+	return nil
+}
+
+// UpdateSchedule replaces the TaskGroup's TaskGroupSchedule with the same ID, and reschedules it
+func (scheduleStore *ScheduleStore) UpdateSchedule(groupID string, schedule *entities.TaskGroupSchedule) error {
+	scheduleStore.mutex.Lock()
+	defer scheduleStore.mutex.Unlock()
+
+	currentSchedule, ok := scheduleStore.schedules[schedule.ID]
+	if !ok || currentSchedule.TaskGroupID != groupID {
+		return e.New(errors.TaskGroupScheduleNotFoundError)
+	}
+	now := time.Now()
+	err := prepareSchedule(schedule, now)
+	if err != nil {
+		return err
+	}
+	schedule.TaskGroupID = currentSchedule.TaskGroupID
+	schedule.CreationDate = currentSchedule.CreationDate
+	schedule.LastFiredTime = currentSchedule.LastFiredTime
+	// A TaskGroup that this schedule already started still stops after the number of minutes
+	if schedule.StopAfterMinutes > 0 && currentSchedule.NextStopTime > now.Unix() {
+		schedule.NextStopTime = currentSchedule.NextStopTime
+	}
+
+	err = commands.UpdateTaskGroupSchedule(*schedule)
+	if err != nil {
+		return err
+	}
+	scheduleStore.schedules[schedule.ID] = copySchedule(*schedule)
+	return nil
+}
+
+// RemoveSchedule removes the TaskGroup's TaskGroupSchedule with the given ID from the database, it won't fire again
+func (scheduleStore *ScheduleStore) RemoveSchedule(groupID string, ID string) error {
+	scheduleStore.mutex.Lock()
+	defer scheduleStore.mutex.Unlock()
+
+	currentSchedule, ok := scheduleStore.schedules[ID]
+	if !ok || currentSchedule.TaskGroupID != groupID {
+		return e.New(errors.TaskGroupScheduleNotFoundError)
+	}
+	err := commands.RemoveTaskGroupSchedule(ID)
+	if err != nil {
+		return err
+	}
+	delete(scheduleStore.schedules, ID)
+	return nil
+}
+
+// RemoveTaskGroupSchedules removes all of the TaskGroup's schedules from the database, for when the TaskGroup is removed
+func (scheduleStore *ScheduleStore) RemoveTaskGroupSchedules(groupID string) error {
+	scheduleStore.mutex.Lock()
+	defer scheduleStore.mutex.Unlock()
+
+	err := commands.RemoveTaskGroupSchedules(groupID)
+	if err != nil {
+		return err
+	}
+	for ID, schedule := range scheduleStore.schedules {
+		if schedule.TaskGroupID == groupID {
+			delete(scheduleStore.schedules, ID)
+		}
+	}
+	return nil
+}
+
+// Reload replaces the ScheduleStore's schedules with the ones in the database, after they were changed outside of the
+// ScheduleStore, e.g. by restoring a Backup. Schedules without next start and stop times have them worked out from now.
+func (scheduleStore *ScheduleStore) Reload() error {
+	schedules, err := queries.GetAllTaskGroupSchedules()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reloaded := make(map[string]*entities.TaskGroupSchedule)
+	for _, schedule := range schedules {
+		if schedule.NextStartTime == 0 && schedule.NextStopTime == 0 && prepareSchedule(&schedule, now) == nil {
+			err = commands.UpdateTaskGroupSchedule(schedule)
+			if err != nil {
+				return err
+			}
+		}
+		reloaded[schedule.ID] = copySchedule(schedule)
+	}
+
+	scheduleStore.mutex.Lock()
+	scheduleStore.schedules = reloaded
+	scheduleStore.mutex.Unlock()
+	return nil
+}
+
+// run fires the schedules that are due every scheduleCheckInterval
+func (scheduleStore *ScheduleStore) run() {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		for _, fired := range scheduleStore.due(now) {
+			err := commands.UpdateTaskGroupSchedule(fired.schedule)
+			if err != nil {
+				log.Println("Error saving task group schedule: " + err.Error())
+			}
+			// Stopping a TaskGroup waits for its Tasks to exit, which shouldn't hold up the other schedules
+			go scheduleStore.fire(fired)
+		}
+	}
+}
+
+// due advances every enabled schedule that is due at now, and returns them
+func (scheduleStore *ScheduleStore) due(now time.Time) []firedSchedule {
+	scheduleStore.mutex.Lock()
+	defer scheduleStore.mutex.Unlock()
+
+	fired := []firedSchedule{}
+	for _, schedule := range scheduleStore.schedules {
+		if !schedule.Enabled {
+			continue
+		}
+		eventTypes := advanceSchedule(schedule, now)
+		if len(eventTypes) > 0 {
+			fired = append(fired, firedSchedule{schedule: *schedule, eventTypes: eventTypes})
+		}
+	}
+	return fired
+}
+
+// fire starts or stops the schedule's TaskGroup and publishes a ScheduleEvent for each thing it does
+func (scheduleStore *ScheduleStore) fire(fired firedSchedule) {
+	for _, eventType := range fired.eventTypes {
+		scheduleEvent := events.ScheduleEvent{
+			ScheduleID:  fired.schedule.ID,
+			TaskGroupID: fired.schedule.TaskGroupID,
+			EventType:   eventType,
+		}
+
+		var err error
+		if eventType != enums.ScheduleMissedStart {
+			var taskGroup entities.TaskGroup
+			taskGroup, err = queries.GetTaskGroup(fired.schedule.TaskGroupID)
+			if err == nil {
+				taskStore := GetTaskStore()
+				switch {
+				case taskStore == nil:
+					err = e.New("task store not initialized")
+				case eventType == enums.ScheduleStart:
+					scheduleEvent.Warnings, err = taskStore.StartTaskGroup(&taskGroup)
+				default:
+					err = taskStore.StopTaskGroup(&taskGroup)
+				}
+			}
+		}
+		if err != nil {
+			scheduleEvent.Error = err.Error()
+			log.Println("Error firing task group schedule: " + err.Error())
+		}
+
+		scheduleStore.EventBus.PublishScheduleEvent(scheduleEvent)
+	}
+}
+
+// prepareSchedule validates the schedule and sets its next start and stop times, starting from now.
+// Start and stop times that have already passed are ignored.
+func prepareSchedule(schedule *entities.TaskGroupSchedule, now time.Time) error {
+	if schedule.StartTime == 0 && schedule.Cron == "" && schedule.StopTime == 0 {
+		return e.New(errors.NoScheduledActionError)
+	}
+	if schedule.StartTime != 0 && schedule.Cron != "" {
+		return e.New(errors.ConflictingStartTimesError)
+	}
+	if schedule.StopAfterMinutes < 0 {
+		return e.New(errors.InvalidStopAfterMinutesError)
+	}
+	if schedule.StopAfterMinutes > 0 {
+		if schedule.StopTime != 0 {
+			return e.New(errors.ConflictingStopTimesError)
+		}
+		if schedule.StartTime == 0 && schedule.Cron == "" {
+			return e.New(errors.StopAfterWithoutStartError)
+		}
+	}
+	if schedule.StartTime != 0 && schedule.StopTime != 0 && schedule.StopTime <= schedule.StartTime {
+		return e.New(errors.StopTimeBeforeStartTimeError)
+	}
+
+	schedule.NextStartTime, schedule.NextStopTime = 0, 0
+	if schedule.Cron != "" {
+		cron, err := common.ParseCron(schedule.Cron)
+		if err != nil {
+			return e.New(errors.InvalidCronError + err.Error())
+		}
+		if next := cron.Next(now); !next.IsZero() {
+			schedule.NextStartTime = next.Unix()
+		}
+	} else if schedule.StartTime > now.Unix() {
+		schedule.NextStartTime = schedule.StartTime
+	}
+	if schedule.StopTime > now.Unix() {
+		schedule.NextStopTime = schedule.StopTime
+	}
+	return nil
+}
+
+// advanceSchedule returns what the schedule needs to do at now, stops first, and moves its next start and stop times past now
+func advanceSchedule(schedule *entities.TaskGroupSchedule, now time.Time) []enums.ScheduleEventType {
+	eventTypes := []enums.ScheduleEventType{}
+	if schedule.NextStopTime != 0 && schedule.NextStopTime <= now.Unix() {
+		eventTypes = append(eventTypes, enums.ScheduleStop)
+		schedule.NextStopTime = 0
+	}
+
+	if schedule.NextStartTime != 0 && schedule.NextStartTime <= now.Unix() {
+		if now.Sub(time.Unix(schedule.NextStartTime, 0)) > scheduleMissedStartGrace {
+			eventTypes = append(eventTypes, enums.ScheduleMissedStart)
+		} else {
+			eventTypes = append(eventTypes, enums.ScheduleStart)
+			if schedule.StopAfterMinutes > 0 {
+				schedule.NextStopTime = now.Add(time.Duration(schedule.StopAfterMinutes) * time.Minute).Unix()
+			}
+		}
+
+		schedule.NextStartTime = 0
+		if schedule.Cron != "" {
+			cron, err := common.ParseCron(schedule.Cron)
+			if err == nil {
+				if next := cron.Next(now); !next.IsZero() {
+					schedule.NextStartTime = next.Unix()
+				}
+			}
+		}
+	}
+
+	if len(eventTypes) > 0 {
+		schedule.LastFiredTime = now.Unix()
+	}
+	return eventTypes
+}
+
+// copySchedule returns a pointer to a copy of the schedule, so that the ScheduleStore's schedules aren't shared
+func copySchedule(schedule entities.TaskGroupSchedule) *entities.TaskGroupSchedule {
+	return &schedule
+}
+
+var scheduleStore *ScheduleStore
+
+// InitScheduleStore initializes the singleton instance of the ScheduleStore with the schedules in the database and starts firing them.
+// Schedules that were due while the app was closed fire straight away, unless they're later than scheduleMissedStartGrace.
+func InitScheduleStore(eventBus *events.EventBus) error {
+	scheduleStore = &ScheduleStore{
+		EventBus:  eventBus,
+		schedules: make(map[string]*entities.TaskGroupSchedule),
+	}
+
+	schedules, err := queries.GetAllTaskGroupSchedules()
+	for _, schedule := range schedules {
+		scheduleStore.schedules[schedule.ID] = copySchedule(schedule)
+	}
+	go scheduleStore.run()
+	return err
+}
+
+// GetScheduleStore returns the singleton instance of the ScheduleStore
+func GetScheduleStore() *ScheduleStore {
+	return scheduleStore
+}
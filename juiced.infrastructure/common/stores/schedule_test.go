@@ -0,0 +1,88 @@
+package stores
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+func TestPrepareSchedule(t *testing.T) {
+	now := time.Date(2021, 10, 15, 10, 7, 30, 0, time.UTC)
+	later := now.Add(time.Hour).Unix()
+
+	invalid := map[string]entities.TaskGroupSchedule{
+		"nothing scheduled":      {},
+		"start time and cron":    {StartTime: later, Cron: "0 9 * * *"},
+		"two stop times":         {StartTime: later, StopAfterMinutes: 10, StopTime: later + 60},
+		"stop after, no start":   {StopAfterMinutes: 10},
+		"negative stop after":    {StartTime: later, StopAfterMinutes: -1},
+		"stop before start":      {StartTime: later, StopTime: later - 60},
+		"invalid cron":           {Cron: "0 25 * * *"},
+		"cron with wrong fields": {Cron: "0 9 * *"},
+	}
+	for name, schedule := range invalid {
+		if err := prepareSchedule(&schedule, now); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	schedule := entities.TaskGroupSchedule{StartTime: later, StopAfterMinutes: 30}
+	if err := prepareSchedule(&schedule, now); err != nil {
+		t.Fatal(err)
+	}
+	// The stop time is only known once the TaskGroup starts
+	if schedule.NextStartTime != later || schedule.NextStopTime != 0 {
+		t.Errorf("unexpected next times for a one-shot schedule: %+v", schedule)
+	}
+
+	schedule = entities.TaskGroupSchedule{Cron: "0 9 * * *", StopTime: later}
+	if err := prepareSchedule(&schedule, now); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.NextStartTime != time.Date(2021, 10, 16, 9, 0, 0, 0, time.UTC).Unix() || schedule.NextStopTime != later {
+		t.Errorf("unexpected next times for a cron schedule: %+v", schedule)
+	}
+}
+
+func TestAdvanceSchedule(t *testing.T) {
+	start := time.Date(2021, 10, 15, 9, 0, 0, 0, time.UTC)
+
+	schedule := &entities.TaskGroupSchedule{StartTime: start.Unix(), StopAfterMinutes: 30, NextStartTime: start.Unix()}
+	if eventTypes := advanceSchedule(schedule, start.Add(-time.Second)); len(eventTypes) != 0 {
+		t.Errorf("expected nothing to fire before the start time, got %v", eventTypes)
+	}
+	eventTypes := advanceSchedule(schedule, start)
+	if !reflect.DeepEqual(eventTypes, []enums.ScheduleEventType{enums.ScheduleStart}) {
+		t.Errorf("expected the schedule to start, got %v", eventTypes)
+	}
+	if schedule.NextStartTime != 0 || schedule.NextStopTime != start.Add(30*time.Minute).Unix() || schedule.LastFiredTime != start.Unix() {
+		t.Errorf("unexpected schedule after starting: %+v", schedule)
+	}
+	eventTypes = advanceSchedule(schedule, start.Add(30*time.Minute))
+	if !reflect.DeepEqual(eventTypes, []enums.ScheduleEventType{enums.ScheduleStop}) || schedule.NextStopTime != 0 {
+		t.Errorf("expected the schedule to stop, got %v (%+v)", eventTypes, schedule)
+	}
+
+	// A cron schedule stops the previous run before starting the next one, and moves on to the next match
+	schedule = &entities.TaskGroupSchedule{Cron: "0 9 * * *", StopAfterMinutes: 24 * 60, NextStartTime: start.Unix(), NextStopTime: start.Unix()}
+	eventTypes = advanceSchedule(schedule, start)
+	if !reflect.DeepEqual(eventTypes, []enums.ScheduleEventType{enums.ScheduleStop, enums.ScheduleStart}) {
+		t.Errorf("expected the schedule to stop and then start, got %v", eventTypes)
+	}
+	if schedule.NextStartTime != start.Add(24*time.Hour).Unix() || schedule.NextStopTime != start.Add(24*time.Hour).Unix() {
+		t.Errorf("unexpected schedule after a cron start: %+v", schedule)
+	}
+
+	// Starts that are too late are skipped, e.g. if the app was closed
+	schedule = &entities.TaskGroupSchedule{Cron: "0 9 * * *", StopAfterMinutes: 30, NextStartTime: start.Unix()}
+	eventTypes = advanceSchedule(schedule, start.Add(scheduleMissedStartGrace+time.Minute))
+	if !reflect.DeepEqual(eventTypes, []enums.ScheduleEventType{enums.ScheduleMissedStart}) {
+		t.Errorf("expected the start to be missed, got %v", eventTypes)
+	}
+	if schedule.NextStartTime != start.Add(24*time.Hour).Unix() || schedule.NextStopTime != 0 {
+		t.Errorf("unexpected schedule after a missed start: %+v", schedule)
+	}
+}
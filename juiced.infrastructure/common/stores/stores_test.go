@@ -22,7 +22,7 @@ import (
 	"backend.juicedbot.io/juiced.sitescripts/walmart"
 )
 
-var amazonMonitorAsset = map[string]*amazon.Monitor{"amazon_test_monitor": {Monitor: monitorAsset, ASINs: []string{"B08V7GT6F3"}}}
+var amazonMonitorAsset = map[string]base.RunnableMonitor{"amazon_test_monitor": &amazon.Monitor{Monitor: newMonitorAsset(), ASINs: []string{"B08V7GT6F3"}}}
 
 var amazonMonitorInfoAsset = &entities.AmazonMonitorInfo{
 	Monitors: []entities.AmazonSingleMonitorInfo{{
@@ -32,7 +32,7 @@ var amazonMonitorInfoAsset = &entities.AmazonMonitorInfo{
 	}},
 }
 
-var bestbuyMonitorAsset = map[string]*bestbuy.Monitor{"bestbuy_test_monitor": {Monitor: monitorAsset, SKUs: []string{"6457447"}}}
+var bestbuyMonitorAsset = map[string]base.RunnableMonitor{"bestbuy_test_monitor": &bestbuy.Monitor{Monitor: newMonitorAsset(), SKUs: []string{"6457447"}}}
 
 var bestbuyMonitorInfoAsset = &entities.BestbuyMonitorInfo{
 	Monitors: []entities.BestbuySingleMonitorInfo{
@@ -43,7 +43,7 @@ var bestbuyMonitorInfoAsset = &entities.BestbuyMonitorInfo{
 	},
 }
 
-var boxlunchMonitorAsset = map[string]*boxlunch.Monitor{"boxlunch_test_monitor": {Monitor: monitorAsset, Pids: []string{""}}}
+var boxlunchMonitorAsset = map[string]base.RunnableMonitor{"boxlunch_test_monitor": &boxlunch.Monitor{Monitor: newMonitorAsset(), Pids: []string{""}}}
 
 var boxlunchMonitorInfoAsset = &entities.BoxlunchMonitorInfo{
 	Monitors: []entities.BoxlunchSingleMonitorInfo{
@@ -54,7 +54,7 @@ var boxlunchMonitorInfoAsset = &entities.BoxlunchMonitorInfo{
 	},
 }
 
-var disneyMonitorAsset = map[string]*disney.Monitor{"disney_test_monitor": {Monitor: monitorAsset, Pids: []string{"5813057814019M"}}}
+var disneyMonitorAsset = map[string]base.RunnableMonitor{"disney_test_monitor": &disney.Monitor{Monitor: newMonitorAsset(), Pids: []string{"5813057814019M"}}}
 
 var disneyMonitorInfoAsset = &entities.DisneyMonitorInfo{
 	Monitors: []entities.DisneySingleMonitorInfo{
@@ -65,7 +65,7 @@ var disneyMonitorInfoAsset = &entities.DisneyMonitorInfo{
 	},
 }
 
-var gamestopMonitorAsset = map[string]*gamestop.Monitor{"gamestop_test_monitor": {Monitor: monitorAsset, SKUs: []string{"11105919"}}}
+var gamestopMonitorAsset = map[string]base.RunnableMonitor{"gamestop_test_monitor": &gamestop.Monitor{Monitor: newMonitorAsset(), SKUs: []string{"11105919"}}}
 
 var gamestopMonitorInfoAsset = &entities.GamestopMonitorInfo{
 	Monitors: []entities.GamestopSingleMonitorInfo{
@@ -76,7 +76,7 @@ var gamestopMonitorInfoAsset = &entities.GamestopMonitorInfo{
 	},
 }
 
-var hottopicMonitorAsset = map[string]*hottopic.Monitor{"hottopic_test_monitor": {Monitor: monitorAsset, Pids: []string{"16078565"}}}
+var hottopicMonitorAsset = map[string]base.RunnableMonitor{"hottopic_test_monitor": &hottopic.Monitor{Monitor: newMonitorAsset(), Pids: []string{"16078565"}}}
 
 var hottopicMonitorInfoAsset = &entities.HottopicMonitorInfo{
 	Monitors: []entities.HottopicSingleMonitorInfo{
@@ -90,7 +90,7 @@ var hottopicMonitorInfoAsset = &entities.HottopicMonitorInfo{
 	},
 }
 
-var neweggMonitorAsset = map[string]*newegg.Monitor{"newegg_test_monitor": {Monitor: monitorAsset, SKUs: []string{"N82E16820147790"}}}
+var neweggMonitorAsset = map[string]base.RunnableMonitor{"newegg_test_monitor": &newegg.Monitor{Monitor: newMonitorAsset(), SKUs: []string{"N82E16820147790"}}}
 
 var neweggMonitorInfoAsset = &entities.NeweggMonitorInfo{
 	Monitors: []entities.NeweggSingleMonitorInfo{
@@ -101,7 +101,7 @@ var neweggMonitorInfoAsset = &entities.NeweggMonitorInfo{
 	},
 }
 
-var shopifyMonitorAsset = map[string]*shopify.Monitor{"shopify_test_monitor": {Monitor: monitorAsset, VIDs: []string{""}}}
+var shopifyMonitorAsset = map[string]base.RunnableMonitor{"shopify_test_monitor": &shopify.Monitor{Monitor: newMonitorAsset(), VIDs: []string{""}}}
 
 var shopifyMonitorInfoAsset = &entities.ShopifyMonitorInfo{
 	Monitors: []entities.ShopifySingleMonitorInfo{
@@ -112,7 +112,7 @@ var shopifyMonitorInfoAsset = &entities.ShopifyMonitorInfo{
 	},
 }
 
-var targetMonitorsAsset = map[string]*target.Monitor{"target_test_monitor": {Monitor: monitorAsset, MonitorType: "SKU_MONITOR", TCINs: []string{"81622440"}, StoreID: "1120"}}
+var targetMonitorsAsset = map[string]base.RunnableMonitor{"target_test_monitor": &target.Monitor{Monitor: newMonitorAsset(), MonitorType: "SKU_MONITOR", TCINs: []string{"81622440"}, StoreID: "1120"}}
 
 var targetMonitorInfoAsset = &entities.TargetMonitorInfo{
 	Monitors: []entities.TargetSingleMonitorInfo{
@@ -126,7 +126,7 @@ var targetMonitorInfoAsset = &entities.TargetMonitorInfo{
 	MonitorType: enums.SKUMonitor,
 }
 
-var toppsMonitorAsset = map[string]*topps.Monitor{"topps_test_monitor": {Monitor: monitorAsset, Items: []string{"on-card-auto-to-25-greg-maddux-2021-mlb-topps-now-reg-turn-back-the-clock-card-134b"}}}
+var toppsMonitorAsset = map[string]base.RunnableMonitor{"topps_test_monitor": &topps.Monitor{Monitor: newMonitorAsset(), Items: []string{"on-card-auto-to-25-greg-maddux-2021-mlb-topps-now-reg-turn-back-the-clock-card-134b"}}}
 
 var toppsMonitorInfoAsset = &entities.ToppsMonitorInfo{
 	Monitors: []entities.ToppsSingleMonitorInfo{
@@ -137,7 +137,7 @@ var toppsMonitorInfoAsset = &entities.ToppsMonitorInfo{
 	},
 }
 
-var walmartMonitorAsset = map[string]*walmart.Monitor{"walmart_test_monitor": {Monitor: monitorAsset, IDs: []string{"544900177"}}}
+var walmartMonitorAsset = map[string]base.RunnableMonitor{"walmart_test_monitor": &walmart.Monitor{Monitor: newMonitorAsset(), IDs: []string{"544900177"}}}
 
 var walmartMonitorInfoAsset = &entities.WalmartMonitorInfo{
 	Monitors: []entities.WalmartSingleMonitorInfo{
@@ -149,7 +149,7 @@ var walmartMonitorInfoAsset = &entities.WalmartMonitorInfo{
 	},
 }
 
-var pokemonCenterMonitorAsset = map[string]*pokemoncenter.Monitor{"pokemoncenter_test_monitor": {Monitor: monitorAsset, SKUs: []string{""}}}
+var pokemonCenterMonitorAsset = map[string]base.RunnableMonitor{"pokemoncenter_test_monitor": &pokemoncenter.Monitor{Monitor: newMonitorAsset(), SKUs: []string{""}}}
 
 var pokemonCenterMonitorInfoAsset = &entities.PokemonCenterMonitorInfo{
 	Monitors: []entities.PokemonCenterSingleMonitorInfo{
@@ -170,18 +170,19 @@ var taskgroupAsset = entities.TaskGroup{
 	CreationDate:        time.Now().Unix(),
 }
 
-var monitorAsset = base.Monitor{
-	TaskGroup: &taskgroupAsset,
-	Proxy: &entities.Proxy{
+// newMonitorAsset returns a fresh base.Monitor for each test asset, since a base.Monitor can't be copied once it has been run
+func newMonitorAsset() (monitor base.Monitor) {
+	monitor.TaskGroup = &taskgroupAsset
+	monitor.EventBus = events.GetEventBus()
+	monitor.SetProxy(&entities.Proxy{
 		Host: "localhost",
 		Port: "3000",
-	},
+	})
+	return
 }
 
 func TestMain(m *testing.M) {
 	events.InitEventBus()
-	eventBus := events.GetEventBus()
-	monitorAsset.EventBus = eventBus
 	m.Run()
 }
 
@@ -214,40 +215,40 @@ func TestStartMonitor(t *testing.T) {
 		tt.args.monitor.MonitorRetailer = tt.retailer
 		switch tt.retailer {
 		case enums.Amazon:
-			tt.monitorStore.AmazonMonitors = amazonMonitorAsset
+			tt.monitorStore.monitors = amazonMonitorAsset
 			tt.args.monitor.AmazonMonitorInfo = amazonMonitorInfoAsset
 		case enums.BestBuy:
-			tt.monitorStore.BestbuyMonitors = bestbuyMonitorAsset
+			tt.monitorStore.monitors = bestbuyMonitorAsset
 			tt.args.monitor.BestbuyMonitorInfo = bestbuyMonitorInfoAsset
 		case enums.BoxLunch:
-			tt.monitorStore.BoxlunchMonitors = boxlunchMonitorAsset
+			tt.monitorStore.monitors = boxlunchMonitorAsset
 			tt.args.monitor.BoxlunchMonitorInfo = boxlunchMonitorInfoAsset
 		case enums.Disney:
-			tt.monitorStore.DisneyMonitors = disneyMonitorAsset
+			tt.monitorStore.monitors = disneyMonitorAsset
 			tt.args.monitor.DisneyMonitorInfo = disneyMonitorInfoAsset
 		case enums.GameStop:
-			tt.monitorStore.GamestopMonitors = gamestopMonitorAsset
+			tt.monitorStore.monitors = gamestopMonitorAsset
 			tt.args.monitor.GamestopMonitorInfo = gamestopMonitorInfoAsset
 		case enums.HotTopic:
-			tt.monitorStore.HottopicMonitors = hottopicMonitorAsset
+			tt.monitorStore.monitors = hottopicMonitorAsset
 			tt.args.monitor.HottopicMonitorInfo = hottopicMonitorInfoAsset
 		case enums.Newegg:
-			tt.monitorStore.NeweggMonitors = neweggMonitorAsset
+			tt.monitorStore.monitors = neweggMonitorAsset
 			tt.args.monitor.NeweggMonitorInfo = neweggMonitorInfoAsset
 		case enums.Shopify:
-			tt.monitorStore.ShopifyMonitors = shopifyMonitorAsset
+			tt.monitorStore.monitors = shopifyMonitorAsset
 			tt.args.monitor.ShopifyMonitorInfo = shopifyMonitorInfoAsset
 		case enums.Target:
-			tt.monitorStore.TargetMonitors = targetMonitorsAsset
+			tt.monitorStore.monitors = targetMonitorsAsset
 			tt.args.monitor.TargetMonitorInfo = targetMonitorInfoAsset
 		case enums.Topps:
-			tt.monitorStore.ToppsMonitors = toppsMonitorAsset
+			tt.monitorStore.monitors = toppsMonitorAsset
 			tt.args.monitor.ToppsMonitorInfo = toppsMonitorInfoAsset
 		case enums.Walmart:
-			tt.monitorStore.WalmartMonitors = walmartMonitorAsset
+			tt.monitorStore.monitors = walmartMonitorAsset
 			tt.args.monitor.WalmartMonitorInfo = walmartMonitorInfoAsset
 		case enums.PokemonCenter:
-			tt.monitorStore.PokemonCenterMonitors = pokemonCenterMonitorAsset
+			tt.monitorStore.monitors = pokemonCenterMonitorAsset
 			tt.args.monitor.PokemonCenterMonitorInfo = pokemonCenterMonitorInfoAsset
 		}
 		t.Run(tt.name, func(t *testing.T) {
@@ -1,8 +1,10 @@
 package stores
 
 import (
+	"context"
 	e "errors"
 	"strings"
+	"sync"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
@@ -10,329 +12,88 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common/errors"
 	"backend.juicedbot.io/juiced.infrastructure/common/events"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"backend.juicedbot.io/juiced.sitescripts/base"
 
-	"backend.juicedbot.io/juiced.sitescripts/amazon"
-	"backend.juicedbot.io/juiced.sitescripts/bestbuy"
-	"backend.juicedbot.io/juiced.sitescripts/boxlunch"
-	"backend.juicedbot.io/juiced.sitescripts/disney"
-	"backend.juicedbot.io/juiced.sitescripts/gamestop"
-	"backend.juicedbot.io/juiced.sitescripts/hottopic"
-	"backend.juicedbot.io/juiced.sitescripts/newegg"
-	"backend.juicedbot.io/juiced.sitescripts/pokemoncenter"
-	"backend.juicedbot.io/juiced.sitescripts/shopify"
-	"backend.juicedbot.io/juiced.sitescripts/target"
-	"backend.juicedbot.io/juiced.sitescripts/topps"
-	"backend.juicedbot.io/juiced.sitescripts/walmart"
-	// Future sitescripts will be imported here
+	// Each sitescript registers itself with the base package when it is imported
+	_ "backend.juicedbot.io/juiced.sitescripts/retailers"
 )
 
 // TODO @silent: Handle TaskUpdatedEvent by updating the task's info in here
 // TODO @silent: Handle TaskRemovedEvent by removing the task's info in here
 // TODO @silent: Handle TaskGroupRemovedEvent by removing all of the task group's tasks in here
 
-// TaskStore stores information about running Tasks
+// TaskStore stores information about running Tasks, it's safe for concurrent use
 type TaskStore struct {
-	AmazonTasks        map[string]*amazon.Task
-	BestbuyTasks       map[string]*bestbuy.Task
-	BoxlunchTasks      map[string]*boxlunch.Task
-	DisneyTasks        map[string]*disney.Task
-	GamestopTasks      map[string]*gamestop.Task
-	HottopicTasks      map[string]*hottopic.Task
-	NeweggTasks        map[string]*newegg.Task
-	PokemonCenterTasks map[string]*pokemoncenter.Task
-	ShopifyTasks       map[string]*shopify.Task
-	TargetTasks        map[string]*target.Task
-	ToppsTasks         map[string]*topps.Task
-	WalmartTasks       map[string]*walmart.Task
-
-	// Future sitescripts will have a field here
+	tasks    map[string]base.RunnableTask
+	mutex    sync.RWMutex
 	EventBus *events.EventBus
 }
 
 // AddTaskToStore adds the Task to the TaskStore and returns true if successful
 func (taskStore *TaskStore) AddTaskToStore(task *entities.Task) error {
-	var queryError error
-	var profile entities.Profile
+	// Check if task exists in store already
+	if runnableTask, ok := taskStore.GetRunnableTask(task.ID); ok && !task.UpdateTask {
+		// Tasks that use the account pool give their Account back when they stop, so they're created again with the
+		// Account that they're given next
+		if task.TaskAccountID == "" || !runnableTask.GetTask().StopFlag() {
+			return nil
+		}
+		// A stopped run may still be unwinding, let it give its Account back first
+		runnableTask.GetTask().Wait()
+	}
+
+	retailer, ok := base.GetRetailer(task.TaskRetailer)
+	if !ok {
+		return e.New(errors.InvalidTaskRetailerError)
+	}
+
 	// Get Profile, ProxyGroup for task
-	if task.TaskRetailer != enums.Amazon {
+	var profile entities.Profile
+	if retailer.RequiresProfile() {
 		var err error
 		profile, err = queries.GetProfile(task.TaskProfileID)
 		if err != nil {
-			queryError = err
+			return err
 		}
 	}
 	var proxyGroup *entities.ProxyGroup
 	if task.TaskProxyGroupID != "" {
-		var ok bool
-		proxyGroup, ok = proxyStore.ProxyGroups[task.TaskProxyGroupID]
+		proxyGroup, ok = proxyStore.GetProxyGroup(task.TaskProxyGroupID)
 		if !ok {
-			queryError = e.New("proxy group failure")
+			return e.New("proxy group failure")
 		}
 	}
-	switch task.TaskRetailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		// Check if task exists in store already
-		if _, ok := taskStore.AmazonTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.AmazonTaskInfo.Email == emptyString || task.AmazonTaskInfo.Password == emptyString {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-		if task.AmazonTaskInfo.LoginType == emptyString {
-			task.AmazonTaskInfo.LoginType = enums.LoginTypeBROWSER
-		}
-
-		// Create task
-		amazonTask, err := amazon.CreateAmazonTask(task, proxyGroup, taskStore.EventBus, task.AmazonTaskInfo.LoginType, task.AmazonTaskInfo.Email, task.AmazonTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.AmazonTasks[task.ID] = &amazonTask
-
-	case enums.BestBuy:
-		// Check if task exists in store already
-		if _, ok := taskStore.BestbuyTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.BestbuyTaskInfo.TaskType == emptyString || (task.BestbuyTaskInfo.TaskType == enums.TaskTypeAccount && (task.BestbuyTaskInfo.Email == emptyString || task.BestbuyTaskInfo.Password == emptyString)) {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-		// Create task
-		bestbuyTask, err := bestbuy.CreateBestbuyTask(task, profile, proxyGroup, taskStore.EventBus, task.BestbuyTaskInfo.TaskType, task.BestbuyTaskInfo.LocationID, task.BestbuyTaskInfo.Email, task.BestbuyTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.BestbuyTasks[task.ID] = &bestbuyTask
-
-	case enums.BoxLunch:
-		// Check if task exists in store already
-		if _, ok := taskStore.BoxlunchTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Create task
-		boxlunchTask, err := boxlunch.CreateBoxlunchTask(task, profile, proxyGroup, taskStore.EventBus)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.BoxlunchTasks[task.ID] = &boxlunchTask
-
-	case enums.GameStop:
-		// Check if task exists in store already
-		if _, ok := taskStore.GamestopTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
 
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.GamestopTaskInfo.TaskType == emptyString || (task.GamestopTaskInfo.TaskType == enums.TaskTypeAccount && (task.GamestopTaskInfo.Email == emptyString || task.GamestopTaskInfo.Password == emptyString)) {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-
-		// Create task
-		gamestopTask, err := gamestop.CreateGamestopTask(task, profile, proxyGroup, taskStore.EventBus, task.GamestopTaskInfo.TaskType, task.GamestopTaskInfo.Email, task.GamestopTaskInfo.Password)
+	// Give the task an Account from the account pool, its credentials are only given to the sitescript
+	createdTask := task
+	var account *entities.Account
+	if task.TaskAccountID != "" {
+		allocated, err := accountStore.Allocate(task.ID, task.TaskRetailer, task.TaskAccountID)
 		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.GamestopTasks[task.ID] = &gamestopTask
-
-	case enums.Disney:
-		// Check if task exists in store already
-		if _, ok := taskStore.DisneyTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.DisneyTaskInfo.TaskType == emptyString || (task.DisneyTaskInfo.TaskType == enums.TaskTypeAccount && (task.DisneyTaskInfo.Email == emptyString || task.DisneyTaskInfo.Password == emptyString)) {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-		// Create task
-		disneyTask, err := disney.CreateDisneyTask(task, profile, proxyGroup, taskStore.EventBus, task.DisneyTaskInfo.TaskType, task.DisneyTaskInfo.Email, task.DisneyTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.DisneyTasks[task.ID] = &disneyTask
-
-	case enums.HotTopic:
-		// Check if task exists in store already
-		if _, ok := taskStore.HottopicTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Create task
-		hottopicTask, err := hottopic.CreateHottopicTask(task, profile, proxyGroup, taskStore.EventBus)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.HottopicTasks[task.ID] = &hottopicTask
-
-	case enums.Newegg:
-		// Check if task exists in store already
-		if _, ok := taskStore.NeweggTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Create task
-		neweggTask, err := newegg.CreateNeweggTask(task, profile, proxyGroup, taskStore.EventBus)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.NeweggTasks[task.ID] = &neweggTask
-
-	case enums.PokemonCenter:
-		// Check if task exists in store already
-		if _, ok := taskStore.PokemonCenterTasks[task.ID]; ok {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.PokemonCenterTaskInfo.TaskType == emptyString || task.PokemonCenterTaskInfo.AddressType == emptyString || (task.PokemonCenterTaskInfo.TaskType == enums.TaskTypeAccount && (task.PokemonCenterTaskInfo.Email == emptyString || task.PokemonCenterTaskInfo.Password == emptyString)) {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-		// Create task
-		pokemonCenterTask, err := pokemoncenter.CreatePokemonCenterTask(task, profile, proxyGroup, taskStore.EventBus, task.PokemonCenterTaskInfo.Email, task.PokemonCenterTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.PokemonCenterTasks[task.ID] = &pokemonCenterTask
-
-	case enums.Shopify:
-		// Check if task exists in store already
-		if _, ok := taskStore.ShopifyTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.ShopifyTaskInfo.SiteURL == emptyString || task.ShopifyTaskInfo.ShopifyRetailer == emptyString {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-
-		// Shopify Site specifics
-		site := task.ShopifyTaskInfo.ShopifyRetailer
-		switch site {
-		case enums.HotWheels:
-			if task.ShopifyTaskInfo.HotWheelsTaskInfo.Email != "" && task.ShopifyTaskInfo.HotWheelsTaskInfo.Password != "" {
-				return e.New(errors.MissingTaskFieldsError)
-			}
-		}
-		// Create task
-		shopifyTask, err := shopify.CreateShopifyTask(task, profile, proxyGroup, taskStore.EventBus, task.ShopifyTaskInfo.CouponCode, task.ShopifyTaskInfo.SiteURL, task.ShopifyTaskInfo.SitePassword, task.ShopifyTaskInfo.HotWheelsTaskInfo.Email, task.ShopifyTaskInfo.HotWheelsTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.ShopifyTasks[task.ID] = &shopifyTask
-
-	case enums.Target:
-		// Check if task exists in store already
-		if _, ok := taskStore.TargetTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.TargetTaskInfo.Email == emptyString || task.TargetTaskInfo.Password == emptyString || task.TargetTaskInfo.PaymentType == emptyString {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-		// Create task
-		targetTask, err := target.CreateTargetTask(task, profile, proxyGroup, taskStore.EventBus, task.TargetTaskInfo.Email, task.TargetTaskInfo.Password, task.TargetTaskInfo.PaymentType)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
-		}
-		// Add task to store
-		taskStore.TargetTasks[task.ID] = &targetTask
-
-	case enums.Topps:
-		// Check if task exists in store already
-		if _, ok := taskStore.ToppsTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-
-		// Make sure necessary fields exist
-		emptyString := ""
-		if task.ToppsTaskInfo.TaskType == emptyString || (task.ToppsTaskInfo.TaskType == enums.TaskTypeAccount && (task.ToppsTaskInfo.Email == emptyString || task.ToppsTaskInfo.Password == emptyString)) {
-			return e.New(errors.MissingTaskFieldsError)
-		}
-
-		// Create task
-		toppsTask, err := topps.CreateToppsTask(task, profile, proxyGroup, taskStore.EventBus, task.ToppsTaskInfo.TaskType, task.ToppsTaskInfo.Email, task.ToppsTaskInfo.Password)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
+			return err
 		}
-		// Add task to store
-		taskStore.ToppsTasks[task.ID] = &toppsTask
+		account = &allocated
+		pooledTask := *task
+		pooledTask.SetAccount(allocated)
+		createdTask = &pooledTask
+	}
 
-	case enums.Walmart:
-		// Check if task exists in store already
-		if _, ok := taskStore.WalmartTasks[task.ID]; ok && !task.UpdateTask {
-			return nil
-		}
-		// Only return false on a query error if the task doesn't exist in the store already
-		if queryError != nil {
-			return queryError
-		}
-		// Create task
-		walmartTask, err := walmart.CreateWalmartTask(task, profile, proxyGroup, taskStore.EventBus)
-		if err != nil {
-			return e.New(errors.CreateBotTaskError + err.Error())
+	// Create task
+	runnableTask, err := retailer.CreateTask(createdTask, profile, proxyGroup, taskStore.EventBus)
+	if err != nil {
+		if account != nil && !taskStore.TasksRunning([]string{task.ID}) {
+			accountStore.Release(task.ID)
 		}
-		// Add task to store
-		taskStore.WalmartTasks[task.ID] = &walmartTask
-
+		return err
+	}
+	runnableTask.GetTask().Account = account
+	// Add task to store (unless another goroutine added it while this one was creating it)
+	taskStore.mutex.Lock()
+	if _, ok := taskStore.tasks[task.ID]; !ok || task.UpdateTask {
+		taskStore.tasks[task.ID] = runnableTask
 	}
+	taskStore.mutex.Unlock()
+
 	task.UpdateTask = false
 	return nil
 }
@@ -350,35 +111,15 @@ func (taskStore *TaskStore) StartTaskGroup(taskGroup *entities.TaskGroup) ([]str
 		// Get the task
 		task, err := queries.GetTask(taskID)
 		if err == nil {
-			var profile entities.Profile
-			if task.TaskRetailer != enums.Amazon {
-				profile, err = queries.GetProfile(task.TaskProfileID)
-			}
+			var validCardType bool
+			validCardType, err = ValidTaskCard(&task)
 			if err == nil {
-				validCardType := true
-				if task.TaskRetailer != enums.Amazon {
-					validCardType = common.ValidCardType([]byte(profile.CreditCard.CardNumber), task.TaskRetailer)
-				}
 				if validCardType {
 					// Add task to store (if it already exists, this will return true)
 					err = taskStore.AddTaskToStore(&task)
 					if err == nil {
-						taskPtr := taskStore.GetTask(task.TaskRetailer, taskID)
-						if taskPtr != nil {
-							task = *taskPtr
-						}
-						// Setting the stop flag to false before running the task
-						taskStore.SetStopFlag(task.TaskRetailer, taskID, false)
-
-						// If the Task is already running, then we're all set already
-						if strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) ||
-							strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) ||
-							strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) ||
-							strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) ||
-							strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
-							// Otherwise, start the Task
-							taskStore.RunTask(task.TaskRetailer, task.ID)
-						}
+						// If the Task is already running, then we're all set already, otherwise start the Task
+						taskStore.runTaskIfIdle(taskID)
 					} else {
 						warnings = append(warnings, err.Error())
 					}
@@ -401,7 +142,7 @@ func (taskStore *TaskStore) StartTaskGroup(taskGroup *entities.TaskGroup) ([]str
 	return warnings, err
 }
 
-// StopTaskGroup sets the stop field for the given TaskGroup's Monitor and each Task in the group and returns true if successful
+// StopTaskGroup stops the given TaskGroup's Monitor and each Task in the group and returns once they have all exited
 func (taskStore *TaskStore) StopTaskGroup(taskGroup *entities.TaskGroup) error {
 	// Stop the task's TaskGroup
 	_, err := monitorStore.StopMonitor(taskGroup)
@@ -409,35 +150,35 @@ func (taskStore *TaskStore) StopTaskGroup(taskGroup *entities.TaskGroup) error {
 		return err
 	}
 
-	// Set the tasks StopFlags to true
-	for _, taskID := range taskGroup.TaskIDs {
-		taskStore.SetStopFlag(taskGroup.MonitorRetailer, taskID, true)
-	}
+	// Cancel the tasks and wait for them to exit
+	taskStore.stopTasks(taskGroup.TaskIDs)
 
 	return nil
 }
 
 func (taskStore *TaskStore) UpdateTask(newTask *entities.Task) error {
-	task := taskStore.GetTask(newTask.TaskRetailer, newTask.ID)
+	task := taskStore.GetTask(newTask.ID)
 
 	if task == nil {
 		return e.New("task not found")
 	}
 
-	return taskStore.AddTaskToStore(newTask)
+	err := taskStore.AddTaskToStore(newTask)
+	// A Task that isn't running doesn't keep an Account from the account pool, it's given one when it starts
+	if err == nil && newTask.TaskAccountID != "" && !taskStore.TasksRunning([]string{newTask.ID}) {
+		accountStore.Release(newTask.ID)
+	}
+	return err
 }
 
 // StartTask runs the RunTask() function for the given Task and returns true if successful
 func (taskStore *TaskStore) StartTask(task *entities.Task) error {
-	if task.TaskRetailer != enums.Amazon {
-		profile, err := queries.GetProfile(task.TaskProfileID)
-		if err != nil {
-			return err
-		}
-
-		if !common.ValidCardType([]byte(profile.CreditCard.CardNumber), task.TaskRetailer) {
-			return e.New(errors.StartTaskInvalidCardError + task.TaskRetailer)
-		}
+	validCardType, err := ValidTaskCard(task)
+	if err != nil {
+		return err
+	}
+	if !validCardType {
+		return e.New(errors.StartTaskInvalidCardError + task.TaskRetailer)
 	}
 
 	taskGroup, err := queries.GetTaskGroup(task.TaskGroupID)
@@ -456,253 +197,123 @@ func (taskStore *TaskStore) StartTask(task *entities.Task) error {
 		return err
 	}
 
-	task = taskStore.GetTask(task.TaskRetailer, task.ID)
+	// If the Task is already running, then we're all set already, otherwise start the Task
+	taskStore.runTaskIfIdle(task.ID)
+	return nil
+}
+
+// runTaskIfIdle starts the given Task if it isn't already running
+func (taskStore *TaskStore) runTaskIfIdle(taskID string) {
+	// A stopped run may still be unwinding, wait for it to exit without holding the lock so the other Tasks aren't blocked, then check again
+	for unwinding := taskStore.startTaskIfIdle(taskID); unwinding != nil; unwinding = taskStore.startTaskIfIdle(taskID) {
+		<-unwinding
+	}
+}
+
+// startTaskIfIdle starts the given Task if it isn't running, unless its previous run hasn't exited yet, then it returns the
+// channel that's closed once that run exits
+func (taskStore *TaskStore) startTaskIfIdle(taskID string) <-chan struct{} {
+	// Hold the lock while checking the status so that two callers can't both start the Task
+	taskStore.mutex.Lock()
+	defer taskStore.mutex.Unlock()
 
-	// If the Task is already running, then we're all set already
-	if !strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-		!strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-		!strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-		!strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-		!strings.Contains(task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+	runnableTask, ok := taskStore.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	task := runnableTask.GetTask()
+	if !task.StopFlag() {
 		return nil
 	}
 
-	// Set the task's StopFlag to false before running the task
-	taskStore.SetStopFlag(task.TaskRetailer, task.ID, false)
-	taskStore.SetDontPublishEvents(task.TaskRetailer, task.ID, false)
+	if !strings.Contains(task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+		!strings.Contains(task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+		!strings.Contains(task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+		!strings.Contains(task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+		!strings.Contains(task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+		// The Task isn't started, so it doesn't keep the Account it was given
+		if task.Account != nil {
+			accountStore.Release(taskID)
+		}
+		return nil
+	}
 
-	// Otherwise, start the Task
-	taskStore.RunTask(task.TaskRetailer, task.ID)
+	if unwinding := task.Unwinding(); unwinding != nil {
+		return unwinding
+	}
+	task.SetDontPublishEvents(false)
+	task.Task.SetTaskStatus(enums.SettingUp)
+
+	// The Account is given back and the logged in session the task shared is removed when the run exits, before Wait returns.
+	// The AccountStore is only there once it's initialized, without it the Task has no Account or shared session to give back.
+	accounts := accountStore
+	hasAccount := task.Account != nil
+	task.Start(func(ctx context.Context) {
+		if accounts != nil {
+			defer accounts.RemoveSession(taskID)
+			if hasAccount {
+				defer accounts.Release(taskID)
+			}
+		}
+		runnableTask.RunTask(ctx)
+	})
 	return nil
 }
 
-// StopTask sets the stop field for the given Task and returns true if successful
+// StopTask cancels the given Task's context and returns true once its goroutine has exited, or false if it wasn't running
 func (taskStore *TaskStore) StopTask(task *entities.Task) (bool, error) {
-	if !taskStore.TasksRunning([]string{task.ID}, task.TaskRetailer) {
+	runnableTask, ok := taskStore.GetRunnableTask(task.ID)
+	if !ok || runnableTask.GetTask().StopFlag() {
 		return false, nil
 	}
-	return true, taskStore.SetStopFlag(task.TaskRetailer, task.ID, true)
+	runnableTask.GetTask().Stop()
+	runnableTask.GetTask().Wait()
+	return true, nil
 }
 
-// TasksRunning checks to see if any tasks in the taskGroup are running, if so it returns true
-func (taskStore *TaskStore) TasksRunning(taskIDs []string, retailer enums.Retailer) bool {
-	for _, taskID := range taskIDs {
-		switch retailer {
-		// Future sitescripts will have a case here
-		case enums.Amazon:
-			if amazonTask, ok := taskStore.AmazonTasks[taskID]; ok {
-				if !amazonTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.BestBuy:
-			if bestbuyTask, ok := taskStore.BestbuyTasks[taskID]; ok {
-				if !bestbuyTask.Task.StopFlag {
-					return true
-				}
-			}
-		case enums.BoxLunch:
-			if boxlunchTask, ok := taskStore.BoxlunchTasks[taskID]; ok {
-				if !boxlunchTask.Task.StopFlag {
-					return true
-				}
-			}
+// TasksRunning checks to see if any of the given tasks are running, if so it returns true
+func (taskStore *TaskStore) TasksRunning(taskIDs []string) bool {
+	taskStore.mutex.RLock()
+	defer taskStore.mutex.RUnlock()
 
-		case enums.GameStop:
-			if gamestopTask, ok := taskStore.GamestopTasks[taskID]; ok {
-				if !gamestopTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Disney:
-			if disneyTask, ok := taskStore.DisneyTasks[taskID]; ok {
-				if !disneyTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.HotTopic:
-			if hottopicTask, ok := taskStore.HottopicTasks[taskID]; ok {
-				if !hottopicTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Newegg:
-			if neweggTask, ok := taskStore.NeweggTasks[taskID]; ok {
-				if !neweggTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.PokemonCenter:
-			if pokemonCenterTask, ok := taskStore.PokemonCenterTasks[taskID]; ok {
-				if !pokemonCenterTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Shopify:
-			if shopifyTask, ok := taskStore.ShopifyTasks[taskID]; ok {
-				if !shopifyTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Target:
-			if targetTask, ok := taskStore.TargetTasks[taskID]; ok {
-				if !targetTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Topps:
-			if toppsTask, ok := taskStore.ToppsTasks[taskID]; ok {
-				if !toppsTask.Task.StopFlag {
-					return true
-				}
-			}
-
-		case enums.Walmart:
-			if walmartTask, ok := taskStore.WalmartTasks[taskID]; ok {
-				if !walmartTask.Task.StopFlag {
-					return true
-				}
+	for _, taskID := range taskIDs {
+		if runnableTask, ok := taskStore.tasks[taskID]; ok {
+			if !runnableTask.GetTask().StopFlag() {
+				return true
 			}
-
 		}
 	}
 
 	return false
 }
 
+// UpdateTaskProxy will update the given task with the given proxy and return true if successful
 func (taskStore *TaskStore) UpdateTaskProxy(task *entities.Task, proxy *entities.Proxy) bool {
-	switch task.TaskRetailer {
-	case enums.Amazon:
-		if amazonTask, ok := taskStore.AmazonTasks[task.ID]; ok {
-			amazonTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.BestBuy:
-		if bestbuyTask, ok := taskStore.BestbuyTasks[task.ID]; ok {
-			bestbuyTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.BoxLunch:
-		if boxlunchTask, ok := taskStore.BoxlunchTasks[task.ID]; ok {
-			boxlunchTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Disney:
-		if disneyTask, ok := taskStore.DisneyTasks[task.ID]; ok {
-			disneyTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.GameStop:
-		if gamestopTask, ok := taskStore.GamestopTasks[task.ID]; ok {
-			gamestopTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.HotTopic:
-		if hottopicTask, ok := taskStore.HottopicTasks[task.ID]; ok {
-			hottopicTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Newegg:
-		if neweggTask, ok := taskStore.NeweggTasks[task.ID]; ok {
-			neweggTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.PokemonCenter:
-		if pokemonCenterTask, ok := taskStore.PokemonCenterTasks[task.ID]; ok {
-			pokemonCenterTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Shopify:
-		if shopifyTask, ok := taskStore.ShopifyTasks[task.ID]; ok {
-			shopifyTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Target:
-		if targetTask, ok := taskStore.TargetTasks[task.ID]; ok {
-			targetTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Topps:
-		if toppsTask, ok := taskStore.ToppsTasks[task.ID]; ok {
-			toppsTask.Task.Proxy = proxy
-		}
-		return true
-
-	case enums.Walmart:
-		if walmartTask, ok := taskStore.WalmartTasks[task.ID]; ok {
-			walmartTask.Task.Proxy = proxy
-		}
-		return true
-
+	if _, ok := base.GetRetailer(task.TaskRetailer); !ok {
+		return false
 	}
 
-	return false
-}
-
-func (taskStore *TaskStore) SetWalmartCardDetails(taskID string, cardInfo walmart.CardInfo) {
-	if walmartTask, ok := taskStore.WalmartTasks[taskID]; ok {
-		walmartTask.CardInfo = cardInfo
+	if runnableTask, ok := taskStore.GetRunnableTask(task.ID); ok {
+		runnableTask.GetTask().SetProxy(proxy)
 	}
+	return true
 }
 
-func (taskStore *TaskStore) RunTask(retailer enums.Retailer, taskID string) {
-	switch retailer {
-	// Future sitescripts will have a case here
-	case enums.Amazon:
-		go taskStore.AmazonTasks[taskID].RunTask()
-
-	case enums.BestBuy:
-		go taskStore.BestbuyTasks[taskID].RunTask()
-
-	case enums.BoxLunch:
-		go taskStore.BoxlunchTasks[taskID].RunTask()
-
-	case enums.Disney:
-		go taskStore.DisneyTasks[taskID].RunTask()
-
-	case enums.GameStop:
-		go taskStore.GamestopTasks[taskID].RunTask()
-
-	case enums.HotTopic:
-		go taskStore.HottopicTasks[taskID].RunTask()
-
-	case enums.Newegg:
-		go taskStore.NeweggTasks[taskID].RunTask()
-
-	case enums.PokemonCenter:
-		go taskStore.PokemonCenterTasks[taskID].RunTask()
-
-	case enums.Shopify:
-		go taskStore.ShopifyTasks[taskID].RunTask()
-
-	case enums.Target:
-		go taskStore.TargetTasks[taskID].RunTask()
-
-	case enums.Topps:
-		go taskStore.ToppsTasks[taskID].RunTask()
-
-	case enums.Walmart:
-		go taskStore.WalmartTasks[taskID].RunTask()
+// ValidTaskCard returns true if the Task's Profile has a card that its retailer accepts, or if its retailer doesn't need a Profile
+func ValidTaskCard(task *entities.Task) (bool, error) {
+	retailer, ok := base.GetRetailer(task.TaskRetailer)
+	if !ok {
+		return false, e.New(errors.InvalidTaskRetailerError)
+	}
+	if !retailer.RequiresProfile() {
+		return true, nil
+	}
 
+	profile, err := queries.GetProfile(task.TaskProfileID)
+	if err != nil {
+		return false, err
 	}
+	return common.ValidCardType([]byte(profile.CreditCard.CardNumber), task.TaskRetailer), nil
 }
 
 var taskStore *TaskStore
@@ -710,19 +321,7 @@ var taskStore *TaskStore
 // InitTaskStore initializes the singleton instance of the TaskStore
 func InitTaskStore(eventBus *events.EventBus) {
 	taskStore = &TaskStore{
-		AmazonTasks:        make(map[string]*amazon.Task),
-		BestbuyTasks:       make(map[string]*bestbuy.Task),
-		BoxlunchTasks:      make(map[string]*boxlunch.Task),
-		DisneyTasks:        make(map[string]*disney.Task),
-		GamestopTasks:      make(map[string]*gamestop.Task),
-		HottopicTasks:      make(map[string]*hottopic.Task),
-		NeweggTasks:        make(map[string]*newegg.Task),
-		PokemonCenterTasks: make(map[string]*pokemoncenter.Task),
-		ShopifyTasks:       make(map[string]*shopify.Task),
-		TargetTasks:        make(map[string]*target.Task),
-		ToppsTasks:         make(map[string]*topps.Task),
-		WalmartTasks:       make(map[string]*walmart.Task),
-
+		tasks:    make(map[string]base.RunnableTask),
 		EventBus: eventBus,
 	}
 }
@@ -731,38 +330,10 @@ func InitTaskStore(eventBus *events.EventBus) {
 func GetTaskStatuses() map[string]string {
 	taskStatuses := make(map[string]string)
 
-	for taskID, task := range taskStore.AmazonTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.BestbuyTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.DisneyTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.GamestopTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.HottopicTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.NeweggTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.PokemonCenterTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.ShopifyTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.TargetTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.ToppsTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
-	}
-	for taskID, task := range taskStore.WalmartTasks {
-		taskStatuses[taskID] = task.Task.Task.TaskStatus
+	taskStore.mutex.RLock()
+	defer taskStore.mutex.RUnlock()
+	for taskID, runnableTask := range taskStore.tasks {
+		taskStatuses[taskID] = runnableTask.GetTask().Task.GetTaskStatus()
 	}
 
 	return taskStatuses
@@ -0,0 +1,401 @@
+-- A database as it was before schema migrations, when each table was brought up to date by diffing it against its schema on startup.
+-- To cover databases created by older releases, settings is missing its darkMode and useAnimations columns.
+
+CREATE TABLE IF NOT EXISTS userInfo (
+	ID INTEGER,
+	email TEXT,
+	licenseKey TEXT,
+	deviceName TEXT,
+	userVer TEXT,
+	discordID TEXT,
+	discordUsername TEXT,
+	discordAvatarURL TEXT,
+	activationToken TEXT,
+	refreshToken TEXT,
+	expiresAt INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	ID TEXT,
+	taskGroupID TEXT,
+	profileID TEXT,
+	proxyGroupID TEXT,
+	retailer TEXT,
+	sizeJoined TEXT,
+	qty INTEGER,
+	status TEXT,
+	taskDelay INTEGER,
+	creationDate INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS amazonTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	loginType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS bestbuyTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	locationID TEXT,
+	taskType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS boxlunchTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS disneyTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	taskType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS gamestopTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	taskType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS hottopicTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS neweggTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS pokemoncenterTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	taskType TEXT,
+	addressType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS targetTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	checkoutType TEXT,
+	email TEXT,
+	password TEXT,
+	paymentType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS toppsTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT,
+	taskType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS walmartTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS shopifyTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	couponCode TEXT,
+	siteURL TEXT,
+	sitePassword TEXT,
+	shopifyRetailer TEXT
+);
+
+CREATE TABLE IF NOT EXISTS hotwheelsTaskInfos (
+	taskID TEXT,
+	taskGroupID TEXT,
+	email TEXT,
+	password TEXT
+);
+
+CREATE TABLE IF NOT EXISTS taskGroups (
+	groupID TEXT,
+	name TEXT,
+	proxyGroupID TEXT,
+	retailer TEXT,
+	input TEXT,
+	delay INTEGER,
+	status TEXT,
+	taskIDsJoined TEXT,
+	creationDate INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS amazonMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS amazonSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	monitorType TEXT,
+	asin TEXT,
+	ofid TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS bestbuyMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS bestbuySingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	sku TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS boxlunchMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS boxlunchSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	pid TEXT,
+	size TEXT,
+	color TEXT,
+	maxPrice INTEGER,
+	monitorType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS disneyMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS disneySingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	pid TEXT,
+	size TEXT,
+	color TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS gamestopMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS gamestopSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	sku TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS hottopicMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS hottopicSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	pid TEXT,
+	size TEXT,
+	color TEXT,
+	maxPrice INTEGER,
+	monitorType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS neweggMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS neweggSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	sku TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS pokemoncenterMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS pokemoncenterSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	sku TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS shopifyMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT,
+	siteURL TEXT,
+	sitePassword TEXT
+);
+
+CREATE TABLE IF NOT EXISTS shopifySingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	variantID TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS targetMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT,
+	storeID TEXT,
+	monitorType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS targetSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	tcin TEXT,
+	maxPrice INTEGER,
+	checkoutType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS toppsMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS toppsSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	item TEXT,
+	maxPrice INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS walmartMonitorInfos (
+	ID TEXT,
+	taskGroupID TEXT
+);
+
+CREATE TABLE IF NOT EXISTS walmartSingleMonitorInfos (
+	monitorID TEXT,
+	taskGroupID TEXT,
+	id TEXT,
+	maxPrice INTEGER,
+	soldByWalmart INTEGER,
+	monitorType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS proxyGroups (
+	groupID TEXT,
+	name TEXT,
+	creationDate INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS proxys (
+	ID TEXT,
+	proxyGroupID TEXT,
+	host TEXT,
+	port TEXT,
+	username TEXT,
+	password TEXT
+);
+
+CREATE TABLE IF NOT EXISTS profileGroups (
+	groupID TEXT,
+	name TEXT,
+	profileIDsJoined TEXT,
+	creationDate INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS profiles (
+	ID TEXT,
+	profileGroupIDsJoined TEXT,
+	name TEXT,
+	email TEXT,
+	phoneNumber TEXT,
+	creationDate INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS shippingAddresses (
+	ID TEXT,
+	profileID TEXT,
+	firstName TEXT,
+	lastName TEXT,
+	address1 TEXT,
+	address2 TEXT,
+	city TEXT,
+	zipCode TEXT,
+	stateCode TEXT,
+	countryCode TEXT
+);
+
+CREATE TABLE IF NOT EXISTS billingAddresses (
+	ID TEXT,
+	profileID TEXT,
+	firstName TEXT,
+	lastName TEXT,
+	address1 TEXT,
+	address2 TEXT,
+	city TEXT,
+	zipCode TEXT,
+	stateCode TEXT,
+	countryCode TEXT
+);
+
+CREATE TABLE IF NOT EXISTS cards (
+	ID TEXT,
+	profileID TEXT,
+	cardHolderName TEXT,
+	cardNumber TEXT,
+	expMonth TEXT,
+	expYear TEXT,
+	cvv TEXT,
+	cardType TEXT
+);
+
+CREATE TABLE IF NOT EXISTS checkouts (
+	itemName TEXT,
+	imageURL TEXT,
+	sku TEXT,
+	price INTEGER,
+	quantity INTEGER,
+	retailer TEXT,
+	profileName TEXT,
+	msToCheckout INTEGER,
+	time INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS settings (
+	id TEXT,
+	successDiscordWebhook TEXT,
+	failureDiscordWebhook TEXT,
+	twoCaptchaAPIKey TEXT,
+	antiCaptchaAPIKey TEXT,
+	capMonsterAPIKey TEXT,
+	aycdAccessToken TEXT,
+	aycdAPIKey TEXT
+);
+
+CREATE TABLE IF NOT EXISTS accounts (
+	ID TEXT,
+	retailer TEXT,
+	email TEXT,
+	password TEXT,
+	creationDate INTEGER
+);
+
+INSERT INTO tasks (ID, taskGroupID, profileID, proxyGroupID, retailer, sizeJoined, qty, status, taskDelay, creationDate) VALUES ('task', 'group', 'profile', '', 'Target', '', 1, 'Idle', 1000, 1625000000);
+INSERT INTO settings (id, successDiscordWebhook, failureDiscordWebhook, twoCaptchaAPIKey, antiCaptchaAPIKey, capMonsterAPIKey, aycdAccessToken, aycdAPIKey) VALUES ('0', 'https://discord.com/api/webhooks/success', '', '', '', '', '', '');
+INSERT INTO checkouts (itemName, imageURL, sku, price, quantity, retailer, profileName, msToCheckout, time) VALUES ('Pre-release checkout', '', '1', 10, 1, 'Target', 'profile', 1000, 1625000000);
+INSERT INTO checkouts (itemName, imageURL, sku, price, quantity, retailer, profileName, msToCheckout, time) VALUES ('Checkout', '', '2', 20, 1, 'Target', 'profile', 1000, 1626000000);
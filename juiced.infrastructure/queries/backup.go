@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetBackup returns a Backup of every record in the database, with its encrypted values decrypted
+func GetBackup() (entities.Backup, error) {
+	backup := entities.Backup{Version: entities.BackupVersion, CreationDate: time.Now().Unix()}
+
+	var err error
+	backup.Settings, err = GetSettings()
+	if err != nil {
+		return backup, err
+	}
+	backup.Accounts = backup.Settings.Accounts
+	backup.Settings.Accounts = nil
+
+	backup.ProfileGroups, err = GetAllProfileGroups()
+	if err != nil {
+		return backup, err
+	}
+	backup.Profiles, err = GetAllProfiles()
+	if err != nil {
+		return backup, err
+	}
+	backup.ProxyGroups, err = GetAllProxyGroups()
+	if err != nil {
+		return backup, err
+	}
+	backup.TaskGroups, err = GetAllTaskGroups()
+	if err != nil {
+		return backup, err
+	}
+	backup.Tasks, err = GetAllTasks()
+	if err != nil {
+		return backup, err
+	}
+	backup.Checkouts, err = GetCheckouts(entities.CheckoutFilter{})
+	return backup, err
+}
@@ -2,30 +2,72 @@ package queries
 
 import (
 	"errors"
+	"math"
+	"strings"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
-
-	"time"
 )
 
-// GetCheckouts returns all the checkouts in the given timeframe and retailer
-func GetCheckouts(retailer enums.Retailer, daysBack int) ([]entities.Checkout, error) {
-	then := time.Now().Add(time.Duration(-daysBack) * (24 * time.Hour)).Unix()
+// checkoutStatsKeys maps each way of grouping Checkouts to the SQL expression for a Checkout's group
+var checkoutStatsKeys = map[enums.CheckoutStatsGroupBy]string{
+	enums.CheckoutStatsByRetailer: "retailer",
+	enums.CheckoutStatsByProfile:  "profileName",
+	enums.CheckoutStatsBySKU:      "sku",
+	enums.CheckoutStatsByDay:      "strftime('%Y-%m-%d', time, 'unixepoch', 'localtime')",
+	enums.CheckoutStatsByWeek:     "strftime('%Y-W%W', time, 'unixepoch', 'localtime')",
+}
 
+// checkoutConditions returns the WHERE clause (if any) and its arguments for the CheckoutFilter
+func checkoutConditions(filter entities.CheckoutFilter) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.Retailer != "" {
+		conditions = append(conditions, "retailer = ?")
+		args = append(args, filter.Retailer)
+	}
+	if filter.ProfileName != "" {
+		conditions = append(conditions, "profileName = ?")
+		args = append(args, filter.ProfileName)
+	}
+	if filter.SKU != "" {
+		conditions = append(conditions, "sku = ?")
+		args = append(args, filter.SKU)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.From != 0 {
+		conditions = append(conditions, "time >= ?")
+		args = append(args, filter.From)
+	}
+	if filter.To != 0 {
+		conditions = append(conditions, "time <= ?")
+		args = append(args, filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// GetCheckouts returns the checkouts that match the filter, most recent first
+func GetCheckouts(filter entities.CheckoutFilter) ([]entities.Checkout, error) {
 	checkouts := []entities.Checkout{}
 	database := common.GetDatabase()
 	if database == nil {
 		return checkouts, errors.New("database not initialized")
 	}
 
-	rows, err := database.Queryx("SELECT * FROM checkouts")
+	conditions, args := checkoutConditions(filter)
+	rows, err := database.Queryx("SELECT * FROM checkouts"+conditions+" ORDER BY time DESC", args...)
 	if err != nil {
 		return checkouts, err
 	}
 
-	emptyString := ""
 	defer rows.Close()
 	for rows.Next() {
 		tempCheckout := entities.Checkout{}
@@ -33,32 +75,86 @@ func GetCheckouts(retailer enums.Retailer, daysBack int) ([]entities.Checkout, e
 		if err != nil {
 			return checkouts, err
 		}
-		// No filters
-		if retailer == emptyString && daysBack == -1 {
-			checkouts = append(checkouts, tempCheckout)
-		}
-		// Only time filter
-		if retailer == emptyString && daysBack != -1 {
-			if then > tempCheckout.Time {
-				checkouts = append(checkouts, tempCheckout)
-			}
-		}
-		// Only retailer filter
-		if retailer != emptyString && daysBack == -1 {
-			if tempCheckout.Retailer == retailer {
-				checkouts = append(checkouts, tempCheckout)
-			}
-		}
-		// Both retailer and time filter
-		if retailer != emptyString && daysBack != -1 {
-			if tempCheckout.Retailer == retailer && then > tempCheckout.Time {
-				checkouts = append(checkouts, tempCheckout)
-			}
+		checkouts = append(checkouts, tempCheckout)
+	}
+	return checkouts, rows.Err()
+}
+
+// GetCheckoutStats returns the spend, unit count, outcomes and checkout speed of the checkouts that match the filter,
+// grouped by groupBy. Spend and units only count successful checkouts, speed is measured across every order.
+func GetCheckoutStats(filter entities.CheckoutFilter, groupBy enums.CheckoutStatsGroupBy) ([]entities.CheckoutStats, error) {
+	stats := []entities.CheckoutStats{}
+	database := common.GetDatabase()
+	if database == nil {
+		return stats, errors.New("database not initialized")
+	}
+
+	key, ok := checkoutStatsKeys[groupBy]
+	if !ok {
+		return stats, errors.New("can't group checkouts by " + groupBy)
+	}
+	conditions, args := checkoutConditions(filter)
+
+	rows, err := database.Queryx(`SELECT `+key+` AS key,
+		COUNT(*) AS orders,
+		SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS successes,
+		SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS declines,
+		SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS failures,
+		SUM(CASE WHEN status = ? THEN price * quantity ELSE 0 END) AS totalSpend,
+		SUM(CASE WHEN status = ? THEN quantity ELSE 0 END) AS units
+		FROM checkouts`+conditions+` GROUP BY key ORDER BY key`,
+		append([]interface{}{enums.OrderStatusSuccess, enums.OrderStatusDeclined, enums.OrderStatusFailed, enums.OrderStatusSuccess, enums.OrderStatusSuccess}, args...)...)
+	if err != nil {
+		return stats, err
+	}
+
+	defer rows.Close()
+	statsIndexes := make(map[string]int)
+	for rows.Next() {
+		tempStats := entities.CheckoutStats{}
+		err = rows.StructScan(&tempStats)
+		if err != nil {
+			return stats, err
 		}
+		statsIndexes[tempStats.Key] = len(stats)
+		stats = append(stats, tempStats)
+	}
+	if err = rows.Err(); err != nil {
+		return stats, err
+	}
+
+	// SQLite doesn't have percentile functions, so the (already filtered and sorted) times are read back to find them
+	timeRows, err := database.Queryx(`SELECT `+key+` AS key, msToCheckout FROM checkouts`+conditions+` ORDER BY key, msToCheckout`, args...)
+	if err != nil {
+		return stats, err
+	}
+
+	defer timeRows.Close()
+	msToCheckouts := make(map[string][]int64)
+	for timeRows.Next() {
+		var groupKey string
+		var msToCheckout int64
+		err = timeRows.Scan(&groupKey, &msToCheckout)
 		if err != nil {
-			return checkouts, err
+			return stats, err
 		}
+		msToCheckouts[groupKey] = append(msToCheckouts[groupKey], msToCheckout)
+	}
+	for groupKey, index := range statsIndexes {
+		stats[index].P50MsToCheckout = percentile(msToCheckouts[groupKey], 50)
+		stats[index].P90MsToCheckout = percentile(msToCheckouts[groupKey], 90)
+	}
+	return stats, timeRows.Err()
+}
 
+// percentile returns the nearest-rank percentile of the sorted values, 0 if there aren't any
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
 	}
-	return checkouts, err
+	return sorted[rank-1]
 }
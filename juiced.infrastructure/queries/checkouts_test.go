@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []int64
+		p      float64
+		want   int64
+	}{
+		{name: "Empty", sorted: []int64{}, p: 50, want: 0},
+		{name: "Single", sorted: []int64{700}, p: 90, want: 700},
+		{name: "Median", sorted: []int64{100, 200, 300, 400}, p: 50, want: 200},
+		{name: "P90", sorted: []int64{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000}, p: 90, want: 900},
+		{name: "P90 Rounds Up", sorted: []int64{100, 200, 300}, p: 90, want: 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("percentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckoutConditions(t *testing.T) {
+	conditions, args := checkoutConditions(entities.CheckoutFilter{})
+	if conditions != "" || len(args) != 0 {
+		t.Errorf("checkoutConditions() = %q, %v, want no conditions", conditions, args)
+	}
+
+	conditions, args = checkoutConditions(entities.CheckoutFilter{Retailer: "Target", Status: "SUCCESS", From: 10, To: 20})
+	want := " WHERE retailer = ? AND status = ? AND time >= ? AND time <= ?"
+	if conditions != want || !reflect.DeepEqual(args, []interface{}{"Target", "SUCCESS", int64(10), int64(20)}) {
+		t.Errorf("checkoutConditions() = %q, %v, want %q", conditions, args, want)
+	}
+}
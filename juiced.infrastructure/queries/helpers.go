@@ -2,9 +2,8 @@ package queries
 
 import (
 	"errors"
-	"fmt"
+	"log"
 	"strings"
-	"time"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
@@ -16,8 +15,6 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 	if database == nil {
 		return task, errors.New("database not initialized")
 	}
-	var encryptedEmail string
-	var encryptedPassword string
 	var currentTaskInfo string
 	switch task.TaskRetailer {
 	case enums.Amazon:
@@ -39,29 +36,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.AmazonTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.AmazonTaskInfo != nil {
+			err = decryptCredentials("amazonTaskInfos", task.ID, &task.AmazonTaskInfo.Email, &task.AmazonTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.AmazonTaskInfo = &tempTaskInfo
 		}
 
 	case enums.BestBuy:
@@ -83,29 +66,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.BestbuyTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.BestbuyTaskInfo != nil {
+			err = decryptCredentials("bestbuyTaskInfos", task.ID, &task.BestbuyTaskInfo.Email, &task.BestbuyTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.BestbuyTaskInfo = &tempTaskInfo
 		}
 
 	case enums.BoxLunch:
@@ -148,29 +117,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.DisneyTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.DisneyTaskInfo != nil {
+			err = decryptCredentials("disneyTaskInfos", task.ID, &task.DisneyTaskInfo.Email, &task.DisneyTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.DisneyTaskInfo = &tempTaskInfo
 		}
 
 	case enums.GameStop:
@@ -192,29 +147,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.GamestopTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.GamestopTaskInfo != nil {
+			err = decryptCredentials("gamestopTaskInfos", task.ID, &task.GamestopTaskInfo.Email, &task.GamestopTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.GamestopTaskInfo = &tempTaskInfo
 		}
 
 	case enums.HotTopic:
@@ -277,29 +218,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.PokemonCenterTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.PokemonCenterTaskInfo != nil {
+			err = decryptCredentials("pokemoncenterTaskInfos", task.ID, &task.PokemonCenterTaskInfo.Email, &task.PokemonCenterTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.PokemonCenterTaskInfo = &tempTaskInfo
 		}
 
 	case enums.Shopify:
@@ -343,29 +270,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 					return task, err
 				}
 
-				decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-				if err == nil {
-					tempTaskInfo.Email = decryptedEmail
-				} else {
-					encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-					if err != nil {
-						return task, err
-					}
-
-				}
-
-				decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-				if err == nil {
-					tempTaskInfo.Password = decryptedPassword
-				} else {
-					encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-					if err != nil {
-						return task, err
-					}
+				task.ShopifyTaskInfo.HotWheelsTaskInfo = &tempTaskInfo
+			}
+			rows.Close()
 
+			if task.ShopifyTaskInfo.HotWheelsTaskInfo != nil {
+				err = decryptCredentials("hotwheelsTaskInfos", task.ID, &task.ShopifyTaskInfo.HotWheelsTaskInfo.Email, &task.ShopifyTaskInfo.HotWheelsTaskInfo.Password)
+				if err != nil {
+					return task, err
 				}
-
-				task.ShopifyTaskInfo.HotWheelsTaskInfo = &tempTaskInfo
 			}
 		}
 
@@ -388,29 +301,15 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 				return task, err
 			}
 
-			decryptedEmail, err := common.Aes256Decrypt(tempTaskInfo.Email, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Email = decryptedEmail
-			} else {
-				encryptedEmail, err = common.Aes256Encrypt(tempTaskInfo.Email, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
-
-			}
-
-			decryptedPassword, err := common.Aes256Decrypt(tempTaskInfo.Password, enums.UserKey)
-			if err == nil {
-				tempTaskInfo.Password = decryptedPassword
-			} else {
-				encryptedPassword, err = common.Aes256Encrypt(tempTaskInfo.Password, enums.UserKey)
-				if err != nil {
-					return task, err
-				}
+			task.TargetTaskInfo = &tempTaskInfo
+		}
+		rows.Close()
 
+		if task.TargetTaskInfo != nil {
+			err = decryptCredentials("targetTaskInfos", task.ID, &task.TargetTaskInfo.Email, &task.TargetTaskInfo.Password)
+			if err != nil {
+				return task, err
 			}
-
-			task.TargetTaskInfo = &tempTaskInfo
 		}
 
 	case enums.Topps:
@@ -432,6 +331,14 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 			}
 			task.ToppsTaskInfo = &tempTaskInfo
 		}
+		rows.Close()
+
+		if task.ToppsTaskInfo != nil {
+			err = decryptCredentials("toppsTaskInfos", task.ID, &task.ToppsTaskInfo.Email, &task.ToppsTaskInfo.Password)
+			if err != nil {
+				return task, err
+			}
+		}
 
 	case enums.Walmart:
 		statement, err := database.Preparex(`SELECT * FROM walmartTaskInfos WHERE taskID = @p1`)
@@ -454,37 +361,14 @@ func GetTaskInfos(task entities.Task) (entities.Task, error) {
 		}
 	}
 
-	if encryptedEmail != "" {
-		go func() {
-			for {
-				_, err := database.Exec(fmt.Sprintf(`UPDATE %v SET email = "%v" WHERE ID = "%v"`, currentTaskInfo, encryptedEmail, task.ID))
-				if err != nil {
-					time.Sleep(1 * time.Second)
-					continue
-				} else {
-					break
-				}
-			}
-		}()
-	}
-
-	if encryptedPassword != "" {
-		go func() {
-			for {
-				_, err := database.Exec(fmt.Sprintf(`UPDATE %v SET password = "%v" WHERE ID = "%v"`, currentTaskInfo, encryptedPassword, task.ID))
-				if err != nil {
-					time.Sleep(1 * time.Second)
-					continue
-				} else {
-					break
-				}
-			}
-		}()
-	}
-
 	return task, nil
 }
 
+// decryptCredentials decrypts the email and password of the Task's retailer credentials in the table
+func decryptCredentials(table string, taskID string, email *string, password *string) error {
+	return decryptColumns(table, "taskID", taskID, []string{"email", "password"}, email, password)
+}
+
 func GetMonitorInfos(taskGroup entities.TaskGroup) (entities.TaskGroup, error) {
 	database := common.GetDatabase()
 	if database == nil {
@@ -1025,208 +909,18 @@ func GetShippingAddress(profile entities.Profile) (entities.Profile, error) {
 
 	defer rows.Close()
 	for rows.Next() {
-		var encryptedFirstName string
-		var encryptedLastName string
-		var encryptedAddress1 string
-		var encryptedAddress2 string
-		var encryptedCity string
-		var encryptedZipCode string
-		var encryptedStateCode string
-		var encryptedCountryCode string
-
 		err = rows.StructScan(&profile.ShippingAddress)
 		if err != nil {
 			return profile, err
 		}
-
-		decryptedFirstName, err := common.Aes256Decrypt(profile.ShippingAddress.FirstName, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.FirstName = decryptedFirstName
-		} else {
-			encryptedFirstName, err = common.Aes256Encrypt(profile.ShippingAddress.FirstName, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedLastName, err := common.Aes256Decrypt(profile.ShippingAddress.LastName, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.LastName = decryptedLastName
-		} else {
-			encryptedLastName, err = common.Aes256Encrypt(profile.ShippingAddress.LastName, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedAddress1, err := common.Aes256Decrypt(profile.ShippingAddress.Address1, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.Address1 = decryptedAddress1
-		} else {
-			encryptedAddress1, err = common.Aes256Encrypt(profile.ShippingAddress.Address1, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedAddress2, err := common.Aes256Decrypt(profile.ShippingAddress.Address2, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.Address2 = decryptedAddress2
-		} else {
-			encryptedAddress2, err = common.Aes256Encrypt(profile.ShippingAddress.Address2, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCity, err := common.Aes256Decrypt(profile.ShippingAddress.City, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.City = decryptedCity
-		} else {
-			encryptedCity, err = common.Aes256Encrypt(profile.ShippingAddress.City, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedZipCode, err := common.Aes256Decrypt(profile.ShippingAddress.ZipCode, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.ZipCode = decryptedZipCode
-		} else {
-			encryptedZipCode, err = common.Aes256Encrypt(profile.ShippingAddress.ZipCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedStateCode, err := common.Aes256Decrypt(profile.ShippingAddress.StateCode, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.StateCode = decryptedStateCode
-		} else {
-			encryptedStateCode, err = common.Aes256Encrypt(profile.ShippingAddress.StateCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCountryCode, err := common.Aes256Decrypt(profile.ShippingAddress.CountryCode, enums.UserKey)
-		if err == nil {
-			profile.ShippingAddress.CountryCode = decryptedCountryCode
-		} else {
-			encryptedCountryCode, err = common.Aes256Encrypt(profile.ShippingAddress.CountryCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-
-		if encryptedFirstName != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET firstName = "%v" WHERE ID = "%v"`, encryptedFirstName, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedLastName != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET lastName = "%v" WHERE ID = "%v"`, encryptedLastName, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedAddress1 != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET address1 = "%v" WHERE ID = "%v"`, encryptedAddress1, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedAddress2 != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET address2 = "%v" WHERE ID = "%v"`, encryptedAddress2, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCity != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET city = "%v" WHERE ID = "%v"`, encryptedCity, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-
-			}()
-		}
-
-		if encryptedZipCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET zipCode = "%v" WHERE ID = "%v"`, encryptedZipCode, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedStateCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET stateCode = "%v" WHERE ID = "%v"`, encryptedStateCode, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCountryCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE shippingAddresses SET countryCode = "%v" WHERE ID = "%v"`, encryptedCountryCode, profile.ShippingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
 	}
+	rows.Close()
 
+	address := &profile.ShippingAddress
+	err = decryptColumns("shippingAddresses", "ID", address.ID,
+		[]string{"firstName", "lastName", "address1", "address2", "city", "zipCode", "stateCode", "countryCode"},
+		&address.FirstName, &address.LastName, &address.Address1, &address.Address2, &address.City, &address.ZipCode, &address.StateCode, &address.CountryCode,
+	)
 	return profile, err
 }
 
@@ -1247,208 +941,18 @@ func GetBillingAddress(profile entities.Profile) (entities.Profile, error) {
 
 	defer rows.Close()
 	for rows.Next() {
-		var encryptedFirstName string
-		var encryptedLastName string
-		var encryptedAddress1 string
-		var encryptedAddress2 string
-		var encryptedCity string
-		var encryptedZipCode string
-		var encryptedStateCode string
-		var encryptedCountryCode string
-
 		err = rows.StructScan(&profile.BillingAddress)
 		if err != nil {
 			return profile, err
 		}
-
-		decryptedFirstName, err := common.Aes256Decrypt(profile.BillingAddress.FirstName, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.FirstName = decryptedFirstName
-		} else {
-			encryptedFirstName, err = common.Aes256Encrypt(profile.BillingAddress.FirstName, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedLastName, err := common.Aes256Decrypt(profile.BillingAddress.LastName, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.LastName = decryptedLastName
-		} else {
-			encryptedLastName, err = common.Aes256Encrypt(profile.BillingAddress.LastName, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedAddress1, err := common.Aes256Decrypt(profile.BillingAddress.Address1, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.Address1 = decryptedAddress1
-		} else {
-			encryptedAddress1, err = common.Aes256Encrypt(profile.BillingAddress.Address1, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedAddress2, err := common.Aes256Decrypt(profile.BillingAddress.Address2, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.Address2 = decryptedAddress2
-		} else {
-			encryptedAddress2, err = common.Aes256Encrypt(profile.BillingAddress.Address2, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCity, err := common.Aes256Decrypt(profile.BillingAddress.City, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.City = decryptedCity
-		} else {
-			encryptedCity, err = common.Aes256Encrypt(profile.BillingAddress.City, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedZipCode, err := common.Aes256Decrypt(profile.BillingAddress.ZipCode, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.ZipCode = decryptedZipCode
-		} else {
-			encryptedZipCode, err = common.Aes256Encrypt(profile.BillingAddress.ZipCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedStateCode, err := common.Aes256Decrypt(profile.BillingAddress.StateCode, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.StateCode = decryptedStateCode
-		} else {
-			encryptedStateCode, err = common.Aes256Encrypt(profile.BillingAddress.StateCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCountryCode, err := common.Aes256Decrypt(profile.BillingAddress.CountryCode, enums.UserKey)
-		if err == nil {
-			profile.BillingAddress.CountryCode = decryptedCountryCode
-		} else {
-			encryptedCountryCode, err = common.Aes256Encrypt(profile.BillingAddress.CountryCode, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-
-		if encryptedFirstName != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET firstName = "%v" WHERE ID = "%v"`, encryptedFirstName, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedLastName != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET lastName = "%v" WHERE ID = "%v"`, encryptedLastName, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedAddress1 != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET address1 = "%v" WHERE ID = "%v"`, encryptedAddress1, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedAddress2 != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET address2 = "%v" WHERE ID = "%v"`, encryptedAddress2, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCity != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET city = "%v" WHERE ID = "%v"`, encryptedCity, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-
-			}()
-		}
-
-		if encryptedZipCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET zipCode = "%v" WHERE ID = "%v"`, encryptedZipCode, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedStateCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET stateCode = "%v" WHERE ID = "%v"`, encryptedStateCode, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCountryCode != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE billingAddresses SET countryCode = "%v" WHERE ID = "%v"`, encryptedCountryCode, profile.BillingAddress.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
 	}
+	rows.Close()
 
+	address := &profile.BillingAddress
+	err = decryptColumns("billingAddresses", "ID", address.ID,
+		[]string{"firstName", "lastName", "address1", "address2", "city", "zipCode", "stateCode", "countryCode"},
+		&address.FirstName, &address.LastName, &address.Address1, &address.Address2, &address.City, &address.ZipCode, &address.StateCode, &address.CountryCode,
+	)
 	return profile, err
 }
 
@@ -1469,222 +973,29 @@ func GetCard(profile entities.Profile) (entities.Profile, error) {
 
 	defer rows.Close()
 	for rows.Next() {
-		var encryptedCardholderName string
-		var encryptedCardNumber string
-		var encryptedExpMonth string
-		var encryptedExpYear string
-		var encryptedCVV string
-		var encryptedCardType string
-
 		err = rows.StructScan(&profile.CreditCard)
 		if err != nil {
 			return profile, err
 		}
-
-		decryptedCardholderName, err := common.Aes256Decrypt(profile.CreditCard.CardholderName, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.CardholderName = decryptedCardholderName
-		} else {
-			encryptedCardholderName, err = common.Aes256Encrypt(profile.CreditCard.CardholderName, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCardNumber, err := common.Aes256Decrypt(profile.CreditCard.CardNumber, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.CardNumber = decryptedCardNumber
-		} else {
-			encryptedCardNumber, err = common.Aes256Encrypt(profile.CreditCard.CardNumber, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedExpMonth, err := common.Aes256Decrypt(profile.CreditCard.ExpMonth, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.ExpMonth = decryptedExpMonth
-		} else {
-			encryptedExpMonth, err = common.Aes256Encrypt(profile.CreditCard.ExpMonth, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedExpYear, err := common.Aes256Decrypt(profile.CreditCard.ExpYear, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.ExpYear = decryptedExpYear
-		} else {
-			encryptedExpYear, err = common.Aes256Encrypt(profile.CreditCard.ExpYear, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCVV, err := common.Aes256Decrypt(profile.CreditCard.CVV, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.CVV = decryptedCVV
-		} else {
-			encryptedCVV, err = common.Aes256Encrypt(profile.CreditCard.CVV, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-		decryptedCardType, err := common.Aes256Decrypt(profile.CreditCard.CardType, enums.UserKey)
-		if err == nil {
-			profile.CreditCard.CardType = decryptedCardType
-		} else {
-			encryptedCardType, err = common.Aes256Encrypt(profile.CreditCard.CardType, enums.UserKey)
-			if err != nil {
-				return profile, err
-			}
-		}
-
-		if encryptedCardholderName != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET cardHolderName = "%v" WHERE ID = "%v"`, encryptedCardholderName, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCardNumber != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET cardNumber = "%v" WHERE ID = "%v"`, encryptedCardNumber, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedExpMonth != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET expMonth = "%v" WHERE ID = "%v"`, encryptedExpMonth, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedExpYear != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET expYear = "%v" WHERE ID = "%v"`, encryptedExpYear, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedCVV != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET cvv = "%v" WHERE ID = "%v"`, encryptedCVV, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-
-			}()
-		}
-
-		if encryptedCardType != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE cards SET cardType = "%v" WHERE ID = "%v"`, encryptedCardType, profile.CreditCard.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
 	}
+	rows.Close()
 
+	card := &profile.CreditCard
+	err = decryptColumns("cards", "ID", card.ID,
+		[]string{"cardHolderName", "cardNumber", "expMonth", "expYear", "cvv", "cardType"},
+		&card.CardholderName, &card.CardNumber, &card.ExpMonth, &card.ExpYear, &card.CVV, &card.CardType,
+	)
 	return profile, err
 }
 
 func GetProfileInfo(profile entities.Profile) (entities.Profile, error) {
-	database := common.GetDatabase()
-	if database == nil {
-		return profile, errors.New("database not initialized")
-	}
-
 	if profile.ProfileGroupIDsJoined != "" {
 		profile.ProfileGroupIDs = strings.Split(profile.ProfileGroupIDsJoined, ",")
 	}
 
-	var (
-		encryptedEmail       string
-		encryptedPhoneNumber string
-	)
-
-	decryptedEmail, err := common.Aes256Decrypt(profile.Email, enums.UserKey)
-	if err == nil {
-		profile.Email = decryptedEmail
-	} else {
-		encryptedEmail, err = common.Aes256Encrypt(profile.Email, enums.UserKey)
-		if err != nil {
-			return profile, err
-		}
-	}
-
-	decryptedPhoneNumber, err := common.Aes256Decrypt(profile.PhoneNumber, enums.UserKey)
-	if err == nil {
-		profile.PhoneNumber = decryptedPhoneNumber
-	} else {
-		encryptedPhoneNumber, err = common.Aes256Encrypt(profile.PhoneNumber, enums.UserKey)
-		if err != nil {
-			return profile, err
-		}
-	}
-
-	if encryptedEmail != "" {
-		go func() {
-			for {
-				_, err = database.Exec(fmt.Sprintf(`UPDATE profiles SET email = "%v" WHERE ID = "%v"`, encryptedEmail, profile.ID))
-				if err != nil {
-					time.Sleep(1 * time.Second)
-					continue
-				} else {
-					break
-				}
-			}
-		}()
-	}
-	if encryptedPhoneNumber != "" {
-		go func() {
-			for {
-				_, err = database.Exec(fmt.Sprintf(`UPDATE profiles SET phoneNumber = "%v" WHERE ID = "%v"`, encryptedPhoneNumber, profile.ID))
-				if err != nil {
-					time.Sleep(1 * time.Second)
-					continue
-				} else {
-					break
-				}
-			}
-		}()
+	err := decryptColumns("profiles", "ID", profile.ID, []string{"email", "phoneNumber"}, &profile.Email, &profile.PhoneNumber)
+	if err != nil {
+		return profile, err
 	}
 
 	profile, err = GetShippingAddress(profile)
@@ -1698,3 +1009,39 @@ func GetProfileInfo(profile entities.Profile) (entities.Profile, error) {
 
 	return GetCard(profile)
 }
+
+// decryptColumns decrypts the values of the row whose idColumn is ID in place. Values in an older encryption format are
+// re-encrypted in the current one and written back. Values that can't be decrypted are reported rather than overwritten.
+func decryptColumns(table string, idColumn string, ID string, columns []string, values ...*string) error {
+	staleColumns := []string{}
+	staleValues := []interface{}{}
+	for i, value := range values {
+		plaintext, stale, err := common.DecryptField(*value, enums.UserKey)
+		if err != nil {
+			return errors.New("decrypting " + columns[i] + " of " + table + " " + ID + " failed: " + err.Error())
+		}
+		if stale {
+			encryptedValue, err := common.EncryptField(plaintext, enums.UserKey)
+			if err != nil {
+				return err
+			}
+			staleColumns = append(staleColumns, columns[i]+" = ?")
+			staleValues = append(staleValues, encryptedValue)
+		}
+		*value = plaintext
+	}
+	if len(staleColumns) == 0 {
+		return nil
+	}
+
+	database := common.GetDatabase()
+	if database == nil {
+		return errors.New("database not initialized")
+	}
+	// The values were read successfully, so if they can't be written back now they're migrated on a later read instead
+	_, err := database.Exec("UPDATE "+table+" SET "+strings.Join(staleColumns, ", ")+" WHERE "+idColumn+" = ?", append(staleValues, ID)...)
+	if err != nil {
+		log.Println("Error migrating the encrypted values of " + table + " " + ID + ": " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package queries
+
+import (
+	"errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// GetOutboxEntries returns the OutboxEntries with the status, or every one if it's empty, oldest first
+func GetOutboxEntries(status enums.OutboxStatus) ([]entities.OutboxEntry, error) {
+	if status == "" {
+		return getOutboxEntries("SELECT * FROM outbox ORDER BY creationDate, rowid")
+	}
+	return getOutboxEntries("SELECT * FROM outbox WHERE status = ? ORDER BY creationDate, rowid", status)
+}
+
+// GetOutboxEntry returns the OutboxEntry with the given ID, and false if there isn't one
+func GetOutboxEntry(ID string) (entities.OutboxEntry, bool, error) {
+	entries, err := getOutboxEntries("SELECT * FROM outbox WHERE ID = ?", ID)
+	if err != nil || len(entries) == 0 {
+		return entities.OutboxEntry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+func getOutboxEntries(query string, args ...interface{}) ([]entities.OutboxEntry, error) {
+	entries := []entities.OutboxEntry{}
+	database := common.GetDatabase()
+	if database == nil {
+		return entries, errors.New("database not initialized")
+	}
+
+	rows, err := database.Queryx(query, args...)
+	if err != nil {
+		return entries, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		tempEntry := entities.OutboxEntry{}
+		err = rows.StructScan(&tempEntry)
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, tempEntry)
+	}
+	err = rows.Err()
+	if err != nil {
+		return entries, err
+	}
+	rows.Close()
+
+	for i := range entries {
+		err = decryptColumns("outbox", "ID", entries[i].ID, []string{"payload"}, &entries[i].Payload)
+		if err != nil {
+			return entries, err
+		}
+	}
+	return entries, nil
+}
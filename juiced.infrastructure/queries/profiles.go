@@ -2,14 +2,11 @@ package queries
 
 import (
 	"errors"
-	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
-	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 )
 
 // GetAllProfileGroups returns all ProfileGroup objects from the database
@@ -91,64 +88,20 @@ func GetAllProfiles() ([]entities.Profile, error) {
 		return profiles, err
 	}
 
+	// The rows are closed before the rest of each Profile is read, so that migrating its encrypted values doesn't block
+	storedProfiles := []entities.Profile{}
 	defer rows.Close()
 	for rows.Next() {
-		var encryptedEmail string
-		var encryptedPhoneNumber string
-
 		tempProfile := entities.Profile{}
 		err = rows.StructScan(&tempProfile)
 		if err != nil {
 			return profiles, err
 		}
+		storedProfiles = append(storedProfiles, tempProfile)
+	}
+	rows.Close()
 
-		decryptedEmail, err := common.Aes256Decrypt(tempProfile.Email, enums.UserKey)
-		if err == nil {
-			tempProfile.Email = decryptedEmail
-		} else {
-			encryptedEmail, err = common.Aes256Encrypt(tempProfile.Email, enums.UserKey)
-			if err != nil {
-				return profiles, err
-			}
-		}
-		decryptedPhoneNumber, err := common.Aes256Decrypt(tempProfile.PhoneNumber, enums.UserKey)
-		if err == nil {
-			tempProfile.PhoneNumber = decryptedPhoneNumber
-		} else {
-			encryptedPhoneNumber, err = common.Aes256Encrypt(tempProfile.PhoneNumber, enums.UserKey)
-			if err != nil {
-				return profiles, err
-			}
-		}
-
-		if encryptedEmail != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE profiles SET email = "%v" WHERE ID = "%v"`, encryptedEmail, tempProfile.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedPhoneNumber != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE profiles SET phoneNumber = "%v" WHERE ID = "%v"`, encryptedPhoneNumber, tempProfile.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
+	for _, tempProfile := range storedProfiles {
 		tempProfile, err = GetProfileInfo(tempProfile)
 		if err != nil {
 			return profiles, err
@@ -191,6 +144,8 @@ func GetProfile(ID string) (entities.Profile, error) {
 		}
 	}
 
+	rows.Close()
+
 	return GetProfileInfo(profile)
 }
 
@@ -220,6 +175,8 @@ func GetProfileByName(name string) (entities.Profile, error) {
 		}
 	}
 
+	rows.Close()
+
 	return GetProfileInfo(profile)
 }
 
@@ -0,0 +1,51 @@
+package queries
+
+import (
+	"errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetAllTaskGroupSchedules returns every TaskGroupSchedule in the database
+func GetAllTaskGroupSchedules() ([]entities.TaskGroupSchedule, error) {
+	return getTaskGroupSchedules("SELECT * FROM taskGroupSchedules ORDER BY creationDate")
+}
+
+// GetTaskGroupSchedules returns the TaskGroup's schedules, oldest first
+func GetTaskGroupSchedules(groupID string) ([]entities.TaskGroupSchedule, error) {
+	return getTaskGroupSchedules("SELECT * FROM taskGroupSchedules WHERE taskGroupID = ? ORDER BY creationDate", groupID)
+}
+
+// GetTaskGroupSchedule returns the TaskGroupSchedule with the given ID, and false if there isn't one
+func GetTaskGroupSchedule(ID string) (entities.TaskGroupSchedule, bool, error) {
+	schedules, err := getTaskGroupSchedules("SELECT * FROM taskGroupSchedules WHERE ID = ?", ID)
+	if err != nil || len(schedules) == 0 {
+		return entities.TaskGroupSchedule{}, false, err
+	}
+	return schedules[0], true, nil
+}
+
+func getTaskGroupSchedules(query string, args ...interface{}) ([]entities.TaskGroupSchedule, error) {
+	schedules := []entities.TaskGroupSchedule{}
+	database := common.GetDatabase()
+	if database == nil {
+		return schedules, errors.New("database not initialized")
+	}
+
+	rows, err := database.Queryx(query, args...)
+	if err != nil {
+		return schedules, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		tempSchedule := entities.TaskGroupSchedule{}
+		err = rows.StructScan(&tempSchedule)
+		if err != nil {
+			return schedules, err
+		}
+		schedules = append(schedules, tempSchedule)
+	}
+	return schedules, rows.Err()
+}
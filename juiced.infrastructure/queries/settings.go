@@ -1,14 +1,13 @@
 package queries
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sort"
-	"time"
 
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
-	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 )
 
 // GetSettings returns the settings object from the database
@@ -32,10 +31,34 @@ func GetSettings() (entities.Settings, error) {
 			return settings, err
 		}
 	}
+	rows.Close()
+
+	err = decryptColumns("settings", "id", fmt.Sprint(settings.ID),
+		[]string{"twoCaptchaAPIKey", "antiCaptchaAPIKey", "capMonsterAPIKey", "aycdAccessToken", "aycdAPIKey", "remoteAccessToken", "notificationSinks"},
+		&settings.TwoCaptchaAPIKey, &settings.AntiCaptchaAPIKey, &settings.CapMonsterAPIKey, &settings.AYCDAccessToken, &settings.AYCDAPIKey, &settings.RemoteAccessToken, &settings.NotificationSinksJoined,
+	)
+	if err != nil {
+		return settings, err
+	}
+	// The NotificationSinks have secrets like bot tokens and passwords, so they're stored encrypted as JSON
+	settings.NotificationSinks = []entities.NotificationSink{}
+	if settings.NotificationSinksJoined != "" {
+		err = json.Unmarshal([]byte(settings.NotificationSinksJoined), &settings.NotificationSinks)
+		if err != nil {
+			return settings, err
+		}
+	}
+	settings.NotificationSinksJoined = ""
 	settings.Accounts, err = GetAccounts()
 	return settings, err
 }
 
+// GetNotificationSinks returns the NotificationSinks in the Settings
+func GetNotificationSinks() ([]entities.NotificationSink, error) {
+	settings, err := GetSettings()
+	return settings.NotificationSinks, err
+}
+
 // GetAccounts returns a list of accounts from the database
 func GetAccounts() ([]entities.Account, error) {
 	accounts := []entities.Account{}
@@ -56,58 +79,15 @@ func GetAccounts() ([]entities.Account, error) {
 		if err != nil {
 			return accounts, err
 		}
+		accounts = append(accounts, account)
+	}
+	rows.Close()
 
-		var encryptedEmail string
-		var encryptedPassword string
-		decryptedEmail, err := common.Aes256Decrypt(account.Email, enums.UserKey)
-		if err == nil {
-			account.Email = decryptedEmail
-		} else {
-			encryptedEmail, err = common.Aes256Encrypt(account.Email, enums.UserKey)
-			if err != nil {
-				return accounts, err
-			}
-		}
-
-		decryptedPassword, err := common.Aes256Decrypt(account.Password, enums.UserKey)
-		if err == nil {
-			account.Password = decryptedPassword
-		} else {
-			encryptedPassword, err = common.Aes256Encrypt(account.Password, enums.UserKey)
-			if err != nil {
-				return accounts, err
-			}
-		}
-
-		if encryptedEmail != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE accounts SET email = "%v" WHERE ID = "%v"`, encryptedEmail, account.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedPassword != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE accounts SET password = "%v" WHERE ID = "%v"`, encryptedPassword, account.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
+	for i := range accounts {
+		err = decryptColumns("accounts", "ID", accounts[i].ID, []string{"email", "password"}, &accounts[i].Email, &accounts[i].Password)
+		if err != nil {
+			return accounts, err
 		}
-
-		accounts = append(accounts, account)
 	}
 
 	sort.SliceStable(accounts, func(i, j int) bool {
@@ -141,58 +121,9 @@ func GetAccount(ID string) (entities.Account, error) {
 		if err != nil {
 			return account, err
 		}
-
-		var encryptedEmail string
-		var encryptedPassword string
-		decryptedEmail, err := common.Aes256Decrypt(account.Email, enums.UserKey)
-		if err == nil {
-			account.Email = decryptedEmail
-		} else {
-			encryptedEmail, err = common.Aes256Encrypt(account.Email, enums.UserKey)
-			if err != nil {
-				return account, err
-			}
-		}
-
-		decryptedPassword, err := common.Aes256Decrypt(account.Password, enums.UserKey)
-		if err == nil {
-			account.Password = decryptedPassword
-		} else {
-			encryptedPassword, err = common.Aes256Encrypt(account.Password, enums.UserKey)
-			if err != nil {
-				return account, err
-			}
-		}
-
-		if encryptedEmail != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE accounts SET email = "%v" WHERE ID = "%v"`, encryptedEmail, account.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
-		if encryptedPassword != "" {
-			go func() {
-				for {
-					_, err = database.Exec(fmt.Sprintf(`UPDATE accounts SET password = "%v" WHERE ID = "%v"`, encryptedPassword, account.ID))
-					if err != nil {
-						time.Sleep(1 * time.Second)
-						continue
-					} else {
-						break
-					}
-				}
-			}()
-		}
-
 	}
+	rows.Close()
 
-	return account, nil
+	err = decryptColumns("accounts", "ID", account.ID, []string{"email", "password"}, &account.Email, &account.Password)
+	return account, err
 }
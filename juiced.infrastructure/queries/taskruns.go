@@ -0,0 +1,79 @@
+package queries
+
+import (
+	"errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+// GetTaskRuns returns the Task's runs, most recent first, each with its status transitions in order
+func GetTaskRuns(taskID string) ([]entities.TaskRun, error) {
+	taskRuns := []entities.TaskRun{}
+	database := common.GetDatabase()
+	if database == nil {
+		return taskRuns, errors.New("database not initialized")
+	}
+
+	rows, err := database.Queryx(`SELECT * FROM taskRuns WHERE kind = ? AND taskID = ? ORDER BY startTime DESC`, entities.TaskRunKindTask, taskID)
+	if err != nil {
+		return taskRuns, err
+	}
+
+	defer rows.Close()
+	runIndexes := make(map[string]int)
+	for rows.Next() {
+		taskRun := entities.TaskRun{Events: []entities.TaskRunEvent{}}
+		err = rows.StructScan(&taskRun)
+		if err != nil {
+			return taskRuns, err
+		}
+		runIndexes[taskRun.RunID] = len(taskRuns)
+		taskRuns = append(taskRuns, taskRun)
+	}
+
+	eventRows, err := database.Queryx(`SELECT * FROM taskRunEvents WHERE kind = ? AND taskID = ? ORDER BY time, rowid`, entities.TaskRunKindTask, taskID)
+	if err != nil {
+		return taskRuns, err
+	}
+
+	defer eventRows.Close()
+	for eventRows.Next() {
+		event := entities.TaskRunEvent{}
+		err = eventRows.StructScan(&event)
+		if err != nil {
+			return taskRuns, err
+		}
+		if index, ok := runIndexes[event.RunID]; ok {
+			taskRuns[index].Events = append(taskRuns[index].Events, event)
+		}
+	}
+
+	return taskRuns, nil
+}
+
+// GetTaskGroupTimeline returns the status transitions of the TaskGroup's Tasks and Monitor since the given time (in ms), in order
+func GetTaskGroupTimeline(groupID string, since int64) ([]entities.TaskRunEvent, error) {
+	timeline := []entities.TaskRunEvent{}
+	database := common.GetDatabase()
+	if database == nil {
+		return timeline, errors.New("database not initialized")
+	}
+
+	rows, err := database.Queryx(`SELECT * FROM taskRunEvents WHERE taskGroupID = ? AND time >= ? ORDER BY time, rowid`, groupID, since)
+	if err != nil {
+		return timeline, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		event := entities.TaskRunEvent{}
+		err = rows.StructScan(&event)
+		if err != nil {
+			return timeline, err
+		}
+		timeline = append(timeline, event)
+	}
+
+	return timeline, nil
+}
@@ -36,6 +36,10 @@ func GetAllTaskGroups() ([]entities.TaskGroup, error) {
 		if err != nil {
 			return taskGroups, err
 		}
+		tempTaskGroup.Schedules, err = GetTaskGroupSchedules(tempTaskGroup.GroupID)
+		if err != nil {
+			return taskGroups, err
+		}
 		taskGroups = append(taskGroups, tempTaskGroup)
 	}
 
@@ -74,6 +78,10 @@ func GetTaskGroup(groupID string) (entities.TaskGroup, error) {
 	if taskGroup.TaskIDsJoined != "" {
 		taskGroup.TaskIDs = strings.Split(taskGroup.TaskIDsJoined, ",")
 	}
+	taskGroup.Schedules, err = GetTaskGroupSchedules(groupID)
+	if err != nil {
+		return taskGroup, err
+	}
 
 	return GetMonitorInfos(taskGroup)
 }
@@ -286,6 +294,7 @@ func ConvertTaskIDsToTasks(taskGroup *entities.TaskGroup) (entities.TaskGroupWit
 		MonitorRetailer:          taskGroup.MonitorRetailer,
 		MonitorDelay:             taskGroup.MonitorDelay,
 		MonitorStatus:            taskGroup.MonitorStatus,
+		Schedules:                taskGroup.Schedules,
 		AmazonMonitorInfo:        taskGroup.AmazonMonitorInfo,
 		BestbuyMonitorInfo:       taskGroup.BestbuyMonitorInfo,
 		BoxlunchMonitorInfo:      taskGroup.BoxlunchMonitorInfo,
@@ -51,10 +51,10 @@ func (task *Task) CreateAmazonEmbed(status enums.OrderStatus, imageURL string) [
 		},
 	}
 
-	if task.Task.Proxy != nil {
+	if task.Task.Proxy() != nil {
 		fields = append(fields, sec.DiscordField{
 			Name:  "Proxy:",
-			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy) + " " + "||",
+			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy()) + " " + "||",
 		})
 	}
 
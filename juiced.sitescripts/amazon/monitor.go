@@ -1,6 +1,7 @@
 package amazon
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -20,14 +21,10 @@ import (
 	"backend.juicedbot.io/juiced.sitescripts/util"
 	"github.com/anaskhan96/soup"
 	browser "github.com/eddycjy/fake-useragent"
-	cmap "github.com/orcaman/concurrent-map"
 )
 
-// Creating a pool to store amazon accounts that will be used to monitor
-var AccountPool = cmap.New()
-
 // CreateAmazonMonitor takes a TaskGroup entity and turns it into a Amazon Monitor
-func CreateAmazonMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.AmazonSingleMonitorInfo) (Monitor, error) {
+func CreateAmazonMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.AmazonSingleMonitorInfo) (*Monitor, error) {
 	storedAmazonMonitors := make(map[string]entities.AmazonSingleMonitorInfo)
 	amazonMonitor := Monitor{}
 	asins := []string{}
@@ -53,18 +50,18 @@ func CreateAmazonMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pro
 		created = true
 	}
 
-	return amazonMonitor, nil
+	return &amazonMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
@@ -79,14 +76,16 @@ func (monitor *Monitor) CheckForStop() bool {
 //	ordered. To order them with the modified net/http you will have to use the request.RawHeader.
 
 // So theres a few different ways we can make the monitoring groups for Amazon, for now I'm going to make it so it runs a goroutine for each ASIN
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		recover()
 		// TODO @silent: Let the UI know that a monitor failed
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 	needToStop := monitor.CheckForStop()
@@ -113,20 +112,15 @@ again:
 	if needToStop {
 		return
 	}
-	accounts, _ := AccountPool.Get(monitor.Monitor.TaskGroup.GroupID)
-	if accounts != nil {
-		if !(len(accounts.([]Acc)) > 0) {
-			time.Sleep(common.MS_TO_WAIT)
-			goto again
-		}
-	} else {
-		time.Sleep(common.MS_TO_WAIT)
+	// The monitor uses the accounts that the TaskGroup's tasks logged in with
+	if len(monitor.Monitor.Sessions()) == 0 {
+		monitor.Monitor.Sleep(common.MS_TO_WAIT)
 		goto again
 	}
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(asin)
 		}
 	}()
@@ -144,6 +138,7 @@ again:
 		if needToStop {
 			return
 		}
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.ASIN == stockData.ASIN
@@ -155,31 +150,34 @@ again:
 			})
 			monitor.InStock = append(monitor.InStock, stockData)
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.ASIN == stockData.ASIN {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(asin)
 }
 
@@ -188,8 +186,12 @@ again:
 func (monitor *Monitor) TurboMonitor(asin string) AmazonInStockData {
 	stockData := AmazonInStockData{}
 	currentEndpoint := AmazonEndpoints[util.RandomNumberInt(0, 2)]
-	pool, _ := AccountPool.Get(monitor.Monitor.TaskGroup.GroupID)
-	account := pool.([]Acc)[rand.Intn(len(pool.([]Acc)))]
+	// The task whose account was picked may have stopped since the monitor checked
+	sessions := monitor.Monitor.Sessions()
+	if len(sessions) == 0 {
+		return stockData
+	}
+	account := sessions[rand.Intn(len(sessions))].(Acc)
 	currentClient := account.Client
 	ua := browser.Chrome()
 
@@ -206,6 +208,7 @@ func (monitor *Monitor) TurboMonitor(asin string) AmazonInStockData {
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: currentClient,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    currentEndpoint + fmt.Sprintf(MonitorEndpoints[util.RandomNumberInt(0, len(MonitorEndpoints))], asin) + util.Randomizer("&pldnSite=1"),
 		RawHeaders: [][2]string{
@@ -386,8 +389,12 @@ func (monitor *Monitor) StockInfo(ua, urL, body, asin string) AmazonInStockData
 // this is also known as OfferID mode.
 func (monitor *Monitor) OFIDMonitor(asin string) AmazonInStockData {
 	stockData := AmazonInStockData{}
-	pool, _ := AccountPool.Get(monitor.Monitor.TaskGroup.GroupID)
-	account := pool.([]Acc)[rand.Intn(len(pool.([]Acc)))]
+	// The task whose account was picked may have stopped since the monitor checked
+	sessions := monitor.Monitor.Sessions()
+	if len(sessions) == 0 {
+		return stockData
+	}
+	account := sessions[rand.Intn(len(sessions))].(Acc)
 	currentEndpoint := AmazonEndpoints[util.RandomNumberInt(0, 2)]
 	form := url.Values{
 		"isAsync":         {"1"},
@@ -413,6 +420,7 @@ func (monitor *Monitor) OFIDMonitor(asin string) AmazonInStockData {
 	ua := browser.Chrome()
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: currentClient,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "POST",
 		URL:    currentEndpoint + "/checkout/turbo-initiate?ref_=dp_start-bbf_1_glance_buyNow_2-1&referrer=detail&pipelineType=turbo&clientId=retailwebsite&weblab=RCX_CHECKOUT_TURBO_DESKTOP_PRIME_87783&temporaryAddToCart=1",
 		RawHeaders: [][2]string{
@@ -517,6 +525,7 @@ func (monitor *Monitor) OFIDMonitor(asin string) AmazonInStockData {
 func (monitor *Monitor) BecomeGuest(client http.Client) bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    BaseEndpoint,
 		RawHeaders: [][2]string{
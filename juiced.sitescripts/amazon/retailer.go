@@ -0,0 +1,101 @@
+package amazon
+
+import (
+	e "errors"
+	"math/rand"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Amazon sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.Amazon
+}
+
+// RequiresProfile returns false since Amazon tasks check out with the account's saved details
+func (Retailer) RequiresProfile() bool {
+	return false
+}
+
+// CreateTask validates the Task's AmazonTaskInfo and turns it into an Amazon Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.AmazonTaskInfo.Email == "" || task.AmazonTaskInfo.Password == "" {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+	if task.AmazonTaskInfo.LoginType == "" {
+		task.AmazonTaskInfo.LoginType = enums.LoginTypeBROWSER
+	}
+
+	amazonTask, err := CreateAmazonTask(task, proxyGroup, eventBus, task.AmazonTaskInfo.LoginType, task.AmazonTaskInfo.Email, task.AmazonTaskInfo.Password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return amazonTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's AmazonMonitorInfo and turns it into an Amazon Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.AmazonMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	for _, monitor := range taskGroup.AmazonMonitorInfo.Monitors {
+		if monitor.MonitorType == enums.FastSKUMonitor && monitor.OFID == "" {
+			return nil, e.New(errors.MissingMonitorFieldsError)
+		}
+	}
+
+	amazonMonitor, err := CreateAmazonMonitor(taskGroup, proxyGroup, eventBus, taskGroup.AmazonMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return amazonMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	if len(inStock) > 0 {
+		task.StockData = inStock[rand.Intn(len(inStock))]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
@@ -28,15 +28,15 @@ import (
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -46,7 +46,7 @@ func (task *Task) CheckForStop() bool {
 var AmazonAccountStore = cmap.New()
 
 // CreateAmazonTask takes a Task entity and turns it into a Amazon Task
-func CreateAmazonTask(task *entities.Task, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, loginType enums.LoginType, email, password string) (Task, error) {
+func CreateAmazonTask(task *entities.Task, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, loginType enums.LoginType, email, password string) (*Task, error) {
 	amazonTask := Task{}
 
 	amazonTask = Task{
@@ -62,32 +62,33 @@ func CreateAmazonTask(task *entities.Task, proxyGroup *entities.ProxyGroup, even
 		},
 	}
 	if proxyGroup != nil {
-		amazonTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		amazonTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		amazonTask.Task.Proxy = nil
+		amazonTask.Task.SetProxy(nil)
 	}
-	return amazonTask, nil
+	return &amazonTask, nil
 }
 
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = AmazonInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = AmazonInStockData{} })
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
 	}
@@ -95,7 +96,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -107,13 +108,8 @@ func (task *Task) RunTask() {
 		return
 	}
 
-	// Adding the account to the pool
-	var accounts = []Acc{{task.Task.Task.TaskGroupID, task.Task.Client, task.AccountInfo}}
-	oldAccounts, _ := AccountPool.Get(task.Task.Task.TaskGroupID)
-	if oldAccounts != nil {
-		accounts = append(accounts, oldAccounts.([]Acc)...)
-	}
-	AccountPool.Set(task.Task.Task.TaskGroupID, accounts)
+	// Share the logged in account with the monitor
+	task.Task.ShareSession(Acc{task.Task.Task.TaskGroupID, task.Task.Client, task.AccountInfo})
 
 	task.PublishEvent(enums.WaitingForMonitor, enums.TaskUpdate, 25)
 	// 2. WaitForMonitor
@@ -122,7 +118,7 @@ func (task *Task) RunTask() {
 		return
 	}
 
-	err = task.Task.UpdateProxy(task.Task.Proxy)
+	err = task.Task.UpdateProxy(task.Task.Proxy())
 	if err != nil {
 		task.PublishEvent(enums.TaskIdle, enums.TaskFail, 0)
 		return
@@ -141,7 +137,7 @@ func (task *Task) RunTask() {
 			addedToCart = task.AddToCart()
 			if !addedToCart {
 				retries++
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
 	} else {
@@ -165,7 +161,7 @@ func (task *Task) RunTask() {
 		placedOrder, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -185,7 +181,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -204,7 +200,7 @@ func (task *Task) RunTask() {
 // Sets the client up by either logging in or waiting for another task to login that is using the same account
 func (task *Task) Setup() bool {
 	// Bad but quick solution to the multiple logins
-	time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+	task.Task.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
 	if AmazonAccountStore.Has(task.AccountInfo.Email) {
 		inMap := true
 		for inMap {
@@ -223,10 +219,10 @@ func (task *Task) Setup() bool {
 					task.AccountInfo = acc.AccountInfo
 					break
 				} else {
-					if task.Task.Task.TaskStatus != enums.WaitingForLogin {
+					if task.Task.Task.GetTaskStatus() != enums.WaitingForLogin {
 						task.PublishEvent(enums.WaitingForLogin, enums.TaskUpdate, 10)
 					}
-					time.Sleep(common.MS_TO_WAIT)
+					task.Task.Sleep(common.MS_TO_WAIT)
 				}
 			} else {
 				inMap = false
@@ -244,9 +240,10 @@ func (task *Task) Setup() bool {
 			}
 			loggedIn = task.Login()
 			if !loggedIn {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
+		task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 
 	}
 
@@ -283,8 +280,8 @@ func (task *Task) browserLogin() bool {
 	launcher_ := launcher.New()
 
 	proxyCleaned := ""
-	if task.Task.Proxy != nil {
-		proxyCleaned = common.ProxyCleaner(*task.Task.Proxy)
+	if task.Task.Proxy() != nil {
+		proxyCleaned = common.ProxyCleaner(*task.Task.Proxy())
 	}
 	if proxyCleaned != "" {
 		proxyURL := proxyCleaned[7:]
@@ -336,11 +333,11 @@ func (task *Task) browserLogin() bool {
 
 	go func() {
 		// Wait until either the StopFlag is set to true or the BrowserComplete flag is set to true
-		for !task.Task.StopFlag && !task.BrowserComplete {
-			time.Sleep(common.MS_TO_WAIT)
+		for !task.Task.StopFlag() && !task.BrowserComplete {
+			task.Task.Sleep(common.MS_TO_WAIT)
 		}
 		// If the StopFlag being set to true is the one that caused us to break out of that for loop, then the browser is still running, so call cancel()
-		if task.Task.StopFlag {
+		if task.Task.StopFlag() {
 			AmazonAccountStore.Remove(task.AccountInfo.Email)
 			browserWithCancel.MustClose()
 			cancel()
@@ -360,12 +357,17 @@ func (task *Task) browserLogin() bool {
 	page.MustNavigate(LoginEndpoint)
 	page.MustWaitLoad()
 	page.MustElement("#ap_email").MustWaitVisible().Input(task.AccountInfo.Email)
-	time.Sleep(2 * time.Second)
+	task.Task.Sleep(2 * time.Second)
 	page.MustElement("#continue").MustWaitVisible().MustClick()
 	page.MustElement("#ap_password").MustWaitVisible().Input(task.AccountInfo.Password)
 	page.MustElementX(`//input[@name="rememberMe"]`).MustWaitVisible().MustClick()
-	time.Sleep(2 * time.Second)
+	task.Task.Sleep(2 * time.Second)
 	page.MustElement("#signInSubmit").MustWaitVisible().MustClick()
+	page.MustWaitLoad()
+	if task.loginFailed(page.MustHTML()) {
+		AmazonAccountStore.Remove(task.AccountInfo.Email)
+		return false
+	}
 	task.PublishEvent("Check for 2FA", enums.TaskUpdate, 15)
 	page.MustElement("#auth-cnep-done-button").MustWaitVisible().MustClick()
 	task.PublishEvent("2FA passed", enums.TaskUpdate, 20)
@@ -438,6 +440,7 @@ func (task *Task) browserLogin() bool {
 func (task *Task) requestsLogin() bool {
 	_, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    LoginEndpoint,
 		RawHeaders: [][2]string{
@@ -474,6 +477,7 @@ func (task *Task) requestsLogin() bool {
 
 	_, _, err = util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                "https://botbypass.com/metadata_api/metadata1_page_1?email=" + task.AccountInfo.Email + "&passwordLength=" + fmt.Sprint(len(task.AccountInfo.Password)) + "&apiKey=" + MetaData1APIKey,
 		ResponseBodyStruct: tempMeta,
@@ -495,8 +499,9 @@ func (task *Task) requestsLogin() bool {
 		"metadata1":        tempMeta.Metadata1,
 	})
 
-	_, _, err = util.MakeRequest(&util.Request{
+	_, body, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SigninEndpoint,
 		RawHeaders: [][2]string{
@@ -521,12 +526,13 @@ func (task *Task) requestsLogin() bool {
 		},
 		Data: []byte(params),
 	})
-	if err != nil {
+	if err != nil || task.loginFailed(body) {
 		return false
 	}
 
 	_, body, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    TestItemEndpoint,
 		RawHeaders: [][2]string{
@@ -570,6 +576,21 @@ func (task *Task) requestsLogin() bool {
 	return ok
 }
 
+// loginFailed returns true and records the account's status if the sign in page shows that the credentials are wrong
+// or that the account is locked
+func (task *Task) loginFailed(body string) bool {
+	if strings.Contains(body, "Your password is incorrect") || strings.Contains(body, "We cannot find an account with that email address") {
+		task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+		task.PublishEvent("Incorrect email or password", enums.TaskFail, 0)
+		return true
+	} else if strings.Contains(body, "Account on hold temporarily") {
+		task.Task.SetAccountStatus(enums.AccountStatusLocked)
+		task.PublishEvent("Account is locked", enums.TaskFail, 0)
+		return true
+	}
+	return false
+}
+
 // WaitForMonitor waits until the Monitor has sent the info to the task to continue
 func (task *Task) WaitForMonitor() bool {
 
@@ -579,12 +600,11 @@ func (task *Task) WaitForMonitor() bool {
 			return true
 		}
 		emptyString := ""
-		if task.StockData.OfferID != emptyString {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.OfferID != emptyString }) {
 			return false
 		}
 		// I see why now
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -602,6 +622,7 @@ func (task *Task) AddToCart() bool {
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    currentEndpoint + "/checkout/turbo-initiate?ref_=dp_start-bbf_1_glance_buyNow_2-1&referrer=detail&pipelineType=turbo&clientId=retailwebsite&weblab=RCX_CHECKOUT_TURBO_DESKTOP_PRIME_87783&temporaryAddToCart=1",
 		RawHeaders: [][2]string{
@@ -688,6 +709,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(CheckoutEndpoint, task.StockData.RID, fmt.Sprint(time.Now().UnixNano())[0:13], task.StockData.PID),
 		RawHeaders: [][2]string{
@@ -11,26 +11,26 @@ import (
 )
 
 func (task *Task) UpdateProxy(proxy *entities.Proxy) error {
-	task.Proxy.RemoveCount()
+	task.Proxy().RemoveCount()
 	if proxy != nil {
 		err := client.UpdateProxy(&task.Client, proxy)
 		if err != nil {
 			return err
 		}
-		task.Proxy = proxy
+		task.SetProxy(proxy)
 	}
 
 	return nil
 }
 
 func (monitor *Monitor) UpdateProxy(proxy *entities.Proxy) error {
-	monitor.Proxy.RemoveCount()
+	monitor.Proxy().RemoveCount()
 	if proxy != nil {
 		err := client.UpdateProxy(&monitor.Client, proxy)
 		if err != nil {
 			return err
 		}
-		monitor.Proxy = proxy
+		monitor.SetProxy(proxy)
 	}
 
 	return nil
@@ -39,7 +39,7 @@ func (monitor *Monitor) UpdateProxy(proxy *entities.Proxy) error {
 // CreateClient creates an HTTP client
 func (task *Task) CreateClient(proxy ...*entities.Proxy) error {
 	var err error
-	task.Proxy.RemoveCount()
+	task.Proxy().RemoveCount()
 	if len(proxy) > 0 {
 		if proxy[0] != nil {
 			proxy[0].AddCount()
@@ -69,7 +69,7 @@ func (task *Task) CreateClient(proxy ...*entities.Proxy) error {
 
 func (monitor *Monitor) CreateClient(proxy ...*entities.Proxy) error {
 	var err error
-	monitor.Proxy.RemoveCount()
+	monitor.Proxy().RemoveCount()
 	if len(proxy) > 0 {
 		if proxy[0] != nil {
 			proxy[0].AddCount()
@@ -1,19 +1,52 @@
 package base
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"backend.juicedbot.io/juiced.client/http"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/events"
 	"backend.juicedbot.io/juiced.sitescripts/hawk-go"
 )
 
 type Monitor struct {
 	TaskGroup  *entities.TaskGroup
-	Proxy      *entities.Proxy
 	ProxyGroup *entities.ProxyGroup
 	EventBus   *events.EventBus
 	Client     http.Client
 	Scraper    hawk.Scraper
-	StopFlag   bool
 	ErrorField string
+	Ctx        context.Context
+	// StockMutex guards the sitescript's in stock list, which the monitor's goroutines change while the MonitorStore hands it off
+	StockMutex sync.RWMutex
+	proxy      *entities.Proxy
+	proxyMutex sync.RWMutex
+	runState
+}
+
+// Sleep waits for the duration to pass or for the monitor to be stopped, whichever comes first
+func (monitor *Monitor) Sleep(duration time.Duration) {
+	Sleep(monitor.Ctx, duration)
+}
+
+// PublishEvent publishes a MonitorEvent for the monitor's current run and proxy
+func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
+	monitor.EventBus.PublishMonitorEventFor(monitor.TaskGroup, monitor.RunID(), proxyID(monitor.Proxy()), status, eventType, data)
+}
+
+// Proxy returns the proxy the monitor is using, the MonitorStore can change it while the monitor is running
+func (monitor *Monitor) Proxy() *entities.Proxy {
+	monitor.proxyMutex.RLock()
+	defer monitor.proxyMutex.RUnlock()
+	return monitor.proxy
+}
+
+// SetProxy sets the proxy the monitor is using
+func (monitor *Monitor) SetProxy(proxy *entities.Proxy) {
+	monitor.proxyMutex.Lock()
+	monitor.proxy = proxy
+	monitor.proxyMutex.Unlock()
 }
@@ -0,0 +1,82 @@
+package base
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+)
+
+// RunnableTask is implemented by every sitescript's Task
+type RunnableTask interface {
+	// GetTask returns the base Task embedded in the sitescript's Task
+	GetTask() *Task
+	// RunTask is the script driver, it blocks until the task finishes or ctx is cancelled
+	RunTask(ctx context.Context)
+}
+
+// RunnableMonitor is implemented by every sitescript's Monitor
+type RunnableMonitor interface {
+	// GetMonitor returns the base Monitor embedded in the sitescript's Monitor
+	GetMonitor() *Monitor
+	// RunMonitor is the script driver, it blocks until ctx is cancelled
+	RunMonitor(ctx context.Context)
+	// HasStock returns true if the monitor has in stock data to hand off to tasks
+	HasStock() bool
+	// ClearStock empties the monitor's in stock data before it is started again
+	ClearStock()
+}
+
+// Retailer is implemented by each sitescript package and registered through RegisterRetailer
+type Retailer interface {
+	// Name returns the retailer that the sitescript handles
+	Name() enums.Retailer
+	// RequiresProfile returns true if tasks for this retailer need a Profile with a valid card to run
+	RequiresProfile() bool
+	// CreateTask validates the Task entity's retailer specific info and turns it into a RunnableTask
+	CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (RunnableTask, error)
+	// CreateMonitor validates the TaskGroup entity's retailer specific info and turns it into a RunnableMonitor
+	CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (RunnableMonitor, error)
+	// HandOffStock gives the task a piece of the monitor's in stock data, both were created by this Retailer
+	HandOffStock(monitor RunnableMonitor, task RunnableTask)
+}
+
+var retailers = make(map[enums.Retailer]Retailer)
+var retailersMutex sync.RWMutex
+
+// RegisterRetailer adds the Retailer to the registry, sitescripts call it from an init function and go generate in the
+// retailers package picks them up
+func RegisterRetailer(retailer Retailer) {
+	retailersMutex.Lock()
+	defer retailersMutex.Unlock()
+
+	if _, ok := retailers[retailer.Name()]; ok {
+		panic("base: RegisterRetailer called twice for " + retailer.Name())
+	}
+	retailers[retailer.Name()] = retailer
+}
+
+// GetRetailer returns the registered Retailer for the given retailer name
+func GetRetailer(name enums.Retailer) (Retailer, bool) {
+	retailersMutex.RLock()
+	defer retailersMutex.RUnlock()
+
+	retailer, ok := retailers[name]
+	return retailer, ok
+}
+
+// GetRetailers returns the names of all registered retailers in sorted order
+func GetRetailers() []enums.Retailer {
+	retailersMutex.RLock()
+	defer retailersMutex.RUnlock()
+
+	names := make([]enums.Retailer, 0, len(retailers))
+	for name := range retailers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package base
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// runState tracks the goroutine running a task's or monitor's script driver, it's safe for concurrent use
+type runState struct {
+	mutex  sync.Mutex
+	runID  string
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Start runs the script driver in a new goroutine with a fresh context and run ID, the context is cancelled by Stop or when the driver returns
+func (state *runState) Start(run func(ctx context.Context)) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	state.runID, state.ctx, state.cancel, state.done = uuid.New().String(), ctx, cancel, done
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		run(ctx)
+	}()
+}
+
+// RunID returns the ID of the current or most recent run, or an empty string if there hasn't been a run yet
+func (state *runState) RunID() string {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.runID
+}
+
+// Stop cancels the current run's context without waiting for it to exit, so the script driver can call it on itself
+func (state *runState) Stop() {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if state.cancel != nil {
+		state.cancel()
+	}
+}
+
+// Wait blocks until the current run's goroutine has exited
+func (state *runState) Wait() {
+	state.mutex.Lock()
+	done := state.done
+	state.mutex.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Unwinding returns a channel that's closed once the current run's goroutine exits, or nil if it has already exited or there
+// hasn't been a run yet
+func (state *runState) Unwinding() <-chan struct{} {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	if state.done == nil {
+		return nil
+	}
+	select {
+	case <-state.done:
+		return nil
+	default:
+		return state.done
+	}
+}
+
+// StopFlag returns true if the current run has been stopped or has finished, or if there hasn't been a run yet
+func (state *runState) StopFlag() bool {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	return state.ctx == nil || state.ctx.Err() != nil
+}
+
+// Sleep waits for the duration to pass or for the context to be cancelled, whichever comes first
+func Sleep(ctx context.Context, duration time.Duration) {
+	if ctx == nil {
+		time.Sleep(duration)
+		return
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
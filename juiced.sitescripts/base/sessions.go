@@ -0,0 +1,41 @@
+package base
+
+import "sync"
+
+// AccountSessions shares the logged in sessions of a TaskGroup's tasks with its monitor, the AccountStore implements it
+type AccountSessions interface {
+	AddSession(groupID string, taskID string, session interface{})
+	GetSessions(groupID string) []interface{}
+}
+
+var accountSessions AccountSessions
+var accountSessionsMutex sync.RWMutex
+
+// SetAccountSessions sets where tasks share their logged in sessions, the AccountStore calls it when it's initialized
+func SetAccountSessions(sessions AccountSessions) {
+	accountSessionsMutex.Lock()
+	defer accountSessionsMutex.Unlock()
+
+	accountSessions = sessions
+}
+
+// ShareSession shares the task's logged in session with its TaskGroup's monitor until the task's run exits
+func (task *Task) ShareSession(session interface{}) {
+	accountSessionsMutex.RLock()
+	defer accountSessionsMutex.RUnlock()
+
+	if accountSessions != nil {
+		accountSessions.AddSession(task.Task.TaskGroupID, task.Task.ID, session)
+	}
+}
+
+// Sessions returns the logged in sessions that the running tasks of the monitor's TaskGroup share
+func (monitor *Monitor) Sessions() []interface{} {
+	accountSessionsMutex.RLock()
+	defer accountSessionsMutex.RUnlock()
+
+	if accountSessions == nil {
+		return nil
+	}
+	return accountSessions.GetSessions(monitor.TaskGroup.GroupID)
+}
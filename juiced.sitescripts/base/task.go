@@ -1,10 +1,16 @@
 package base
 
 import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"backend.juicedbot.io/juiced.client/http"
+	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/events"
 	"backend.juicedbot.io/juiced.sitescripts/hawk-go"
 )
@@ -12,15 +18,106 @@ import (
 type Task struct {
 	Task              *entities.Task
 	Profile           entities.Profile
-	Proxy             *entities.Proxy
 	ProxyGroup        *entities.ProxyGroup
+	Account           *entities.Account // the Account the task was given from the account pool, if it uses it
 	EventBus          *events.EventBus
 	Client            http.Client
 	Scraper           hawk.Scraper
 	StartTime         time.Time
 	EndTime           time.Time
-	HasStockData      bool
-	StopFlag          bool
-	DontPublishEvents bool
 	ErrorField        string
+	Ctx               context.Context
+	dontPublishEvents int32
+	proxy             *entities.Proxy
+	proxyMutex        sync.RWMutex
+	hasStockData      bool
+	stockMutex        sync.Mutex
+	runState
+}
+
+// Sleep waits for the duration to pass or for the task to be stopped, whichever comes first
+func (task *Task) Sleep(duration time.Duration) {
+	Sleep(task.Ctx, duration)
+}
+
+// PublishEvent publishes a TaskEvent for the task's current run and proxy
+func (task *Task) PublishEvent(status enums.TaskStatus, statusPercentage int, eventType enums.TaskEventType, data interface{}) {
+	task.EventBus.PublishTaskEventFor(task.Task, task.RunID(), proxyID(task.Proxy()), status, statusPercentage, eventType, data)
+}
+
+// Proxy returns the proxy the task is using, the TaskStore can change it while the task is running
+func (task *Task) Proxy() *entities.Proxy {
+	task.proxyMutex.RLock()
+	defer task.proxyMutex.RUnlock()
+	return task.proxy
+}
+
+// SetProxy sets the proxy the task is using
+func (task *Task) SetProxy(proxy *entities.Proxy) {
+	task.proxyMutex.Lock()
+	task.proxy = proxy
+	task.proxyMutex.Unlock()
+}
+
+// ResetStock runs reset to clear the sitescript's stock data before the task waits for its monitor again
+func (task *Task) ResetStock(reset func()) {
+	task.stockMutex.Lock()
+	defer task.stockMutex.Unlock()
+	reset()
+	task.hasStockData = false
+}
+
+// ReceiveStock runs handOff to give the task stock data from its monitor, unless the task has already taken some
+func (task *Task) ReceiveStock(handOff func()) {
+	task.stockMutex.Lock()
+	defer task.stockMutex.Unlock()
+	if !task.hasStockData {
+		handOff()
+	}
+}
+
+// TakeStock returns true and stops the monitor from handing off any more stock data if hasStock reports that the
+// sitescript's stock data has been filled in, the data is safe to read without the lock afterwards
+func (task *Task) TakeStock(hasStock func() bool) bool {
+	task.stockMutex.Lock()
+	defer task.stockMutex.Unlock()
+	task.hasStockData = hasStock()
+	return task.hasStockData
+}
+
+// SetAccountStatus records how logging in with the task's Account went, if it was given one from the account pool
+func (task *Task) SetAccountStatus(status enums.AccountStatus) {
+	if task.Account == nil || task.Account.Status == status {
+		return
+	}
+	task.Account.Status = status
+	task.Account.StatusDate = time.Now().Unix()
+	err := commands.UpdateAccountStatus(task.Account.ID, task.Account.Status, task.Account.StatusDate)
+	if err != nil {
+		log.Println("Error saving the account's status: " + err.Error())
+	}
+}
+
+// DontPublishEvents returns true if the task shouldn't publish a stop event when it stops
+func (task *Task) DontPublishEvents() bool {
+	return atomic.LoadInt32(&task.dontPublishEvents) == 1
+}
+
+// SetDontPublishEvents sets whether the task should publish a stop event when it stops
+func (task *Task) SetDontPublishEvents(flag bool) {
+	atomic.StoreInt32(&task.dontPublishEvents, boolToInt32(flag))
+}
+
+func boolToInt32(flag bool) int32 {
+	if flag {
+		return 1
+	}
+	return 0
+}
+
+func proxyID(proxy *entities.Proxy) string {
+	if proxy == nil {
+		return ""
+	}
+	return proxy.ID
 }
@@ -1,6 +1,7 @@
 package bestbuy
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -15,7 +16,7 @@ import (
 )
 
 // CreateBestbuyMonitor takes a TaskGroup entity and turns it into a Bestbuy Monitor
-func CreateBestbuyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.BestbuySingleMonitorInfo) (Monitor, error) {
+func CreateBestbuyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.BestbuySingleMonitorInfo) (*Monitor, error) {
 	storedBestbuyMonitors := make(map[string]entities.BestbuySingleMonitorInfo)
 	bestbuyMonitor := Monitor{}
 	skus := []string{}
@@ -35,35 +36,37 @@ func CreateBestbuyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pr
 		SKUWithInfo: storedBestbuyMonitors,
 	}
 
-	return bestbuyMonitor, nil
+	return &bestbuyMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
 	return false
 }
 
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorComplete, nil)
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 	needToStop := monitor.CheckForStop()
@@ -94,7 +97,7 @@ func (monitor *Monitor) RunMonitor() {
 
 			becameGuest = BecomeGuest(monitor.Monitor.Client)
 			if !becameGuest {
-				time.Sleep(1000 * time.Millisecond)
+				monitor.Monitor.Sleep(1000 * time.Millisecond)
 			}
 		}
 	}
@@ -110,7 +113,7 @@ func (monitor *Monitor) RunSingleMonitor() {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor()
 		}
 	}()
@@ -130,6 +133,7 @@ func (monitor *Monitor) RunSingleMonitor() {
 	}
 
 	if stockData.SKU != "" {
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -142,31 +146,34 @@ func (monitor *Monitor) RunSingleMonitor() {
 					{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor()
 }
 
@@ -176,6 +183,7 @@ func (monitor *Monitor) GetSKUStock() BestbuyInStockData {
 	monitorResponse := MonitorResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(MonitorEndpoint, skus),
 		RawHeaders: [][2]string{
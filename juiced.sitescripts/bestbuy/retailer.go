@@ -0,0 +1,91 @@
+package bestbuy
+
+import (
+	e "errors"
+	"math/rand"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Best Buy sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.BestBuy
+}
+
+// RequiresProfile returns true since Best Buy tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's BestbuyTaskInfo and turns it into a Best Buy Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.BestbuyTaskInfo.TaskType == "" || (task.BestbuyTaskInfo.TaskType == enums.TaskTypeAccount && (task.BestbuyTaskInfo.Email == "" || task.BestbuyTaskInfo.Password == "")) {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+	bestbuyTask, err := CreateBestbuyTask(task, profile, proxyGroup, eventBus, task.BestbuyTaskInfo.TaskType, task.BestbuyTaskInfo.LocationID, task.BestbuyTaskInfo.Email, task.BestbuyTaskInfo.Password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return bestbuyTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's BestbuyMonitorInfo and turns it into a Best Buy Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.BestbuyMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	bestbuyMonitor, err := CreateBestbuyMonitor(taskGroup, proxyGroup, eventBus, taskGroup.BestbuyMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return bestbuyMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	if len(inStock) > 0 {
+		task.StockData = inStock[rand.Intn(len(inStock))]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
@@ -2,6 +2,7 @@ package bestbuy
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -24,7 +25,7 @@ import (
 )
 
 // CreateBestbuyTask takes a Task entity and turns it into a Bestbuy Task
-func CreateBestbuyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, locationID, email, password string) (Task, error) {
+func CreateBestbuyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, locationID, email, password string) (*Task, error) {
 	bestbuyTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -40,24 +41,24 @@ func CreateBestbuyTask(task *entities.Task, profile entities.Profile, proxyGroup
 		LocationID: locationID,
 	}
 	if proxyGroup != nil {
-		bestbuyTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		bestbuyTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		bestbuyTask.Task.Proxy = nil
+		bestbuyTask.Task.SetProxy(nil)
 	}
-	return bestbuyTask, nil
+	return &bestbuyTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -73,25 +74,26 @@ func (task *Task) CheckForStop() bool {
 //		5. SetShippingInfo
 // 		6. SetPaymentInfo
 // 		7. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = BestbuyInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = BestbuyInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -100,7 +102,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -117,6 +119,7 @@ func (task *Task) RunTask() {
 			task.PublishEvent(enums.LoggingIn, enums.TaskStart, 10)
 			sessionMade = task.Login()
 			if sessionMade {
+				task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 				task.ClearCart()
 			}
 		case enums.TaskTypeGuest:
@@ -125,7 +128,7 @@ func (task *Task) RunTask() {
 		}
 
 		if !sessionMade {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -146,7 +149,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 	needToStop = task.CheckForStop()
@@ -166,7 +169,7 @@ func (task *Task) RunTask() {
 		}
 		gotCartInfo = task.Checkout()
 		if !gotCartInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -181,7 +184,7 @@ func (task *Task) RunTask() {
 		}
 		setShippingInfo = task.SetShippingInfo()
 		if !setShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -196,7 +199,7 @@ func (task *Task) RunTask() {
 		}
 		setPaymentInfo, doNotRetry = task.SetPaymentInfo()
 		if !setPaymentInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -216,7 +219,7 @@ func (task *Task) RunTask() {
 		placedOrder, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -243,7 +246,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -263,6 +266,7 @@ func (task *Task) RunTask() {
 func (task *Task) Login() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:     task.Task.Client,
+		Ctx:        task.Task.Ctx,
 		Method:     "GET",
 		URL:        BaseEndpoint,
 		RawHeaders: DefaultRawHeaders,
@@ -279,6 +283,7 @@ func (task *Task) Login() bool {
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    LoginPageEndpoint,
 		RawHeaders: [][2]string{
@@ -312,6 +317,7 @@ func (task *Task) Login() bool {
 	}
 	_, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(tmxURL, common.RandString(16), common.RandString(16), ZPLANK),
 	})
@@ -382,6 +388,7 @@ func (task *Task) Login() bool {
 	var loginResponse LoginResponse
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    LoginEndpoint,
 		RawHeaders: [][2]string{
@@ -405,12 +412,22 @@ func (task *Task) Login() bool {
 		ResponseBodyStruct: &loginResponse,
 	})
 	if err != nil || resp.StatusCode != 200 {
-		fmt.Println(err.Error())
+		if strings.Contains(strings.ToLower(loginResponse.Status), "locked") {
+			task.Task.SetAccountStatus(enums.AccountStatusLocked)
+			task.PublishEvent("Account is locked", enums.TaskFail, 0)
+		} else if loginResponse.Status == "failure" {
+			task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+			task.PublishEvent("Incorrect email or password", enums.TaskFail, 0)
+		}
+		if err != nil {
+			fmt.Println(err.Error())
+		}
 		return false
 	}
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    BaseEndpoint,
 		RawHeaders: [][2]string{
@@ -440,6 +457,7 @@ func (task *Task) ClearCart() bool {
 	var clearCartResponse ClearCartResponse
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "GET",
 		URL:                CartInfoEndpoint,
 		RawHeaders:         DefaultRawHeaders,
@@ -453,6 +471,7 @@ func (task *Task) ClearCart() bool {
 	for _, lineItem := range clearCartResponse.Cart.Lineitems {
 		resp, _, err := util.MakeRequest(&util.Request{
 			Client:     task.Task.Client,
+			Ctx:        task.Task.Ctx,
 			Method:     "DELETE",
 			URL:        BaseEndpoint + fmt.Sprintf("/cart/item/%v", lineItem.ID),
 			RawHeaders: DefaultRawHeaders,
@@ -474,11 +493,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.SKU != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.SKU != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -518,6 +536,7 @@ func (task *Task) AddToCart() bool {
 
 		resp, _, err := util.MakeRequest(&util.Request{
 			Client: task.Task.Client,
+			Ctx:    task.Task.Ctx,
 			Method: "POST",
 			URL:    AddToCartEndpoint,
 			RawHeaders: [][2]string{
@@ -565,9 +584,9 @@ func (task *Task) AddToCart() bool {
 
 		case 500:
 			if task.TaskType == enums.TaskTypeGuest {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			} else {
-				time.Sleep(3 * time.Second)
+				task.Task.Sleep(3 * time.Second)
 			}
 		}
 
@@ -601,13 +620,13 @@ func (task *Task) HandleQueue(resp *http.Response, data []byte) (handled bool) {
 		fmt.Println("Joining Queue")
 		queueChan := make(chan bool)
 		go func() {
-			time.Sleep(time.Duration(times*60000) * time.Millisecond)
+			task.Task.Sleep(time.Duration(times*60000) * time.Millisecond)
 			queueChan <- true
 		}()
 		go func() {
 			for {
 				queueChan <- false
-				time.Sleep(common.MS_TO_WAIT)
+				task.Task.Sleep(common.MS_TO_WAIT)
 			}
 		}()
 		for {
@@ -625,6 +644,7 @@ func (task *Task) HandleQueue(resp *http.Response, data []byte) (handled bool) {
 		addToCartResponse := AddToCartResponse{}
 		resp, _, err = util.MakeRequest(&util.Request{
 			Client: task.Task.Client,
+			Ctx:    task.Task.Ctx,
 			Method: "POST",
 			URL:    AddToCartEndpoint,
 			RawHeaders: [][2]string{
@@ -677,9 +697,9 @@ func (task *Task) HandleQueue(resp *http.Response, data []byte) (handled bool) {
 		task.PublishEvent("Queued for "+fmt.Sprint(int(times))+" minutes, Retrying", enums.TaskUpdate, 35)
 		//	As a guest you do not ever get blocked adding to cart, but while logged in you will get blocked
 		if task.TaskType == enums.TaskTypeGuest {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		} else {
-			time.Sleep(3 * time.Second)
+			task.Task.Sleep(3 * time.Second)
 		}
 		return task.AddToCart()
 	}
@@ -690,6 +710,7 @@ func (task *Task) HandleQueue(resp *http.Response, data []byte) (handled bool) {
 func (task *Task) Checkout() bool {
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client:     task.Task.Client,
+		Ctx:        task.Task.Ctx,
 		Method:     "GET",
 		URL:        CheckoutEndpoint,
 		RawHeaders: DefaultRawHeaders,
@@ -783,6 +804,7 @@ func (task *Task) SetShippingInfo() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "PATCH",
 		URL:    fmt.Sprintf(OrderEndpoint, task.CheckoutInfo.ID) + "/items",
 		RawHeaders: [][2]string{
@@ -860,6 +882,7 @@ func (task *Task) SetShippingInfo() bool {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "PATCH",
 		URL:    fmt.Sprintf(OrderEndpoint, task.CheckoutInfo.ID) + "/",
 		RawHeaders: [][2]string{
@@ -910,6 +933,7 @@ func (task *Task) SetShippingInfo() bool {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(OrderEndpoint, task.CheckoutInfo.ID) + "/validate",
 		RawHeaders: [][2]string{
@@ -1015,6 +1039,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 	})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "PUT",
 		URL:    fmt.Sprintf(PaymentEndpoint, task.CheckoutInfo.PaymentID),
 		RawHeaders: [][2]string{
@@ -1061,6 +1086,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(RefreshPaymentEndpoint, task.CheckoutInfo.ID),
 		RawHeaders: [][2]string{
@@ -1115,6 +1141,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 	prelookupResonse := PrelookupResponse{}
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(PrelookupEndpoint, task.CheckoutInfo.PaymentID),
 		RawHeaders: [][2]string{
@@ -1182,6 +1209,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PlaceOrderEndpoint,
 		RawHeaders: [][2]string{
@@ -1226,6 +1254,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 	placeOrderResponse := UniversalOrderResponse{}
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(OrderEndpoint, task.CheckoutInfo.ID) + "/",
 		RawHeaders: [][2]string{
@@ -0,0 +1,92 @@
+package boxlunch
+
+import (
+	e "errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+	"backend.juicedbot.io/juiced.sitescripts/util"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the BoxLunch sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.BoxLunch
+}
+
+// RequiresProfile returns true since BoxLunch tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask turns the Task entity into a BoxLunch Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	boxlunchTask, err := CreateBoxlunchTask(task, profile, proxyGroup, eventBus)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return boxlunchTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's BoxlunchMonitorInfo and turns it into a BoxLunch Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.BoxlunchMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	boxlunchMonitor, err := CreateBoxlunchMonitor(taskGroup, proxyGroup, eventBus, taskGroup.BoxlunchMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return boxlunchMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list in one of the task's sizes,
+// or leaves it waiting if none of them are in stock and its size fallback doesn't allow other sizes
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	sizes := make([]string, len(inStock))
+	for i, stockData := range inStock {
+		sizes[i] = stockData.Size
+	}
+	if i, ok := util.SelectSize(task.Task.Task.TaskSize, sizes, task.Task.Task.TaskSizeFallback); ok {
+		task.StockData = inStock[i]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
@@ -1,6 +1,7 @@
 package boxlunch
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -16,7 +17,7 @@ import (
 )
 
 // CreateBoxlunch takes a Task entity and turns it into a Boxlunch Task
-func CreateBoxlunchTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (Task, error) {
+func CreateBoxlunchTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (*Task, error) {
 	boxLunchTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -26,24 +27,24 @@ func CreateBoxlunchTask(task *entities.Task, profile entities.Profile, proxyGrou
 		},
 	}
 	if proxyGroup != nil {
-		boxLunchTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		boxLunchTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		boxLunchTask.Task.Proxy = nil
+		boxLunchTask.Task.SetProxy(nil)
 	}
-	return boxLunchTask, nil
+	return &boxLunchTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -51,24 +52,25 @@ func (task *Task) CheckForStop() bool {
 }
 
 // Start task
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = BoxlunchInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = BoxlunchInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -77,7 +79,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -99,7 +101,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -115,7 +117,7 @@ func (task *Task) RunTask() {
 		}
 		gotCheckout = task.GetCheckout()
 		if !gotCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -129,7 +131,7 @@ func (task *Task) RunTask() {
 		}
 		proceededToCheckout = task.ProceedToCheckout()
 		if !proceededToCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -143,7 +145,7 @@ func (task *Task) RunTask() {
 		}
 		gotGuestCheckout = task.GuestCheckout()
 		if !gotGuestCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -157,7 +159,7 @@ func (task *Task) RunTask() {
 		}
 		submittedShipping = task.SubmitShipping()
 		if !submittedShipping {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -171,7 +173,7 @@ func (task *Task) RunTask() {
 		}
 		usedOrigAddress = task.UseOrigAddress()
 		if !usedOrigAddress {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -186,7 +188,7 @@ func (task *Task) RunTask() {
 		}
 		submittedPayment, doNotRetry = task.SubmitPaymentInfo()
 		if !submittedPayment {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -206,7 +208,7 @@ func (task *Task) RunTask() {
 		submittedOrder, status = task.SubmitOrder()
 		if !submittedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -223,7 +225,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      submittedOrder,
 		Status:       status,
 		Content:      "",
@@ -247,11 +249,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.PID != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.PID != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -283,6 +284,7 @@ func (task *Task) AddToCart() bool {
 
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                AddToCartEndpoint,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -296,6 +298,7 @@ func (task *Task) AddToCart() bool {
 func (task *Task) GetCheckout() bool {
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "GET",
 		URL:                GetCheckoutEndpoint,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -316,6 +319,7 @@ func (task *Task) ProceedToCheckout() bool {
 
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                ProceedToCheckoutEndpoint + task.Dwcont,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -344,6 +348,7 @@ func (task *Task) GuestCheckout() bool {
 
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                GuestCheckoutEndpoint + task.Dwcont,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -387,6 +392,7 @@ func (task *Task) SubmitShipping() bool {
 	}
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                SubmitShippingEndpoint + task.Dwcont,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -410,6 +416,7 @@ func (task *Task) UseOrigAddress() bool {
 	}
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                UseOrigAddressEndpoint + task.Dwcont,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -469,6 +476,7 @@ func (task *Task) SubmitPaymentInfo() (bool, bool) {
 	}
 	_, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                SubmitPaymentInfoEndpoint + task.Dwcont,
 		AddHeadersFunction: AddBoxlunchHeaders,
@@ -488,6 +496,7 @@ func (task *Task) SubmitOrder() (bool, enums.OrderStatus) {
 	}
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                SubmitOrderEndpoint,
 		AddHeadersFunction: AddBoxlunchHeaders,
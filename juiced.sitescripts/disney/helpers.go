@@ -97,10 +97,10 @@ func (task *Task) CreateDisneyEmbed(status enums.OrderStatus, imageURL string) [
 		},
 	}
 
-	if task.Task.Proxy != nil {
+	if task.Task.Proxy() != nil {
 		fields = append(fields, sec.DiscordField{
 			Name:  "Proxy:",
-			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy) + " " + "||",
+			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy()) + " " + "||",
 		})
 	}
 
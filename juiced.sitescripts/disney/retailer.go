@@ -0,0 +1,96 @@
+package disney
+
+import (
+	e "errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+	"backend.juicedbot.io/juiced.sitescripts/util"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Disney sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.Disney
+}
+
+// RequiresProfile returns true since Disney tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's DisneyTaskInfo and turns it into a Disney Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.DisneyTaskInfo.TaskType == "" || (task.DisneyTaskInfo.TaskType == enums.TaskTypeAccount && (task.DisneyTaskInfo.Email == "" || task.DisneyTaskInfo.Password == "")) {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+	disneyTask, err := CreateDisneyTask(task, profile, proxyGroup, eventBus, task.DisneyTaskInfo.TaskType, task.DisneyTaskInfo.Email, task.DisneyTaskInfo.Password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return disneyTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's DisneyMonitorInfo and turns it into a Disney Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.DisneyMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	disneyMonitor, err := CreateDisneyMonitor(taskGroup, proxyGroup, eventBus, taskGroup.DisneyMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return disneyMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list in one of the task's sizes,
+// or leaves it waiting if none of them are in stock and its size fallback doesn't allow other sizes
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	sizes := make([]string, len(inStock))
+	for i, stockData := range inStock {
+		sizes[i] = stockData.Size
+	}
+	if i, ok := util.SelectSize(task.Task.Task.TaskSize, sizes, task.Task.Task.TaskSizeFallback); ok {
+		task.StockData = inStock[i]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
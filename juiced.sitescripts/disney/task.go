@@ -1,6 +1,7 @@
 package disney
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -21,7 +22,7 @@ import (
 )
 
 // CreateDisneyTask takes a Task entity and turns it into a Disney Task
-func CreateDisneyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (Task, error) {
+func CreateDisneyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (*Task, error) {
 	disneyTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -36,24 +37,24 @@ func CreateDisneyTask(task *entities.Task, profile entities.Profile, proxyGroup
 		},
 	}
 	if proxyGroup != nil {
-		disneyTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		disneyTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		disneyTask.Task.Proxy = nil
+		disneyTask.Task.SetProxy(nil)
 	}
-	return disneyTask, nil
+	return &disneyTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -72,25 +73,26 @@ func (task *Task) CheckForStop() bool {
 // 		8. GetPaysheetAE
 // 		9. GetCardToken
 // 		10. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = DisneyInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = DisneyInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -99,7 +101,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -113,20 +115,23 @@ func (task *Task) RunTask() {
 		}
 		switch task.TaskType {
 		case enums.TaskTypeAccount:
-			if task.Task.Task.TaskStatus != enums.LoggingIn {
+			if task.Task.Task.GetTaskStatus() != enums.LoggingIn {
 				task.PublishEvent(enums.LoggingIn, enums.TaskStart, 10)
 			}
 			sessionMade = task.Login()
+			if sessionMade {
+				task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
+			}
 
 		case enums.TaskTypeGuest:
-			if task.Task.Task.TaskStatus != enums.SettingUp {
+			if task.Task.Task.GetTaskStatus() != enums.SettingUp {
 				task.PublishEvent(enums.SettingUp, enums.TaskStart, 10)
 			}
 			sessionMade = BecomeGuest(&task.Task.Client)
 		}
 
 		if !sessionMade {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -141,7 +146,7 @@ func (task *Task) RunTask() {
 			newAbck = true
 		}
 		if !newAbck {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -162,7 +167,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -178,7 +183,7 @@ func (task *Task) RunTask() {
 		}
 		gotCheckoutInfo = task.GetCheckoutInfo()
 		if !gotCheckoutInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -192,7 +197,7 @@ func (task *Task) RunTask() {
 		}
 		validatedCheckout = task.ValidateCheckout()
 		if !validatedCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -206,7 +211,7 @@ func (task *Task) RunTask() {
 		}
 		submittedShippingInfo = task.SubmitShippingInfo()
 		if !submittedShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -220,7 +225,7 @@ func (task *Task) RunTask() {
 		}
 		establishedAppSession = task.EstablishAppSession()
 		if !establishedAppSession {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -234,7 +239,7 @@ func (task *Task) RunTask() {
 		}
 		gotPaymentAE = task.GetPaysheetAE()
 		if !gotPaymentAE {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -248,7 +253,7 @@ func (task *Task) RunTask() {
 		}
 		gotCardToken = task.GetCardToken()
 		if !gotCardToken {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -269,7 +274,7 @@ func (task *Task) RunTask() {
 		placedOrder, doNotRetry, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -289,7 +294,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -308,6 +313,7 @@ func (task *Task) RunTask() {
 func (task *Task) Login() bool {
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    BaseEndpoint,
 		RawHeaders: http.RawHeader{
@@ -351,6 +357,7 @@ func (task *Task) Login() bool {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "OPTIONS",
 		URL:    "https://registerdisney.go.com/jgc/v6/client/DCP-DISNEYSTORE.WEB-PROD/api-key?langPref=en-US",
 		RawHeaders: http.RawHeader{
@@ -377,6 +384,7 @@ func (task *Task) Login() bool {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    "https://registerdisney.go.com/jgc/v6/client/DCP-DISNEYSTORE.WEB-PROD/api-key?langPref=en-US",
 		RawHeaders: http.RawHeader{
@@ -415,20 +423,19 @@ func (task *Task) Login() bool {
 	}
 
 	proxy := entities.Proxy{}
-	if task.Task.Proxy != nil {
-		proxy = *task.Task.Proxy
+	if task.Task.Proxy() != nil {
+		proxy = *task.Task.Proxy()
 	}
 	token, err := captcha.RequestCaptchaToken(enums.ReCaptchaV3, enums.Disney, BaseEndpoint+"/", "login", 0.9, proxy)
 	if err != nil {
 		return false
 	}
-	for token == nil {
-		needToStop := task.CheckForStop()
-		if needToStop {
+	if token == nil {
+		token, err = captcha.WaitForCaptchaToken(task.Task.Ctx, enums.ReCaptchaV3, enums.Disney, BaseEndpoint+"/", proxy)
+		if err != nil {
+			task.CheckForStop()
 			return false
 		}
-		token = captcha.PollCaptchaTokens(enums.ReCaptchaV3, enums.Disney, BaseEndpoint+"/", proxy)
-		time.Sleep(1 * time.Second / 10)
 	}
 	tokenInfo, ok := token.(entities.ReCaptchaToken)
 	if !ok {
@@ -440,8 +447,9 @@ func (task *Task) Login() bool {
 		return false
 	}
 	loginResponse := LoginResponse{}
-	resp, _, err = util.MakeRequest(&util.Request{
+	resp, body, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    FirstLoginEndpoint,
 		RawHeaders: http.RawHeader{
@@ -474,6 +482,16 @@ func (task *Task) Login() bool {
 		fmt.Println(err)
 		return false
 	}
+	if loginResponse.Error != nil {
+		if strings.Contains(body, "LOCKED_OUT") {
+			task.Task.SetAccountStatus(enums.AccountStatusLocked)
+			task.PublishEvent("Account is locked", enums.TaskFail, 0)
+		} else if strings.Contains(body, "AUTHORIZATION_CREDENTIALS") {
+			task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+			task.PublishEvent("Incorrect email or password", enums.TaskFail, 0)
+		}
+		return false
+	}
 
 	apiKey2 := resp.Header.Get("api-key")
 	if apiKey2 == "" {
@@ -530,6 +548,7 @@ func (task *Task) Login() bool {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SecondLoginEndpoint,
 		RawHeaders: http.RawHeader{
@@ -569,11 +588,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.PID != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.PID != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -597,6 +615,7 @@ func (task *Task) AddToCart() bool {
 	addToCartResponse := AddToCartResponse{}
 	_, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    AddToCartEndpoint,
 		RawHeaders: http.RawHeader{
@@ -630,6 +649,7 @@ func (task *Task) GetCheckoutInfo() bool {
 	getCheckoutInfoResponse := GetCheckoutInfoResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    GetCheckoutInfoEndpoint,
 		RawHeaders: http.RawHeader{
@@ -661,6 +681,7 @@ func (task *Task) GetCheckoutInfo() bool {
 func (task *Task) ValidateCheckout() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    ValidateCheckoutEndpoint,
 		RawHeaders: http.RawHeader{
@@ -708,6 +729,7 @@ func (task *Task) SubmitShippingInfo() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitShippingInfoEndpoint,
 		RawHeaders: http.RawHeader{
@@ -740,6 +762,7 @@ func (task *Task) EstablishAppSession() bool {
 	establishAppSessionResponse := EstablishAppSessionResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    EstablishAppSessionEndpoint,
 		RawHeaders: http.RawHeader{
@@ -770,6 +793,7 @@ func (task *Task) EstablishAppSession() bool {
 func (task *Task) GetPaysheetAE() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(GetPaysheetAEEndpoint, task.PaymentData.Config.Session),
 		RawHeaders: http.RawHeader{
@@ -809,6 +833,7 @@ func (task *Task) GetCardToken() bool {
 	getCardTokenResponse := GetCardTokenResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    GetCardTokenEndpoint,
 		RawHeaders: http.RawHeader{
@@ -902,6 +927,7 @@ func (task *Task) PlaceOrder() (bool, bool, enums.OrderStatus) {
 	placeOrderResponse := PlaceOrderResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(PlaceOrderEndpoint, task.PaymentData.Config.Session),
 		RawHeaders: http.RawHeader{
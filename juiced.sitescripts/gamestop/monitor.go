@@ -1,6 +1,7 @@
 package gamestop
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -16,7 +17,7 @@ import (
 )
 
 // CreateGamestopMonitor takes a TaskGroup entity and turns it into a Gamestop Monitor
-func CreateGamestopMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.GamestopSingleMonitorInfo) (Monitor, error) {
+func CreateGamestopMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.GamestopSingleMonitorInfo) (*Monitor, error) {
 	storedGamestopMonitors := make(map[string]entities.GamestopSingleMonitorInfo)
 	gamestopMonitor := Monitor{}
 	skus := []string{}
@@ -37,34 +38,36 @@ func CreateGamestopMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.P
 		SKUWithInfo: storedGamestopMonitors,
 	}
 
-	return gamestopMonitor, nil
+	return &gamestopMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
 	return false
 }
 
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 
@@ -90,7 +93,7 @@ func (monitor *Monitor) RunMonitor() {
 			}
 			becameGuest = BecomeGuest(&monitor.Monitor.Client)
 			if !becameGuest {
-				time.Sleep(1000 * time.Millisecond)
+				monitor.Monitor.Sleep(1000 * time.Millisecond)
 			}
 		}
 	}
@@ -120,7 +123,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(sku)
 		}
 	}()
@@ -140,6 +143,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -151,31 +155,34 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 					{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(sku)
 }
 
@@ -185,6 +192,7 @@ func (monitor *Monitor) GetSKUStock(sku string) GamestopInStockData {
 	monitorResponse := MonitorResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(MonitorEndpoint, sku),
 		RawHeaders: [][2]string{
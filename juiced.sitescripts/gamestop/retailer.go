@@ -0,0 +1,91 @@
+package gamestop
+
+import (
+	e "errors"
+	"math/rand"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the GameStop sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.GameStop
+}
+
+// RequiresProfile returns true since GameStop tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's GamestopTaskInfo and turns it into a GameStop Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.GamestopTaskInfo.TaskType == "" || (task.GamestopTaskInfo.TaskType == enums.TaskTypeAccount && (task.GamestopTaskInfo.Email == "" || task.GamestopTaskInfo.Password == "")) {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+	gamestopTask, err := CreateGamestopTask(task, profile, proxyGroup, eventBus, task.GamestopTaskInfo.TaskType, task.GamestopTaskInfo.Email, task.GamestopTaskInfo.Password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return gamestopTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's GamestopMonitorInfo and turns it into a GameStop Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.GamestopMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	gamestopMonitor, err := CreateGamestopMonitor(taskGroup, proxyGroup, eventBus, taskGroup.GamestopMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return gamestopMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	if len(inStock) > 0 {
+		task.StockData = inStock[rand.Intn(len(inStock))]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
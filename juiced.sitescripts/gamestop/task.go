@@ -1,6 +1,7 @@
 package gamestop
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -19,7 +20,7 @@ import (
 )
 
 // CreateGamestopTask takes a Task entity and turns it into a Gamestop Task
-func CreateGamestopTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (Task, error) {
+func CreateGamestopTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (*Task, error) {
 	gamestopTask := Task{}
 
 	gamestopTask = Task{
@@ -36,24 +37,24 @@ func CreateGamestopTask(task *entities.Task, profile entities.Profile, proxyGrou
 		TaskType: taskType,
 	}
 	if proxyGroup != nil {
-		gamestopTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		gamestopTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		gamestopTask.Task.Proxy = nil
+		gamestopTask.Task.SetProxy(nil)
 	}
-	return gamestopTask, nil
+	return &gamestopTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -69,25 +70,26 @@ func (task *Task) CheckForStop() bool {
 //		5. SetShippingInfo
 // 		6. SetPaymentInfo
 // 		7. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = GamestopInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = GamestopInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -96,7 +98,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -118,7 +120,7 @@ func (task *Task) RunTask() {
 		}
 
 		if !sessionMade {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -139,7 +141,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -154,7 +156,7 @@ func (task *Task) RunTask() {
 		}
 		gotCartInfo = task.Checkout()
 		if !gotCartInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -168,7 +170,7 @@ func (task *Task) RunTask() {
 		}
 		setShippingInfo = task.SetShippingInfo()
 		if !setShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -183,7 +185,7 @@ func (task *Task) RunTask() {
 		}
 		setPaymentInfo, doNotRetry = task.SetPaymentInfo()
 		if !setPaymentInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -204,7 +206,7 @@ func (task *Task) RunTask() {
 		placedOrder, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -229,7 +231,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -249,6 +251,7 @@ func (task *Task) RunTask() {
 func (task *Task) Login() bool {
 	_, body, err := util.MakeRequest(&util.Request{
 		Client:     task.Task.Client,
+		Ctx:        task.Task.Ctx,
 		Method:     "GET",
 		URL:        BaseLoginEndpoint,
 		RawHeaders: DefaultRawHeaders,
@@ -276,6 +279,7 @@ func (task *Task) Login() bool {
 	}
 	_, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    LoginEndpoint,
 		RawHeaders: [][2]string{
@@ -304,10 +308,14 @@ func (task *Task) Login() bool {
 	}
 
 	if !loginResponse.Loginstatus.Success {
+		if loginResponse.Loginstatus.Accountlocked {
+			task.Task.SetAccountStatus(enums.AccountStatusLocked)
+		}
 		return false
 	}
 	_, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    AccountEndpoint + "/",
 		RawHeaders: [][2]string{
@@ -331,6 +339,7 @@ func (task *Task) Login() bool {
 		fmt.Println(err.Error())
 	}
 
+	task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 	return loginResponse.Loginstatus.Success
 }
 
@@ -342,11 +351,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.SKU != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.SKU != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -373,6 +381,7 @@ func (task *Task) AddToCart(token ...string) bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    fmt.Sprintf(AddToCartEndpoint, task.StockData.PID),
 		RawHeaders: [][2]string{
@@ -407,20 +416,19 @@ func (task *Task) AddToCart(token ...string) bool {
 				task.PublishEvent(enums.WaitingForCaptcha, enums.TaskUpdate, 30)
 				task.CheckoutInfo.CaptchaProtected = true
 				proxy := entities.Proxy{}
-				if task.Task.Proxy != nil {
-					proxy = *task.Task.Proxy
+				if task.Task.Proxy() != nil {
+					proxy = *task.Task.Proxy()
 				}
 				token, err := captcha.RequestCaptchaToken(enums.ReCaptchaV2, enums.GameStop, task.StockData.ProductURL, "atc", 0.8, proxy)
 				if err != nil {
 					return false
 				}
-				for token == nil {
-					needToStop := task.CheckForStop()
-					if needToStop {
+				if token == nil {
+					token, err = captcha.WaitForCaptchaToken(task.Task.Ctx, enums.ReCaptchaV2, enums.GameStop, task.StockData.ProductURL, proxy)
+					if err != nil {
+						task.CheckForStop()
 						return false
 					}
-					token = captcha.PollCaptchaTokens(enums.ReCaptchaV2, enums.GameStop, task.StockData.ProductURL, proxy)
-					time.Sleep(1 * time.Second / 10)
 				}
 				tokenInfo, ok := token.(entities.ReCaptchaToken)
 				if !ok {
@@ -443,6 +451,7 @@ func (task *Task) AddToCart(token ...string) bool {
 func (task *Task) Checkout() bool {
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    CheckoutEndpoint + "/",
 		RawHeaders: [][2]string{
@@ -502,6 +511,7 @@ func (task *Task) SetShippingInfo() bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    ShippingEndpoint,
 		RawHeaders: [][2]string{
@@ -585,6 +595,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PaymentEndpoint,
 		RawHeaders: [][2]string{
@@ -629,20 +640,19 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 	if task.CheckoutInfo.CaptchaProtected {
 		task.PublishEvent(enums.WaitingForCaptcha, enums.TaskUpdate, 90)
 		proxy := entities.Proxy{}
-		if task.Task.Proxy != nil {
-			proxy = *task.Task.Proxy
+		if task.Task.Proxy() != nil {
+			proxy = *task.Task.Proxy()
 		}
 		token, err := captcha.RequestCaptchaToken(enums.ReCaptchaV2, enums.GameStop, CheckoutEndpoint+"/", "checkout", 0.8, proxy)
 		if err != nil {
 			return false, status
 		}
-		for token == nil {
-			needToStop := task.CheckForStop()
-			if needToStop {
+		if token == nil {
+			token, err = captcha.WaitForCaptchaToken(task.Task.Ctx, enums.ReCaptchaV2, enums.GameStop, CheckoutEndpoint+"/", proxy)
+			if err != nil {
+				task.CheckForStop()
 				return false, status
 			}
-			token = captcha.PollCaptchaTokens(enums.ReCaptchaV2, enums.GameStop, CheckoutEndpoint+"/", proxy)
-			time.Sleep(1 * time.Second / 10)
 		}
 		tokenInfo, ok := token.(entities.ReCaptchaToken)
 		if !ok {
@@ -654,6 +664,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PlaceOrderEndpoint,
 		RawHeaders: [][2]string{
@@ -1,6 +1,7 @@
 package hottopic
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -17,7 +18,7 @@ import (
 )
 
 // CreateHottopicMonitor takes a TaskGroup entity and turns it into a Hottopic Monitor
-func CreateHottopicMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.HottopicSingleMonitorInfo) (Monitor, error) {
+func CreateHottopicMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.HottopicSingleMonitorInfo) (*Monitor, error) {
 	storedHottopicMonitors := make(map[string]entities.HottopicSingleMonitorInfo)
 	hottopicMonitor := Monitor{}
 
@@ -37,33 +38,35 @@ func CreateHottopicMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.P
 		PidWithInfo: storedHottopicMonitors,
 	}
 
-	return hottopicMonitor, nil
+	return &hottopicMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
 	return false
 }
 
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 	needToStop := monitor.CheckForStop()
@@ -97,7 +100,7 @@ func (monitor *Monitor) RunSingleMonitor(pid string) {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(pid)
 		}
 	}()
@@ -249,7 +252,9 @@ func (monitor *Monitor) RunSingleMonitor(pid string) {
 								stockData.ImageURL = imageURL
 							}
 							// Add each in stock combination to the monitor's InStock list, then update the status
+							monitor.Monitor.StockMutex.Lock()
 							monitor.InStock = append(monitor.InStock, stockData)
+							monitor.Monitor.StockMutex.Unlock()
 							atLeastOneInPriceRange = true
 						}
 					}
@@ -287,6 +292,7 @@ func (monitor *Monitor) RunSingleMonitor(pid string) {
 		} else {
 			// This code is only run for items that have no size/color variations
 			if stockData.PID != "" && !stockData.OutOfPriceRange {
+				monitor.Monitor.StockMutex.Lock()
 				var inSlice bool
 				for _, monitorStock := range monitor.InStock {
 					inSlice = monitorStock.PID == stockData.PID
@@ -298,10 +304,11 @@ func (monitor *Monitor) RunSingleMonitor(pid string) {
 							{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 					})
 				}
+				monitor.Monitor.StockMutex.Unlock()
 			} else {
-				if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock &&
-					monitor.Monitor.TaskGroup.MonitorStatus != enums.UnableToFindProduct &&
-					monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+				if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock &&
+					monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.UnableToFindProduct &&
+					monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 					if !stockData.OutOfPriceRange {
 						if stockData.ProductName != "" && stockData.ImageURL != "" {
 							monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
@@ -316,17 +323,19 @@ func (monitor *Monitor) RunSingleMonitor(pid string) {
 						})
 					}
 				}
+				monitor.Monitor.StockMutex.Lock()
 				for i, monitorStock := range monitor.InStock {
 					if monitorStock.PID == stockData.PID {
 						monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 						break
 					}
 				}
+				monitor.Monitor.StockMutex.Unlock()
 			}
 		}
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(pid)
 }
 
@@ -338,6 +347,7 @@ func (monitor *Monitor) GetSizeAndColor(pid string) ([]HottopicSizeInfo, []strin
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    endpoint,
 		RawHeaders: [][2]string{
@@ -540,6 +550,7 @@ func (monitor *Monitor) GetInStockSizesForColor(pid string, sizes []HottopicSize
 			endpoint := fmt.Sprintf(MonitorEndpoint2, pid, pid, color, pid, s)
 			resp, body, err := util.MakeRequest(&util.Request{
 				Client: monitor.Monitor.Client,
+				Ctx:    monitor.Monitor.Ctx,
 				Method: "GET",
 				URL:    endpoint,
 				RawHeaders: [][2]string{
@@ -1,6 +1,7 @@
 package newegg
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -15,7 +16,7 @@ import (
 )
 
 // CreateNeweggMonitor takes a TaskGroup entity and turns it into a Newegg Monitor
-func CreateNeweggMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.NeweggSingleMonitorInfo) (Monitor, error) {
+func CreateNeweggMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.NeweggSingleMonitorInfo) (*Monitor, error) {
 	storedNeweggMonitors := make(map[string]entities.NeweggSingleMonitorInfo)
 	neweggMonitor := Monitor{}
 	skus := []string{}
@@ -36,18 +37,18 @@ func CreateNeweggMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pro
 		SKUWithInfo: storedNeweggMonitors,
 	}
 
-	return neweggMonitor, nil
+	return &neweggMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
@@ -55,16 +56,18 @@ func (monitor *Monitor) CheckForStop() bool {
 }
 
 // So theres a few different ways we can make the monitoring groups for Amazon, for now I'm going to make it so it runs a goroutine for each ASIN
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			monitor.PublishEvent(fmt.Sprintf(enums.MonitorFailed, r), enums.MonitorFail, 0)
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 
@@ -90,7 +93,7 @@ func (monitor *Monitor) RunMonitor() {
 			}
 			becameGuest = BecomeGuest(monitor.Monitor.Client)
 			if !becameGuest {
-				time.Sleep(1000 * time.Millisecond)
+				monitor.Monitor.Sleep(1000 * time.Millisecond)
 			}
 		}
 	}
@@ -119,7 +122,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(sku)
 		}
 	}()
@@ -131,6 +134,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -142,31 +146,34 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 					{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(sku)
 }
 
@@ -175,6 +182,7 @@ func (monitor *Monitor) GetSKUStock(sku string) NeweggInStockData {
 	var monitorResponse MonitorResponse
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(MonitorEndpoint, sku),
 		RawHeaders: http.RawHeader{
@@ -1,6 +1,7 @@
 package newegg
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -21,7 +22,7 @@ import (
 )
 
 // CreateNeweggTask takes a Task entity and turns it into a Newegg Task
-func CreateNeweggTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (Task, error) {
+func CreateNeweggTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (*Task, error) {
 	neweggTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -31,24 +32,24 @@ func CreateNeweggTask(task *entities.Task, profile entities.Profile, proxyGroup
 		},
 	}
 	if proxyGroup != nil {
-		neweggTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		neweggTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		neweggTask.Task.Proxy = nil
+		neweggTask.Task.SetProxy(nil)
 	}
-	return neweggTask, nil
+	return &neweggTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -69,25 +70,26 @@ func (task *Task) CheckForStop() bool {
 //		10. InitOrder
 //		11. PlaceOrder
 //		12. Verify
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = NeweggInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = NeweggInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -96,7 +98,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -112,7 +114,7 @@ retry:
 		}
 		becameGuest = BecomeGuest(task.Task.Client)
 		if !becameGuest {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -126,7 +128,7 @@ retry:
 		retry := false
 		verifiedCookie, retry = task.VerifyCookie()
 		if !verifiedCookie {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 		if retry {
 			goto retry
@@ -150,7 +152,7 @@ retry:
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -167,7 +169,7 @@ retry:
 		retry := false
 		preparedCheckout, retry = task.PrepareCheckout()
 		if !preparedCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 		if retry {
 			task.PublishEvent("Bad cookie. Retrying", enums.TaskUpdate, 50)
@@ -185,7 +187,7 @@ retry:
 		}
 		gotCheckout = task.Checkout()
 		if !gotCheckout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -199,7 +201,7 @@ retry:
 		}
 		submittedShipping = task.SubmitShippingInfo()
 		if !submittedShipping {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -213,7 +215,7 @@ retry:
 		}
 		gotPaymentToken = task.GetPaymentToken()
 		if !gotPaymentToken {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -226,7 +228,7 @@ retry:
 		}
 		submittedPayment = task.SubmitPaymentInfo()
 		if !submittedPayment {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -241,7 +243,7 @@ retry:
 
 		initiatedOrder = task.InitOrder()
 		if !initiatedOrder {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -261,7 +263,7 @@ retry:
 		submittedOrder, status = task.PlaceOrder()
 		if !submittedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -286,7 +288,7 @@ retry:
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      submittedOrder,
 		Status:       status,
 		Content:      "",
@@ -325,6 +327,7 @@ func (task *Task) VerifyCookie() (bool, bool) {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PrepareCheckoutEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -365,11 +368,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.ItemNumber != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.ItemNumber != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -401,6 +403,7 @@ func (task *Task) AddToCart() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    AddToCartEndpoint,
 		RawHeaders: http.RawHeader{
@@ -459,6 +462,7 @@ func (task *Task) PrepareCheckout() (bool, bool) {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PrepareCheckoutEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -526,6 +530,7 @@ func (task *Task) PrepareCheckout() (bool, bool) {
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    AuthCheckoutEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -559,6 +564,7 @@ func (task *Task) PrepareCheckout() (bool, bool) {
 func (task *Task) Checkout() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(GuestCheckoutEndpoint, task.TaskInfo.SessionID),
 		RawHeaders: http.RawHeader{
@@ -615,6 +621,7 @@ func (task *Task) SubmitShippingInfo() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitShippingInfoEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -667,6 +674,7 @@ func (task *Task) GetPaymentToken() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    GetPaymentTokenEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -739,6 +747,7 @@ func (task *Task) SubmitPaymentInfo() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitPaymentInfoEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -796,6 +805,7 @@ func (task *Task) InitOrder() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    InitOrderEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -854,6 +864,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 	var placeOrderResponse PlaceOrderResponse
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    PlaceOrderEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -926,6 +937,7 @@ func (task *Task) VerifyPayment() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    VerifyPaymentEndpoint,
 		RawHeaders: http.RawHeader{
@@ -968,6 +980,7 @@ func (task *Task) VerifyOrder() bool {
 	respMap := make(map[string]interface{})
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    VerifyOrderEndpoint + "?" + params,
 		RawHeaders: http.RawHeader{
@@ -38,7 +38,7 @@ func AddPokemonCenterHeaders(request *http.Request, referer ...string) {
 	}
 }
 
-func SetDatadomeCookie(datadomeInfo DatadomeInfo, proxy *entities.Proxy, client *http.Client, cancellationToken *util.CancellationToken) error {
+func SetDatadomeCookie(ctx context.Context, datadomeInfo DatadomeInfo, proxy *entities.Proxy, client *http.Client) error {
 	params := common.CreateParams(map[string]string{
 		"initialCid": datadomeInfo.InitialCID,
 		"hash":       datadomeInfo.Hash,
@@ -55,12 +55,11 @@ func SetDatadomeCookie(datadomeInfo DatadomeInfo, proxy *entities.Proxy, client
 	if err != nil {
 		return err
 	}
-	for token == nil {
-		if cancellationToken.Cancel {
-			return nil
+	if token == nil {
+		token, err = captcha.WaitForCaptchaToken(ctx, enums.ReCaptchaV2, enums.PokemonCenter, DatadomeEndpoint+params, proxy_)
+		if err != nil {
+			return err
 		}
-		token = captcha.PollCaptchaTokens(enums.ReCaptchaV2, enums.PokemonCenter, DatadomeEndpoint+params, proxy_)
-		time.Sleep(1 * time.Second / 10)
 	}
 
 	tokenInfo, ok := token.(entities.ReCaptchaToken)
@@ -346,10 +345,10 @@ func (task *Task) CreatePokemonCenterEmbed(status enums.OrderStatus, imageURL st
 		},
 	}
 
-	if task.Task.Proxy != nil {
+	if task.Task.Proxy() != nil {
 		fields = append(fields, sec.DiscordField{
 			Name:  "Proxy:",
-			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy) + " " + "||",
+			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy()) + " " + "||",
 		})
 	}
 
@@ -403,23 +402,23 @@ func (task *Task) RunUntilSuccessful(fn func() (bool, string), maxRetries int) (
 		success, status = task.RunUntilSuccessfulHelper(fn, attempt)
 		needToStop := task.CheckForStop()
 		if needToStop {
-			if task.Task.Task.TaskStatus != "Idle" {
+			if task.Task.Task.GetTaskStatus() != "Idle" {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 			return false, ""
 		}
 		if attempt >= maxRetries {
-			if task.Task.Task.TaskStatus != "Idle" {
+			if task.Task.Task.GetTaskStatus() != "Idle" {
 				task.PublishEvent(fmt.Sprintf(enums.TaskFailed, status), enums.TaskFail, 0)
 			}
-			task.Task.StopFlag = true
+			task.Task.Stop()
 			return false, ""
 		}
 		if attempt >= 0 {
 			attempt++
 		}
 
-		time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+		task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 	}
 
 	return true, status
@@ -430,11 +429,11 @@ func (task *Task) RunUntilSuccessfulHelper(fn func() (bool, string), attempt int
 
 	if !success {
 		if attempt > 0 {
-			if status != "" && !task.Task.StopFlag && task.Task.Task.TaskStatus != "Idle" {
+			if status != "" && !task.Task.StopFlag() && task.Task.Task.GetTaskStatus() != "Idle" {
 				task.PublishEvent(fmt.Sprint(fmt.Sprintf("(Attempt #%d) ", attempt), status), enums.TaskUpdate, -1)
 			}
 		} else {
-			if status != "" && !task.Task.StopFlag && task.Task.Task.TaskStatus != "Idle" && task.Task.Task.TaskStatus != fmt.Sprint("(Retrying) ", status) {
+			if status != "" && !task.Task.StopFlag() && task.Task.Task.GetTaskStatus() != "Idle" && task.Task.Task.GetTaskStatus() != fmt.Sprint("(Retrying) ", status) {
 				task.PublishEvent(fmt.Sprint("(Retrying) ", status), enums.TaskUpdate, -1)
 			}
 		}
@@ -39,7 +39,9 @@ const (
 
 // Errors
 const (
-	UnknownError = "unknown error"
+	UnknownError        = "unknown error"
+	BadCredentialsError = "incorrect email or password"
+	AccountLockedError  = "account is locked"
 
 	RetrieveCyberSourcePublicKeyError    = "could not retrieve CyberSource public key"
 	CyberSourceEncryptionError           = "could not perform CyberSource encryption"
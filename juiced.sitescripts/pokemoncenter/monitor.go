@@ -1,6 +1,7 @@
 package pokemoncenter
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,7 +20,7 @@ import (
 )
 
 // CreatePokemonCenterMonitor takes a TaskGroup entity and turns it into a pokemoncenter Monitor
-func CreatePokemonCenterMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.PokemonCenterSingleMonitorInfo) (Monitor, error) {
+func CreatePokemonCenterMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.PokemonCenterSingleMonitorInfo) (*Monitor, error) {
 	storedPokemonCenterMonitors := make(map[string]entities.PokemonCenterSingleMonitorInfo)
 	skus := []string{}
 
@@ -28,7 +29,7 @@ func CreatePokemonCenterMonitor(taskGroup *entities.TaskGroup, proxyGroup *entit
 		skus = append(skus, monitor.SKU)
 	}
 
-	return Monitor{
+	return &Monitor{
 		Monitor: base.Monitor{
 			TaskGroup:  taskGroup,
 			ProxyGroup: proxyGroup,
@@ -43,12 +44,12 @@ func CreatePokemonCenterMonitor(taskGroup *entities.TaskGroup, proxyGroup *entit
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 //This checks if we want to stop
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
@@ -56,16 +57,18 @@ func (monitor *Monitor) CheckForStop() bool {
 }
 
 //This is responsible for starting the pokemoncenter Product monitor
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 
@@ -102,7 +105,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(sku)
 		}
 	}()
@@ -121,6 +124,7 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -132,31 +136,34 @@ func (monitor *Monitor) RunSingleMonitor(sku string) {
 					{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(sku)
 }
 
@@ -165,6 +172,7 @@ func (monitor *Monitor) GetSKUStock(sku string) PokemonCenterInStockData {
 	monitorResponse := MonitorResponse{}
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(MonitorEndpoint, sku),
 		RawHeaders: [][2]string{
@@ -232,30 +240,9 @@ func (monitor *Monitor) GetSKUStock(sku string) PokemonCenterInStockData {
 	}
 }
 
-func (monitor Monitor) HandleDatadome(body string) {
-	status := monitor.Monitor.TaskGroup.MonitorStatus
+func (monitor *Monitor) HandleDatadome(body string) {
+	status := monitor.Monitor.TaskGroup.GetMonitorStatus()
 	monitor.PublishEvent(enums.WaitingForCaptchaMonitor, enums.MonitorUpdate, nil)
-	quit := make(chan bool)
-	defer func() {
-		quit <- true
-	}()
-
-	cancellationToken := util.CancellationToken{Cancel: false}
-	go func() {
-		for {
-			select {
-			case <-quit:
-				return
-			default:
-				needToStop := monitor.CheckForStop()
-				if needToStop {
-					cancellationToken.Cancel = true
-					return
-				}
-			}
-			time.Sleep(common.MS_TO_WAIT)
-		}
-	}()
 
 	datadomeStr, err := util.FindInString(body, "<script>var dd=", "}")
 	if err != nil {
@@ -282,7 +269,7 @@ func (monitor Monitor) HandleDatadome(body string) {
 		return
 	}
 
-	err = SetDatadomeCookie(datadomeInfo, monitor.Monitor.Proxy, &monitor.Monitor.Client, &cancellationToken)
+	err = SetDatadomeCookie(monitor.Monitor.Ctx, datadomeInfo, monitor.Monitor.Proxy(), &monitor.Monitor.Client)
 	if err != nil {
 		log.Println(err.Error())
 		return
@@ -0,0 +1,91 @@
+package pokemoncenter
+
+import (
+	e "errors"
+	"math/rand"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Pokemon Center sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.PokemonCenter
+}
+
+// RequiresProfile returns true since Pokemon Center tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's PokemonCenterTaskInfo and turns it into a Pokemon Center Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.PokemonCenterTaskInfo.TaskType == "" || task.PokemonCenterTaskInfo.AddressType == "" || (task.PokemonCenterTaskInfo.TaskType == enums.TaskTypeAccount && (task.PokemonCenterTaskInfo.Email == "" || task.PokemonCenterTaskInfo.Password == "")) {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+	pokemonCenterTask, err := CreatePokemonCenterTask(task, profile, proxyGroup, eventBus, task.PokemonCenterTaskInfo.Email, task.PokemonCenterTaskInfo.Password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return pokemonCenterTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's PokemonCenterMonitorInfo and turns it into a Pokemon Center Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.PokemonCenterMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	pokemonCenterMonitor, err := CreatePokemonCenterMonitor(taskGroup, proxyGroup, eventBus, taskGroup.PokemonCenterMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return pokemonCenterMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock list
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	if len(inStock) > 0 {
+		task.StockData = inStock[rand.Intn(len(inStock))]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock items
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
@@ -2,6 +2,7 @@ package pokemoncenter
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,7 +20,7 @@ import (
 	"backend.juicedbot.io/juiced.sitescripts/util"
 )
 
-func CreatePokemonCenterTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, email, password string) (Task, error) {
+func CreatePokemonCenterTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, email, password string) (*Task, error) {
 	pokemonCenterTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -33,46 +34,47 @@ func CreatePokemonCenterTask(task *entities.Task, profile entities.Profile, prox
 		},
 	}
 	if proxyGroup != nil {
-		pokemonCenterTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		pokemonCenterTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		pokemonCenterTask.Task.Proxy = nil
+		pokemonCenterTask.Task.SetProxy(nil)
 	}
-	return pokemonCenterTask, nil
+	return &pokemonCenterTask, nil
 }
 
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
 	return false
 }
 
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = PokemonCenterInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = PokemonCenterInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -82,7 +84,7 @@ func (task *Task) RunTask() {
 	}
 
 	for {
-		time.Sleep(1 * time.Second)
+		task.Task.Sleep(1 * time.Second)
 	}
 
 	// 1. Login/LoginGuest
@@ -91,6 +93,7 @@ func (task *Task) RunTask() {
 		if success, _ := task.RunUntilSuccessful(task.Login, common.MAX_RETRIES); !success {
 			return
 		}
+		task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 	} else {
 		task.PublishEvent(enums.SettingUp, enums.TaskUpdate, 10)
 		if success, _ := task.RunUntilSuccessful(task.LoginGuest, common.MAX_RETRIES); !success {
@@ -160,7 +163,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      success,
 		Status:       status,
 		Embeds:       task.CreatePokemonCenterEmbed(status, task.StockData.ImageURL),
@@ -187,6 +190,7 @@ func (task *Task) Login() (bool, string) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    LoginEndpoint,
 		RawHeaders: [][2]string{
@@ -217,7 +221,11 @@ func (task *Task) Login() (bool, string) {
 		task.AccessToken = loginResponse.AccessToken
 		return true, enums.LoginSuccess
 	case 401:
-		return false, fmt.Sprintf(enums.LoginFailure, err.Error())
+		task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+		return false, fmt.Sprintf(enums.LoginFailure, BadCredentialsError)
+	case 423:
+		task.Task.SetAccountStatus(enums.AccountStatusLocked)
+		return false, fmt.Sprintf(enums.LoginFailure, AccountLockedError)
 	}
 
 	return false, fmt.Sprintf(enums.LoginFailure, UnknownError)
@@ -226,6 +234,7 @@ func (task *Task) Login() (bool, string) {
 func (task *Task) LoginGuest() (bool, string) {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    AuthKeyEndpoint,
 		RawHeaders: [][2]string{
@@ -284,7 +293,7 @@ func (task *Task) RefreshLogin() {
 			}
 			task.RefreshAt = time.Now().Unix() + 1800
 		}
-		time.Sleep(time.Millisecond * common.MS_TO_WAIT)
+		task.Task.Sleep(time.Millisecond * common.MS_TO_WAIT)
 	}
 }
 
@@ -329,6 +338,7 @@ func (task *Task) RetrievePublicKey() (bool, string) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    PublicPaymentKeyEndpoint,
 		RawHeaders: [][2]string{
@@ -365,6 +375,7 @@ func (task *Task) RetrievePublicKey() (bool, string) {
 func (task *Task) RetrieveToken() (bool, string) {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    CyberSourceTokenEndpoint,
 		RawHeaders: [][2]string{
@@ -409,11 +420,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.AddToCartForm != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.AddToCartForm != "" }) {
 			return false
 		}
-		time.Sleep(time.Millisecond * common.MS_TO_WAIT)
+		task.Task.Sleep(time.Millisecond * common.MS_TO_WAIT)
 	}
 }
 
@@ -432,6 +442,7 @@ func (task *Task) AddToCart() (bool, string) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    AddToCartEndpoint,
 		RawHeaders: [][2]string{
@@ -483,6 +494,7 @@ func (task *Task) SubmitEmailAddress() (bool, string) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitEmailEndpoint,
 		RawHeaders: [][2]string{
@@ -552,6 +564,7 @@ func (task *Task) SubmitAddressDetails() (bool, string) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitAddressEndpoint,
 		RawHeaders: [][2]string{
@@ -599,6 +612,7 @@ func (task *Task) SubmitPaymentDetails() (bool, string) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitPaymentDetailsEndpoint,
 		RawHeaders: [][2]string{
@@ -643,6 +657,7 @@ func (task *Task) Checkout() (bool, string) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    CheckoutEndpoint,
 		RawHeaders: [][2]string{
@@ -714,6 +729,7 @@ func (task *Task) SubmitAddressDetailsValidate() (bool, string) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SubmitAddressValidateEndpoint,
 		RawHeaders: [][2]string{
@@ -0,0 +1,6 @@
+// Package retailers imports every sitescript so that each one registers itself with the base package. The imports in
+// retailers.go are generated from the sitescript packages that call base.RegisterRetailer, so adding a retailer only takes
+// adding its package and running go generate in this directory.
+package retailers
+
+//go:generate go run gen.go
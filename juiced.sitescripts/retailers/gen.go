@@ -0,0 +1,75 @@
+//go:build ignore
+// +build ignore
+
+// gen.go writes retailers.go, which imports every sitescript package that registers itself with base.RegisterRetailer
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// registerCall matches a sitescript's call to base.RegisterRetailer
+var registerCall = regexp.MustCompile(`(?m)^\s*base\.RegisterRetailer\(`)
+
+func main() {
+	packages, err := retailerPackages("..")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage retailers\n\nimport (\n")
+	for _, name := range packages {
+		fmt.Fprintf(&source, "\t_ \"backend.juicedbot.io/juiced.sitescripts/%s\"\n", name)
+	}
+	source.WriteString(")\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile("retailers.go", formatted, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// retailerPackages returns the names of the directories in root with a Go file that calls base.RegisterRetailer, in order
+func retailerPackages(root string) ([]string, error) {
+	dirs, err := ioutil.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := []string{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		filenames, err := filepath.Glob(filepath.Join(root, dir.Name(), "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, filename := range filenames {
+			if strings.HasSuffix(filename, "_test.go") {
+				continue
+			}
+			source, err := ioutil.ReadFile(filename)
+			if err != nil {
+				return nil, err
+			}
+			if registerCall.Match(source) {
+				packages = append(packages, dir.Name())
+				break
+			}
+		}
+	}
+	return packages, nil
+}
@@ -0,0 +1,43 @@
+package retailers
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestEveryRetailerImported fails if a sitescript that registers itself with base.RegisterRetailer isn't imported by
+// retailers.go, which go generate fixes
+func TestEveryRetailerImported(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "retailers.go", nil, parser.ImportsOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := map[string]bool{}
+	for _, spec := range file.Imports {
+		imported[strings.Trim(spec.Path.Value, `"`)] = true
+	}
+
+	registerCall := regexp.MustCompile(`(?m)^\s*base\.RegisterRetailer\(`)
+	filenames, err := filepath.Glob(filepath.Join("..", "*", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		source, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := "backend.juicedbot.io/juiced.sitescripts/" + filepath.Base(filepath.Dir(filename))
+		if registerCall.Match(source) && !imported[path] {
+			t.Errorf("%s registers a retailer but retailers.go doesn't import it, run go generate", path)
+		}
+	}
+}
@@ -30,14 +30,16 @@ func (task *Task) HotWheelsLogin() {
 		}
 		loggedIn = task.HotWheelsLoginHelper()
 		if !loggedIn {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
+	task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 }
 
 func (task *Task) HotWheelsLoginHelper() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    task.Task.Task.ShopifyTaskInfo.SiteURL,
 		RawHeaders: http.RawHeader{
@@ -69,6 +71,7 @@ func (task *Task) HotWheelsLoginHelper() bool {
 	data, _ := json.Marshal(loginRequest)
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    HotWheelsLoginEndpoint,
 		RawHeaders: http.RawHeader{
@@ -91,15 +94,24 @@ func (task *Task) HotWheelsLoginHelper() bool {
 	})
 	if err != nil {
 		fmt.Println(err)
+		return false
 	}
 	switch resp.StatusCode {
 	case 307:
 		fmt.Println("Redirected")
-
+	case 401:
+		task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+		task.PublishEvent("Incorrect email or password", enums.TaskFail, 0)
+		return false
+	case 423:
+		task.Task.SetAccountStatus(enums.AccountStatusLocked)
+		task.PublishEvent("Account is locked", enums.TaskFail, 0)
+		return false
 	}
 
 	resp, _, err = util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    "https://login.platform.mattel/oauth/auth?" + query,
 		RawHeaders: http.RawHeader{
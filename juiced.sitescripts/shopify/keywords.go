@@ -0,0 +1,73 @@
+package shopify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.sitescripts/util"
+)
+
+// VariantSize returns the variant's value for the product's size option, or the variant's title if the product doesn't
+// have a size option
+func (product Products) VariantSize(variant Variants) string {
+	for _, option := range product.Options {
+		if strings.Contains(strings.ToLower(option.Name), "size") {
+			switch option.Position {
+			case 1:
+				return variant.Option1
+			case 2:
+				return variant.Option2
+			case 3:
+				return variant.Option3
+			}
+		}
+	}
+	if variant.Title == "Default Title" {
+		return ""
+	}
+	return variant.Title
+}
+
+// KeywordStock returns the available variants of the products whose titles match the keyword monitor's keywords,
+// that cost at most its MaxPrice (unless it's -1) and come in one of its sizes (if it has any)
+func KeywordStock(products []Products, monitorInfo entities.ShopifySingleMonitorInfo) []ShopifyInStockData {
+	positive, negative := util.ParseKeywords(monitorInfo.Keywords)
+	sizes := []string{}
+	if strings.TrimSpace(monitorInfo.Size) != "" {
+		sizes = strings.Split(monitorInfo.Size, ",")
+	}
+
+	inStock := []ShopifyInStockData{}
+	for _, product := range products {
+		if !util.MatchesKeywords(product.Title, positive, negative) {
+			continue
+		}
+		imageURL := ""
+		if len(product.Images) > 0 {
+			imageURL = product.Images[0].Src
+		}
+		for _, variant := range product.Variants {
+			if !variant.Available {
+				continue
+			}
+			price, err := strconv.ParseFloat(variant.Price, 64)
+			if err != nil || (monitorInfo.MaxPrice != -1 && price > float64(monitorInfo.MaxPrice)) {
+				continue
+			}
+			size := product.VariantSize(variant)
+			if !util.SizeMatches(sizes, size) {
+				continue
+			}
+			inStock = append(inStock, ShopifyInStockData{
+				VariantID: fmt.Sprint(variant.ID),
+				Size:      size,
+				Price:     price,
+				ItemName:  product.Title,
+				ImageURL:  imageURL,
+			})
+		}
+	}
+	return inStock
+}
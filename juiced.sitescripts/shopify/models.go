@@ -13,6 +13,7 @@ import (
 const (
 	ClearCartEndpoint     = "/cart/clear"
 	ProductsEndpoint      = "/products.json"
+	CatalogEndpoint       = "/products.json?limit=250"
 	SearchEndpoint        = "/search/suggest.json?q=%v&resources[type]=product"
 	AddToCartEndpoint     = "/cart/add.js"
 	CartEndpoint          = "/cart"
@@ -47,6 +48,7 @@ type Task struct {
 
 type ShopifyInStockData struct {
 	VariantID string
+	Size      string
 	Price     float64
 	ItemName  string
 	ImageURL  string
@@ -58,6 +60,7 @@ type Monitor struct {
 	SKUsSentToTask []string
 	OutOfStockSKUs []string
 	VIDs           []string
+	Keywords       []entities.ShopifySingleMonitorInfo
 	InStock        []ShopifyInStockData
 	SiteURL        string
 	SitePassword   string
@@ -87,11 +90,31 @@ type ProductsResponse struct {
 }
 
 type Products struct {
+	ID       int64      `json:"id"`
+	Title    string     `json:"title"`
+	Handle   string     `json:"handle"`
+	Options  []Options  `json:"options"`
 	Variants []Variants `json:"variants"`
+	Images   []Images   `json:"images"`
+}
+
+type Options struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
 }
 
 type Variants struct {
-	ID int64 `json:"id"`
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Option1   string `json:"option1"`
+	Option2   string `json:"option2"`
+	Option3   string `json:"option3"`
+	Price     string `json:"price"`
+	Available bool   `json:"available"`
+}
+
+type Images struct {
+	Src string `json:"src"`
 }
 
 type AddToCartResponse struct {
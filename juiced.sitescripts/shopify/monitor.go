@@ -1,6 +1,7 @@
 package shopify
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -15,12 +16,17 @@ import (
 )
 
 // CreateShopifyMonitor takes a TaskGroup entity and turns it into a Shopify Monitor
-func CreateShopifyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, siteURL, sitePassword string, singleMonitors []entities.ShopifySingleMonitorInfo) (Monitor, error) {
+func CreateShopifyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, siteURL, sitePassword string, singleMonitors []entities.ShopifySingleMonitorInfo) (*Monitor, error) {
 	storedShopifyMonitors := make(map[string]entities.ShopifySingleMonitorInfo)
 	shopifyMonitor := Monitor{}
 	vIDs := []string{}
+	keywords := []entities.ShopifySingleMonitorInfo{}
 
 	for _, monitor := range singleMonitors {
+		if monitor.MonitorType == enums.KeywordMonitor {
+			keywords = append(keywords, monitor)
+			continue
+		}
 		storedShopifyMonitors[monitor.VariantID] = monitor
 		vIDs = append(vIDs, monitor.VariantID)
 	}
@@ -34,37 +40,40 @@ func CreateShopifyMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pr
 		SiteURL:      siteURL,
 		SitePassword: sitePassword,
 		VIDs:         vIDs,
+		Keywords:     keywords,
 		SKUWithInfo:  storedShopifyMonitors,
 	}
 
-	return shopifyMonitor, nil
+	return &shopifyMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
 	return false
 }
 
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 
@@ -90,7 +99,7 @@ func (monitor *Monitor) RunMonitor() {
 			}
 			becameGuest = BecomeGuest(monitor.Monitor.Client, monitor.SiteURL, monitor.SitePassword)
 			if !becameGuest {
-				time.Sleep(1000 * time.Millisecond)
+				monitor.Monitor.Sleep(1000 * time.Millisecond)
 			}
 		}
 
@@ -102,13 +111,19 @@ func (monitor *Monitor) RunMonitor() {
 	}
 
 	wg := sync.WaitGroup{}
-	wg.Add(len(monitor.VIDs))
+	wg.Add(len(monitor.VIDs) + len(monitor.Keywords))
 	for _, vid := range monitor.VIDs {
 		go func(x string) {
 			monitor.RunSingleMonitor(x)
 			wg.Done()
 		}(vid)
 	}
+	for _, keywordMonitor := range monitor.Keywords {
+		go func(x entities.ShopifySingleMonitorInfo) {
+			monitor.RunKeywordMonitor(x, map[string]bool{})
+			wg.Done()
+		}(keywordMonitor)
+	}
 	wg.Wait()
 
 }
@@ -121,7 +136,7 @@ func (monitor *Monitor) RunSingleMonitor(vid string) {
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(vid)
 		}
 	}()
@@ -141,6 +156,7 @@ func (monitor *Monitor) RunSingleMonitor(vid string) {
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.VariantID == stockData.VariantID
@@ -152,22 +168,131 @@ func (monitor *Monitor) RunSingleMonitor(vid string) {
 					{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
-		if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+		if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 			monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, nil)
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.VariantID == stockData.VariantID {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(vid)
 }
 
+// RunKeywordMonitor polls the storefront's product catalog until the monitor stops, keeping the variants that match the
+// keyword monitor in the in stock list. The sent map holds the variant IDs that it has added to the list.
+func (monitor *Monitor) RunKeywordMonitor(keywordMonitor entities.ShopifySingleMonitorInfo, sent map[string]bool) {
+	needToStop := monitor.CheckForStop()
+	if needToStop {
+		return
+	}
+
+	defer func() {
+		if recover() != nil {
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.RunKeywordMonitor(keywordMonitor, sent)
+		}
+	}()
+
+	for {
+		if monitor.Monitor.ProxyGroup != nil && len(monitor.Monitor.ProxyGroup.Proxies) > 0 {
+			monitor.Monitor.UpdateProxy(util.RandomLeastUsedProxy(monitor.Monitor.ProxyGroup.Proxies))
+		}
+
+		stockDatas, err := monitor.GetKeywordStock(keywordMonitor)
+		needToStop := monitor.CheckForStop()
+		if needToStop {
+			return
+		}
+		if err == nil {
+			monitor.UpdateKeywordStock(sent, stockDatas)
+		}
+
+		monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	}
+}
+
+// GetKeywordStock returns the in stock variants in the storefront's product catalog that match the keyword monitor
+func (monitor *Monitor) GetKeywordStock(keywordMonitor entities.ShopifySingleMonitorInfo) ([]ShopifyInStockData, error) {
+	productsResponse := ProductsResponse{}
+	resp, _, err := util.MakeRequest(&util.Request{
+		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
+		Method: "GET",
+		URL:    monitor.SiteURL + CatalogEndpoint,
+		RawHeaders: http.RawHeader{
+			{"sec-ch-ua", `" Not;A Brand";v="99", "Google Chrome";v="91", "Chromium";v="91"`},
+			{"accept", "application/json, text/javascript, */*; q=0.01"},
+			{"sec-ch-ua-mobile", "?0"},
+			{"user-agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.106 Safari/537.36"},
+			{"sec-fetch-site", "same-origin"},
+			{"sec-fetch-mode", "cors"},
+			{"sec-fetch-dest", "empty"},
+			{"referer", monitor.SiteURL + "/"},
+			{"accept-encoding", "gzip, deflate"},
+			{"accept-language", "en-US,en;q=0.9"},
+		},
+		ResponseBodyStruct: &productsResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return KeywordStock(productsResponse.Products, keywordMonitor), nil
+}
+
+// UpdateKeywordStock replaces the variants that a keyword monitor previously added to the in stock list (the ones in sent)
+// with the ones that are in stock now, and sends the new ones to the tasks
+func (monitor *Monitor) UpdateKeywordStock(sent map[string]bool, stockDatas []ShopifyInStockData) {
+	inStockNow := make(map[string]bool)
+	for _, stockData := range stockDatas {
+		inStockNow[stockData.VariantID] = true
+	}
+
+	// The VID monitors and the MonitorStore use the in stock list at the same time
+	monitor.Monitor.StockMutex.Lock()
+	inStock := []ShopifyInStockData{}
+	listed := make(map[string]bool)
+	for _, monitorStock := range monitor.InStock {
+		if sent[monitorStock.VariantID] && !inStockNow[monitorStock.VariantID] {
+			delete(sent, monitorStock.VariantID)
+			continue
+		}
+		inStock = append(inStock, monitorStock)
+		listed[monitorStock.VariantID] = true
+	}
+
+	products := []events.Product{}
+	for _, stockData := range stockDatas {
+		if listed[stockData.VariantID] {
+			continue
+		}
+		inStock = append(inStock, stockData)
+		listed[stockData.VariantID] = true
+		sent[stockData.VariantID] = true
+		products = append(products, events.Product{ProductName: stockData.ItemName, ProductImageURL: stockData.ImageURL})
+	}
+	monitor.InStock = inStock
+	monitor.Monitor.StockMutex.Unlock()
+
+	if len(products) > 0 {
+		monitor.PublishEvent(enums.SendingProductInfoToTasks, enums.MonitorUpdate, events.ProductInfo{Products: products})
+	} else if len(inStock) == 0 && monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
+		monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, nil)
+	}
+}
+
 // Getting stock by adding to cart
 func (monitor *Monitor) GetVIDstock(vid string) ShopifyInStockData {
 	stockData := ShopifyInStockData{}
@@ -181,6 +306,7 @@ func (monitor *Monitor) GetVIDstock(vid string) ShopifyInStockData {
 	monitorResponse := AddToCartResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "POST",
 		URL:    monitor.SiteURL + AddToCartEndpoint,
 		RawHeaders: http.RawHeader{
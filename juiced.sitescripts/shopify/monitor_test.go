@@ -0,0 +1,130 @@
+package shopify
+
+import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.client/http"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+)
+
+// catalog is the fake storefront's product catalog
+var catalog = ProductsResponse{Products: []Products{
+	{
+		ID:      1,
+		Title:   "Nike Dunk Low Retro",
+		Options: []Options{{Name: "Size", Position: 1}, {Name: "Color", Position: 2}},
+		Variants: []Variants{
+			{ID: 101, Title: "9 / White", Option1: "9", Option2: "White", Price: "110.00", Available: true},
+			{ID: 102, Title: "10 / White", Option1: "10", Option2: "White", Price: "110.00", Available: false},
+			{ID: 103, Title: "11 / White", Option1: "11", Option2: "White", Price: "110.00", Available: true},
+		},
+		Images: []Images{{Src: "https://cdn.example.com/dunk-low.png"}},
+	},
+	{
+		ID:       2,
+		Title:    "Nike Dunk Low Kids",
+		Options:  []Options{{Name: "Size", Position: 1}},
+		Variants: []Variants{{ID: 201, Title: "5Y", Option1: "5Y", Price: "80.00", Available: true}},
+	},
+	{
+		ID:       3,
+		Title:    "Nike Dunk High Pro",
+		Options:  []Options{{Name: "Shoe Size", Position: 1}},
+		Variants: []Variants{{ID: 301, Title: "9", Option1: "9", Price: "250.00", Available: true}},
+	},
+	{
+		ID:       4,
+		Title:    "Nike Air Max 90",
+		Options:  []Options{{Name: "Size", Position: 1}},
+		Variants: []Variants{{ID: 401, Title: "9", Option1: "9", Price: "130.00", Available: true}},
+	},
+}}
+
+func newFakeStorefront(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(nethttp.HandlerFunc(func(response nethttp.ResponseWriter, request *nethttp.Request) {
+		if request.Method != "GET" || request.URL.Path != ProductsEndpoint {
+			response.WriteHeader(nethttp.StatusNotFound)
+			return
+		}
+		response.Header().Set("content-type", "application/json")
+		json.NewEncoder(response).Encode(catalog)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newKeywordMonitor(siteURL string) *Monitor {
+	return &Monitor{
+		Monitor: base.Monitor{
+			TaskGroup: &entities.TaskGroup{MonitorRetailer: enums.Shopify},
+			EventBus:  events.GetEventBus(),
+			Client:    http.Client{Transport: http.DefaultTransport},
+		},
+		SiteURL: siteURL,
+	}
+}
+
+func TestGetKeywordStock(t *testing.T) {
+	events.InitEventBus()
+	server := newFakeStorefront(t)
+
+	tests := []struct {
+		name           string
+		keywordMonitor entities.ShopifySingleMonitorInfo
+		wantVariantIDs []string
+	}{
+		{name: "Keywords", keywordMonitor: entities.ShopifySingleMonitorInfo{Keywords: "+dunk,-kids", MaxPrice: -1}, wantVariantIDs: []string{"101", "103", "301"}},
+		{name: "Max Price", keywordMonitor: entities.ShopifySingleMonitorInfo{Keywords: "+dunk,-kids", MaxPrice: 200}, wantVariantIDs: []string{"101", "103"}},
+		{name: "Sizes", keywordMonitor: entities.ShopifySingleMonitorInfo{Keywords: "+dunk,-kids", Size: "11,12", MaxPrice: -1}, wantVariantIDs: []string{"103"}},
+		{name: "No Matches", keywordMonitor: entities.ShopifySingleMonitorInfo{Keywords: "+jordan", MaxPrice: -1}, wantVariantIDs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stockDatas, err := newKeywordMonitor(server.URL).GetKeywordStock(tt.keywordMonitor)
+			if err != nil {
+				t.Fatalf("GetKeywordStock returned an error: %v", err)
+			}
+			variantIDs := []string{}
+			for _, stockData := range stockDatas {
+				variantIDs = append(variantIDs, stockData.VariantID)
+			}
+			if !reflect.DeepEqual(variantIDs, tt.wantVariantIDs) {
+				t.Errorf("expected variants %v, got %v", tt.wantVariantIDs, variantIDs)
+			}
+		})
+	}
+
+	stockDatas, _ := newKeywordMonitor(server.URL).GetKeywordStock(entities.ShopifySingleMonitorInfo{Keywords: "+dunk low,-kids", MaxPrice: -1})
+	want := ShopifyInStockData{VariantID: "101", Size: "9", Price: 110, ItemName: "Nike Dunk Low Retro", ImageURL: "https://cdn.example.com/dunk-low.png"}
+	if len(stockDatas) == 0 || stockDatas[0] != want {
+		t.Errorf("expected the first variant to be %+v, got %+v", want, stockDatas)
+	}
+}
+
+func TestUpdateKeywordStock(t *testing.T) {
+	events.InitEventBus()
+	monitor := newKeywordMonitor("")
+	monitor.InStock = []ShopifyInStockData{{VariantID: "999"}}
+	sent := map[string]bool{}
+
+	monitor.UpdateKeywordStock(sent, []ShopifyInStockData{{VariantID: "101", Size: "9"}, {VariantID: "103", Size: "11"}})
+	if len(monitor.InStock) != 3 || !sent["101"] || !sent["103"] {
+		t.Fatalf("expected the matches to be added to the in stock list, got %+v", monitor.InStock)
+	}
+	if monitor.Monitor.TaskGroup.MonitorStatus != enums.SendingProductInfoToTasks {
+		t.Errorf("expected the monitor status to be %q, got %q", enums.SendingProductInfoToTasks, monitor.Monitor.TaskGroup.MonitorStatus)
+	}
+
+	// Variants that sold out are removed, the ones from other monitors are kept
+	monitor.UpdateKeywordStock(sent, []ShopifyInStockData{{VariantID: "103", Size: "11"}})
+	if !reflect.DeepEqual(monitor.InStock, []ShopifyInStockData{{VariantID: "999"}, {VariantID: "103", Size: "11"}}) || sent["101"] {
+		t.Errorf("expected variant 101 to be removed, got %+v", monitor.InStock)
+	}
+}
@@ -0,0 +1,116 @@
+package shopify
+
+import (
+	e "errors"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+	"backend.juicedbot.io/juiced.sitescripts/util"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Shopify sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.Shopify
+}
+
+// RequiresProfile returns true since Shopify tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's ShopifyTaskInfo and turns it into a Shopify Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.ShopifyTaskInfo.SiteURL == "" || task.ShopifyTaskInfo.ShopifyRetailer == "" {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+
+	// Shopify Site specifics
+	var email, password string
+	if task.ShopifyTaskInfo.HotWheelsTaskInfo != nil {
+		email = task.ShopifyTaskInfo.HotWheelsTaskInfo.Email
+		password = task.ShopifyTaskInfo.HotWheelsTaskInfo.Password
+	}
+	switch task.ShopifyTaskInfo.ShopifyRetailer {
+	case enums.HotWheels:
+		if email != "" && password != "" {
+			return nil, e.New(errors.MissingTaskFieldsError)
+		}
+	}
+
+	shopifyTask, err := CreateShopifyTask(task, profile, proxyGroup, eventBus, task.ShopifyTaskInfo.CouponCode, task.ShopifyTaskInfo.SiteURL, task.ShopifyTaskInfo.SitePassword, email, password)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return shopifyTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's ShopifyMonitorInfo and turns it into a Shopify Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.ShopifyMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	for _, monitor := range taskGroup.ShopifyMonitorInfo.Monitors {
+		if positive, _ := util.ParseKeywords(monitor.Keywords); monitor.MonitorType == enums.KeywordMonitor && len(positive) == 0 {
+			return nil, e.New(errors.MissingKeywordsError)
+		}
+	}
+
+	shopifyMonitor, err := CreateShopifyMonitor(taskGroup, proxyGroup, eventBus, taskGroup.ShopifyMonitorInfo.SiteURL, taskGroup.ShopifyMonitorInfo.SitePassword, taskGroup.ShopifyMonitorInfo.Monitors)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return shopifyMonitor, nil
+}
+
+// HandOffStock gives the task a random variant from the monitor's in stock list in one of the task's sizes,
+// or leaves it waiting if none of them are in stock and its size fallback doesn't allow other sizes
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	inStock := monitor.InStock
+	sizes := make([]string, len(inStock))
+	for i, stockData := range inStock {
+		sizes[i] = stockData.Size
+	}
+	if i, ok := util.SelectSize(task.Task.Task.TaskSize, sizes, task.Task.Task.TaskSizeFallback); ok {
+		task.InStockData = inStock[i]
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any in stock variants
+func (monitor *Monitor) HasStock() bool {
+	monitor.Monitor.StockMutex.RLock()
+	defer monitor.Monitor.StockMutex.RUnlock()
+	return len(monitor.InStock) > 0
+}
+
+// ClearStock empties the monitor's in stock list
+func (monitor *Monitor) ClearStock() {
+	monitor.Monitor.StockMutex.Lock()
+	defer monitor.Monitor.StockMutex.Unlock()
+	monitor.InStock = monitor.InStock[:0]
+}
@@ -1,6 +1,7 @@
 package shopify
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -19,7 +20,7 @@ import (
 	"backend.juicedbot.io/juiced.sitescripts/util"
 )
 
-func CreateShopifyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, couponCode, siteURL, sitePassword, email, password string) (Task, error) {
+func CreateShopifyTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, couponCode, siteURL, sitePassword, email, password string) (*Task, error) {
 	shopifyTask := Task{}
 
 	shopifyTask = Task{
@@ -38,24 +39,24 @@ func CreateShopifyTask(task *entities.Task, profile entities.Profile, proxyGroup
 		SitePassword: sitePassword,
 	}
 	if proxyGroup != nil {
-		shopifyTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		shopifyTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		shopifyTask.Task.Proxy = nil
+		shopifyTask.Task.SetProxy(nil)
 	}
-	return shopifyTask, nil
+	return &shopifyTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -72,25 +73,26 @@ func (task *Task) CheckForAdditionalSteps() {
 	}
 }
 
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.InStockData = ShopifyInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.InStockData = ShopifyInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -99,7 +101,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -116,7 +118,7 @@ func (task *Task) RunTask() {
 		}
 		becameGuest = BecomeGuest(task.Client, task.SiteURL, task.SitePassword)
 		if !becameGuest {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -132,7 +134,7 @@ func (task *Task) RunTask() {
 		}
 		preloaded = task.Preload()
 		if !preloaded {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -161,7 +163,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart(task.VariantID)
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -175,7 +177,7 @@ func (task *Task) RunTask() {
 		}
 		checkout = task.Checkout()
 		if !checkout {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -189,7 +191,7 @@ func (task *Task) RunTask() {
 		}
 		setShippingInfo = task.SetShippingInfo()
 		if !setShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -201,7 +203,7 @@ func (task *Task) RunTask() {
 		}
 		setShippingRate = task.SetShippingRate()
 		if !setShippingRate {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -215,7 +217,7 @@ func (task *Task) RunTask() {
 		}
 		getCreditID = task.GetCreditID()
 		if !getCreditID {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -227,7 +229,7 @@ func (task *Task) RunTask() {
 		}
 		setPaymentInfo = task.SetPaymentInfo()
 		if !setPaymentInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -247,7 +249,7 @@ func (task *Task) RunTask() {
 		processOrder, status = task.ProcessOrder()
 		if !processOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -266,7 +268,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      processOrder,
 		Status:       status,
 		Content:      "",
@@ -285,6 +287,7 @@ func (task *Task) RunTask() {
 func (task *Task) ClearCart() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    task.SiteURL + ClearCartEndpoint,
 		RawHeaders: http.RawHeader{
@@ -313,6 +316,7 @@ func (task *Task) Preload() bool {
 	productsResponse := ProductsResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    task.SiteURL + AddToCartEndpoint,
 		RawHeaders: http.RawHeader{
@@ -366,12 +370,11 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.InStockData.VariantID != "" {
+		if task.Task.TakeStock(func() bool { return task.InStockData.VariantID != "" }) {
 			task.VariantID = task.InStockData.VariantID
-			task.Task.HasStockData = true
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -386,6 +389,7 @@ func (task *Task) AddToCart(vid string) bool {
 	addToCartResponse := AddToCartResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    task.SiteURL + AddToCartEndpoint,
 		RawHeaders: http.RawHeader{
@@ -435,6 +439,7 @@ func (task *Task) Checkout() bool {
 		data := []byte("checkout=")
 		resp, body, err := util.MakeRequest(&util.Request{
 			Client: task.Client,
+			Ctx:    task.Task.Ctx,
 			Method: "POST",
 			URL:    task.SiteURL + CartEndpoint,
 			RawHeaders: http.RawHeader{
@@ -496,6 +501,7 @@ func (task *Task) HandleQueue() bool {
 	}))
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    task.SiteURL + "/throttle/queue",
 		RawHeaders: http.RawHeader{
@@ -543,6 +549,7 @@ func (task *Task) HandleQueue() bool {
 		pollResponse := PollResponse{}
 		_, _, err := util.MakeRequest(&util.Request{
 			Client: task.Client,
+			Ctx:    task.Task.Ctx,
 			Method: "POST",
 			URL:    task.SiteURL + "/queue/poll",
 			RawHeaders: http.RawHeader{
@@ -576,7 +583,7 @@ func (task *Task) HandleQueue() bool {
 		if pollResponse.Data.Poll.Typename == "PollComplete" {
 			inQueue = false
 		} else {
-			time.Sleep(1 * time.Second)
+			task.Task.Sleep(1 * time.Second)
 		}
 
 	}
@@ -609,6 +616,7 @@ func (task *Task) SetShippingInfo() bool {
 	}))
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    task.TaskInfo.CheckoutURL,
 		RawHeaders: http.RawHeader{
@@ -639,6 +647,7 @@ func (task *Task) SetShippingRate() bool {
 	shippingRatesResponse := ShippingRatesResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    task.SiteURL + fmt.Sprintf(ShippingRatesEndpoint, task.Task.Profile.ShippingAddress.ZipCode, task.Task.Profile.ShippingAddress.CountryCode, task.Task.Profile.ShippingAddress.StateCode),
 		RawHeaders: http.RawHeader{
@@ -690,6 +699,7 @@ func (task *Task) SetShippingRate() bool {
 	}))
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    task.TaskInfo.CheckoutURL,
 		RawHeaders: http.RawHeader{
@@ -760,6 +770,7 @@ func (task *Task) GetCreditID() bool {
 	data, _ := json.Marshal(creditIDRequest)
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    CreditIDEndpoint,
 		Headers: http.Header{
@@ -809,6 +820,7 @@ func (task *Task) SetPaymentInfo() bool {
 	}))
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    task.TaskInfo.CheckoutURL,
 		RawHeaders: http.RawHeader{
@@ -838,9 +850,10 @@ func (task *Task) SetPaymentInfo() bool {
 func (task *Task) ProcessOrder() (bool, enums.OrderStatus) {
 	var status enums.OrderStatus
 
-	time.Sleep(3 * time.Second)
+	task.Task.Sleep(3 * time.Second)
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    task.TaskInfo.CheckoutURL + "/processing?from_processing_page=1",
 		RawHeaders: http.RawHeader{
@@ -2,9 +2,14 @@ package target
 
 import (
 	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"backend.juicedbot.io/juiced.client/http"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	sec "backend.juicedbot.io/juiced.security/auth/util"
 	"backend.juicedbot.io/juiced.sitescripts/util"
@@ -31,6 +36,63 @@ func GetTCINStockRequestToMap(getTCINStockRequest GetTCINStockRequest) map[strin
 	}
 }
 
+// SearchRequestToMap maps a SearchRequest object to a map[string]string for converting to a query string
+func SearchRequestToMap(searchRequest SearchRequest) map[string]string {
+	return map[string]string{
+		"key":              searchRequest.Key,
+		"keyword":          searchRequest.Keyword,
+		"channel":          searchRequest.Channel,
+		"count":            searchRequest.Count,
+		"offset":           searchRequest.Offset,
+		"page":             searchRequest.Page,
+		"pricing_store_id": searchRequest.PricingStoreID,
+	}
+}
+
+// tcinPathRegex matches the TCIN at the end of a product page path, like /p/product-name/-/A-12345678
+var tcinPathRegex = regexp.MustCompile(`/A-(\d+)`)
+
+// tcinRegex matches a bare TCIN
+var tcinRegex = regexp.MustCompile(`^\d+$`)
+
+// TCINFromURL returns the TCIN of a product page URL, from its preselect parameter (the selected variation) or its path.
+// A bare TCIN is returned as is.
+func TCINFromURL(productURL string) (string, bool) {
+	productURL = strings.TrimSpace(productURL)
+	if tcinRegex.MatchString(productURL) {
+		return productURL, true
+	}
+	parsedURL, err := url.Parse(productURL)
+	if err != nil {
+		return "", false
+	}
+	if preselect := parsedURL.Query().Get("preselect"); tcinRegex.MatchString(preselect) {
+		return preselect, true
+	}
+	if match := tcinPathRegex.FindStringSubmatch(parsedURL.Path); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+// FilterSearchResults returns the TCINs of the search results whose titles match the keyword monitor's keywords
+// and that cost at most its MaxPrice (unless it's -1)
+func FilterSearchResults(products []SearchProduct, keywordMonitor entities.TargetSingleMonitorInfo) []string {
+	positive, negative := util.ParseKeywords(keywordMonitor.Keywords)
+	tcins := []string{}
+	for _, product := range products {
+		title := html.UnescapeString(product.Item.ProductDescription.Title)
+		if !util.MatchesKeywords(title, positive, negative) {
+			continue
+		}
+		if keywordMonitor.MaxPrice != -1 && product.Price.CurrentRetail > float64(keywordMonitor.MaxPrice) {
+			continue
+		}
+		tcins = append(tcins, product.TCIN)
+	}
+	return tcins
+}
+
 // Creates a embed for the DiscordWebhook function
 func (task *Task) CreateTargetEmbed(status enums.OrderStatus, imageURL string) []sec.DiscordEmbed {
 	fields := []sec.DiscordField{
@@ -59,10 +121,10 @@ func (task *Task) CreateTargetEmbed(status enums.OrderStatus, imageURL string) [
 		},
 	}
 
-	if task.Task.Proxy != nil {
+	if task.Task.Proxy() != nil {
 		fields = append(fields, sec.DiscordField{
 			Name:  "Proxy:",
-			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy) + " " + "||",
+			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy()) + " " + "||",
 		})
 	}
 
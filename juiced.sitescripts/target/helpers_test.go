@@ -0,0 +1,62 @@
+package target
+
+import (
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+)
+
+func TestTCINFromURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		productURL string
+		wantTCIN   string
+		wantOK     bool
+	}{
+		{name: "Product Page", productURL: "https://www.target.com/p/pokemon-trading-card-game-booster/-/A-81114477", wantTCIN: "81114477", wantOK: true},
+		{name: "Query String", productURL: "https://www.target.com/p/-/A-81114477?ref=tgt_adv#lnk", wantTCIN: "81114477", wantOK: true},
+		{name: "Preselect", productURL: "https://www.target.com/p/dunk-low/-/A-80000000?preselect=80000012#lnk=sametab", wantTCIN: "80000012", wantOK: true},
+		{name: "Bare TCIN", productURL: " 81114477 ", wantTCIN: "81114477", wantOK: true},
+		{name: "Short Link", productURL: "https://tgt.biz/abc123", wantTCIN: "", wantOK: false},
+		{name: "Search Page", productURL: "https://www.target.com/s?searchTerm=pokemon", wantTCIN: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tcin, ok := TCINFromURL(tt.productURL)
+			if tcin != tt.wantTCIN || ok != tt.wantOK {
+				t.Errorf("TCINFromURL(%q) = %q, %v, want %q, %v", tt.productURL, tcin, ok, tt.wantTCIN, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFilterSearchResults(t *testing.T) {
+	product := func(tcin, title string, price float64) SearchProduct {
+		return SearchProduct{TCIN: tcin, Price: Price{CurrentRetail: price}, Item: Item{ProductDescription: ProductDescription{Title: title}}}
+	}
+	products := []SearchProduct{
+		product("1", "Pok&#233;mon Trading Card Game: Booster Bundle", 24.99),
+		product("2", "Pokemon Trading Card Game: Elite Trainer Box", 49.99),
+		product("3", "Pokemon Card Sleeves", 9.99),
+		product("4", "Pokemon Trading Card Game: Booster Box", 143.99),
+	}
+
+	tests := []struct {
+		name           string
+		keywordMonitor entities.TargetSingleMonitorInfo
+		want           []string
+	}{
+		{name: "Keywords", keywordMonitor: entities.TargetSingleMonitorInfo{Keywords: "+trading card,-elite", MaxPrice: -1}, want: []string{"1", "4"}},
+		{name: "Escaped Titles", keywordMonitor: entities.TargetSingleMonitorInfo{Keywords: "+pokémon", MaxPrice: -1}, want: []string{"1"}},
+		{name: "Max Price", keywordMonitor: entities.TargetSingleMonitorInfo{Keywords: "+pokemon", MaxPrice: 50}, want: []string{"2", "3"}},
+		{name: "No Matches", keywordMonitor: entities.TargetSingleMonitorInfo{Keywords: "+lego", MaxPrice: -1}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterSearchResults(products, tt.keywordMonitor); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterSearchResults(%q) = %v, want %v", tt.keywordMonitor.Keywords, got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ const (
 	GetTCINStockReferer         = "https://www.target.com/"
 	TCINInfoEndpoint            = "https://redsky.target.com/redsky_aggregations/v1/web/pdp_client_v1?"
 	TCINInfoReferer             = "https://www.target.com/p/-/A-"
+	SearchEndpoint              = "https://redsky.target.com/redsky_aggregations/v1/web/plp_search_v1?"
+	SearchReferer               = "https://www.target.com/s?searchTerm="
 	LoginEndpoint               = "https://gsp.target.com/gsp/authentications/v1/auth_codes?client_id=ecom-web-1.0.0&state=1619237851891&redirect_uri=https%3A%2F%2Fwww.target.com%2F&assurance_level=M"
 	RefreshLoginEndpoint        = "https://gsp.target.com/gsp/oauth_tokens/v2/client_tokens"
 	RefreshLoginReferer         = "https://www.target.com/"
@@ -46,6 +48,8 @@ type Monitor struct {
 	Monitor          base.Monitor
 	MonitorType      enums.MonitorType
 	TCINs            []string
+	URLs             []entities.TargetSingleMonitorInfo
+	Keywords         []entities.TargetSingleMonitorInfo
 	StoreID          string
 	TCINsWithInfo    map[string]entities.TargetSingleMonitorInfo
 	InStockForShip   cmap.ConcurrentMap
@@ -529,6 +533,20 @@ type PriceData struct {
 	Product Product `json:"product"`
 }
 
+// Used in SearchKeywords function by SearchResponse
+
+type SearchData struct {
+	Search Search `json:"search"`
+}
+type Search struct {
+	Products []SearchProduct `json:"products"`
+}
+type SearchProduct struct {
+	TCIN  string `json:"tcin"`
+	Price Price  `json:"price"`
+	Item  Item   `json:"item"`
+}
+
 type Alerts struct {
 	Code string `json:"code"`
 }
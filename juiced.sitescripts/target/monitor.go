@@ -1,6 +1,9 @@
 package target
 
 import (
+	"context"
+	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -14,13 +17,22 @@ import (
 )
 
 // CreateTargetMonitor takes a TaskGroup entity and turns it into a Target Monitor
-func CreateTargetMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, monitor *entities.TargetMonitorInfo) (Monitor, error) {
+func CreateTargetMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, monitor *entities.TargetMonitorInfo) (*Monitor, error) {
 	storedTargetMonitors := make(map[string]entities.TargetSingleMonitorInfo)
 	targetMonitor := Monitor{}
 	tcins := []string{}
-	for _, monitor := range monitor.Monitors {
-		storedTargetMonitors[monitor.TCIN] = monitor
-		tcins = append(tcins, monitor.TCIN)
+	urls := []entities.TargetSingleMonitorInfo{}
+	keywords := []entities.TargetSingleMonitorInfo{}
+	for _, singleMonitor := range monitor.Monitors {
+		switch monitor.MonitorType {
+		case enums.URLMonitor:
+			urls = append(urls, singleMonitor)
+		case enums.KeywordMonitor:
+			keywords = append(keywords, singleMonitor)
+		default:
+			storedTargetMonitors[singleMonitor.TCIN] = singleMonitor
+			tcins = append(tcins, singleMonitor.TCIN)
+		}
 	}
 
 	targetMonitor = Monitor{
@@ -29,23 +41,27 @@ func CreateTargetMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pro
 			ProxyGroup: proxyGroup,
 			EventBus:   eventBus,
 		},
-		TCINs:         tcins,
-		StoreID:       monitor.StoreID,
-		TCINsWithInfo: storedTargetMonitors,
-		MonitorType:   monitor.MonitorType,
+		TCINs:            tcins,
+		URLs:             urls,
+		Keywords:         keywords,
+		StoreID:          monitor.StoreID,
+		TCINsWithInfo:    storedTargetMonitors,
+		MonitorType:      monitor.MonitorType,
+		InStockForShip:   cmap.New(),
+		InStockForPickup: cmap.New(),
 	}
-	return targetMonitor, nil
+	return &targetMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
@@ -56,11 +72,13 @@ func (monitor *Monitor) CheckForStop() bool {
 // Function order:
 // 		1. Get___Stock (TCIN/URL/Keyword)
 //		2. SendToTasks
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorComplete, nil)
@@ -69,7 +87,7 @@ func (monitor *Monitor) RunMonitor() {
 	monitor.InStockForShip = cmap.New()
 	monitor.InStockForPickup = cmap.New()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 	needToStop := monitor.CheckForStop()
@@ -98,9 +116,9 @@ func (monitor *Monitor) RunMonitor() {
 	case enums.SKUMonitor:
 		stockData = monitor.GetTCINStock()
 	case enums.URLMonitor:
-		// inStockForShip, inStockForPickup = monitor.GetURLStock()
+		stockData = monitor.GetURLStock()
 	case enums.KeywordMonitor:
-		// inStockForShip, inStockForPickup = monitor.GetKeywordStock()
+		stockData = monitor.GetKeywordStock()
 	default:
 		stockData = monitor.GetTCINStock()
 	}
@@ -141,14 +159,14 @@ func (monitor *Monitor) RunMonitor() {
 
 	} else {
 		if len(stockData.OutOfStockForShip) > 0 || len(stockData.OutOfStockForPickup) > 0 {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, nil)
 			}
 		}
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
-	monitor.RunMonitor()
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.RunMonitor(monitor.Monitor.Ctx)
 }
 
 // GetTCINStock returns a map of in stock TCINs given a list of TCINs joined by commas
@@ -173,6 +191,7 @@ func (monitor *Monitor) GetTCINStock() TargetStockData {
 	params := common.CreateParams(getTCINStockRequest)
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             monitor.Monitor.Client,
+		Ctx:                monitor.Monitor.Ctx,
 		Method:             "GET",
 		URL:                GetTCINStockEndpoint + params,
 		AddHeadersFunction: AddTargetHeaders,
@@ -243,6 +262,131 @@ func (monitor *Monitor) GetTCINStock() TargetStockData {
 	return targetStockData
 }
 
+// GetURLStock resolves the URL monitors' product page URLs to TCINs, then returns the stock of every resolved TCIN like GetTCINStock.
+// URLs that can't be resolved yet are tried again on the next run.
+func (monitor *Monitor) GetURLStock() TargetStockData {
+	unresolved := []entities.TargetSingleMonitorInfo{}
+	for _, urlMonitor := range monitor.URLs {
+		tcin, err := monitor.ResolveURL(urlMonitor.URL)
+		if err != nil {
+			unresolved = append(unresolved, urlMonitor)
+			continue
+		}
+		if _, ok := monitor.TCINsWithInfo[tcin]; !ok {
+			urlMonitor.TCIN = tcin
+			monitor.TCINsWithInfo[tcin] = urlMonitor
+			monitor.TCINs = append(monitor.TCINs, tcin)
+		}
+	}
+	monitor.URLs = unresolved
+
+	if len(monitor.TCINs) == 0 {
+		return TargetStockData{}
+	}
+	return monitor.GetTCINStock()
+}
+
+// ResolveURL returns the TCIN of the product page URL, from the URL itself if it has one and otherwise from the page
+func (monitor *Monitor) ResolveURL(productURL string) (string, error) {
+	if tcin, ok := TCINFromURL(productURL); ok {
+		return tcin, nil
+	}
+
+	resp, body, err := util.MakeRequest(&util.Request{
+		Client:             monitor.Monitor.Client,
+		Ctx:                monitor.Monitor.Ctx,
+		Method:             "GET",
+		URL:                productURL,
+		AddHeadersFunction: AddTargetHeaders,
+		Referer:            BaseEndpoint + "/",
+	})
+	if err != nil {
+		return "", err
+	}
+	// Short links redirect to the product page
+	if resp.Request != nil {
+		if tcin, ok := TCINFromURL(resp.Request.URL.String()); ok {
+			return tcin, nil
+		}
+	}
+	if match := tcinPathRegex.FindStringSubmatch(body); match != nil {
+		return match[1], nil
+	}
+	return "", fmt.Errorf("no TCIN found at %s", productURL)
+}
+
+// GetKeywordStock searches for each keyword monitor's keywords, then returns the stock of the matching TCINs like GetTCINStock.
+// TCINs that stopped matching are removed from the in stock maps.
+func (monitor *Monitor) GetKeywordStock() TargetStockData {
+	tcins := []string{}
+	tcinsWithInfo := make(map[string]entities.TargetSingleMonitorInfo)
+	for _, keywordMonitor := range monitor.Keywords {
+		products, err := monitor.SearchKeywords(keywordMonitor.Keywords)
+		if err != nil {
+			return TargetStockData{}
+		}
+		for _, tcin := range FilterSearchResults(products, keywordMonitor) {
+			if _, ok := tcinsWithInfo[tcin]; !ok {
+				keywordMonitor.TCIN = tcin
+				tcinsWithInfo[tcin] = keywordMonitor
+				tcins = append(tcins, tcin)
+			}
+		}
+	}
+
+	for _, inStock := range []cmap.ConcurrentMap{monitor.InStockForShip, monitor.InStockForPickup} {
+		for _, tcin := range inStock.Keys() {
+			if _, ok := tcinsWithInfo[tcin]; !ok {
+				inStock.Remove(tcin)
+			}
+		}
+	}
+	monitor.TCINs = tcins
+	monitor.TCINsWithInfo = tcinsWithInfo
+
+	if len(monitor.TCINs) == 0 {
+		return TargetStockData{}
+	}
+	return monitor.GetTCINStock()
+}
+
+// SearchKeywords returns the products in Target's search results for the positive keywords in the keyword list
+func (monitor *Monitor) SearchKeywords(keywords string) ([]SearchProduct, error) {
+	positive, _ := util.ParseKeywords(keywords)
+	query := strings.Join(positive, " ")
+	storeID := monitor.StoreID
+	if storeID == "" {
+		storeID = "199"
+	}
+	params := common.CreateParams(SearchRequestToMap(SearchRequest{
+		Key:            "ff457966e64d5e877fdbad070f276d18ecec4a01",
+		Keyword:        query,
+		Channel:        "WEB",
+		Count:          "24",
+		Offset:         "0",
+		Page:           "/s/" + query,
+		PricingStoreID: storeID,
+	}))
+
+	searchResponse := SearchResponse{}
+	resp, _, err := util.MakeRequest(&util.Request{
+		Client:             monitor.Monitor.Client,
+		Ctx:                monitor.Monitor.Ctx,
+		Method:             "GET",
+		URL:                SearchEndpoint + params,
+		AddHeadersFunction: AddTargetHeaders,
+		Referer:            SearchReferer + url.QueryEscape(query),
+		ResponseBodyStruct: &searchResponse,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return searchResponse.Data.Search.Products, nil
+}
+
 func (monitor *Monitor) GetTCINInfo(sku string) (string, string, bool) {
 	var storeID string
 	storeID = monitor.StoreID
@@ -265,6 +409,7 @@ func (monitor *Monitor) GetTCINInfo(sku string) (string, string, bool) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             monitor.Monitor.Client,
+		Ctx:                monitor.Monitor.Ctx,
 		Method:             "GET",
 		URL:                TCINInfoEndpoint + params,
 		AddHeadersFunction: AddTargetHeaders,
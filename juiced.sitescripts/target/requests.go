@@ -8,6 +8,17 @@ type GetTCINStockRequest struct {
 	ScheduledDeliveryStoreID string
 }
 
+// SearchRequest is sent by the SearchKeywords function
+type SearchRequest struct {
+	Key            string
+	Keyword        string
+	Channel        string
+	Count          string
+	Offset         string
+	Page           string
+	PricingStoreID string
+}
+
 // RefreshLoginRequest is sent by the RefreshLogin function
 type RefreshLoginRequest struct {
 	GrantType        string           `json:"grant_type"`
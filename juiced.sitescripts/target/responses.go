@@ -11,6 +11,11 @@ type GetTCINInfoResponse struct {
 	Data PriceData `json:"data"`
 }
 
+// SearchResponse is returned by the Search endpoint
+type SearchResponse struct {
+	Data SearchData `json:"data"`
+}
+
 // RefreshLoginResponse is returned by the RefreshLogin endpoint
 type RefreshLoginResponse struct {
 	AccessToken  string `json:"access_token"`
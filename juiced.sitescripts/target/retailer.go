@@ -0,0 +1,119 @@
+package target
+
+import (
+	e "errors"
+	"math/rand"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.sitescripts/base"
+	"backend.juicedbot.io/juiced.sitescripts/util"
+	cmap "github.com/orcaman/concurrent-map"
+)
+
+func init() {
+	base.RegisterRetailer(Retailer{})
+}
+
+// Retailer registers the Target sitescript with the base package
+type Retailer struct{}
+
+// Name returns the retailer that this sitescript handles
+func (Retailer) Name() enums.Retailer {
+	return enums.Target
+}
+
+// RequiresProfile returns true since Target tasks check out with a Profile
+func (Retailer) RequiresProfile() bool {
+	return true
+}
+
+// CreateTask validates the Task's TargetTaskInfo and turns it into a Target Task
+func (Retailer) CreateTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableTask, error) {
+	// Make sure necessary fields exist
+	if task.TargetTaskInfo.Email == "" || task.TargetTaskInfo.Password == "" || task.TargetTaskInfo.PaymentType == "" {
+		return nil, e.New(errors.MissingTaskFieldsError)
+	}
+
+	targetTask, err := CreateTargetTask(task, profile, proxyGroup, eventBus, task.TargetTaskInfo.Email, task.TargetTaskInfo.Password, task.TargetTaskInfo.PaymentType)
+	if err != nil {
+		return nil, e.New(errors.CreateBotTaskError + err.Error())
+	}
+	return targetTask, nil
+}
+
+// CreateMonitor validates the TaskGroup's TargetMonitorInfo and turns it into a Target Monitor
+func (Retailer) CreateMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (base.RunnableMonitor, error) {
+	if len(taskGroup.TargetMonitorInfo.Monitors) == 0 {
+		return nil, e.New(errors.NoMonitorsError)
+	}
+	for _, monitor := range taskGroup.TargetMonitorInfo.Monitors {
+		switch taskGroup.TargetMonitorInfo.MonitorType {
+		case enums.URLMonitor:
+			if monitor.URL == "" {
+				return nil, e.New(errors.MissingMonitorFieldsError)
+			}
+		case enums.KeywordMonitor:
+			if positive, _ := util.ParseKeywords(monitor.Keywords); len(positive) == 0 {
+				return nil, e.New(errors.MissingKeywordsError)
+			}
+		default:
+			if monitor.TCIN == "" {
+				return nil, e.New(errors.MissingMonitorFieldsError)
+			}
+		}
+	}
+
+	targetMonitor, err := CreateTargetMonitor(taskGroup, proxyGroup, eventBus, taskGroup.TargetMonitorInfo)
+	if err != nil {
+		return nil, e.New(errors.CreateMonitorError + err.Error())
+	}
+	return targetMonitor, nil
+}
+
+// HandOffStock gives the task a random item from the monitor's in stock lists, preferring pickup over ship
+func (Retailer) HandOffStock(runnableMonitor base.RunnableMonitor, runnableTask base.RunnableTask) {
+	monitor := runnableMonitor.(*Monitor)
+	task := runnableTask.(*Task)
+
+	var inStockForShip []SingleStockData
+	var inStockForPickup []SingleStockData
+	for _, value := range monitor.InStockForShip.Items() {
+		inStockForShip = append(inStockForShip, value.(SingleStockData))
+	}
+	for _, value := range monitor.InStockForPickup.Items() {
+		inStockForPickup = append(inStockForPickup, value.(SingleStockData))
+	}
+
+	if len(inStockForPickup) > 0 {
+		task.InStockData = inStockForPickup[rand.Intn(len(inStockForPickup))]
+		task.AccountInfo.StoreID = monitor.StoreID
+		task.CheckoutType = enums.CheckoutTypePICKUP
+	} else if len(inStockForShip) > 0 {
+		task.InStockData = inStockForShip[rand.Intn(len(inStockForShip))]
+		task.CheckoutType = enums.CheckoutTypeSHIP
+	}
+}
+
+// GetTask returns the base Task
+func (task *Task) GetTask() *base.Task {
+	return &task.Task
+}
+
+// GetMonitor returns the base Monitor
+func (monitor *Monitor) GetMonitor() *base.Monitor {
+	return &monitor.Monitor
+}
+
+// HasStock returns true if the monitor has found any items in stock for ship or pickup
+func (monitor *Monitor) HasStock() bool {
+	return monitor.InStockForShip.Count() > 0 || monitor.InStockForPickup.Count() > 0
+}
+
+// ClearStock empties the monitor's in stock maps
+func (monitor *Monitor) ClearStock() {
+	monitor.InStockForShip = cmap.New()
+	monitor.InStockForPickup = cmap.New()
+}
@@ -33,7 +33,7 @@ import (
 var TargetAccountStore = cmap.New()
 
 // CreateTargetTask takes a Task entity and turns it into a Target Task
-func CreateTargetTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, email, password string, paymentType enums.PaymentType) (Task, error) {
+func CreateTargetTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, email, password string, paymentType enums.PaymentType) (*Task, error) {
 	targetTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -49,26 +49,26 @@ func CreateTargetTask(task *entities.Task, profile entities.Profile, proxyGroup
 		},
 	}
 	if proxyGroup != nil {
-		targetTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		targetTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		targetTask.Task.Proxy = nil
+		targetTask.Task.SetProxy(nil)
 	}
-	return targetTask, nil
+	return &targetTask, nil
 }
 
 var baseURL, _ = url.Parse(BaseEndpoint)
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -84,25 +84,26 @@ func (task *Task) CheckForStop() bool {
 //		5. SetShippingInfo
 // 		6. SetPaymentInfo
 // 		7. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.InStockData = SingleStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.InStockData = SingleStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -111,7 +112,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -145,7 +146,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -160,7 +161,7 @@ func (task *Task) RunTask() {
 		}
 		_, gotCartInfo = task.GetCartInfo()
 		if !gotCartInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -175,7 +176,7 @@ func (task *Task) RunTask() {
 			}
 			setShippingInfo = task.SetShippingInfo()
 			if !setShippingInfo {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
 	}
@@ -191,7 +192,7 @@ func (task *Task) RunTask() {
 		}
 		setPaymentInfo, doNotRetry = task.SetPaymentInfo()
 		if !setPaymentInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -212,7 +213,7 @@ func (task *Task) RunTask() {
 		placedOrder, status, dontRetry = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -231,7 +232,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -250,7 +251,7 @@ func (task *Task) RunTask() {
 // Sets the client up by either logging in or waiting for another task to login that is using the same account
 func (task *Task) Setup() bool {
 	// Bad but quick solution to the multiple logins
-	time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+	task.Task.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
 	if TargetAccountStore.Has(task.AccountInfo.Email) {
 		inMap := true
 		for inMap {
@@ -268,10 +269,10 @@ func (task *Task) Setup() bool {
 					}
 					break
 				} else {
-					if task.Task.Task.TaskStatus != enums.WaitingForLogin {
+					if task.Task.Task.GetTaskStatus() != enums.WaitingForLogin {
 						task.PublishEvent(enums.WaitingForLogin, enums.TaskUpdate, 15)
 					}
-					time.Sleep(common.MS_TO_WAIT)
+					task.Task.Sleep(common.MS_TO_WAIT)
 				}
 			} else {
 				inMap = false
@@ -289,7 +290,7 @@ func (task *Task) Setup() bool {
 			}
 			loggedIn = task.Login()
 			if !loggedIn {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
 
@@ -304,7 +305,7 @@ func (task *Task) Setup() bool {
 			}
 			clearedCart = task.ClearCart()
 			if !clearedCart {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
 
@@ -336,8 +337,8 @@ func (task *Task) Login() bool {
 	}
 
 	proxyCleaned := ""
-	if task.Task.Proxy != nil {
-		proxyCleaned = common.ProxyCleaner(*task.Task.Proxy)
+	if task.Task.Proxy() != nil {
+		proxyCleaned = common.ProxyCleaner(*task.Task.Proxy())
 	}
 	if proxyCleaned != "" {
 		proxyURL := proxyCleaned[7:]
@@ -389,11 +390,11 @@ func (task *Task) Login() bool {
 
 	go func() {
 		// Wait until either the StopFlag is set to true or the BrowserComplete flag is set to true
-		for !task.Task.StopFlag && !task.BrowserComplete {
-			time.Sleep(common.MS_TO_WAIT)
+		for !task.Task.StopFlag() && !task.BrowserComplete {
+			task.Task.Sleep(common.MS_TO_WAIT)
 		}
 		// If the StopFlag being set to true is the one that caused us to break out of that for loop, then the browser is still running, so call cancel()
-		if task.Task.StopFlag {
+		if task.Task.StopFlag() {
 			TargetAccountStore.Remove(task.AccountInfo.Email)
 			browserWithCancel.MustClose()
 			cancel()
@@ -427,17 +428,17 @@ func (task *Task) Login() bool {
 	usernameBox.MustTap()
 	for i := range task.AccountInfo.Email {
 		usernameBox.Input(string(task.AccountInfo.Email[i]))
-		time.Sleep(125 * time.Millisecond)
+		task.Task.Sleep(125 * time.Millisecond)
 	}
 
-	time.Sleep(1 * time.Second / 2)
+	task.Task.Sleep(1 * time.Second / 2)
 	passwordBox := page.MustElement("#password").MustWaitVisible()
 	passwordBox.MustTap()
 	for i := range task.AccountInfo.Password {
 		passwordBox.Input(string(task.AccountInfo.Password[i]))
-		time.Sleep(125 * time.Millisecond)
+		task.Task.Sleep(125 * time.Millisecond)
 	}
-	time.Sleep(1 * time.Second / 2)
+	task.Task.Sleep(1 * time.Second / 2)
 
 	checkbox, err := page.ElementX(`//*[contains(@class, 'nds-checkbox')]`)
 	if err != nil {
@@ -450,11 +451,13 @@ func (task *Task) Login() bool {
 	checkbox.MustWaitVisible().MustClick()
 	page.MustElement("#login").MustWaitVisible().MustClick().MustWaitLoad()
 
-	time.Sleep(1 * time.Second / 2)
+	task.Task.Sleep(1 * time.Second / 2)
 	if strings.Contains(page.MustHTML(), "That password is incorrect.") {
+		task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
 		task.PublishEvent("Incorrect password", enums.TaskFail, 0)
 		return false
 	} else if strings.Contains(page.MustHTML(), "Your account is locked") {
+		task.Task.SetAccountStatus(enums.AccountStatusLocked)
 		task.PublishEvent("Account is locked", enums.TaskFail, 0)
 		return false
 	}
@@ -483,6 +486,7 @@ func (task *Task) Login() bool {
 	task.Task.Client.Jar.SetCookies(baseURL, cookies)
 	TargetAccountStore.Set(task.AccountInfo.Email, task.Task.Client)
 	task.BrowserComplete = true
+	task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 
 	return true
 }
@@ -506,6 +510,7 @@ func (task *Task) RefreshLogin() {
 			}
 			resp, _, err := util.MakeRequest(&util.Request{
 				Client:             client.(http.Client),
+				Ctx:                task.Task.Ctx,
 				Method:             "POST",
 				URL:                RefreshLoginEndpoint,
 				AddHeadersFunction: AddTargetHeaders,
@@ -541,7 +546,7 @@ func (task *Task) RefreshLogin() {
 			for !loggedIn {
 				loggedIn = task.Login()
 				if !loggedIn {
-					time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+					task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 				}
 			}
 		}
@@ -557,6 +562,7 @@ func (task *Task) ClearCart() bool {
 	for _, cartItem := range cartInfo.CartItems {
 		resp, _, err := util.MakeRequest(&util.Request{
 			Client: task.Task.Client,
+			Ctx:    task.Task.Ctx,
 			Method: "DELETE",
 			URL:    fmt.Sprintf(ClearCartEndpoint, cartItem.CartItemID),
 			RawHeaders: http.RawHeader{
@@ -592,13 +598,12 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.InStockData.TCIN != "" {
+		if task.Task.TakeStock(func() bool { return task.InStockData.TCIN != "" }) {
 			task.TCINType = task.InStockData.TCINType
 			task.TCIN = task.InStockData.TCIN
-			task.Task.HasStockData = true
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -659,6 +664,7 @@ func (task *Task) AddToCart() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                AddToCartEndpoint,
 		AddHeadersFunction: AddTargetHeaders,
@@ -708,6 +714,7 @@ func (task *Task) GetCartInfo() (getCartInfoResponse GetCartInfoResponse, _ bool
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                GetCartInfoEndpoint,
 		AddHeadersFunction: AddTargetHeaders,
@@ -736,6 +743,7 @@ func (task *Task) GetCartInfo() (getCartInfoResponse GetCartInfoResponse, _ bool
 func (task *Task) SetShippingInfo() bool {
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "PUT",
 		URL:                fmt.Sprintf(SetShippingInfoEndpoint, task.AccountInfo.CartInfo.Addresses[1].AddressID),
 		AddHeadersFunction: AddTargetHeaders,
@@ -823,6 +831,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "PUT",
 		URL:                endpoint,
 		AddHeadersFunction: AddTargetHeaders,
@@ -852,6 +861,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus, bool) {
 	placeOrderResponse := PlaceOrderResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client:             task.Task.Client,
+		Ctx:                task.Task.Ctx,
 		Method:             "POST",
 		URL:                PlaceOrderEndpoint,
 		AddHeadersFunction: AddTargetHeaders,
@@ -994,6 +1004,7 @@ func (task *Task) TargetCancelMethod(placeOrderResponse PlaceOrderResponse) {
 	}
 	_, _, err = util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    TargetCancelMethodEndpoint,
 		RawHeaders: http.RawHeader{
@@ -1,6 +1,7 @@
 package topps
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
@@ -18,14 +19,10 @@ import (
 	"backend.juicedbot.io/juiced.sitescripts/hawk-go"
 	"backend.juicedbot.io/juiced.sitescripts/util"
 	"github.com/anaskhan96/soup"
-	cmap "github.com/orcaman/concurrent-map"
 )
 
-// Creating a pool to store topps accounts that will be used to monitor
-var AccountPool = cmap.New()
-
 // CreateToppsMonitor takes a TaskGroup entity and turns it into a Topps Monitor
-func CreateToppsMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.ToppsSingleMonitorInfo) (Monitor, error) {
+func CreateToppsMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.ToppsSingleMonitorInfo) (*Monitor, error) {
 	storedToppsMonitors := make(map[string]entities.ToppsSingleMonitorInfo)
 
 	items := []string{}
@@ -45,34 +42,36 @@ func CreateToppsMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Prox
 		ItemWithInfo: storedToppsMonitors,
 	}
 
-	return toppsMonitor, nil
+	return &toppsMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
 	return false
 }
 
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if recover() != nil {
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 	}()
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorStart, nil)
 	}
 
@@ -100,7 +99,7 @@ func (monitor *Monitor) RunMonitor() {
 			}
 			becameGuest = BecomeGuest(monitor.Monitor.Scraper)
 			if !becameGuest {
-				time.Sleep(1000 * time.Millisecond)
+				monitor.Monitor.Sleep(1000 * time.Millisecond)
 			}
 		}
 	}
@@ -128,20 +127,15 @@ again:
 	if needToStop {
 		return
 	}
-	accounts, _ := AccountPool.Get(monitor.Monitor.TaskGroup.GroupID)
-	if accounts != nil {
-		if !(len(accounts.([]Acc)) > 0) {
-			time.Sleep(common.MS_TO_WAIT)
-			goto again
-		}
-	} else {
-		time.Sleep(common.MS_TO_WAIT)
+	// The monitor uses the accounts that the TaskGroup's tasks logged in with
+	if len(monitor.Monitor.Sessions()) == 0 {
+		monitor.Monitor.Sleep(common.MS_TO_WAIT)
 		goto again
 	}
 
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(item)
 		}
 	}()
@@ -154,6 +148,7 @@ again:
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStock {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -165,43 +160,51 @@ again:
 					{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
 		if stockData.OutOfPriceRange {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.OutOfPriceRange {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.OutOfPriceRange {
 				monitor.PublishEvent(enums.OutOfPriceRange, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		} else {
-			if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+			if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 				monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 					Products: []events.Product{
 						{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 				})
 			}
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStock {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStock = append(monitor.InStock[:i], monitor.InStock[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	}
 
-	time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+	monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 	monitor.RunSingleMonitor(item)
 }
 
 // Gets the items stock
 func (monitor *Monitor) GetItemStock(itemURL string) ToppsInStockData {
-	pool, _ := AccountPool.Get(monitor.Monitor.TaskGroup.GroupID)
-	account := pool.([]Acc)[rand.Intn(len(pool.([]Acc)))]
+	stockData := ToppsInStockData{}
+	// The task whose account was picked may have stopped since the monitor checked
+	sessions := monitor.Monitor.Sessions()
+	if len(sessions) == 0 {
+		return stockData
+	}
+	account := sessions[rand.Intn(len(sessions))].(Acc)
 	currentScraper := account.Scraper
 
-	stockData := ToppsInStockData{}
 	resp, body, err := util.MakeRequest(&util.Request{
 		Scraper: currentScraper,
+		Ctx:     monitor.Monitor.Ctx,
 		Method:  "GET",
 		URL:     itemURL,
 		RawHeaders: http.RawHeader{
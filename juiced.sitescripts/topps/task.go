@@ -1,6 +1,7 @@
 package topps
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -28,7 +29,7 @@ import (
 var ToppsAccountStore = cmap.New()
 
 // CreateToppsTask takes a Task entity and turns it into a Topps Task
-func CreateToppsTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (Task, error) {
+func CreateToppsTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, taskType enums.TaskType, email, password string) (*Task, error) {
 	toppsTask := Task{}
 
 	toppsTask = Task{
@@ -45,24 +46,24 @@ func CreateToppsTask(task *entities.Task, profile entities.Profile, proxyGroup *
 		},
 	}
 	if proxyGroup != nil {
-		toppsTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		toppsTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		toppsTask.Task.Proxy = nil
+		toppsTask.Task.SetProxy(nil)
 	}
-	return toppsTask, nil
+	return &toppsTask, nil
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -78,25 +79,26 @@ func (task *Task) CheckForStop() bool {
 //		5. SubmitShippingInfo
 // 		6. GetCardToken
 // 		7. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = ToppsInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = ToppsInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -105,7 +107,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -117,14 +119,8 @@ func (task *Task) RunTask() {
 		return
 	}
 
-	// Adding the account to the pool
-	var accounts = []Acc{{task.Task.Task.TaskGroupID, task.Task.Scraper, task.AccountInfo}}
-	oldAccounts, _ := AccountPool.Get(task.Task.Task.TaskGroupID)
-	if oldAccounts != nil {
-		accounts = append(accounts, oldAccounts.([]Acc)...)
-	}
-
-	AccountPool.Set(task.Task.Task.TaskGroupID, accounts)
+	// Share the logged in account with the monitor
+	task.Task.ShareSession(Acc{task.Task.Task.TaskGroupID, task.Task.Scraper, task.AccountInfo})
 
 	task.PublishEvent(enums.WaitingForMonitor, enums.TaskUpdate, 20)
 	// 2. WaitForMonitor
@@ -143,7 +139,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -158,7 +154,7 @@ func (task *Task) RunTask() {
 		}
 		gotCartInfo = task.GetCartInfo()
 		if !gotCartInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -172,7 +168,7 @@ func (task *Task) RunTask() {
 		}
 		submittedShippingInfo = task.SubmitShippingInfo()
 		if !submittedShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -186,7 +182,7 @@ func (task *Task) RunTask() {
 		}
 		gotCardToken = task.GetCardToken()
 		if !gotCardToken {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -207,7 +203,7 @@ func (task *Task) RunTask() {
 		placedOrder, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -227,7 +223,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -249,7 +245,7 @@ func (task *Task) Setup() bool {
 		return !BecomeGuest(task.Task.Scraper)
 	}
 	// Bad but quick solution to the multiple logins
-	time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
+	task.Task.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
 	if ToppsAccountStore.Has(task.AccountInfo.Email) {
 		inMap := true
 		for inMap {
@@ -268,10 +264,10 @@ func (task *Task) Setup() bool {
 					task.AccountInfo = acc.AccountInfo
 					break
 				} else {
-					if task.Task.Task.TaskStatus != enums.WaitingForLogin {
+					if task.Task.Task.GetTaskStatus() != enums.WaitingForLogin {
 						task.PublishEvent(enums.WaitingForLogin, enums.TaskUpdate, 15)
 					}
-					time.Sleep(common.MS_TO_WAIT)
+					task.Task.Sleep(common.MS_TO_WAIT)
 				}
 			} else {
 				inMap = false
@@ -289,9 +285,10 @@ func (task *Task) Setup() bool {
 			}
 			loggedIn = task.Login()
 			if !loggedIn {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
+		task.Task.SetAccountStatus(enums.AccountStatusLoggedIn)
 
 	}
 
@@ -310,6 +307,7 @@ func (task *Task) Login() bool {
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "GET",
 		URL:     BaseLoginEndpoint,
 		RawHeaders: http.RawHeader{
@@ -340,8 +338,8 @@ func (task *Task) Login() bool {
 	formKey := elem.Attrs()["value"]
 
 	proxy := entities.Proxy{}
-	if task.Task.Proxy != nil {
-		proxy = *task.Task.Proxy
+	if task.Task.Proxy() != nil {
+		proxy = *task.Task.Proxy()
 	}
 	token, err := captcha.RequestCaptchaToken(enums.ReCaptchaV2, enums.Topps, BaseLoginEndpoint+"/", "login", 0.7, proxy)
 	if err != nil {
@@ -349,9 +347,12 @@ func (task *Task) Login() bool {
 		return false
 	}
 
-	for token == nil {
-		token = captcha.PollCaptchaTokens(enums.ReCaptchaV2, enums.Topps, BaseLoginEndpoint+"/", proxy)
-		time.Sleep(common.MS_TO_WAIT)
+	if token == nil {
+		token, err = captcha.WaitForCaptchaToken(task.Task.Ctx, enums.ReCaptchaV2, enums.Topps, BaseLoginEndpoint+"/", proxy)
+		if err != nil {
+			ToppsAccountStore.Remove(task.AccountInfo.Email)
+			return false
+		}
 	}
 	tokenInfo, ok := token.(entities.ReCaptchaToken)
 	if !ok {
@@ -370,8 +371,9 @@ func (task *Task) Login() bool {
 		"send":                               ``,
 	})
 
-	resp, _, err = util.MakeRequest(&util.Request{
+	resp, body, err = util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     LoginEndpoint,
 		RawHeaders: http.RawHeader{
@@ -398,6 +400,17 @@ func (task *Task) Login() bool {
 		ToppsAccountStore.Remove(task.AccountInfo.Email)
 		return false
 	}
+	if strings.Contains(body, "The account sign-in was incorrect") {
+		task.Task.SetAccountStatus(enums.AccountStatusBadCredentials)
+		task.PublishEvent("Incorrect email or password", enums.TaskFail, 0)
+		ToppsAccountStore.Remove(task.AccountInfo.Email)
+		return false
+	} else if strings.Contains(body, "The account is locked") {
+		task.Task.SetAccountStatus(enums.AccountStatusLocked)
+		task.PublishEvent("Account is locked", enums.TaskFail, 0)
+		ToppsAccountStore.Remove(task.AccountInfo.Email)
+		return false
+	}
 
 	acc := Acc{
 		GroupID: task.Task.Task.TaskGroupID,
@@ -421,11 +434,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.SKU != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.SKU != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -443,6 +455,7 @@ func (task *Task) AddToCart() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     task.StockData.AddURL,
 		RawHeaders: http.RawHeader{
@@ -477,6 +490,7 @@ func (task *Task) GetCartInfo() bool {
 	var getCartInfoResponse GetCartInfoResponse
 	resp, _, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "GET",
 		URL:     GetCartInfoEndpoint + fmt.Sprint(time.Now().UnixNano()),
 		RawHeaders: http.RawHeader{
@@ -581,6 +595,7 @@ func (task *Task) SubmitShippingInfo() bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     currentEndpoint,
 		RawHeaders: http.RawHeader{
@@ -614,6 +629,7 @@ func (task *Task) GetCardToken() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     GetCardTokenEndpoint,
 		Headers: http.Header{
@@ -664,6 +680,7 @@ func (task *Task) GetCardToken() bool {
 	var getCardTokenResponse GetCardTokenResponse
 	resp, _, err = util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     GetCardTokenEndpoint,
 		Headers: http.Header{
@@ -736,6 +753,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 	data, _ := json.Marshal(placeOrderRequest)
 	resp, _, err := util.MakeRequest(&util.Request{
 		Scraper: task.Task.Scraper,
+		Ctx:     task.Task.Ctx,
 		Method:  "POST",
 		URL:     currentEndpoint,
 		RawHeaders: http.RawHeader{
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,7 +13,6 @@ import (
 	"net/url"
 	"os"
 	"regexp"
-	"sort"
 	"strings"
 	"time"
 
@@ -25,6 +25,8 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
 	sec "backend.juicedbot.io/juiced.security/auth/util"
 	"backend.juicedbot.io/juiced.sitescripts/base"
@@ -96,7 +98,11 @@ func MakeRequest(requestInfo *Request) (*http.Response, string, error) {
 		}
 		payload = bytes.NewBuffer(data)
 	}
-	request, err := http.NewRequest(requestInfo.Method, requestInfo.URL, payload)
+	ctx := requestInfo.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	request, err := http.NewRequestWithContext(ctx, requestInfo.Method, requestInfo.URL, payload)
 	ok := HandleErrors(err, RequestCreateError)
 	if !ok {
 		return nil, "", err
@@ -176,33 +182,31 @@ func MakeRequest(requestInfo *Request) (*http.Response, string, error) {
 	return response, newBody, nil
 }
 
-var hookChan = make(chan HookInfo)
-
+// QueueWebhook queues the embeds in the outbox for the success or failure Discord webhook, if the Settings have one.
+// The OutboxStore delivers them in order, and keeps retrying them across restarts.
 func QueueWebhook(success bool, content string, embeds []Embed) {
-	hookChan <- HookInfo{
-		Success: success,
-		Content: content,
-		Embeds:  embeds,
+	settings, err := queries.GetSettings()
+	if err != nil {
+		log.Println("Error queueing a Discord webhook: " + err.Error())
+		return
+	}
+	destination, webhookURL := enums.DiscordSuccessDestination, settings.SuccessDiscordWebhook
+	if !success {
+		destination, webhookURL = enums.DiscordFailureDestination, settings.FailureDiscordWebhook
+	}
+	if webhookURL == "" {
+		return
 	}
-}
 
-func DiscordWebhookQueue() {
-	for {
-		hook := <-hookChan
-		settings, err := queries.GetSettings()
-		if err != nil {
-			return
-		}
-		var webhookURL string
-		if hook.Success {
-			webhookURL = settings.SuccessDiscordWebhook
-		} else {
-			webhookURL = settings.FailureDiscordWebhook
-		}
-		if webhookURL != "" {
-			SendDiscordWebhook(webhookURL, hook.Embeds)
-		}
-		time.Sleep(2*time.Second + (time.Second / 2))
+	payload, err := json.Marshal(DiscordWebhook{
+		Content: nil,
+		Embeds:  embeds,
+	})
+	if err == nil {
+		err = commands.CreateOutboxEntry(notifications.NewOutboxEntry(destination, "", string(payload)))
+	}
+	if err != nil {
+		log.Println("Error queueing a Discord webhook: " + err.Error())
 	}
 }
 
@@ -243,14 +247,7 @@ func ProxyCleaner(proxyDirty *entities.Proxy) string {
 	if proxyDirty == nil {
 		return ""
 	}
-	if proxyDirty.Host == "" {
-		return ""
-	}
-	if proxyDirty.Username == "" && proxyDirty.Password == "" {
-		return fmt.Sprintf("http://%s:%s", proxyDirty.Host, proxyDirty.Port)
-	} else {
-		return fmt.Sprintf("http://%s:%s@%s:%s", proxyDirty.Username, proxyDirty.Password, proxyDirty.Host, proxyDirty.Port)
-	}
+	return proxyDirty.URL()
 }
 
 func FindInString(str string, start string, end string) (string, error) {
@@ -475,18 +472,67 @@ func ProcessCheckout(pci *ProcessCheckoutInfo) {
 		return
 	}
 	pci.UserInfo = user
+	if pci.BaseTask != nil && pci.BaseTask.EventBus != nil {
+		pci.BaseTask.EventBus.PublishCheckoutEvent(events.CheckoutEvent{
+			TaskID:      pci.BaseTask.Task.ID,
+			TaskGroupID: pci.BaseTask.Task.TaskGroupID,
+			Retailer:    pci.BaseTask.Task.TaskRetailer,
+			Status:      pci.Status,
+			ItemName:    pci.ItemName,
+			SKU:         pci.Sku,
+			Price:       pci.Price,
+			Quantity:    pci.Quantity,
+		})
+	}
 	if pci.Status != enums.OrderStatusFailed {
 		go sec.DiscordWebhook(pci.Success, pci.Content, pci.Embeds, pci.UserInfo)
 	}
 	if pci.Success {
 		go sec.LogCheckout(pci.ItemName, pci.Sku, pci.Retailer, int(pci.Price), pci.Quantity, pci.UserInfo)
-		go SendCheckout(&pci.BaseTask, pci.ItemName, pci.ImageURL, pci.Sku, int(pci.Price), pci.Quantity, pci.MsToCheckout)
+	}
+	if pci.BaseTask != nil {
+		go SendCheckout(pci.BaseTask, pci.Status, pci.ItemName, pci.ImageURL, pci.Sku, int(pci.Price), pci.Quantity, pci.MsToCheckout)
+	}
+	if sinks, err := queries.GetNotificationSinks(); err == nil {
+		notifications.Notify(sinks, CheckoutMessage(pci))
+	} else {
+		log.Println("Error getting the notification sinks: " + err.Error())
 	}
 	QueueWebhook(pci.Success, pci.Content, SecToUtil(pci.Embeds))
 }
 
-// Logs the checkout
-func SendCheckout(task *base.Task, itemName string, imageURL string, sku string, price int, quantity int, msToCheckout int64) {
+// CheckoutMessage returns the notification Message for the checkout, failed checkouts are task failures
+func CheckoutMessage(pci *ProcessCheckoutInfo) notifications.Message {
+	message := notifications.Message{
+		Event:        enums.NotificationTaskFailure,
+		Success:      pci.Success,
+		Status:       pci.Status,
+		ItemName:     pci.ItemName,
+		ImageURL:     pci.ImageURL,
+		Sku:          pci.Sku,
+		Retailer:     pci.Retailer,
+		Price:        pci.Price,
+		Quantity:     pci.Quantity,
+		MsToCheckout: pci.MsToCheckout,
+	}
+	switch pci.Status {
+	case enums.OrderStatusSuccess:
+		message.Event = enums.NotificationCheckoutSuccess
+	case enums.OrderStatusDeclined:
+		message.Event = enums.NotificationCheckoutDecline
+	}
+	if pci.BaseTask != nil {
+		message.ProfileName = pci.BaseTask.Profile.Name
+		if pci.BaseTask.Task != nil {
+			message.TaskID = pci.BaseTask.Task.ID
+			message.TaskGroupID = pci.BaseTask.Task.TaskGroupID
+		}
+	}
+	return message
+}
+
+// Logs the checkout, whatever its status
+func SendCheckout(task *base.Task, status enums.OrderStatus, itemName string, imageURL string, sku string, price int, quantity int, msToCheckout int64) {
 	commands.CreateCheckout(entities.Checkout{
 		ItemName:     itemName,
 		ImageURL:     imageURL,
@@ -497,6 +543,7 @@ func SendCheckout(task *base.Task, itemName string, imageURL string, sku string,
 		ProfileName:  task.Profile.Name,
 		MsToCheckout: msToCheckout,
 		Time:         time.Now().Unix(),
+		Status:       status,
 	})
 }
 
@@ -653,23 +700,14 @@ func GetCardType(cardNumber []byte, retailer enums.Retailer) string {
 	return ""
 }
 
-// Takes a slice of proxies and returns a random proxy from the proxies being used least
+// Takes a slice of proxies and returns a random proxy from the proxies being used least.
+// Proxies that their last test found dead or slow are skipped, unless none of the proxies are healthy.
 func RandomLeastUsedProxy(proxies []*entities.Proxy) *entities.Proxy {
 	if len(proxies) == 0 {
 		return &entities.Proxy{}
 	}
-	countMap := make(map[int][]*entities.Proxy)
-	for _, proxy := range proxies {
-		countMap[proxy.Count] = append(countMap[proxy.Count], proxy)
-	}
-
-	var proxyCounts []int
-	for key := range countMap {
-		proxyCounts = append(proxyCounts, key)
-	}
-	sort.Ints(proxyCounts)
-
-	return countMap[proxyCounts[0]][rand.Intn(len(countMap[proxyCounts[0]]))]
+	leastUsedProxies := entities.LeastUsedProxies(proxies)
+	return leastUsedProxies[rand.Intn(len(leastUsedProxies))]
 }
 
 // CreateClient creates an HTTP client
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"strings"
+)
+
+// ParseKeywords splits a keyword list like "+dunk,-kids" into the keywords that a product's title must contain and the
+// ones that it must not, keywords without a + or - are positive
+func ParseKeywords(keywords string) ([]string, []string) {
+	positive, negative := []string{}, []string{}
+	for _, keyword := range strings.Split(keywords, ",") {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+		switch {
+		case strings.HasPrefix(keyword, "-"):
+			if keyword = strings.TrimSpace(keyword[1:]); keyword != "" {
+				negative = append(negative, keyword)
+			}
+		case strings.HasPrefix(keyword, "+"):
+			if keyword = strings.TrimSpace(keyword[1:]); keyword != "" {
+				positive = append(positive, keyword)
+			}
+		case keyword != "":
+			positive = append(positive, keyword)
+		}
+	}
+	return positive, negative
+}
+
+// MatchesKeywords returns true if the title contains every positive keyword and none of the negative ones, ignoring case.
+// A title never matches without any positive keywords, otherwise every product would.
+func MatchesKeywords(title string, positive []string, negative []string) bool {
+	if len(positive) == 0 {
+		return false
+	}
+	title = strings.ToLower(title)
+	for _, keyword := range positive {
+		if !strings.Contains(title, keyword) {
+			return false
+		}
+	}
+	for _, keyword := range negative {
+		if strings.Contains(title, keyword) {
+			return false
+		}
+	}
+	return true
+}
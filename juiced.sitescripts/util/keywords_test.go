@@ -0,0 +1,39 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeywords(t *testing.T) {
+	positive, negative := ParseKeywords(" +Dunk, low ,-kids,-, +")
+	if !reflect.DeepEqual(positive, []string{"dunk", "low"}) {
+		t.Errorf("expected positive keywords [dunk low], got %v", positive)
+	}
+	if !reflect.DeepEqual(negative, []string{"kids"}) {
+		t.Errorf("expected negative keywords [kids], got %v", negative)
+	}
+}
+
+func TestMatchesKeywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		keywords string
+		want     bool
+	}{
+		{name: "Every Positive Keyword", title: "Nike Dunk Low Retro", keywords: "+dunk,+low", want: true},
+		{name: "Missing Positive Keyword", title: "Nike Dunk High Pro", keywords: "+dunk,+low", want: false},
+		{name: "Negative Keyword", title: "Nike Dunk Low Kids", keywords: "+dunk,-kids", want: false},
+		{name: "Ignores Case", title: "NIKE DUNK LOW", keywords: "+Dunk", want: true},
+		{name: "Only Negative Keywords", title: "Nike Air Max 90", keywords: "-kids", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positive, negative := ParseKeywords(tt.keywords)
+			if got := MatchesKeywords(tt.title, positive, negative); got != tt.want {
+				t.Errorf("MatchesKeywords(%q, %q) = %v, want %v", tt.title, tt.keywords, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"context"
 	"time"
 
 	"backend.juicedbot.io/juiced.client/http"
@@ -29,6 +30,8 @@ type AddHeadersFunction func(*http.Request, ...string)
 
 // Request parameters
 type Request struct {
+	// Ctx cancels the request when it's done, requests made without one can't be cancelled
+	Ctx                context.Context
 	Client             http.Client
 	Scraper            hawk.Scraper
 	Method             string
@@ -53,12 +56,6 @@ type DiscordWebhook struct {
 	Embeds  []Embed     `json:"embeds"`
 }
 
-type HookInfo struct {
-	Success bool
-	Content string
-	Embeds  []Embed
-}
-
 type Embed struct {
 	Title     string    `json:"title"`
 	Color     int       `json:"color"`
@@ -93,7 +90,7 @@ type SensorResponse struct {
 
 // All info needed for ProcessCheckout
 type ProcessCheckoutInfo struct {
-	BaseTask     base.Task
+	BaseTask     *base.Task
 	Success      bool
 	Status       enums.OrderStatus
 	Content      string
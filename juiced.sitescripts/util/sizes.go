@@ -0,0 +1,50 @@
+package util
+
+import (
+	"math/rand"
+	"strings"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+// SizeMatches returns true if the size is one of the preferred sizes, ignoring case and surrounding spaces.
+// No preferred sizes, a preferred size of "random" or "any", or a product without sizes (an empty size) matches every preference.
+func SizeMatches(preferredSizes []string, size string) bool {
+	size = strings.TrimSpace(size)
+	if size == "" || len(preferredSizes) == 0 {
+		return true
+	}
+	for _, preferredSize := range preferredSizes {
+		preferredSize = strings.TrimSpace(preferredSize)
+		if strings.EqualFold(preferredSize, enums.RandomSize) || strings.EqualFold(preferredSize, enums.AnySize) || strings.EqualFold(preferredSize, size) {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectSizes returns the indexes of the in stock sizes that match the preferred sizes. If none of them match, the fallback
+// decides between every index (enums.SizeFallbackAny) and none of them (enums.SizeFallbackWait, the default).
+func SelectSizes(preferredSizes []string, inStockSizes []string, fallback enums.SizeFallback) []int {
+	indexes := []int{}
+	for i, size := range inStockSizes {
+		if SizeMatches(preferredSizes, size) {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 && fallback == enums.SizeFallbackAny {
+		for i := range inStockSizes {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// SelectSize returns the index of a random in stock size out of the ones that SelectSizes returns, or false if there aren't any.
+func SelectSize(preferredSizes []string, inStockSizes []string, fallback enums.SizeFallback) (int, bool) {
+	indexes := SelectSizes(preferredSizes, inStockSizes, fallback)
+	if len(indexes) == 0 {
+		return 0, false
+	}
+	return indexes[rand.Intn(len(indexes))], true
+}
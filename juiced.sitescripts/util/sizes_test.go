@@ -0,0 +1,72 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+)
+
+func TestSizeMatches(t *testing.T) {
+	tests := []struct {
+		name           string
+		preferredSizes []string
+		size           string
+		want           bool
+	}{
+		{name: "No Preferred Sizes", preferredSizes: nil, size: "M", want: true},
+		{name: "Exact Size", preferredSizes: []string{"S", "M"}, size: "M", want: true},
+		{name: "Ignores Case And Spaces", preferredSizes: []string{" xl "}, size: "XL", want: true},
+		{name: "No Substring Matches", preferredSizes: []string{"S"}, size: "XS", want: false},
+		{name: "Other Size", preferredSizes: []string{"S", "M"}, size: "L", want: false},
+		{name: "Random", preferredSizes: []string{"Random"}, size: "L", want: true},
+		{name: "Any", preferredSizes: []string{"S", "any"}, size: "L", want: true},
+		{name: "No Sizes", preferredSizes: []string{"S"}, size: "", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SizeMatches(tt.preferredSizes, tt.size); got != tt.want {
+				t.Errorf("SizeMatches(%v, %q) = %v, want %v", tt.preferredSizes, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSizes(t *testing.T) {
+	inStockSizes := []string{"S", "M", "L", "M"}
+	tests := []struct {
+		name           string
+		preferredSizes []string
+		fallback       enums.SizeFallback
+		want           []int
+	}{
+		{name: "Preferred Sizes", preferredSizes: []string{"M"}, fallback: enums.SizeFallbackWait, want: []int{1, 3}},
+		{name: "Preferred Sizes Ignore Fallback", preferredSizes: []string{"S", "L"}, fallback: enums.SizeFallbackAny, want: []int{0, 2}},
+		{name: "Random", preferredSizes: []string{enums.RandomSize}, fallback: enums.SizeFallbackWait, want: []int{0, 1, 2, 3}},
+		{name: "Wait Fallback", preferredSizes: []string{"XL"}, fallback: enums.SizeFallbackWait, want: []int{}},
+		{name: "Default Fallback Waits", preferredSizes: []string{"XL"}, fallback: "", want: []int{}},
+		{name: "Any Fallback", preferredSizes: []string{"XL"}, fallback: enums.SizeFallbackAny, want: []int{0, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectSizes(tt.preferredSizes, inStockSizes, tt.fallback); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectSizes(%v, %v, %q) = %v, want %v", tt.preferredSizes, inStockSizes, tt.fallback, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSize(t *testing.T) {
+	inStockSizes := []string{"S", "M", "L", "M"}
+	for i := 0; i < 20; i++ {
+		if got, ok := SelectSize([]string{"M"}, inStockSizes, enums.SizeFallbackWait); !ok || inStockSizes[got] != "M" {
+			t.Fatalf("SelectSize([M], %v, wait) = %d, %v, want an index of M", inStockSizes, got, ok)
+		}
+	}
+	if got, ok := SelectSize([]string{"XL"}, inStockSizes, enums.SizeFallbackWait); ok {
+		t.Errorf("SelectSize([XL], %v, wait) = %d, %v, want no size", inStockSizes, got, ok)
+	}
+	if _, ok := SelectSize(nil, nil, enums.SizeFallbackAny); ok {
+		t.Error("SelectSize with no in stock sizes returned a size")
+	}
+}
@@ -1,6 +1,7 @@
 package walmart
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	sec "backend.juicedbot.io/juiced.security/auth/util"
+	"backend.juicedbot.io/juiced.sitescripts/base"
 	"backend.juicedbot.io/juiced.sitescripts/util"
 	"github.com/anaskhan96/soup"
 )
@@ -31,7 +33,7 @@ func AddWalmartHeaders(request *http.Request, referer ...string) {
 	}
 }
 
-func SetPXCookie(proxy *entities.Proxy, client *http.Client, cancellationToken *util.CancellationToken) (util.PXValues, bool, error) {
+func SetPXCookie(ctx context.Context, proxy *entities.Proxy, client *http.Client, cancellationToken *util.CancellationToken) (util.PXValues, bool, error) {
 	var px3 string
 	var pxValues util.PXValues
 	var cancel bool
@@ -41,7 +43,7 @@ func SetPXCookie(proxy *entities.Proxy, client *http.Client, cancellationToken *
 		if err == nil || err.Error() != "retry" {
 			break
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		base.Sleep(ctx, common.MS_TO_WAIT)
 	}
 	if cancel {
 		return pxValues, true, nil
@@ -70,7 +72,7 @@ func SetPXCookie(proxy *entities.Proxy, client *http.Client, cancellationToken *
 	return pxValues, false, nil
 }
 
-func SetPXCapCookie(captchaURL string, pxValues *util.PXValues, proxy *entities.Proxy, client *http.Client, cancellationToken *util.CancellationToken) error {
+func SetPXCapCookie(ctx context.Context, captchaURL string, pxValues *util.PXValues, proxy *entities.Proxy, client *http.Client, cancellationToken *util.CancellationToken) error {
 	var px3 string
 	var cancel bool
 	var err error
@@ -79,7 +81,7 @@ func SetPXCapCookie(captchaURL string, pxValues *util.PXValues, proxy *entities.
 		if err == nil || err.Error() != "retry" {
 			break
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		base.Sleep(ctx, common.MS_TO_WAIT)
 	}
 
 	if cancel {
@@ -156,10 +158,10 @@ func (task *Task) CreateWalmartEmbed(status enums.OrderStatus, imageURL string)
 		},
 	}
 
-	if task.Task.Proxy != nil {
+	if task.Task.Proxy() != nil {
 		fields = append(fields, sec.DiscordField{
 			Name:  "Proxy:",
-			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy) + " " + "||",
+			Value: "||" + " " + util.ProxyCleaner(task.Task.Proxy()) + " " + "||",
 		})
 	}
 
@@ -1,6 +1,7 @@
 package walmart
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -18,7 +19,7 @@ import (
 )
 
 // CreateWalmartMonitor takes a TaskGroup entity and turns it into a Walmart Monitor
-func CreateWalmartMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.WalmartSingleMonitorInfo) (Monitor, error) {
+func CreateWalmartMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus, singleMonitors []entities.WalmartSingleMonitorInfo) (*Monitor, error) {
 	storedWalmartMonitors := make(map[string]entities.WalmartSingleMonitorInfo)
 	walmartMonitor := Monitor{}
 	ids := []string{}
@@ -39,18 +40,18 @@ func CreateWalmartMonitor(taskGroup *entities.TaskGroup, proxyGroup *entities.Pr
 		IDWithInfo: storedWalmartMonitors,
 	}
 
-	return walmartMonitor, nil
+	return &walmartMonitor, nil
 }
 
 // PublishEvent wraps the EventBus's PublishMonitorEvent function
 func (monitor *Monitor) PublishEvent(status enums.MonitorStatus, eventType enums.MonitorEventType, data interface{}) {
 	monitor.Monitor.TaskGroup.SetMonitorStatus(status)
-	monitor.Monitor.EventBus.PublishMonitorEvent(status, eventType, data, monitor.Monitor.TaskGroup.GroupID)
+	monitor.Monitor.PublishEvent(status, eventType, data)
 }
 
 //This checks if we want to stop
 func (monitor *Monitor) CheckForStop() bool {
-	if monitor.Monitor.StopFlag {
+	if monitor.Monitor.StopFlag() {
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorStop, nil)
 		return true
 	}
@@ -58,12 +59,14 @@ func (monitor *Monitor) CheckForStop() bool {
 }
 
 //This is responsible for starting the Walmart Product monitor
-func (monitor *Monitor) RunMonitor() {
+func (monitor *Monitor) RunMonitor(ctx context.Context) {
+	monitor.Monitor.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			log.Println(r)
-			monitor.Monitor.StopFlag = true
+			monitor.Monitor.Stop()
 			monitor.PublishEvent(enums.MonitorIdle, enums.MonitorFail, nil)
 		}
 		monitor.PublishEvent(enums.MonitorIdle, enums.MonitorComplete, nil)
@@ -77,7 +80,7 @@ func (monitor *Monitor) RunMonitor() {
 
 	}
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.MonitorIdle {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.MonitorIdle {
 		monitor.PublishEvent(enums.SettingUpMonitor, enums.MonitorStart, nil)
 
 		if monitor.PXValues.RefreshAt == 0 {
@@ -87,12 +90,12 @@ func (monitor *Monitor) RunMonitor() {
 				if needToStop {
 					return
 				}
-				time.Sleep(common.MS_TO_WAIT)
+				monitor.Monitor.Sleep(common.MS_TO_WAIT)
 			}
 		}
 	}
 
-	if monitor.Monitor.TaskGroup.MonitorStatus == enums.SettingUpMonitor {
+	if monitor.Monitor.TaskGroup.GetMonitorStatus() == enums.SettingUpMonitor {
 		monitor.PublishEvent(enums.WaitingForProductData, enums.MonitorUpdate, nil)
 	}
 
@@ -115,7 +118,7 @@ func (monitor *Monitor) RunMonitor() {
 func (monitor *Monitor) RunSingleMonitor(id string) {
 	defer func() {
 		if recover() != nil {
-			time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+			monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 			monitor.RunSingleMonitor(id)
 		}
 	}()
@@ -148,6 +151,7 @@ func (monitor *Monitor) RunSingleMonitor(id string) {
 			return
 		}
 
+		monitor.Monitor.StockMutex.Lock()
 		var inSlice bool
 		for _, monitorStock := range monitor.InStockForShip {
 			inSlice = monitorStock.SKU == stockData.SKU
@@ -159,21 +163,24 @@ func (monitor *Monitor) RunSingleMonitor(id string) {
 					{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Unlock()
 	} else {
-		if monitor.Monitor.TaskGroup.MonitorStatus != enums.WaitingForInStock {
+		if monitor.Monitor.TaskGroup.GetMonitorStatus() != enums.WaitingForInStock {
 			monitor.PublishEvent(enums.WaitingForInStock, enums.MonitorUpdate, events.ProductInfo{
 				Products: []events.Product{
 					{ProductName: stockData.ProductName, ProductImageURL: stockData.ImageURL}},
 			})
 		}
+		monitor.Monitor.StockMutex.Lock()
 		for i, monitorStock := range monitor.InStockForShip {
 			if monitorStock.SKU == stockData.SKU {
 				monitor.InStockForShip = append(monitor.InStockForShip[:i], monitor.InStockForShip[i+1:]...)
 				break
 			}
 		}
+		monitor.Monitor.StockMutex.Unlock()
 
-		time.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
+		monitor.Monitor.Sleep(time.Duration(monitor.Monitor.TaskGroup.MonitorDelay) * time.Millisecond)
 		monitor.RunSingleMonitor(id)
 	}
 }
@@ -198,14 +205,14 @@ func (monitor *Monitor) RefreshPX3() {
 					return
 				}
 			}
-			time.Sleep(common.MS_TO_WAIT)
+			monitor.Monitor.Sleep(common.MS_TO_WAIT)
 		}
 	}()
 
 	retry := true
 	for retry {
 		retry = monitor.RefreshPX3Helper(cancellationToken)
-		time.Sleep(common.MS_TO_WAIT)
+		monitor.Monitor.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -215,7 +222,7 @@ func (monitor *Monitor) RefreshPX3Helper(cancellationToken *util.CancellationTok
 			return false
 		}
 		if monitor.PXValues.RefreshAt == 0 || time.Now().Unix() > monitor.PXValues.RefreshAt {
-			pxValues, cancelled, err := SetPXCookie(monitor.Monitor.Proxy, &monitor.Monitor.Client, cancellationToken)
+			pxValues, cancelled, err := SetPXCookie(monitor.Monitor.Ctx, monitor.Monitor.Proxy(), &monitor.Monitor.Client, cancellationToken)
 			if cancelled {
 				return false
 			}
@@ -227,11 +234,11 @@ func (monitor *Monitor) RefreshPX3Helper(cancellationToken *util.CancellationTok
 			monitor.PXValues = pxValues
 			monitor.PXValues.RefreshAt = time.Now().Unix() + 240
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		monitor.Monitor.Sleep(common.MS_TO_WAIT)
 	}
 }
 
-func (monitor Monitor) HandlePXCap(resp *http.Response, redirectURL string) bool {
+func (monitor *Monitor) HandlePXCap(resp *http.Response, redirectURL string) bool {
 	quit := make(chan bool)
 	defer func() {
 		quit <- true
@@ -250,7 +257,7 @@ func (monitor Monitor) HandlePXCap(resp *http.Response, redirectURL string) bool
 					return
 				}
 			}
-			time.Sleep(common.MS_TO_WAIT)
+			monitor.Monitor.Sleep(common.MS_TO_WAIT)
 		}
 	}()
 
@@ -259,7 +266,7 @@ func (monitor Monitor) HandlePXCap(resp *http.Response, redirectURL string) bool
 	if redirectURL != "" {
 		captchaURL = BaseEndpoint + redirectURL[1:]
 	}
-	err := SetPXCapCookie(strings.ReplaceAll(captchaURL, "affil.", ""), &monitor.PXValues, monitor.Monitor.Proxy, &monitor.Monitor.Client, &cancellationToken)
+	err := SetPXCapCookie(monitor.Monitor.Ctx, strings.ReplaceAll(captchaURL, "affil.", ""), &monitor.PXValues, monitor.Monitor.Proxy(), &monitor.Monitor.Client, &cancellationToken)
 	if err != nil {
 		log.Println(err.Error())
 		return false
@@ -286,6 +293,7 @@ func (monitor *Monitor) GetOfferIDStock(offerID string) WalmartInStockData {
 	addToCartResponse := AddToCartResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "POST",
 		URL:    AddToCartEndpoint,
 		RawHeaders: [][2]string{
@@ -354,6 +362,7 @@ func (monitor *Monitor) GetSkuStock(sku string) WalmartInStockData {
 
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: monitor.Monitor.Client,
+		Ctx:    monitor.Monitor.Ctx,
 		Method: "GET",
 		URL:    fmt.Sprintf(MonitorEndpoint, sku),
 		RawHeaders: [][2]string{
@@ -1,6 +1,7 @@
 package walmart
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -19,7 +20,7 @@ import (
 )
 
 // CreateWalmartTask takes a Task entity and turns it into a Walmart Task
-func CreateWalmartTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (Task, error) {
+func CreateWalmartTask(task *entities.Task, profile entities.Profile, proxyGroup *entities.ProxyGroup, eventBus *events.EventBus) (*Task, error) {
 	walmartTask := Task{
 		Task: base.Task{
 			Task:       task,
@@ -29,11 +30,11 @@ func CreateWalmartTask(task *entities.Task, profile entities.Profile, proxyGroup
 		},
 	}
 	if proxyGroup != nil {
-		walmartTask.Task.Proxy = util.RandomLeastUsedProxy(proxyGroup.Proxies)
+		walmartTask.Task.SetProxy(util.RandomLeastUsedProxy(proxyGroup.Proxies))
 	} else {
-		walmartTask.Task.Proxy = nil
+		walmartTask.Task.SetProxy(nil)
 	}
-	return walmartTask, nil
+	return &walmartTask, nil
 }
 
 // RefreshPX3 refreshes the px3 cookie every 4 minutes since it expires every 5 minutes
@@ -56,14 +57,14 @@ func (task *Task) RefreshPX3() {
 					return
 				}
 			}
-			time.Sleep(common.MS_TO_WAIT)
+			task.Task.Sleep(common.MS_TO_WAIT)
 		}
 	}()
 
 	retry := true
 	for retry {
 		retry = task.RefreshPX3Helper(cancellationToken)
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -73,7 +74,7 @@ func (task *Task) RefreshPX3Helper(cancellationToken *util.CancellationToken) bo
 			return false
 		}
 		if task.PXValues.RefreshAt == 0 || time.Now().Unix() > task.PXValues.RefreshAt {
-			pxValues, cancelled, err := SetPXCookie(task.Task.Proxy, &task.Task.Client, cancellationToken)
+			pxValues, cancelled, err := SetPXCookie(task.Task.Ctx, task.Task.Proxy(), &task.Task.Client, cancellationToken)
 			if cancelled {
 				return false
 			}
@@ -85,21 +86,21 @@ func (task *Task) RefreshPX3Helper(cancellationToken *util.CancellationToken) bo
 			task.PXValues = pxValues
 			task.PXValues.RefreshAt = time.Now().Unix() + 240
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
 // PublishEvent wraps the EventBus's PublishTaskEvent function
 func (task *Task) PublishEvent(status enums.TaskStatus, eventType enums.TaskEventType, statusPercentage int) {
-	if status == enums.TaskIdle || !task.Task.StopFlag {
+	if status == enums.TaskIdle || !task.Task.StopFlag() {
 		task.Task.Task.SetTaskStatus(status)
-		task.Task.EventBus.PublishTaskEvent(status, statusPercentage, eventType, nil, task.Task.Task.ID)
+		task.Task.PublishEvent(status, statusPercentage, eventType, nil)
 	}
 }
 
 // CheckForStop checks the stop flag and stops the monitor if it's true
 func (task *Task) CheckForStop() bool {
-	if task.Task.StopFlag && !task.Task.DontPublishEvents {
+	if task.Task.StopFlag() && !task.Task.DontPublishEvents() {
 		task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 		return true
 	}
@@ -117,25 +118,26 @@ func (task *Task) CheckForStop() bool {
 //		7. SetCreditCard
 //		8. SetPaymentInfo
 //		9. PlaceOrder
-func (task *Task) RunTask() {
+func (task *Task) RunTask(ctx context.Context) {
+	task.Task.Ctx = ctx
+
 	// If the function panics due to a runtime error, recover from it
 	defer func() {
 		if r := recover(); r != nil {
 			task.PublishEvent(fmt.Sprintf(enums.TaskFailed, r), enums.TaskFail, 0)
 		} else {
-			if !task.Task.StopFlag &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
-				!strings.Contains(task.Task.Task.TaskStatus, strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
+			if !task.Task.StopFlag() &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskIdle, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutFailure, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CardDeclined, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.CheckingOutSuccess, " %s", "")) &&
+				!strings.Contains(task.Task.Task.GetTaskStatus(), strings.ReplaceAll(enums.TaskFailed, " %s", "")) {
 				task.PublishEvent(enums.TaskIdle, enums.TaskStop, 0)
 			}
 		}
-		task.Task.StopFlag = true
+		task.Task.Stop()
 	}()
-	task.StockData = WalmartInStockData{}
-	task.Task.HasStockData = false
+	task.Task.ResetStock(func() { task.StockData = WalmartInStockData{} })
 
 	if task.Task.Task.TaskDelay == 0 {
 		task.Task.Task.TaskDelay = 2000
@@ -144,7 +146,7 @@ func (task *Task) RunTask() {
 		task.Task.Task.TaskQty = 1
 	}
 
-	err := task.Task.CreateClient(task.Task.Proxy)
+	err := task.Task.CreateClient(task.Task.Proxy())
 	if err != nil {
 		return
 	}
@@ -156,7 +158,7 @@ func (task *Task) RunTask() {
 		if needToStop {
 			return
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 
 	setup := false
@@ -167,7 +169,7 @@ func (task *Task) RunTask() {
 		}
 		setup = task.Setup()
 		if !setup {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -192,7 +194,7 @@ func (task *Task) RunTask() {
 		}
 		addedToCart = task.AddToCart()
 		if !addedToCart {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -201,7 +203,7 @@ func (task *Task) RunTask() {
 		for pieValues.K == "" {
 			pieValues = task.GetPIEValues()
 			if pieValues.K == "" {
-				time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+				task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 			}
 		}
 		cardInfo := EncryptCardInfo{
@@ -209,7 +211,7 @@ func (task *Task) RunTask() {
 			CardCVV:    task.Task.Profile.CreditCard.CVV,
 			PIEValues:  pieValues,
 		}
-		task.Task.EventBus.PublishTaskEvent(enums.EncryptingCardInfo, -1, enums.TaskUpdate, cardInfo, task.Task.Task.ID)
+		task.Task.PublishEvent(enums.EncryptingCardInfo, -1, enums.TaskUpdate, cardInfo)
 	}()
 
 	// 3. GetCartInfo
@@ -222,7 +224,7 @@ func (task *Task) RunTask() {
 		}
 		gotCartInfo = task.GetCartInfo()
 		if !gotCartInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -236,7 +238,7 @@ func (task *Task) RunTask() {
 	// 	}
 	// 	setPCID = task.SetPCID()
 	// 	if !setPCID {
-	// 		time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+	// 		task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 	// 	}
 	// }
 
@@ -250,7 +252,7 @@ func (task *Task) RunTask() {
 		}
 		setShippingInfo = task.SetShippingInfo()
 		if !setShippingInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -274,7 +276,7 @@ func (task *Task) RunTask() {
 		}
 		setCreditCard = task.SetCreditCard()
 		if !setCreditCard {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	} */
 
@@ -288,7 +290,7 @@ func (task *Task) RunTask() {
 		}
 		setPaymentInfo, doNotRetry = task.SetPaymentInfo()
 		if !setPaymentInfo {
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -308,7 +310,7 @@ func (task *Task) RunTask() {
 		placedOrder, status = task.PlaceOrder()
 		if !placedOrder {
 			retries++
-			time.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
+			task.Task.Sleep(time.Duration(task.Task.Task.TaskDelay) * time.Millisecond)
 		}
 	}
 
@@ -333,7 +335,7 @@ func (task *Task) RunTask() {
 	}
 
 	go util.ProcessCheckout(&util.ProcessCheckoutInfo{
-		BaseTask:     task.Task,
+		BaseTask:     &task.Task,
 		Success:      placedOrder,
 		Status:       status,
 		Content:      "",
@@ -356,11 +358,10 @@ func (task *Task) WaitForMonitor() bool {
 		if needToStop {
 			return true
 		}
-		if task.StockData.OfferID != "" && task.StockData.SKU != "" {
-			task.Task.HasStockData = true
+		if task.Task.TakeStock(func() bool { return task.StockData.OfferID != "" && task.StockData.SKU != "" }) {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -386,7 +387,7 @@ func (task *Task) HandlePXCap(resp *http.Response, redirectURL string) bool {
 					return
 				}
 			}
-			time.Sleep(common.MS_TO_WAIT)
+			task.Task.Sleep(common.MS_TO_WAIT)
 		}
 	}()
 
@@ -395,7 +396,7 @@ func (task *Task) HandlePXCap(resp *http.Response, redirectURL string) bool {
 	if redirectURL != "" {
 		captchaURL = BaseEndpoint + redirectURL[1:]
 	}
-	err := SetPXCapCookie(strings.ReplaceAll(captchaURL, "affil.", ""), &task.PXValues, task.Task.Proxy, &task.Task.Client, &cancellationToken)
+	err := SetPXCapCookie(task.Task.Ctx, strings.ReplaceAll(captchaURL, "affil.", ""), &task.PXValues, task.Task.Proxy(), &task.Task.Client, &cancellationToken)
 	if err != nil {
 		log.Println(err.Error())
 		return false
@@ -419,6 +420,7 @@ func (task *Task) Setup() bool {
 
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    BlockedToBaseEndpoint,
 		RawHeaders: [][2]string{
@@ -450,6 +452,7 @@ func (task *Task) GetPIEValues() PIEValues {
 	pieValues := PIEValues{}
 	resp, body, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "GET",
 		URL:    PIEEndpoint + fmt.Sprint(time.Now().Unix()),
 		RawHeaders: [][2]string{
@@ -537,6 +540,7 @@ func (task *Task) AddToCart() bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    AddToCartEndpoint,
 		RawHeaders: [][2]string{
@@ -603,6 +607,7 @@ func (task *Task) GetCartInfo() bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    GetCartInfoEndpoint,
 		RawHeaders: [][2]string{
@@ -653,6 +658,7 @@ func (task *Task) SetPCID() bool {
 	setPCIDResponse := SetPCIDResponse{}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SetPcidEndpoint,
 		RawHeaders: [][2]string{
@@ -720,6 +726,7 @@ func (task *Task) SetShippingInfo() bool {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SetShippingInfoEndpoint,
 		RawHeaders: [][2]string{
@@ -777,7 +784,7 @@ func (task *Task) WaitForEncryptedPaymentInfo() bool {
 		if task.CardInfo.EncryptedPan != "" {
 			return false
 		}
-		time.Sleep(common.MS_TO_WAIT)
+		task.Task.Sleep(common.MS_TO_WAIT)
 	}
 }
 
@@ -818,6 +825,7 @@ func (task *Task) SetCreditCard() (bool, bool) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SetCreditCardEndpoint,
 		RawHeaders: [][2]string{
@@ -909,6 +917,7 @@ func (task *Task) SetPaymentInfo() (bool, bool) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "POST",
 		URL:    SetPaymentInfoEndpoint,
 		RawHeaders: [][2]string{
@@ -981,6 +990,7 @@ func (task *Task) PlaceOrder() (bool, enums.OrderStatus) {
 	}
 	resp, _, err := util.MakeRequest(&util.Request{
 		Client: task.Task.Client,
+		Ctx:    task.Task.Ctx,
 		Method: "PUT",
 		URL:    PlaceOrderEndpoint,
 		RawHeaders: [][2]string{
@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"sync"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"github.com/gorilla/websocket"
+)
+
+// clientBufferSize is the number of Events buffered for each Client before older statuses are coalesced
+const clientBufferSize = 4096
+
+// Client is a connected WebSocket, it receives the Events it's subscribed to and the replies to its requests
+type Client struct {
+	conn       *websocket.Conn
+	eventBus   *events.EventBus
+	writeMutex sync.Mutex
+
+	mutex      sync.Mutex
+	subscriber *events.Subscriber
+	options    events.SubscriberOptions
+	closed     bool
+}
+
+// newClient returns a Client for the connection that's subscribed to every Event
+func newClient(conn *websocket.Conn, eventBus *events.EventBus) *Client {
+	client := &Client{conn: conn, eventBus: eventBus}
+	client.subscribe(events.SubscriberOptions{})
+	return client
+}
+
+// writeJSON sends the value to the Client, the connection only allows one writer at a time
+func (client *Client) writeJSON(v interface{}) error {
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	return client.conn.WriteJSON(v)
+}
+
+// subscribe replaces the Client's subscription with one for the Events matching the options
+func (client *Client) subscribe(options events.SubscriberOptions) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.closed {
+		return
+	}
+
+	client.removeSubscriber()
+	// Only the latest status of each task and monitor matters to the frontend, so older ones can be coalesced if it falls behind
+	options.BufferSize = clientBufferSize
+	options.Policy = events.CoalesceLatestStatus
+	// ManageEvents sends the CloseEvent to every Client itself, right before exiting
+	options.Filter = func(event events.Event) bool {
+		return event.EventType != events.CloseEventType
+	}
+	client.options = options
+	client.subscriber = client.eventBus.AddSubscriber(options)
+	go client.forward(client.subscriber)
+}
+
+// unsubscribe stops sending Events to the Client, replies to its requests are still sent
+func (client *Client) unsubscribe() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.removeSubscriber()
+	client.options = events.SubscriberOptions{}
+}
+
+// subscription returns the options of the Client's subscription, and false if it isn't subscribed
+func (client *Client) subscription() (events.SubscriberOptions, bool) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.options, client.subscriber != nil
+}
+
+// close removes the Client's subscription for good, once its connection is gone
+func (client *Client) close() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.closed = true
+	client.removeSubscriber()
+}
+
+// removeSubscriber removes the Client's Subscriber from the EventBus, the Client's mutex must be held
+func (client *Client) removeSubscriber() {
+	if client.subscriber != nil {
+		client.eventBus.RemoveSubscriber(client.subscriber)
+		client.subscriber = nil
+	}
+}
+
+// forward writes the Subscriber's Events to the Client until the Subscriber is removed
+func (client *Client) forward(subscriber *events.Subscriber) {
+	for event := range subscriber.Events() {
+		client.writeJSON(event)
+	}
+}
@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+)
+
+// ProtocolVersion is the version of the Frame protocol that the backend speaks
+const ProtocolVersion = 1
+
+// Frame is a request from the frontend or the backend's reply to one. Every request with an ID gets exactly one
+// reply with the same ID and route: Ack is true and Payload holds the result if the request succeeded,
+// otherwise Ack is false and Error says why. Events are still sent as plain Events, outside of Frames.
+type Frame struct {
+	// Version is the protocol version, requests without one are treated as the current ProtocolVersion
+	Version int             `json:"version"`
+	ID      string          `json:"id"`
+	Route   string          `json:"route"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Ack     bool            `json:"ack"`
+	Error   *FrameError     `json:"error,omitempty"`
+}
+
+// FrameErrorCode is a machine-readable reason that a request failed
+type FrameErrorCode = string
+
+const (
+	InvalidFrameCode       FrameErrorCode = "invalid_frame"
+	UnsupportedVersionCode FrameErrorCode = "unsupported_version"
+	UnknownRouteCode       FrameErrorCode = "unknown_route"
+	InvalidPayloadCode     FrameErrorCode = "invalid_payload"
+	NotFoundCode           FrameErrorCode = "not_found"
+	FailedCode             FrameErrorCode = "failed"
+)
+
+// FrameError is the reason that a request failed
+type FrameError struct {
+	Code    FrameErrorCode `json:"code"`
+	Message string         `json:"message"`
+}
+
+func newFrameError(code FrameErrorCode, message string) *FrameError {
+	return &FrameError{Code: code, Message: message}
+}
+
+// routeHandler handles a request's payload and returns the reply's payload
+type routeHandler func(client *Client, payload json.RawMessage) (interface{}, *FrameError)
+
+// handleMessage handles a message from the frontend, which is either a Frame or a legacy IncomingMessage
+func handleMessage(client *Client, message []byte) {
+	frame := Frame{}
+	err := json.Unmarshal(message, &frame)
+	if err != nil {
+		log.Println("Error reading message from frontend: " + err.Error())
+		client.writeJSON(Frame{Version: ProtocolVersion, Error: newFrameError(InvalidFrameCode, errors.ParseFrameError+err.Error())})
+		return
+	}
+	if frame.Route == "" {
+		handleIncomingMessage(message)
+		return
+	}
+
+	client.writeJSON(handleFrame(client, frame))
+}
+
+// handleFrame runs the request's route and returns the reply to it
+func handleFrame(client *Client, frame Frame) Frame {
+	reply := Frame{Version: ProtocolVersion, ID: frame.ID, Route: frame.Route}
+
+	var result interface{}
+	var frameError *FrameError
+	if frame.Version != 0 && frame.Version != ProtocolVersion {
+		frameError = newFrameError(UnsupportedVersionCode, errors.UnsupportedProtocolVersionError+fmt.Sprint(frame.Version))
+	} else if frame.ID == "" {
+		frameError = newFrameError(InvalidFrameCode, errors.MissingFrameIDError)
+	} else if handler, ok := routes[frame.Route]; !ok {
+		frameError = newFrameError(UnknownRouteCode, errors.UnknownRouteError+frame.Route)
+	} else {
+		result, frameError = handler(client, frame.Payload)
+	}
+	if frameError != nil {
+		reply.Error = frameError
+		return reply
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		reply.Error = newFrameError(FailedCode, err.Error())
+		return reply
+	}
+	reply.Ack = true
+	reply.Payload = payload
+	return reply
+}
+
+// parsePayload parses the request's payload into v, a missing payload leaves v as it is
+func parsePayload(payload json.RawMessage, v interface{}) *FrameError {
+	if len(payload) == 0 {
+		return nil
+	}
+	err := json.Unmarshal(payload, v)
+	if err != nil {
+		return newFrameError(InvalidPayloadCode, errors.ParseFramePayloadError+err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package ws
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHandleFrameErrors(t *testing.T) {
+	cases := []struct {
+		frame Frame
+		code  FrameErrorCode
+	}{
+		{Frame{Version: 2, ID: "1", Route: "task.start"}, UnsupportedVersionCode},
+		{Frame{Version: ProtocolVersion, Route: "task.start"}, InvalidFrameCode},
+		{Frame{Version: ProtocolVersion, ID: "1", Route: "task.restart"}, UnknownRouteCode},
+		{Frame{ID: "1", Route: "task.start", Payload: json.RawMessage(`{"taskID": 1}`)}, InvalidPayloadCode},
+		{Frame{ID: "1", Route: "events.subscribe", Payload: json.RawMessage(`{"topics": ["tasks"]}`)}, InvalidPayloadCode},
+	}
+	for _, c := range cases {
+		reply := handleFrame(nil, c.frame)
+		if reply.Ack || reply.Error == nil || reply.Error.Code != c.code {
+			t.Errorf("%+v: expected a %s error, got %+v", c.frame, c.code, reply)
+			continue
+		}
+		if reply.ID != c.frame.ID || reply.Route != c.frame.Route || reply.Version != ProtocolVersion {
+			t.Errorf("%+v: the reply isn't correlated with the request: %+v", c.frame, reply)
+		}
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	request := TaskGroupPayload{GroupID: "default"}
+	if frameError := parsePayload(nil, &request); frameError != nil || request.GroupID != "default" {
+		t.Errorf("expected a missing payload to be ignored, got %v (%+v)", frameError, request)
+	}
+	if frameError := parsePayload(json.RawMessage(`{"groupID": "group"}`), &request); frameError != nil || request.GroupID != "group" {
+		t.Errorf("expected the payload to be parsed, got %v (%+v)", frameError, request)
+	}
+}
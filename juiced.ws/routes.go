@@ -0,0 +1,389 @@
+package ws
+
+import (
+	"encoding/json"
+
+	"backend.juicedbot.io/juiced.api/endpoints"
+	"backend.juicedbot.io/juiced.api/responses"
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/errors"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+)
+
+// routes are the requests that a Client can make, by Frame route
+var routes = map[string]routeHandler{
+	"task.start":         startTask,
+	"task.stop":          stopTask,
+	"task.update":        updateTask,
+	"task.status":        taskStatus,
+	"group.start":        startTaskGroup,
+	"group.stop":         stopTaskGroup,
+	"group.update":       updateTaskGroup,
+	"group.status":       taskGroupStatus,
+	"events.subscribe":   subscribe,
+	"events.unsubscribe": unsubscribe,
+}
+
+// TaskPayload is the payload of the task.start, task.stop and task.status routes
+type TaskPayload struct {
+	TaskID string `json:"taskID"`
+}
+
+// UpdateTaskPayload is the payload of the task.update route, fields that are left out aren't updated
+type UpdateTaskPayload struct {
+	TaskID       string  `json:"taskID"`
+	ProfileID    *string `json:"profileID"`
+	ProxyGroupID *string `json:"proxyGroupID"`
+	Quantity     *int    `json:"quantity"`
+	Delay        *int    `json:"delay"`
+}
+
+// TaskGroupPayload is the payload of the group.start, group.stop and group.status routes,
+// group.status returns every TaskGroup if the GroupID is left out
+type TaskGroupPayload struct {
+	GroupID string `json:"groupID"`
+}
+
+// UpdateTaskGroupPayload is the payload of the group.update route, fields that are left out aren't updated
+type UpdateTaskGroupPayload struct {
+	GroupID      string  `json:"groupID"`
+	Name         *string `json:"name"`
+	ProxyGroupID *string `json:"proxyGroupID"`
+	Delay        *int    `json:"delay"`
+}
+
+// StartTaskGroupResult is the reply to the group.start route
+type StartTaskGroupResult struct {
+	TaskGroup entities.TaskGroupWithTasks `json:"taskGroup"`
+	Warnings  []string                    `json:"warnings"`
+}
+
+// SubscriptionPayload is the payload of the events.subscribe route and the reply to both events routes.
+// Empty lists match everything, so subscribing to nothing receives every Event.
+type SubscriptionPayload struct {
+	Subscribed   bool             `json:"subscribed"`
+	Topics       []events.Topic   `json:"topics"`
+	TaskGroupIDs []string         `json:"taskGroupIDs"`
+	Retailers    []enums.Retailer `json:"retailers"`
+}
+
+// getTask returns the Task with the given ID, or a not_found FrameError if there isn't one
+func getTask(taskID string) (entities.Task, *FrameError) {
+	task, err := queries.GetTask(taskID)
+	if err != nil {
+		return task, newFrameError(FailedCode, errors.GetTaskError+err.Error())
+	}
+	if task.ID == "" {
+		return task, newFrameError(NotFoundCode, errors.TaskNotFoundError)
+	}
+	return task, nil
+}
+
+// getTaskGroup returns the TaskGroup with the given ID, or a not_found FrameError if there isn't one
+func getTaskGroup(groupID string) (entities.TaskGroup, *FrameError) {
+	taskGroup, err := queries.GetTaskGroup(groupID)
+	if err != nil {
+		return taskGroup, newFrameError(FailedCode, errors.GetTaskGroupError+err.Error())
+	}
+	if taskGroup.GroupID == "" {
+		return taskGroup, newFrameError(NotFoundCode, errors.TaskGroupNotFoundError)
+	}
+	return taskGroup, nil
+}
+
+// taskSnapshot returns the Task with its current status from the task store, and its password masked
+func taskSnapshot(task entities.Task) entities.Task {
+	if status := stores.GetTaskStatuses()[task.ID]; status != "" {
+		task.SetTaskStatus(status)
+	}
+	return responses.MaskTask(task)
+}
+
+// taskGroupSnapshot returns the TaskGroup and its Tasks with their current statuses from the stores, and their
+// passwords masked
+func taskGroupSnapshot(taskGroup entities.TaskGroup) (entities.TaskGroupWithTasks, *FrameError) {
+	taskGroupWithTasks, err := queries.ConvertTaskIDsToTasks(&taskGroup)
+	if err != nil {
+		return taskGroupWithTasks, newFrameError(FailedCode, errors.GetTaskError+err.Error())
+	}
+	taskGroupWithTasks = endpoints.UpdateStatuses(taskGroupWithTasks)
+	taskGroupWithTasks.Tasks = responses.MaskTasks(taskGroupWithTasks.Tasks)
+	return taskGroupWithTasks, nil
+}
+
+func startTask(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	task, frameError := getTask(request.TaskID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	err := stores.GetTaskStore().StartTask(&task)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StartTaskError+err.Error())
+	}
+	return taskSnapshot(task), nil
+}
+
+func stopTask(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	task, frameError := getTask(request.TaskID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	taskStore := stores.GetTaskStore()
+	_, err := taskStore.StopTask(&task)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StopTaskError+err.Error())
+	}
+	// The Monitor isn't needed once none of its TaskGroup's Tasks are running
+	taskGroup, frameError := getTaskGroup(task.TaskGroupID)
+	if frameError != nil {
+		return nil, frameError
+	}
+	if !taskStore.TasksRunning(taskGroup.TaskIDs) {
+		_, err = stores.GetMonitorStore().StopMonitor(&taskGroup)
+		if err != nil {
+			return nil, newFrameError(FailedCode, errors.StopMonitorError+err.Error())
+		}
+	}
+	return taskSnapshot(task), nil
+}
+
+func updateTask(client *Client, payload json.RawMessage) (result interface{}, frameError *FrameError) {
+	request := UpdateTaskPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	task, frameError := getTask(request.TaskID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	taskStore := stores.GetTaskStore()
+	// StopTask waits for the task to exit, so it has to know not to publish its stop event beforehand.
+	// If the update fails, the task goes back to publishing its events.
+	taskStore.SetDontPublishEvents(task.ID, true)
+	defer func() {
+		if frameError != nil {
+			taskStore.SetDontPublishEvents(task.ID, false)
+		}
+	}()
+	wasRunning, err := taskStore.StopTask(&task)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StopTaskError+err.Error())
+	}
+	if request.ProfileID != nil {
+		task.TaskProfileID = *request.ProfileID
+	}
+	if request.ProxyGroupID != nil {
+		task.TaskProxyGroupID = *request.ProxyGroupID
+	}
+	if request.Quantity != nil && *request.Quantity > 0 {
+		task.TaskQty = *request.Quantity
+	}
+	if request.Delay != nil && *request.Delay >= 0 {
+		task.TaskDelay = *request.Delay
+	}
+
+	_, err = commands.UpdateTask(task.ID, task)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.UpdateTaskError+err.Error())
+	}
+	task.UpdateTask = true
+	if wasRunning {
+		err = taskStore.StartTask(&task)
+		if err != nil {
+			return nil, newFrameError(FailedCode, errors.StartTaskError+err.Error())
+		}
+	} else if taskStore.GetTask(task.ID) != nil {
+		err = taskStore.UpdateTask(&task)
+		if err != nil {
+			return nil, newFrameError(FailedCode, errors.UpdateTaskError+err.Error())
+		}
+	}
+	return taskSnapshot(task), nil
+}
+
+func taskStatus(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	task, frameError := getTask(request.TaskID)
+	if frameError != nil {
+		return nil, frameError
+	}
+	return taskSnapshot(task), nil
+}
+
+func startTaskGroup(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskGroupPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	taskGroup, frameError := getTaskGroup(request.GroupID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	warnings, err := stores.GetTaskStore().StartTaskGroup(&taskGroup)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StartTaskGroupError+err.Error())
+	}
+	taskGroupWithTasks, frameError := taskGroupSnapshot(taskGroup)
+	if frameError != nil {
+		return nil, frameError
+	}
+	if warnings == nil {
+		warnings = []string{}
+	}
+	return StartTaskGroupResult{TaskGroup: taskGroupWithTasks, Warnings: warnings}, nil
+}
+
+func stopTaskGroup(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskGroupPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	taskGroup, frameError := getTaskGroup(request.GroupID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	err := stores.GetTaskStore().StopTaskGroup(&taskGroup)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StopTaskError+err.Error())
+	}
+	return taskGroupSnapshot(taskGroup)
+}
+
+func updateTaskGroup(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := UpdateTaskGroupPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	taskGroup, frameError := getTaskGroup(request.GroupID)
+	if frameError != nil {
+		return nil, frameError
+	}
+
+	monitorStore := stores.GetMonitorStore()
+	wasRunning, err := monitorStore.StopMonitor(&taskGroup)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.StopMonitorError+err.Error())
+	}
+	if request.Name != nil {
+		taskGroup.Name = *request.Name
+	}
+	if request.ProxyGroupID != nil {
+		taskGroup.MonitorProxyGroupID = *request.ProxyGroupID
+	}
+	if request.Delay != nil && *request.Delay >= 0 {
+		taskGroup.MonitorDelay = *request.Delay
+	}
+
+	newTaskGroup, err := commands.UpdateTaskGroup(taskGroup.GroupID, taskGroup)
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.UpdateTaskGroupError+err.Error())
+	}
+	newTaskGroup.UpdateMonitor = true
+	if wasRunning {
+		err = monitorStore.StartMonitor(&newTaskGroup)
+		if err != nil {
+			return nil, newFrameError(FailedCode, errors.StartMonitorError+err.Error())
+		}
+	} else if monitorStore.GetMonitor(newTaskGroup.GroupID) != nil {
+		err = monitorStore.UpdateMonitor(&newTaskGroup)
+		if err != nil {
+			return nil, newFrameError(FailedCode, errors.UpdateTaskGroupError+err.Error())
+		}
+	}
+	return taskGroupSnapshot(newTaskGroup)
+}
+
+func taskGroupStatus(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := TaskGroupPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	if request.GroupID != "" {
+		taskGroup, frameError := getTaskGroup(request.GroupID)
+		if frameError != nil {
+			return nil, frameError
+		}
+		return taskGroupSnapshot(taskGroup)
+	}
+
+	taskGroups, err := queries.GetAllTaskGroups()
+	if err != nil {
+		return nil, newFrameError(FailedCode, errors.GetTaskGroupError+err.Error())
+	}
+	snapshots := []entities.TaskGroupWithTasks{}
+	for _, taskGroup := range taskGroups {
+		snapshot, frameError := taskGroupSnapshot(taskGroup)
+		if frameError != nil {
+			return nil, frameError
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+func subscribe(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	request := SubscriptionPayload{}
+	if frameError := parsePayload(payload, &request); frameError != nil {
+		return nil, frameError
+	}
+	for _, topic := range request.Topics {
+		switch topic {
+		case events.TaskTopic, events.MonitorTopic, events.CheckoutTopic, events.ScheduleTopic, events.ProxyTopic, events.SystemTopic:
+		default:
+			return nil, newFrameError(InvalidPayloadCode, errors.InvalidTopicError+topic)
+		}
+	}
+
+	client.subscribe(events.SubscriberOptions{
+		Topics:       request.Topics,
+		TaskGroupIDs: request.TaskGroupIDs,
+		Retailers:    request.Retailers,
+	})
+	return subscription(client), nil
+}
+
+func unsubscribe(client *Client, payload json.RawMessage) (interface{}, *FrameError) {
+	client.unsubscribe()
+	return subscription(client), nil
+}
+
+// subscription returns the Client's current subscription
+func subscription(client *Client) SubscriptionPayload {
+	options, subscribed := client.subscription()
+	result := SubscriptionPayload{
+		Subscribed:   subscribed,
+		Topics:       options.Topics,
+		TaskGroupIDs: options.TaskGroupIDs,
+		Retailers:    options.Retailers,
+	}
+	if result.Topics == nil {
+		result.Topics = []events.Topic{}
+	}
+	if result.TaskGroupIDs == nil {
+		result.TaskGroupIDs = []string{}
+	}
+	if result.Retailers == nil {
+		result.Retailers = []enums.Retailer{}
+	}
+	return result
+}
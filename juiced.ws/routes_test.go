@@ -0,0 +1,220 @@
+package ws
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"backend.juicedbot.io/juiced.infrastructure/commands"
+	"backend.juicedbot.io/juiced.infrastructure/common"
+	"backend.juicedbot.io/juiced.infrastructure/common/entities"
+	"backend.juicedbot.io/juiced.infrastructure/common/enums"
+	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+	"backend.juicedbot.io/juiced.infrastructure/queries"
+	"github.com/gorilla/websocket"
+)
+
+const testPassword = "hunter2hunter2"
+
+// setupRoutes opens a database in a temporary directory with a Target TaskGroup "group" that has the Task "task",
+// and initializes the stores that the routes use
+func setupRoutes(t *testing.T) *events.EventBus {
+	err := common.OpenDatabase(filepath.Join(t.TempDir(), "juiced.db"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { common.GetDatabase().Close() })
+	enums.UserKey = "0123456789abcdef0123456789abcdef"
+
+	events.InitEventBus()
+	eventBus := events.GetEventBus()
+	stores.InitTaskStore(eventBus)
+	stores.InitMonitorStore(eventBus)
+	stores.InitAccountStore()
+
+	err = commands.CreateTaskGroup(entities.TaskGroup{GroupID: "group", Name: "Group", MonitorRetailer: enums.Target, TaskIDs: []string{"task"}, TargetMonitorInfo: &entities.TargetMonitorInfo{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = commands.CreateTask(entities.Task{ID: "task", TaskGroupID: "group", TaskRetailer: enums.Target, TaskQty: 1, TargetTaskInfo: &entities.TargetTaskInfo{Email: "task@example.com", Password: testPassword}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eventBus
+}
+
+// request runs the route with the payload and returns the reply, failing the test if it isn't acknowledged
+func request(t *testing.T, route string, payload string) Frame {
+	reply := handleFrame(nil, Frame{Version: ProtocolVersion, ID: "1", Route: route, Payload: json.RawMessage(payload)})
+	if !reply.Ack || reply.Error != nil {
+		t.Fatalf("%s: expected the request to succeed, got %+v", route, reply.Error)
+	}
+	return reply
+}
+
+func TestRoutesMaskPasswords(t *testing.T) {
+	setupRoutes(t)
+
+	for _, c := range []struct {
+		route   string
+		payload string
+	}{
+		{"task.status", `{"taskID": "task"}`},
+		{"task.update", `{"taskID": "task", "quantity": 2}`},
+		{"group.status", `{"groupID": "group"}`},
+		{"group.status", `{}`},
+		{"group.update", `{"groupID": "group", "name": "Renamed"}`},
+	} {
+		reply := request(t, c.route, c.payload)
+		if strings.Contains(string(reply.Payload), testPassword) {
+			t.Errorf("%s: the reply has the task's password in plaintext: %s", c.route, reply.Payload)
+		}
+		if !strings.Contains(string(reply.Payload), common.MaskSecret(testPassword)) {
+			t.Errorf("%s: expected the reply to have the task's masked password: %s", c.route, reply.Payload)
+		}
+	}
+}
+
+func TestTaskRoutes(t *testing.T) {
+	setupRoutes(t)
+
+	reply := request(t, "task.update", `{"taskID": "task", "quantity": 3, "delay": 500}`)
+	task := entities.Task{}
+	if err := json.Unmarshal(reply.Payload, &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.ID != "task" || task.TaskQty != 3 || task.TaskDelay != 500 {
+		t.Errorf("expected the updated task, got %+v", task)
+	}
+	reply = request(t, "task.status", `{"taskID": "task"}`)
+	task = entities.Task{}
+	if err := json.Unmarshal(reply.Payload, &task); err != nil {
+		t.Fatal(err)
+	}
+	if task.TaskQty != 3 || task.TaskDelay != 500 {
+		t.Errorf("expected the update to be stored, got %+v", task)
+	}
+
+	for _, route := range []string{"task.status", "task.start", "task.stop", "task.update"} {
+		reply = handleFrame(nil, Frame{ID: "1", Route: route, Payload: json.RawMessage(`{"taskID": "missing"}`)})
+		if reply.Ack || reply.Error == nil || reply.Error.Code != NotFoundCode {
+			t.Errorf("%s: expected a missing task to be not_found, got %+v", route, reply)
+		}
+	}
+	reply = handleFrame(nil, Frame{ID: "1", Route: "group.status", Payload: json.RawMessage(`{"groupID": "missing"}`)})
+	if reply.Ack || reply.Error == nil || reply.Error.Code != NotFoundCode {
+		t.Errorf("expected a missing task group to be not_found, got %+v", reply)
+	}
+}
+
+func TestFailedTaskUpdatePublishesEvents(t *testing.T) {
+	setupRoutes(t)
+	task, err := queries.GetTask("task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = stores.GetTaskStore().AddTaskToStore(&task); err != nil {
+		t.Fatal(err)
+	}
+	// The task can't be stored again, so the update fails after the task was told not to publish its events
+	_, err = common.GetDatabase().Exec("CREATE TRIGGER failTaskInsert BEFORE INSERT ON tasks BEGIN SELECT RAISE(FAIL, 'insert failed'); END")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply := handleFrame(nil, Frame{ID: "1", Route: "task.update", Payload: json.RawMessage(`{"taskID": "task", "quantity": 2}`)})
+	if reply.Ack || reply.Error == nil || reply.Error.Code != FailedCode {
+		t.Fatalf("expected the update to fail, got %+v", reply)
+	}
+	runnableTask, ok := stores.GetTaskStore().GetRunnableTask("task")
+	if !ok {
+		t.Fatal("expected the task to still be in the store")
+	}
+	if runnableTask.GetTask().DontPublishEvents() {
+		t.Error("expected the task to publish its events again after the update failed")
+	}
+}
+
+// dialClient starts a server that serves one Client on the EventBus like HandleConnections does, and connects to it
+func dialClient(t *testing.T, eventBus *events.EventBus) *websocket.Conn {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		client := newClient(conn, eventBus)
+		defer client.close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			handleMessage(client, message)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestSubscriptionFiltering(t *testing.T) {
+	eventBus := setupRoutes(t)
+	conn := dialClient(t, eventBus)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	err := conn.WriteJSON(Frame{ID: "1", Route: "events.subscribe", Payload: json.RawMessage(`{"topics": ["task"], "taskGroupIDs": ["group"]}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply := Frame{}
+	if err = conn.ReadJSON(&reply); err != nil {
+		t.Fatal(err)
+	}
+	subscription := SubscriptionPayload{}
+	if err = json.Unmarshal(reply.Payload, &subscription); err != nil || !reply.Ack || !subscription.Subscribed {
+		t.Fatalf("expected to be subscribed, got %+v (%v)", reply, err)
+	}
+
+	otherTask := entities.Task{ID: "other", TaskGroupID: "other", TaskRetailer: enums.Target}
+	task := entities.Task{ID: "task", TaskGroupID: "group", TaskRetailer: enums.Target}
+	eventBus.PublishTaskEventFor(&otherTask, "run", "", enums.SettingUp, 0, enums.TaskStart, nil)
+	eventBus.PublishMonitorEventFor(&entities.TaskGroup{GroupID: "group", MonitorRetailer: enums.Target}, "run", "", enums.SettingUpMonitor, enums.MonitorStart, nil)
+	eventBus.PublishTaskEventFor(&task, "run", "", enums.SettingUp, 0, enums.TaskStart, nil)
+
+	event := events.Event{}
+	if err = conn.ReadJSON(&event); err != nil {
+		t.Fatal(err)
+	}
+	if event.EventType != events.TaskEventType || event.TaskEvent.TaskID != "task" {
+		t.Fatalf("expected only the subscribed task group's task event, got %+v", event)
+	}
+
+	err = conn.WriteJSON(Frame{ID: "2", Route: "events.unsubscribe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply = Frame{}
+	if err = conn.ReadJSON(&reply); err != nil {
+		t.Fatal(err)
+	}
+	subscription = SubscriptionPayload{}
+	if err = json.Unmarshal(reply.Payload, &subscription); err != nil || reply.ID != "2" || subscription.Subscribed {
+		t.Fatalf("expected to be unsubscribed, got %+v (%v)", reply, err)
+	}
+	eventBus.PublishTaskEventFor(&task, "run", "", enums.SettingUp, 0, enums.TaskStart, nil)
+	conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if err = conn.ReadJSON(&event); err == nil {
+		t.Errorf("expected no events after unsubscribing, got %+v", event)
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"backend.juicedbot.io/juiced.api/middleware"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/events"
 	"backend.juicedbot.io/juiced.infrastructure/common/stores"
+	"backend.juicedbot.io/juiced.sitescripts/walmart"
 
 	"flag"
 	"net/http"
@@ -18,8 +21,9 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-var clients = make(map[*websocket.Conn]bool)
-var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+var clients = make(map[*Client]bool)
+var clientsMutex sync.Mutex
+var upgrader = websocket.Upgrader{CheckOrigin: middleware.AllowedOrigin}
 var timer *time.Timer
 
 // Message is any WebSocket message
@@ -46,24 +50,34 @@ func StartWebsocketServer(eventBus *events.EventBus) {
 	}()
 	addr := flag.String("addr", "localhost:8080", "http service address")
 	flag.Parse()
-	http.HandleFunc("/", HandleConnections)
-	http.ListenAndServe(*addr, nil)
+	// The default ServeMux also has the pprof handlers, which shouldn't be served here
+	serveMux := http.NewServeMux()
+	serveMux.Handle("/", middleware.Authenticate(middleware.SessionTokens)(http.HandlerFunc(HandleConnections)))
+	http.ListenAndServe(*addr, serveMux)
 }
 
-// HandleConnections handles new WebSocket connections
+// HandleConnections handles new WebSocket connections, they must already be authenticated
 func HandleConnections(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		return
 	}
 	defer conn.Close()
 
-	clients[conn] = true
+	client := newClient(conn, events.GetEventBus())
+	clientsMutex.Lock()
+	clients[client] = true
+	clientsMutex.Unlock()
+	defer func() {
+		clientsMutex.Lock()
+		delete(clients, client)
+		clientsMutex.Unlock()
+		client.close()
+	}()
+
 	events.GetEventBus().PublishConnectEvent()
 	timer.Reset(999999 * time.Hour)
 	conn.SetCloseHandler(func(code int, text string) error {
-		clients[conn] = false
 		// @silent: Here is where you can change the amount of time before it exits
 		timer.Reset(5 * time.Minute)
 		return nil
@@ -75,33 +89,40 @@ func HandleConnections(w http.ResponseWriter, r *http.Request) {
 			if err.Error() != "websocket: close 1001 (going away)" {
 				log.Println("Error receiving message from frontend: " + err.Error())
 			}
-			// delete(clients, conn)
 			break
-		} else {
-			incomingMessage := IncomingMessage{}
-			err = json.Unmarshal(message, &incomingMessage)
-			if err != nil {
-				log.Println("Error reading message from frontend: " + err.Error())
-			}
-			if incomingMessage.EventType == "WalmartEncryptionEvent" {
-				taskStore := stores.GetTaskStore()
-				taskStore.SetWalmartCardDetails(incomingMessage.TaskID, incomingMessage.CardDetails)
+		}
+		// Stopping a task waits for it to exit, which shouldn't hold up the Client's other requests
+		go handleMessage(client, message)
+	}
+}
+
+// handleIncomingMessage handles a message from before the Frame protocol, which has an eventType instead of a route
+func handleIncomingMessage(message []byte) {
+	incomingMessage := IncomingMessage{}
+	err := json.Unmarshal(message, &incomingMessage)
+	if err != nil {
+		log.Println("Error reading message from frontend: " + err.Error())
+		return
+	}
+	if incomingMessage.EventType == "WalmartEncryptionEvent" {
+		taskStore := stores.GetTaskStore()
+		if runnableTask, ok := taskStore.GetRunnableTask(incomingMessage.TaskID); ok {
+			if walmartTask, ok := runnableTask.(*walmart.Task); ok {
+				walmartTask.CardInfo = incomingMessage.CardDetails
 			}
 		}
-		// err = conn.WriteMessage(mt, message)
-		// if err != nil {
-		// 	delete(clients, conn)
-		// 	break
-		// }
 	}
 }
 
-// ManageEvents manages the events for the EventBus
+// ManageEvents manages the events for the EventBus, each Client receives the ones it's subscribed to through its own Subscriber
 func ManageEvents(eventBus *events.EventBus) {
-	channel := make(chan events.Event)
-	eventBus.Subscribe(channel)
+	// Only the latest status of each task and monitor matters here, so older ones can be coalesced if it falls behind
+	subscriber := eventBus.AddSubscriber(events.SubscriberOptions{
+		BufferSize: 4096,
+		Policy:     events.CoalesceLatestStatus,
+	})
 	for {
-		event := <-channel
+		event := <-subscriber.Events()
 		// log.Println("Event received: " + event.EventType)
 		// if event.EventType == events.MonitorEventType {
 		// 	log.Println("Event info: " + string(event.MonitorEvent.EventType) + ", " + string(event.MonitorEvent.Status))
@@ -113,12 +134,12 @@ func ManageEvents(eventBus *events.EventBus) {
 			monitorStore := stores.GetMonitorStore()
 			monitorStore.CheckMonitorTasksRunning()
 		}
-		for client, connected := range clients {
-			if connected {
-				client.WriteJSON(event)
-			}
-		}
 		if event.EventType == events.CloseEventType {
+			clientsMutex.Lock()
+			for client := range clients {
+				client.writeJSON(event)
+			}
+			clientsMutex.Unlock()
 			os.Exit(0)
 		}
 	}
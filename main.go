@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
 	"log"
 	"math/rand"
@@ -13,15 +14,17 @@ import (
 	rpc "backend.juicedbot.io/juiced.rpc"
 
 	api "backend.juicedbot.io/juiced.api"
+	"backend.juicedbot.io/juiced.api/middleware"
+	"backend.juicedbot.io/juiced.infrastructure/commands"
 	"backend.juicedbot.io/juiced.infrastructure/common"
 	"backend.juicedbot.io/juiced.infrastructure/common/captcha"
 	"backend.juicedbot.io/juiced.infrastructure/common/entities"
 	"backend.juicedbot.io/juiced.infrastructure/common/enums"
 	"backend.juicedbot.io/juiced.infrastructure/common/events"
+	"backend.juicedbot.io/juiced.infrastructure/common/notifications"
 	"backend.juicedbot.io/juiced.infrastructure/common/stores"
 	"backend.juicedbot.io/juiced.infrastructure/queries"
 	sec "backend.juicedbot.io/juiced.security/auth/util"
-	"backend.juicedbot.io/juiced.sitescripts/util"
 
 	ws "backend.juicedbot.io/juiced.ws"
 	"github.com/denisbrodbeck/machineid"
@@ -53,17 +56,23 @@ func main() {
 	events.InitEventBus()
 	eventBus := events.GetEventBus()
 
+	// Every request to the API and websocket server needs the session token, the app reads it from stdout
+	sessionToken, err := middleware.InitSessionToken()
+	if err != nil {
+		os.Exit(0)
+	}
+	fmt.Println("SessionToken: " + sessionToken)
+
 	// Start the websocket server
 	go ws.StartWebsocketServer(eventBus)
 
 	go func() {
 		// Wait for the client to connect to the websocket server
-		channel := make(chan events.Event)
-		eventBus.Subscribe(channel)
+		subscriber := eventBus.AddSubscriber(events.SubscriberOptions{Topics: []events.Topic{events.SystemTopic}})
 		for {
-			event := <-channel
+			event := <-subscriber.Events()
 			if event.EventType == events.ConnectEventType {
-				eventBus.Unsubscribe(channel)
+				eventBus.RemoveSubscriber(subscriber)
 				break
 			}
 		}
@@ -79,16 +88,29 @@ func main() {
 		if err != nil {
 			eventBus.PublishCloseEvent()
 		} else {
-			enums.UserKey, _, err = sec.GetEncryptionKey(userInfo)
+			enums.AccountKey, _, err = sec.GetEncryptionKey(userInfo)
+			if err == nil {
+				enums.UserKey, err = commands.LoadUserKey(enums.AccountKey)
+			}
 			if err != nil {
 				// No encryption key = no working cards/accounts with saved cards
 				eventBus.PublishCloseEvent()
 			} else {
 				rand.Seed(time.Now().UnixNano())
+				err = commands.EncryptPendingColumns()
+				if err != nil {
+					log.Println("Error encrypting stored credentials: " + err.Error())
+				}
 				go Heartbeat(eventBus, userInfo)
-				go stores.InitTaskStore(eventBus)
+				stores.InitTaskStore(eventBus)
 				stores.InitMonitorStore(eventBus)
-				stores.InitProxyStore()
+				stores.InitProxyStore(eventBus)
+				stores.InitAccountStore()
+				stores.InitHistoryStore(eventBus)
+				err = stores.InitScheduleStore(eventBus)
+				if err != nil {
+					log.Println("Error loading task group schedules: " + err.Error())
+				}
 				captcha.InitCaptchaStore(eventBus)
 				err := captcha.InitAycd()
 				if err == nil {
@@ -109,7 +131,8 @@ func main() {
 					log.Println("Error initializing AYCD: " + err.Error())
 					// TODO @silent: Handle
 				}
-				go util.DiscordWebhookQueue()
+				stores.InitOutboxStore()
+				go notifications.Listen(eventBus, queries.GetNotificationSinks)
 				go api.StartServer()
 
 				rpc.EnableRPC()